@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/importer"
+)
+
+type Config struct {
+	AtlasDomain string
+	ProjectID   string
+	Namespace   string
+	Connection  atlas.Connection
+}
+
+// importer generates the Atlas Custom Resources equivalent to an existing Atlas project and prints them to stdout.
+// Nothing is changed in Atlas and no secret values are read: the generated resources reference Secrets which must
+// be created before applying them.
+func main() {
+	config := parseConfiguration()
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error building logger config: %s\n", err)
+		os.Exit(1)
+	}
+	log := logger.Sugar()
+
+	hasAPIKeys := config.Connection.PublicKey != "" && config.Connection.PrivateKey != ""
+	hasServiceAccount := config.Connection.ClientID != "" && config.Connection.ClientSecret != ""
+	if config.ProjectID == "" || (!hasAPIKeys && !hasServiceAccount) {
+		log.Fatal("the project ID and either the Atlas API keys or the service account credentials must be provided")
+	}
+
+	// the importer doesn't read any credentials from the cluster
+	provider := atlas.NewProductionProvider(config.AtlasDomain, client.ObjectKey{}, nil)
+	atlasClient, err := provider.CreateClient(&config.Connection, log)
+	if err != nil {
+		log.Fatalf("failed to create the Atlas client: %s", err)
+	}
+
+	imp := importer.Importer{Client: atlasClient, Namespace: config.Namespace}
+	result, err := imp.Import(context.Background(), config.ProjectID)
+	if err != nil {
+		log.Fatalf("failed to import the Atlas project: %s", err)
+	}
+
+	if err = importer.WriteManifests(os.Stdout, result); err != nil {
+		log.Fatalf("failed to write the manifests: %s", err)
+	}
+}
+
+func parseConfiguration() Config {
+	config := Config{}
+	flag.StringVar(&config.AtlasDomain, "atlas-domain", "https://cloud.mongodb.com/", "the Atlas URL domain name (with slash in the end).")
+	flag.StringVar(&config.ProjectID, "project-id", "", "The ID of the Atlas project to import.")
+	flag.StringVar(&config.Namespace, "namespace", "", "The namespace of the generated resources.")
+	flag.StringVar(&config.Connection.OrgID, "org-id", os.Getenv("ATLAS_ORG_ID"), "The ID of the Atlas organization. Defaults to ATLAS_ORG_ID.")
+	flag.StringVar(&config.Connection.PublicKey, "public-key", os.Getenv("ATLAS_PUBLIC_KEY"), "The public Atlas API key. Defaults to ATLAS_PUBLIC_KEY.")
+	flag.StringVar(&config.Connection.PrivateKey, "private-key", os.Getenv("ATLAS_PRIVATE_KEY"), "The private Atlas API key. Defaults to ATLAS_PRIVATE_KEY.")
+	flag.StringVar(&config.Connection.ClientID, "client-id", os.Getenv("ATLAS_CLIENT_ID"), "The client ID of the Atlas service account, instead of the API keys. Defaults to ATLAS_CLIENT_ID.")
+	flag.StringVar(&config.Connection.ClientSecret, "client-secret", os.Getenv("ATLAS_CLIENT_SECRET"), "The client secret of the Atlas service account. Defaults to ATLAS_CLIENT_SECRET.")
+	flag.Parse()
+
+	return config
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -39,17 +40,27 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/admission"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasbackuppolicy"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasbackupschedule"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasbackupsnapshot"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasdatabaseuser"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasdatafederation"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasdeployment"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasfederatedauth"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasproject"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlassearchindex"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/concurrency"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/healthcheck"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/version"
@@ -62,11 +73,27 @@ const (
 	subobjectDeletionProtectionEnvVar  = "SUBOBJECT_DELETION_PROTECTION"
 	objectDeletionProtectionDefault    = true
 	subobjectDeletionProtectionDefault = true
+
+	syncPeriodFlag                    = "sync-period"
+	maxConcurrentReconcilesFlag       = "max-concurrent-reconciles"
+	serializeProjectReconcilesFlag    = "serialize-project-reconciles"
+	syncPeriodEnvVar                  = "SYNC_PERIOD"
+	maxConcurrentReconcilesEnvVar     = "MAX_CONCURRENT_RECONCILES"
+	serializeProjectReconcilesEnvVar  = "SERIALIZE_PROJECT_RECONCILES"
+	syncPeriodDefault                 = time.Hour * 3
+	serializeProjectReconcilesDefault = true
 )
 
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
+
+	// controllerKinds are the kinds whose number of workers can be set with --max-concurrent-reconciles
+	controllerKinds = []string{
+		"AtlasProject", "AtlasDeployment", "AtlasDatabaseUser", "AtlasDataFederation", "AtlasFederatedAuth",
+		"AtlasBackupSchedule", "AtlasBackupPolicy", "AtlasBackupSnapshot", "AtlasBackupRestoreJob", "AtlasIPAccessList",
+		"AtlasNetworkPeering", "AtlasPrivateEndpoint", "AtlasSearchIndex",
+	}
 )
 
 func init() {
@@ -91,7 +118,7 @@ func main() {
 
 	ctrl.SetLogger(zapr.NewLogger(logger))
 
-	syncPeriod := time.Hour * 3
+	syncPeriod := config.SyncPeriod
 
 	var cacheFunc cache.NewCacheFunc
 	if len(config.WatchedNamespaces) > 1 {
@@ -113,15 +140,17 @@ func main() {
 	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                 scheme,
-		MetricsBindAddress:     config.MetricsAddr,
-		Port:                   9443,
-		Namespace:              config.Namespace,
-		HealthProbeBindAddress: config.ProbeAddr,
+		Scheme:             scheme,
+		MetricsBindAddress: config.MetricsAddr,
+		Port:               9443,
+		Namespace:          config.Namespace,
+		// The probes are served by healthcheck.ProbeServer
+		HealthProbeBindAddress: "0",
 		LeaderElection:         config.EnableLeaderElection,
 		LeaderElectionID:       "06d035fb.mongodb.com",
 		SyncPeriod:             &syncPeriod,
 		NewCache:               cacheFunc,
+		CertDir:                config.WebhookCertDir,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -136,7 +165,29 @@ func main() {
 		watch.SelectNamespacesPredicate(config.WatchedNamespaces), // select only desired namespaces
 	}
 
-	atlasProvider := atlas.NewProductionProvider(config.AtlasDomain, config.GlobalAPISecret, mgr.GetClient())
+	atlasProvider := atlas.NewProductionProvider(config.AtlasDomain, config.GlobalAPISecret, mgr.GetClient(),
+		atlas.WithRequestPolicy(config.AtlasRequestPolicy),
+		atlas.WithCredentialsDir(config.AtlasCredentialsDir),
+		atlas.WithAtlasConnections(config.EnableAtlasConnections),
+	)
+
+	// credentialsEvents subscribes a controller to the changes of the credentials directory, if the credentials are
+	// read from one
+	credentialsEvents := func() <-chan event.GenericEvent { return nil }
+	if config.AtlasCredentialsDir != "" {
+		credentialsWatcher := atlas.NewCredentialsWatcher(config.AtlasCredentialsDir, config.GlobalAPISecret,
+			atlas.DefaultCredentialsPollInterval, logger.Named("credentials").Sugar())
+		credentialsEvents = credentialsWatcher.Events
+		if err = mgr.Add(credentialsWatcher); err != nil {
+			setupLog.Error(err, "unable to watch the credentials directory")
+			os.Exit(1)
+		}
+	}
+
+	var projectLocks *concurrency.ProjectLocks
+	if config.SerializeProjectReconciles {
+		projectLocks = concurrency.NewProjectLocks()
+	}
 
 	if err = (&atlasdeployment.AtlasDeploymentReconciler{
 		Client:                      mgr.GetClient(),
@@ -148,38 +199,179 @@ func main() {
 		AtlasProvider:               atlasProvider,
 		ObjectDeletionProtection:    config.ObjectDeletionProtection,
 		SubObjectDeletionProtection: config.SubObjectDeletionProtection,
+		DryRun:                      config.DryRun,
+		ResyncInterval:              config.ResyncIntervals.Deployment,
+		DriftPolicy:                 config.DriftPolicy,
+		MaxConcurrentReconciles:     config.Workers.For("AtlasDeployment"),
+		EnablePolicies:              config.EnablePolicies,
+		GlobalAPISecret:             config.GlobalAPISecret,
+		CredentialsEvents:           credentialsEvents(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AtlasDeployment")
 		os.Exit(1)
 	}
 
+	if err = (&atlasbackupschedule.AtlasBackupScheduleReconciler{
+		Client:                  mgr.GetClient(),
+		Log:                     logger.Named("controllers").Named("AtlasBackupSchedule").Sugar(),
+		Scheme:                  mgr.GetScheme(),
+		GlobalPredicates:        globalPredicates,
+		EventRecorder:           mgr.GetEventRecorderFor("AtlasBackupSchedule"),
+		AtlasProvider:           atlasProvider,
+		DryRun:                  config.DryRun,
+		MaxConcurrentReconciles: config.Workers.For("AtlasBackupSchedule"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasBackupSchedule")
+		os.Exit(1)
+	}
+
+	if err = (&atlassearchindex.AtlasSearchIndexReconciler{
+		Client:                   mgr.GetClient(),
+		Log:                      logger.Named("controllers").Named("AtlasSearchIndex").Sugar(),
+		Scheme:                   mgr.GetScheme(),
+		GlobalPredicates:         globalPredicates,
+		EventRecorder:            mgr.GetEventRecorderFor("AtlasSearchIndex"),
+		AtlasProvider:            atlasProvider,
+		ObjectDeletionProtection: config.ObjectDeletionProtection,
+		DryRun:                   config.DryRun,
+		MaxConcurrentReconciles:  config.Workers.For("AtlasSearchIndex"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasSearchIndex")
+		os.Exit(1)
+	}
+
+	if err = (&atlasbackupsnapshot.AtlasBackupSnapshotReconciler{
+		Client:                   mgr.GetClient(),
+		Log:                      logger.Named("controllers").Named("AtlasBackupSnapshot").Sugar(),
+		Scheme:                   mgr.GetScheme(),
+		GlobalPredicates:         globalPredicates,
+		EventRecorder:            mgr.GetEventRecorderFor("AtlasBackupSnapshot"),
+		AtlasProvider:            atlasProvider,
+		ObjectDeletionProtection: config.ObjectDeletionProtection,
+		DryRun:                   config.DryRun,
+		MaxConcurrentReconciles:  config.Workers.For("AtlasBackupSnapshot"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasBackupSnapshot")
+		os.Exit(1)
+	}
+
+	if err = (&atlasbackupsnapshot.AtlasBackupRestoreJobReconciler{
+		Client:                   mgr.GetClient(),
+		Log:                      logger.Named("controllers").Named("AtlasBackupRestoreJob").Sugar(),
+		Scheme:                   mgr.GetScheme(),
+		GlobalPredicates:         globalPredicates,
+		EventRecorder:            mgr.GetEventRecorderFor("AtlasBackupRestoreJob"),
+		AtlasProvider:            atlasProvider,
+		ObjectDeletionProtection: config.ObjectDeletionProtection,
+		DryRun:                   config.DryRun,
+		MaxConcurrentReconciles:  config.Workers.For("AtlasBackupRestoreJob"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasBackupRestoreJob")
+		os.Exit(1)
+	}
+
+	if err = (&atlasbackuppolicy.AtlasBackupPolicyReconciler{
+		Client:                  mgr.GetClient(),
+		Log:                     logger.Named("controllers").Named("AtlasBackupPolicy").Sugar(),
+		Scheme:                  mgr.GetScheme(),
+		GlobalPredicates:        globalPredicates,
+		EventRecorder:           mgr.GetEventRecorderFor("AtlasBackupPolicy"),
+		AtlasProvider:           atlasProvider,
+		DryRun:                  config.DryRun,
+		MaxConcurrentReconciles: config.Workers.For("AtlasBackupPolicy"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasBackupPolicy")
+		os.Exit(1)
+	}
+
 	if err = (&atlasproject.AtlasProjectReconciler{
 		Client:                      mgr.GetClient(),
 		Log:                         logger.Named("controllers").Named("AtlasProject").Sugar(),
 		Scheme:                      mgr.GetScheme(),
 		AtlasDomain:                 config.AtlasDomain,
+		AtlasProvider:               atlasProvider,
 		ResourceWatcher:             watch.NewResourceWatcher(),
 		GlobalAPISecret:             config.GlobalAPISecret,
 		GlobalPredicates:            globalPredicates,
 		EventRecorder:               mgr.GetEventRecorderFor("AtlasProject"),
 		ObjectDeletionProtection:    config.ObjectDeletionProtection,
 		SubObjectDeletionProtection: config.SubObjectDeletionProtection,
+		DryRun:                      config.DryRun,
+		ResyncInterval:              config.ResyncIntervals.Project,
+		DriftPolicy:                 config.DriftPolicy,
+		MaxConcurrentReconciles:     config.Workers.For("AtlasProject"),
+		ProjectLocks:                projectLocks,
+		CredentialsEvents:           credentialsEvents(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AtlasProject")
 		os.Exit(1)
 	}
 
+	if err = (&atlasproject.AtlasIPAccessListReconciler{
+		Client:                   mgr.GetClient(),
+		Log:                      logger.Named("controllers").Named("AtlasIPAccessList").Sugar(),
+		Scheme:                   mgr.GetScheme(),
+		GlobalPredicates:         globalPredicates,
+		EventRecorder:            mgr.GetEventRecorderFor("AtlasIPAccessList"),
+		AtlasProvider:            atlasProvider,
+		ObjectDeletionProtection: config.ObjectDeletionProtection,
+		DryRun:                   config.DryRun,
+		MaxConcurrentReconciles:  config.Workers.For("AtlasIPAccessList"),
+		ProjectLocks:             projectLocks,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasIPAccessList")
+		os.Exit(1)
+	}
+
+	if err = (&atlasproject.AtlasNetworkPeeringReconciler{
+		Client:                   mgr.GetClient(),
+		Log:                      logger.Named("controllers").Named("AtlasNetworkPeering").Sugar(),
+		Scheme:                   mgr.GetScheme(),
+		GlobalPredicates:         globalPredicates,
+		EventRecorder:            mgr.GetEventRecorderFor("AtlasNetworkPeering"),
+		AtlasProvider:            atlasProvider,
+		ObjectDeletionProtection: config.ObjectDeletionProtection,
+		DryRun:                   config.DryRun,
+		MaxConcurrentReconciles:  config.Workers.For("AtlasNetworkPeering"),
+		ProjectLocks:             projectLocks,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasNetworkPeering")
+		os.Exit(1)
+	}
+
+	if err = (&atlasproject.AtlasPrivateEndpointReconciler{
+		Client:                   mgr.GetClient(),
+		Log:                      logger.Named("controllers").Named("AtlasPrivateEndpoint").Sugar(),
+		Scheme:                   mgr.GetScheme(),
+		GlobalPredicates:         globalPredicates,
+		EventRecorder:            mgr.GetEventRecorderFor("AtlasPrivateEndpoint"),
+		AtlasProvider:            atlasProvider,
+		ObjectDeletionProtection: config.ObjectDeletionProtection,
+		DryRun:                   config.DryRun,
+		MaxConcurrentReconciles:  config.Workers.For("AtlasPrivateEndpoint"),
+		ProjectLocks:             projectLocks,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasPrivateEndpoint")
+		os.Exit(1)
+	}
+
 	if err = (&atlasdatabaseuser.AtlasDatabaseUserReconciler{
 		ResourceWatcher:             watch.NewResourceWatcher(),
 		Client:                      mgr.GetClient(),
 		Log:                         logger.Named("controllers").Named("AtlasDatabaseUser").Sugar(),
 		Scheme:                      mgr.GetScheme(),
 		AtlasDomain:                 config.AtlasDomain,
+		AtlasProvider:               atlasProvider,
 		GlobalAPISecret:             config.GlobalAPISecret,
 		EventRecorder:               mgr.GetEventRecorderFor("AtlasDatabaseUser"),
 		GlobalPredicates:            globalPredicates,
 		ObjectDeletionProtection:    config.ObjectDeletionProtection,
 		SubObjectDeletionProtection: config.SubObjectDeletionProtection,
+		DryRun:                      config.DryRun,
+		ResyncInterval:              config.ResyncIntervals.DatabaseUser,
+		DriftPolicy:                 config.DriftPolicy,
+		MaxConcurrentReconciles:     config.Workers.For("AtlasDatabaseUser"),
+		CredentialsEvents:           credentialsEvents(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AtlasDatabaseUser")
 		os.Exit(1)
@@ -190,12 +382,18 @@ func main() {
 		Log:                         logger.Named("controllers").Named("AtlasDataFederation").Sugar(),
 		Scheme:                      mgr.GetScheme(),
 		AtlasDomain:                 config.AtlasDomain,
+		AtlasProvider:               atlasProvider,
 		GlobalAPISecret:             config.GlobalAPISecret,
 		ResourceWatcher:             watch.NewResourceWatcher(),
 		GlobalPredicates:            globalPredicates,
 		EventRecorder:               mgr.GetEventRecorderFor("AtlasDataFederation"),
 		ObjectDeletionProtection:    config.ObjectDeletionProtection,
 		SubObjectDeletionProtection: config.SubObjectDeletionProtection,
+		ResyncInterval:              config.ResyncIntervals.DataFederation,
+		DriftPolicy:                 config.DriftPolicy,
+		MaxConcurrentReconciles:     config.Workers.For("AtlasDataFederation"),
+		DryRun:                      config.DryRun,
+		CredentialsEvents:           credentialsEvents(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AtlasDataFederation")
 		os.Exit(1)
@@ -206,25 +404,54 @@ func main() {
 		Log:                         logger.Named("controllers").Named("AtlasFederatedAuth").Sugar(),
 		Scheme:                      mgr.GetScheme(),
 		AtlasDomain:                 config.AtlasDomain,
+		AtlasProvider:               atlasProvider,
 		ResourceWatcher:             watch.NewResourceWatcher(),
 		GlobalPredicates:            globalPredicates,
 		EventRecorder:               mgr.GetEventRecorderFor("AtlasFederatedAuth"),
 		ObjectDeletionProtection:    config.ObjectDeletionProtection,
 		SubObjectDeletionProtection: config.SubObjectDeletionProtection,
+		MaxConcurrentReconciles:     config.Workers.For("AtlasFederatedAuth"),
+		GlobalAPISecret:             config.GlobalAPISecret,
+		DryRun:                      config.DryRun,
+		CredentialsEvents:           credentialsEvents(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AtlasFederatedAuth")
 		os.Exit(1)
 	}
 
+	if config.EnableWebhooks {
+		if err = admission.SetupWebhooksWithManager(mgr, config.AtlasDomain, atlasProvider, config.EnablePolicies); err != nil {
+			setupLog.Error(err, "unable to create webhooks")
+			os.Exit(1)
+		}
+	}
+
 	// +kubebuilder:scaffold:builder
 
-	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up health check")
-		os.Exit(1)
+	var atlasCheck *healthcheck.AtlasCheck
+	if config.AtlasCheckInterval > 0 {
+		atlasCheck = healthcheck.NewAtlasCheck(
+			atlasProvider,
+			healthcheck.OrganizationPing(atlasProvider, logger.Named("healthcheck").Sugar()),
+			config.AtlasCheckInterval,
+			logger.Named("healthcheck").Sugar(),
+		)
+		if err := mgr.Add(atlasCheck); err != nil {
+			setupLog.Error(err, "unable to set up the Atlas check")
+			os.Exit(1)
+		}
 	}
-	if err := mgr.AddReadyzCheck("check", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up ready check")
-		os.Exit(1)
+
+	if config.ProbeAddr != "0" {
+		var webhookChecker healthz.Checker
+		if config.EnableWebhooks {
+			webhookChecker = mgr.GetWebhookServer().StartedChecker()
+		}
+		readiness := healthcheck.NewReadiness(mgr.Elected(), webhookChecker, atlasCheck, logger.Named("healthcheck").Sugar())
+		if err := mgr.Add(healthcheck.NewProbeServer(config.ProbeAddr, readiness, logger.Named("healthcheck").Sugar())); err != nil {
+			setupLog.Error(err, "unable to set up health checks")
+			os.Exit(1)
+		}
 	}
 
 	setupLog.Info("starting manager")
@@ -241,22 +468,50 @@ type Config struct {
 	Namespace                   string
 	WatchedNamespaces           map[string]bool
 	ProbeAddr                   string
+	SyncPeriod                  time.Duration
+	Workers                     concurrency.Workers
+	SerializeProjectReconciles  bool
+	AtlasCheckInterval          time.Duration
 	GlobalAPISecret             client.ObjectKey
+	AtlasCredentialsDir         string
 	LogLevel                    string
 	LogEncoder                  string
 	ObjectDeletionProtection    bool
 	SubObjectDeletionProtection bool
+	AtlasRequestPolicy          atlas.RequestPolicy
+	EnableWebhooks              bool
+	WebhookCertDir              string
+	DryRun                      bool
+	ResyncIntervals             ResyncIntervals
+	DriftPolicy                 drift.Policy
+	EnablePolicies              bool
+	EnableAtlasConnections      bool
+}
+
+// ResyncIntervals configures how often the resources of each kind are reconciled to find the changes made in Atlas
+// outside the Operator. 0 means no periodic reconciliation
+type ResyncIntervals struct {
+	Project        time.Duration
+	Deployment     time.Duration
+	DatabaseUser   time.Duration
+	DataFederation time.Duration
 }
 
 // ParseConfiguration fills the 'OperatorConfig' from the flags passed to the program
 func parseConfiguration() Config {
-	var globalAPISecretName string
+	var globalAPISecretName, driftPolicy, maxConcurrentReconciles string
 	config := Config{}
 	flag.StringVar(&config.AtlasDomain, "atlas-domain", "https://cloud.mongodb.com/", "the Atlas URL domain name (with slash in the end).")
 	flag.StringVar(&config.MetricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&config.ProbeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.DurationVar(&config.AtlasCheckInterval, "atlas-check-interval", healthcheck.DefaultAtlasCheckInterval, "How often the "+
+		"Operator checks that Atlas is reachable with the global API keys, reported by the atlas_operator_atlas_reachable metric. "+
+		"Set to 0 to disable the check")
 	flag.StringVar(&globalAPISecretName, "global-api-secret-name", "", "The name of the Secret that contains Atlas API keys. "+
 		"It is used by the Operator if AtlasProject configuration doesn't contain API key reference. Defaults to <deployment_name>-api-key.")
+	flag.StringVar(&config.AtlasCredentialsDir, "atlas-credentials-dir", "", "The directory to read the Atlas credentials from "+
+		"instead of the Secrets, with a file per key of the connection Secret. The global credentials are at the top of the directory, "+
+		"a connection Secret referenced by a resource is read from the <namespace>/<name> subdirectory. The files are watched for changes")
 	flag.BoolVar(&config.EnableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -266,6 +521,43 @@ func parseConfiguration() Config {
 		"when a Custom Resource is deleted")
 	flag.BoolVar(&config.SubObjectDeletionProtection, subobjectDeletionProtectionFlag, subobjectDeletionProtectionDefault, "Defines if the operator overwrites "+
 		"(and consequently delete) subresources that were not previously created by the operator")
+	flag.IntVar(&config.AtlasRequestPolicy.MaxRetries, "atlas-max-retries", atlas.DefaultMaxRetries, "The number of times a request to Atlas "+
+		"is retried if it failed with HTTP 429 or a server error. Server errors are retried for idempotent requests only")
+	flag.Float64Var(&config.AtlasRequestPolicy.OrgRequestsPerSecond, "atlas-org-rate-limit", 0, "The number of requests per second "+
+		"all the controllers can send to Atlas for a single organization. 0 means no limit")
+	flag.IntVar(&config.AtlasRequestPolicy.OrgBurst, "atlas-org-rate-burst", atlas.DefaultOrgBurst, "The number of requests that can be sent to Atlas "+
+		"at once within the organization rate limit")
+	flag.Float64Var(&config.AtlasRequestPolicy.ProjectRequestsPerSecond, "atlas-project-rate-limit", 0, "The number of requests per second "+
+		"a single Atlas project can consume from the organization rate limit. 0 means no limit per project")
+	flag.BoolVar(&config.EnableWebhooks, "enable-webhooks", false, "Enable the validating admission webhooks for Atlas Custom Resources. "+
+		"Requires the ValidatingWebhookConfiguration and a serving certificate")
+	flag.StringVar(&config.WebhookCertDir, "webhook-cert-dir", "", "The directory containing tls.crt and tls.key for the webhook server. "+
+		"Defaults to <temp-dir>/k8s-webhook-server/serving-certs")
+	flag.BoolVar(&config.DryRun, "dry-run", false, "Only plan the changes of the Atlas Custom Resources to Atlas "+
+		"without applying them. The planned changes are reported in the status and as events. "+
+		"Can be overridden per resource with the "+customresource.DryRunAnnotation+" annotation")
+	flag.DurationVar(&config.ResyncIntervals.Project, "atlas-project-resync-interval", 0, "How often AtlasProjects are "+
+		"reconciled to correct the changes made in Atlas outside the Operator. 0 disables the periodic resync")
+	flag.DurationVar(&config.ResyncIntervals.Deployment, "atlas-deployment-resync-interval", 0, "How often AtlasDeployments are "+
+		"checked for the changes made in Atlas outside the Operator. 0 disables the periodic resync")
+	flag.DurationVar(&config.ResyncIntervals.DatabaseUser, "atlas-database-user-resync-interval", 0, "How often AtlasDatabaseUsers are "+
+		"checked for the changes made in Atlas outside the Operator. 0 disables the periodic resync")
+	flag.DurationVar(&config.ResyncIntervals.DataFederation, "atlas-data-federation-resync-interval", 0, "How often AtlasDataFederations are "+
+		"checked for the changes made in Atlas outside the Operator. 0 disables the periodic resync")
+	flag.BoolVar(&config.EnableAtlasConnections, "enable-atlas-connections", false, "Allow the AtlasProjects and the "+
+		"AtlasFederatedAuths to reference AtlasConnections. Requires the cluster wide permissions to read AtlasConnections")
+	flag.BoolVar(&config.EnablePolicies, "enable-policies", false, "Enforce the AtlasPolicies on the AtlasDeployments during "+
+		"reconciliation and at admission. Requires the cluster wide permissions to read AtlasPolicies and namespaces")
+	flag.StringVar(&driftPolicy, "drift-policy", string(drift.PolicyCorrect), "What to do once a resource was changed in Atlas "+
+		"outside the Operator. Available values: correct | report. Can be overridden per resource with the "+drift.PolicyAnnotation+" annotation")
+	flag.DurationVar(&config.SyncPeriod, syncPeriodFlag, syncPeriodDefault, "How often all the watched resources are reconciled. "+
+		"Can be set with the "+syncPeriodEnvVar+" environment variable")
+	flag.StringVar(&maxConcurrentReconciles, maxConcurrentReconcilesFlag, "", "The number of resources each controller reconciles "+
+		"concurrently, as a comma separated list of a default number and of numbers per kind, e.g. 4,AtlasDatabaseUser=16. "+
+		"Can be set with the "+maxConcurrentReconcilesEnvVar+" environment variable")
+	flag.BoolVar(&config.SerializeProjectReconciles, serializeProjectReconcilesFlag, serializeProjectReconcilesDefault, "Defines if the "+
+		"reconciliations of the resources of the same Atlas project are serialized across the controllers. "+
+		"Can be set with the "+serializeProjectReconcilesEnvVar+" environment variable")
 	appVersion := flag.Bool("v", false, "prints application version")
 	flag.Parse()
 
@@ -276,6 +568,12 @@ func parseConfiguration() Config {
 
 	config.GlobalAPISecret = operatorGlobalKeySecretOrDefault(globalAPISecretName)
 
+	policy, err := drift.ParsePolicy(driftPolicy)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	config.DriftPolicy = policy
+
 	// dev note: we pass the watched namespace as the env variable to use the Kubernetes Downward API. Unfortunately
 	// there is no way to use it for container arguments
 	watchedNamespace := os.Getenv("WATCH_NAMESPACE")
@@ -291,6 +589,10 @@ func parseConfiguration() Config {
 
 	configureDeletionProtection(&config)
 
+	if err = configureConcurrency(&config, maxConcurrentReconciles); err != nil {
+		log.Fatal(err.Error())
+	}
+
 	return config
 }
 
@@ -386,3 +688,41 @@ func configureDeletionProtection(config *Config) {
 		}
 	}
 }
+
+// configureConcurrency reads the concurrency settings from the environment variables unless they're set by the flags
+func configureConcurrency(config *Config, maxConcurrentReconciles string) error {
+	setFlags := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = true
+	})
+
+	if value, ok := os.LookupEnv(syncPeriodEnvVar); ok && !setFlags[syncPeriodFlag] {
+		syncPeriod, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s environment variable: %w", syncPeriodEnvVar, err)
+		}
+		config.SyncPeriod = syncPeriod
+	}
+	if config.SyncPeriod <= 0 {
+		return fmt.Errorf("the sync period must be positive, got %s", config.SyncPeriod)
+	}
+
+	if value, ok := os.LookupEnv(maxConcurrentReconcilesEnvVar); ok && !setFlags[maxConcurrentReconcilesFlag] {
+		maxConcurrentReconciles = value
+	}
+	workers, err := concurrency.ParseWorkers(maxConcurrentReconciles, controllerKinds)
+	if err != nil {
+		return err
+	}
+	config.Workers = workers
+
+	if value, ok := os.LookupEnv(serializeProjectReconcilesEnvVar); ok && !setFlags[serializeProjectReconcilesFlag] {
+		serialize, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s environment variable: %w", serializeProjectReconcilesEnvVar, err)
+		}
+		config.SerializeProjectReconciles = serialize
+	}
+
+	return nil
+}
@@ -180,3 +180,43 @@ func Test_configureDeletionProtection(t *testing.T) {
 		)
 	})
 }
+
+func Test_configureConcurrency(t *testing.T) {
+	parseFlags := func(config *Config, args ...string) {
+		os.Args = append([]string{"app"}, args...)
+		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+		flag.DurationVar(&config.SyncPeriod, syncPeriodFlag, syncPeriodDefault, "")
+		flag.BoolVar(&config.SerializeProjectReconciles, serializeProjectReconcilesFlag, serializeProjectReconcilesDefault, "")
+		flag.Parse()
+	}
+
+	t.Run("should use the env vars when the flags were not set", func(t *testing.T) {
+		config := Config{}
+		defer func(old []string) { os.Args = old }(os.Args)
+		t.Setenv(serializeProjectReconcilesEnvVar, "false")
+		t.Setenv(maxConcurrentReconcilesEnvVar, "4,AtlasDatabaseUser=16")
+		parseFlags(&config)
+
+		assert.NoError(t, configureConcurrency(&config, ""))
+		assert.False(t, config.SerializeProjectReconciles)
+		assert.Equal(t, 4, config.Workers.For("AtlasDeployment"))
+		assert.Equal(t, 16, config.Workers.For("AtlasDatabaseUser"))
+	})
+
+	t.Run("should fail on an invalid serialization env var", func(t *testing.T) {
+		config := Config{}
+		defer func(old []string) { os.Args = old }(os.Args)
+		t.Setenv(serializeProjectReconcilesEnvVar, "yes please")
+		parseFlags(&config)
+
+		assert.ErrorContains(t, configureConcurrency(&config, ""), "invalid "+serializeProjectReconcilesEnvVar+" environment variable")
+	})
+
+	t.Run("should fail on an unknown kind of workers", func(t *testing.T) {
+		config := Config{}
+		defer func(old []string) { os.Args = old }(os.Args)
+		parseFlags(&config)
+
+		assert.ErrorContains(t, configureConcurrency(&config, "AtlasCluster=4"), `unknown kind "AtlasCluster"`)
+	})
+}
@@ -0,0 +1,106 @@
+// Package controllertest has the fixtures shared by the tests of the controllers, which reconcile the Atlas Custom
+// Resources stored in a fake Kubernetes client with a mocked Atlas client
+package controllertest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	atlas_mock "github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/mocks/atlas"
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+const (
+	Namespace   = "test-namespace"
+	ProjectName = "my-project"
+	ProjectID   = "my-project-id"
+)
+
+// NewKubeClient returns a fake Kubernetes client storing the Atlas Custom Resources and the Secrets
+func NewKubeClient(objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(mdbv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+// NewProvider returns a provider connecting all the resources to Atlas for commercial with the given client
+func NewProvider(atlasClient mongodbatlas.Client) *atlas_mock.TestProvider {
+	return &atlas_mock.TestProvider{
+		CreateConnectionFunc: func(secretRef *client.ObjectKey) (atlas.Connection, error) {
+			return atlas.Connection{}, nil
+		},
+		CreateClientFunc: func() (mongodbatlas.Client, error) {
+			return atlasClient, nil
+		},
+		IsCloudGovFunc: func() bool {
+			return false
+		},
+		IsSupportedFunc: func() bool {
+			return true
+		},
+	}
+}
+
+// Project returns the project the resources of the test namespace reference, as created in Atlas
+func Project() *mdbv1.AtlasProject {
+	return &mdbv1.AtlasProject{
+		ObjectMeta: metav1.ObjectMeta{Name: ProjectName, Namespace: Namespace},
+		Status: status.AtlasProjectStatus{
+			ID: ProjectID,
+		},
+	}
+}
+
+// Deployment returns an idle deployment of the project
+func Deployment(name, clusterName string) *mdbv1.AtlasDeployment {
+	return &mdbv1.AtlasDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: Namespace},
+		Spec: mdbv1.AtlasDeploymentSpec{
+			Project:        common.ResourceRefNamespaced{Name: ProjectName},
+			DeploymentSpec: &mdbv1.AdvancedDeploymentSpec{Name: clusterName},
+		},
+		Status: status.AtlasDeploymentStatus{
+			StateName: status.StateIDLE,
+		},
+	}
+}
+
+// Read returns the resource as stored in Kubernetes, usually after it's reconciled
+func Read[T client.Object](t *testing.T, k8sClient client.Client, resource T) T {
+	t.Helper()
+
+	stored := reflect.New(reflect.TypeOf(resource).Elem()).Interface().(T)
+	require.NoError(t, k8sClient.Get(context.Background(), kube.ObjectKeyFromObject(resource), stored))
+	return stored
+}
+
+// AssertCondition checks the status and the reason of the condition of the resource
+func AssertCondition(t *testing.T, resource status.Reader, conditionType status.ConditionType, conditionStatus corev1.ConditionStatus, reason string) {
+	t.Helper()
+
+	for _, condition := range resource.GetStatus().GetConditions() {
+		if condition.Type == conditionType {
+			assert.Equal(t, conditionStatus, condition.Status)
+			assert.Equal(t, reason, condition.Reason)
+			return
+		}
+	}
+	t.Errorf("condition %s not found in %v", conditionType, resource.GetStatus().GetConditions())
+}
@@ -0,0 +1,307 @@
+package fakeatlas
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+const (
+	advancedClustersPath = "/api/atlas/v1.5/groups/{groupID}/clusters"
+	clustersPath         = "/api/atlas/v1.0/groups/{groupID}/clusters"
+
+	defaultMongoDBMajorVersion = "6.0"
+
+	stateIdle      = "IDLE"
+	stateCreating  = "CREATING"
+	stateUpdating  = "UPDATING"
+	stateRepairing = "REPAIRING"
+	stateDeleting  = "DELETING"
+)
+
+type cluster struct {
+	mongodbatlas.AdvancedCluster
+	processArgs mongodbatlas.ProcessArgs
+
+	// since is when the ongoing operation started, the cluster reaches the IDLE state or is deleted once it's over
+	since time.Time
+}
+
+func (s *Server) clusterRoutes() {
+	s.handle(http.MethodGet, advancedClustersPath, s.listClusters)
+	s.handle(http.MethodPost, advancedClustersPath, s.createCluster)
+	s.handle(http.MethodGet, advancedClustersPath+"/{name}", s.getCluster)
+	s.handle(http.MethodPatch, advancedClustersPath+"/{name}", s.updateCluster)
+	s.handle(http.MethodDelete, advancedClustersPath+"/{name}", s.deleteCluster)
+	s.handle(http.MethodPost, advancedClustersPath+"/{name}/restartPrimaries", s.testFailover)
+	s.handle(http.MethodGet, advancedClustersPath+"/{name}/globalWrites", s.getGlobalWrites)
+	s.handle(http.MethodGet, clustersPath+"/{name}/processArgs", s.getProcessArgs)
+	s.handle(http.MethodPatch, clustersPath+"/{name}/processArgs", s.updateProcessArgs)
+}
+
+func (s *Server) listClusters(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	clusters := make([]mongodbatlas.AdvancedCluster, 0, len(p.clusters))
+	for _, c := range s.activeClusters(p) {
+		clusters = append(clusters, c.AdvancedCluster)
+	}
+	writePage(w, r, clusters)
+}
+
+func (s *Server) createCluster(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	c := &cluster{}
+	if !readJSON(w, r, &c.AdvancedCluster) {
+		return
+	}
+
+	if c.Name == "" {
+		writeError(w, http.StatusBadRequest, "MISSING_ATTRIBUTE", "The required attribute name was not specified.")
+		return
+	}
+	if len(c.ReplicationSpecs) == 0 {
+		writeError(w, http.StatusBadRequest, "MISSING_ATTRIBUTE", "The required attribute replicationSpecs was not specified.")
+		return
+	}
+	if _, exists := s.activeCluster(p, c.Name); exists {
+		writeError(w, http.StatusBadRequest, "DUPLICATE_CLUSTER_NAME", fmt.Sprintf("A cluster named %s is already present in group %s.", c.Name, p.ID))
+		return
+	}
+
+	s.setClusterDefaults(p, c)
+	c.StateName = stateCreating
+	c.since = time.Now()
+	p.clusters[c.Name] = c
+
+	writeJSON(w, http.StatusCreated, c.AdvancedCluster)
+}
+
+func (s *Server) getCluster(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	_, c, ok := s.cluster(w, params)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, c.AdvancedCluster)
+}
+
+func (s *Server) updateCluster(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, c, ok := s.cluster(w, params)
+	if !ok {
+		return
+	}
+
+	if c.StateName == stateDeleting {
+		writeError(w, http.StatusBadRequest, "CLUSTER_ALREADY_REQUESTED_DELETION", fmt.Sprintf("Cluster %s has already been requested for deletion.", c.Name))
+		return
+	}
+
+	name, id, created := c.Name, c.ID, c.CreateDate
+	if !merge(w, r, &c.AdvancedCluster) {
+		return
+	}
+	c.Name, c.ID, c.CreateDate = name, id, created
+
+	s.setClusterDefaults(p, c)
+	c.StateName = stateUpdating
+	c.since = time.Now()
+
+	writeJSON(w, http.StatusOK, c.AdvancedCluster)
+}
+
+func (s *Server) deleteCluster(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	_, c, ok := s.cluster(w, params)
+	if !ok {
+		return
+	}
+
+	if c.TerminationProtectionEnabled != nil && *c.TerminationProtectionEnabled {
+		writeError(w, http.StatusBadRequest, "CANNOT_TERMINATE_CLUSTER_WHEN_TERMINATION_PROTECTION_ENABLED",
+			"Unable to terminate cluster when termination protection is enabled.")
+		return
+	}
+
+	if c.StateName != stateDeleting {
+		c.StateName = stateDeleting
+		c.since = time.Now()
+	}
+
+	writeJSON(w, http.StatusAccepted, nil)
+}
+
+// testFailover restarts the primaries, the cluster is repairing until the failover is over
+func (s *Server) testFailover(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	_, c, ok := s.cluster(w, params)
+	if !ok {
+		return
+	}
+
+	if c.StateName != stateIdle {
+		writeError(w, http.StatusConflict, "CLUSTER_RESTART_INVALID", fmt.Sprintf("Cluster %s is not ready for a restart of its primaries.", c.Name))
+		return
+	}
+
+	c.StateName = stateRepairing
+	c.since = time.Now()
+
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+func (s *Server) getGlobalWrites(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	if _, _, ok := s.cluster(w, params); !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mongodbatlas.GlobalCluster{
+		CustomZoneMapping: map[string]string{},
+		ManagedNamespaces: []mongodbatlas.ManagedNamespace{},
+	})
+}
+
+func (s *Server) getProcessArgs(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	_, c, ok := s.cluster(w, params)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, c.processArgs)
+}
+
+func (s *Server) updateProcessArgs(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, c, ok := s.cluster(w, params)
+	if !ok {
+		return
+	}
+
+	if !merge(w, r, &c.processArgs) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, c.processArgs)
+}
+
+// cluster returns the cluster of the groupID and name path parameters, it replies with an error if the cluster
+// doesn't exist
+func (s *Server) cluster(w http.ResponseWriter, params map[string]string) (*project, *cluster, bool) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+
+	c, ok := s.activeCluster(p, params["name"])
+	if !ok {
+		writeError(w, http.StatusNotFound, "CLUSTER_NOT_FOUND", fmt.Sprintf("No cluster named %s exists in group %s.", params["name"], p.ID))
+		return nil, nil, false
+	}
+
+	return p, c, true
+}
+
+// activeCluster returns the cluster unless it doesn't exist or its deletion is over
+func (s *Server) activeCluster(p *project, name string) (*cluster, bool) {
+	c, ok := p.clusters[name]
+	if !ok || !s.settleCluster(p, c) {
+		return nil, false
+	}
+	return c, true
+}
+
+// activeClusters returns the clusters of the project whose deletion isn't over, sorted by name
+func (s *Server) activeClusters(p *project) []*cluster {
+	var clusters []*cluster
+	for _, c := range sortedValues(p.clusters) {
+		if s.settleCluster(p, c) {
+			clusters = append(clusters, c)
+		}
+	}
+	return clusters
+}
+
+// settleCluster ends the ongoing operation of the cluster if it's over. It returns false if the cluster got deleted
+func (s *Server) settleCluster(p *project, c *cluster) bool {
+	if c.StateName == stateIdle || !s.settled(c.since) {
+		return true
+	}
+
+	if c.StateName == stateDeleting {
+		delete(p.clusters, c.Name)
+		return false
+	}
+
+	c.StateName = stateIdle
+	return true
+}
+
+// setClusterDefaults sets the fields Atlas computes or defaults
+func (s *Server) setClusterDefaults(p *project, c *cluster) {
+	if c.ID == "" {
+		c.ID = s.newID()
+		c.CreateDate = time.Now().UTC().Format(time.RFC3339)
+		c.processArgs = defaultProcessArgs()
+	}
+	c.GroupID = p.ID
+
+	if c.ClusterType == "" {
+		c.ClusterType = "REPLICASET"
+	}
+	if c.MongoDBMajorVersion == "" {
+		c.MongoDBMajorVersion = defaultMongoDBMajorVersion
+	}
+	c.MongoDBVersion = c.MongoDBMajorVersion + ".0"
+	if c.VersionReleaseSystem == "" {
+		c.VersionReleaseSystem = "LTS"
+	}
+	if c.RootCertType == "" {
+		c.RootCertType = "ISRGROOTX1"
+	}
+	if c.EncryptionAtRestProvider == "" {
+		c.EncryptionAtRestProvider = "NONE"
+	}
+	if c.BiConnector == nil {
+		c.BiConnector = &mongodbatlas.BiConnector{Enabled: toptr.MakePtr(false), ReadPreference: "secondary"}
+	}
+	for _, flag := range []**bool{&c.BackupEnabled, &c.PitEnabled, &c.Paused, &c.TerminationProtectionEnabled} {
+		if *flag == nil {
+			*flag = toptr.MakePtr(false)
+		}
+	}
+
+	for i, replicationSpec := range c.ReplicationSpecs {
+		if replicationSpec.ID == "" {
+			replicationSpec.ID = s.newID()
+		}
+		if replicationSpec.NumShards == 0 {
+			replicationSpec.NumShards = 1
+		}
+		if replicationSpec.ZoneName == "" {
+			replicationSpec.ZoneName = fmt.Sprintf("Zone %d", i+1)
+		}
+	}
+
+	host := fmt.Sprintf("%s.%s.mongodb.net", c.Name, c.ID[len(c.ID)-5:])
+	c.ConnectionStrings = &mongodbatlas.ConnectionStrings{
+		Standard:    fmt.Sprintf("mongodb://%s-shard-00-00.%s:27017/?ssl=true&authSource=admin", c.Name, host),
+		StandardSrv: fmt.Sprintf("mongodb+srv://%s", host),
+	}
+}
+
+func defaultProcessArgs() mongodbatlas.ProcessArgs {
+	return mongodbatlas.ProcessArgs{
+		FailIndexKeyTooLong:       toptr.MakePtr(false),
+		JavascriptEnabled:         toptr.MakePtr(true),
+		MinimumEnabledTLSProtocol: "TLS1_2",
+		NoTableScan:               toptr.MakePtr(false),
+	}
+}
@@ -0,0 +1,152 @@
+package fakeatlas
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+const containersPath = "/api/atlas/v1.0/groups/{groupID}/containers"
+
+func (s *Server) containerRoutes() {
+	s.handle(http.MethodGet, containersPath, s.listContainers)
+	s.handle(http.MethodGet, containersPath+"/all", s.listAllContainers)
+	s.handle(http.MethodPost, containersPath, s.createContainer)
+	s.handle(http.MethodGet, containersPath+"/{containerID}", s.getContainer)
+	s.handle(http.MethodPatch, containersPath+"/{containerID}", s.updateContainer)
+	s.handle(http.MethodDelete, containersPath+"/{containerID}", s.deleteContainer)
+}
+
+// listContainers lists the containers of the provider of the providerName query parameter, AWS by default like in
+// Atlas
+func (s *Server) listContainers(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	providerName := r.URL.Query().Get("providerName")
+	if providerName == "" {
+		providerName = "AWS"
+	}
+
+	containers := make([]mongodbatlas.Container, 0, len(p.containers))
+	for _, container := range sortedValues(p.containers) {
+		if container.ProviderName == providerName {
+			containers = append(containers, *container)
+		}
+	}
+	writePage(w, r, containers)
+}
+
+func (s *Server) listAllContainers(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	containers := make([]mongodbatlas.Container, 0, len(p.containers))
+	for _, container := range sortedValues(p.containers) {
+		containers = append(containers, *container)
+	}
+	writePage(w, r, containers)
+}
+
+func (s *Server) createContainer(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	container := &mongodbatlas.Container{}
+	if !readJSON(w, r, container) {
+		return
+	}
+
+	if _, _, err := net.ParseCIDR(container.AtlasCIDRBlock); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ATTRIBUTE", fmt.Sprintf("Invalid attribute atlasCidrBlock %q.", container.AtlasCIDRBlock))
+		return
+	}
+
+	container.ID = s.newID()
+	container.Provisioned = toptr.MakePtr(false)
+	switch container.ProviderName {
+	case "AWS":
+		if container.RegionName == "" {
+			writeError(w, http.StatusBadRequest, "MISSING_ATTRIBUTE", "The required attribute regionName was not specified.")
+			return
+		}
+		container.VPCID = "vpc-" + container.ID[len(container.ID)-17:]
+	case "GCP":
+		container.GCPProjectID = "p-" + container.ID[len(container.ID)-12:]
+		container.NetworkName = "nt-" + container.ID
+	case "AZURE":
+		if container.Region == "" {
+			writeError(w, http.StatusBadRequest, "MISSING_ATTRIBUTE", "The required attribute region was not specified.")
+			return
+		}
+		container.AzureSubscriptionID = container.ID
+		container.VNetName = "vnet_" + container.ID
+	default:
+		writeError(w, http.StatusBadRequest, "INVALID_PROVIDER", fmt.Sprintf("Invalid provider %q.", container.ProviderName))
+		return
+	}
+	p.containers[container.ID] = container
+
+	writeJSON(w, http.StatusCreated, container)
+}
+
+func (s *Server) getContainer(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	_, container, ok := s.container(w, params)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, container)
+}
+
+func (s *Server) updateContainer(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, container, ok := s.container(w, params)
+	if !ok {
+		return
+	}
+
+	id, providerName := container.ID, container.ProviderName
+	if !merge(w, r, container) {
+		return
+	}
+	container.ID, container.ProviderName = id, providerName
+
+	writeJSON(w, http.StatusOK, container)
+}
+
+func (s *Server) deleteContainer(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, container, ok := s.container(w, params)
+	if !ok {
+		return
+	}
+
+	delete(p.containers, container.ID)
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+// container returns the container of the groupID and containerID path parameters, it replies with an error if the
+// container doesn't exist
+func (s *Server) container(w http.ResponseWriter, params map[string]string) (*project, *mongodbatlas.Container, bool) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+
+	container, ok := p.containers[params["containerID"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "CLOUD_PROVIDER_CONTAINER_NOT_FOUND", fmt.Sprintf("Container %s not found in group %s.", params["containerID"], p.ID))
+		return nil, nil, false
+	}
+
+	return p, container, true
+}
@@ -0,0 +1,125 @@
+package fakeatlas
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const databaseUsersPath = "/api/atlas/v1.0/groups/{groupID}/databaseUsers"
+
+func (s *Server) databaseUserRoutes() {
+	s.handle(http.MethodGet, databaseUsersPath, s.listDatabaseUsers)
+	s.handle(http.MethodPost, databaseUsersPath, s.createDatabaseUser)
+	s.handle(http.MethodGet, databaseUsersPath+"/{authDB}/{username}", s.getDatabaseUser)
+	s.handle(http.MethodPatch, databaseUsersPath+"/{authDB}/{username}", s.updateDatabaseUser)
+	s.handle(http.MethodDelete, databaseUsersPath+"/{authDB}/{username}", s.deleteDatabaseUser)
+}
+
+func (s *Server) listDatabaseUsers(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	users := make([]mongodbatlas.DatabaseUser, 0, len(p.databaseUsers))
+	for _, user := range sortedValues(p.databaseUsers) {
+		users = append(users, databaseUserView(user))
+	}
+	writePage(w, r, users)
+}
+
+func (s *Server) createDatabaseUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	user := &mongodbatlas.DatabaseUser{}
+	if !readJSON(w, r, user) {
+		return
+	}
+
+	if user.Username == "" || user.DatabaseName == "" {
+		writeError(w, http.StatusBadRequest, "MISSING_ATTRIBUTE", "The required attributes username and databaseName were not specified.")
+		return
+	}
+	key := databaseUserKey(user.DatabaseName, user.Username)
+	if _, exists := p.databaseUsers[key]; exists {
+		writeError(w, http.StatusConflict, "USER_ALREADY_EXISTS", fmt.Sprintf("The specified user %s already exists.", user.Username))
+		return
+	}
+
+	user.GroupID = p.ID
+	if user.Scopes == nil {
+		user.Scopes = []mongodbatlas.Scope{}
+	}
+	p.databaseUsers[key] = user
+
+	writeJSON(w, http.StatusCreated, databaseUserView(user))
+}
+
+func (s *Server) getDatabaseUser(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	_, user, ok := s.databaseUser(w, params)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, databaseUserView(user))
+}
+
+func (s *Server) updateDatabaseUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, user, ok := s.databaseUser(w, params)
+	if !ok {
+		return
+	}
+
+	if !merge(w, r, user) {
+		return
+	}
+	user.GroupID, user.DatabaseName, user.Username = p.ID, params["authDB"], params["username"]
+	if user.Scopes == nil {
+		user.Scopes = []mongodbatlas.Scope{}
+	}
+
+	writeJSON(w, http.StatusOK, databaseUserView(user))
+}
+
+func (s *Server) deleteDatabaseUser(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, user, ok := s.databaseUser(w, params)
+	if !ok {
+		return
+	}
+
+	delete(p.databaseUsers, databaseUserKey(user.DatabaseName, user.Username))
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+// databaseUser returns the database user of the groupID, authDB and username path parameters, it replies with an
+// error if the user doesn't exist
+func (s *Server) databaseUser(w http.ResponseWriter, params map[string]string) (*project, *mongodbatlas.DatabaseUser, bool) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+
+	user, ok := p.databaseUsers[databaseUserKey(params["authDB"], params["username"])]
+	if !ok {
+		writeError(w, http.StatusNotFound, "USERNAME_NOT_FOUND", fmt.Sprintf("No user with username %s exists.", params["username"]))
+		return nil, nil, false
+	}
+
+	return p, user, true
+}
+
+func databaseUserKey(authDB, username string) string {
+	return authDB + "/" + username
+}
+
+// databaseUserView returns the user as Atlas returns it, without its password
+func databaseUserView(user *mongodbatlas.DatabaseUser) mongodbatlas.DatabaseUser {
+	view := *user
+	view.Password = ""
+	return view
+}
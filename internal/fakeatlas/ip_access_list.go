@@ -0,0 +1,168 @@
+package fakeatlas
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	accessListPath = "/api/atlas/v1.0/groups/{groupID}/accessList"
+
+	accessListStatusPending = "PENDING"
+	accessListStatusActive  = "ACTIVE"
+)
+
+type accessListEntry struct {
+	mongodbatlas.ProjectIPAccessList
+
+	// since is when the entry was added, it's active once the transition is over
+	since time.Time
+}
+
+func (s *Server) ipAccessListRoutes() {
+	s.handle(http.MethodGet, accessListPath, s.listAccessList)
+	s.handle(http.MethodPost, accessListPath, s.addAccessList)
+	s.handle(http.MethodGet, accessListPath+"/{entry}", s.getAccessListEntry)
+	s.handle(http.MethodDelete, accessListPath+"/{entry}", s.deleteAccessListEntry)
+	s.handle(http.MethodGet, accessListPath+"/{entry}/status", s.getAccessListEntryStatus)
+}
+
+func (s *Server) listAccessList(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	writePage(w, r, accessList(p))
+}
+
+// addAccessList adds the entries of the request to the access list, or updates them if they already exist, and replies
+// with the whole access list
+func (s *Server) addAccessList(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	var entries []mongodbatlas.ProjectIPAccessList
+	if !readJSON(w, r, &entries) {
+		return
+	}
+
+	for _, entry := range entries {
+		if err := validateAccessListEntry(entry); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_IP_ADDRESS_OR_CIDR_NOTATION", err.Error())
+			return
+		}
+	}
+
+	for _, entry := range entries {
+		entry.GroupID = p.ID
+		key := accessListKey(entry)
+		if existing, ok := p.accessList[key]; ok {
+			existing.Comment, existing.DeleteAfterDate = entry.Comment, entry.DeleteAfterDate
+			continue
+		}
+		p.accessList[key] = &accessListEntry{ProjectIPAccessList: entry, since: time.Now()}
+	}
+
+	writePage(w, r, accessList(p))
+}
+
+func (s *Server) getAccessListEntry(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	_, entry, ok := s.accessListEntry(w, params)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entry.ProjectIPAccessList)
+}
+
+func (s *Server) deleteAccessListEntry(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, entry, ok := s.accessListEntry(w, params)
+	if !ok {
+		return
+	}
+
+	delete(p.accessList, accessListKey(entry.ProjectIPAccessList))
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) getAccessListEntryStatus(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	_, entry, ok := s.accessListEntry(w, params)
+	if !ok {
+		return
+	}
+
+	status := accessListStatusPending
+	if s.settled(entry.since) {
+		status = accessListStatusActive
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"STATUS": status})
+}
+
+// accessListEntry returns the access list entry of the groupID and entry path parameters, it replies with an error if
+// the entry doesn't exist
+func (s *Server) accessListEntry(w http.ResponseWriter, params map[string]string) (*project, *accessListEntry, bool) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+
+	entry, ok := p.accessList[params["entry"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", fmt.Sprintf("No IP access list entry %s exists in group %s.", params["entry"], p.ID))
+		return nil, nil, false
+	}
+
+	return p, entry, true
+}
+
+func accessList(p *project) []mongodbatlas.ProjectIPAccessList {
+	entries := make([]mongodbatlas.ProjectIPAccessList, 0, len(p.accessList))
+	for _, entry := range sortedValues(p.accessList) {
+		entries = append(entries, entry.ProjectIPAccessList)
+	}
+	return entries
+}
+
+// accessListKey returns the value identifying the entry in the paths of the access list endpoints
+func accessListKey(entry mongodbatlas.ProjectIPAccessList) string {
+	switch {
+	case entry.CIDRBlock != "":
+		return entry.CIDRBlock
+	case entry.IPAddress != "":
+		return entry.IPAddress
+	default:
+		return entry.AwsSecurityGroup
+	}
+}
+
+func validateAccessListEntry(entry mongodbatlas.ProjectIPAccessList) error {
+	set := 0
+	for _, value := range []string{entry.CIDRBlock, entry.IPAddress, entry.AwsSecurityGroup} {
+		if value != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("an access list entry must have exactly one of cidrBlock, ipAddress or awsSecurityGroup")
+	}
+
+	if entry.CIDRBlock != "" {
+		if _, _, err := net.ParseCIDR(entry.CIDRBlock); err != nil {
+			return fmt.Errorf("the CIDR block %s is invalid", entry.CIDRBlock)
+		}
+	}
+
+	if entry.IPAddress != "" && net.ParseIP(entry.IPAddress) == nil {
+		return fmt.Errorf("the IP address %s is invalid", entry.IPAddress)
+	}
+
+	return nil
+}
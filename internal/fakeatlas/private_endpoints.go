@@ -0,0 +1,283 @@
+package fakeatlas
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+const (
+	privateEndpointsPath = "/api/atlas/v1.0/groups/{groupID}/privateEndpoint"
+
+	privateEndpointInitiating        = "INITIATING"
+	privateEndpointPendingAcceptance = "PENDING_ACCEPTANCE"
+	privateEndpointAvailable         = "AVAILABLE"
+)
+
+type privateEndpointService struct {
+	mongodbatlas.PrivateEndpointConnection
+
+	// since is when the service was created, it's available once the transition is over
+	since time.Time
+
+	// endpoints are the interface endpoints of the service by the ID used in their path
+	endpoints map[string]*interfaceEndpoint
+}
+
+type interfaceEndpoint struct {
+	mongodbatlas.InterfaceEndpointConnection
+
+	// since is when the endpoint was added, it's available once the transition is over
+	since time.Time
+}
+
+func (s *Server) privateEndpointRoutes() {
+	s.handle(http.MethodPost, privateEndpointsPath+"/endpointService", s.createPrivateEndpointService)
+	s.handle(http.MethodGet, privateEndpointsPath+"/{provider}/endpointService", s.listPrivateEndpointServices)
+	s.handle(http.MethodGet, privateEndpointsPath+"/{provider}/endpointService/{serviceID}", s.getPrivateEndpointService)
+	s.handle(http.MethodDelete, privateEndpointsPath+"/{provider}/endpointService/{serviceID}", s.deletePrivateEndpointService)
+	s.handle(http.MethodPost, privateEndpointsPath+"/{provider}/endpointService/{serviceID}/endpoint", s.addInterfaceEndpoint)
+	s.handle(http.MethodGet, privateEndpointsPath+"/{provider}/endpointService/{serviceID}/endpoint/{endpointID}", s.getInterfaceEndpoint)
+	s.handle(http.MethodDelete, privateEndpointsPath+"/{provider}/endpointService/{serviceID}/endpoint/{endpointID}", s.deleteInterfaceEndpoint)
+}
+
+func (s *Server) createPrivateEndpointService(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	request := mongodbatlas.PrivateEndpointConnection{}
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	if request.ProviderName != "AWS" && request.ProviderName != "AZURE" && request.ProviderName != "GCP" {
+		writeError(w, http.StatusBadRequest, "INVALID_PROVIDER", fmt.Sprintf("Invalid provider %q.", request.ProviderName))
+		return
+	}
+	if request.Region == "" {
+		writeError(w, http.StatusBadRequest, "MISSING_ATTRIBUTE", "The required attribute region was not specified.")
+		return
+	}
+
+	service := &privateEndpointService{
+		PrivateEndpointConnection: mongodbatlas.PrivateEndpointConnection{
+			ID:           s.newID(),
+			ProviderName: request.ProviderName,
+			Region:       request.Region,
+			Status:       privateEndpointInitiating,
+		},
+		since:     time.Now(),
+		endpoints: map[string]*interfaceEndpoint{},
+	}
+	p.privateEndpoints[service.ID] = service
+
+	writeJSON(w, http.StatusCreated, service.PrivateEndpointConnection)
+}
+
+func (s *Server) listPrivateEndpointServices(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	services := make([]mongodbatlas.PrivateEndpointConnection, 0, len(p.privateEndpoints))
+	for _, service := range sortedValues(p.privateEndpoints) {
+		if service.ProviderName == params["provider"] {
+			services = append(services, s.privateEndpointServiceView(service))
+		}
+	}
+	writeJSON(w, http.StatusOK, services)
+}
+
+func (s *Server) getPrivateEndpointService(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	_, service, ok := s.privateEndpointService(w, params)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.privateEndpointServiceView(service))
+}
+
+func (s *Server) deletePrivateEndpointService(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, service, ok := s.privateEndpointService(w, params)
+	if !ok {
+		return
+	}
+
+	if len(service.endpoints) > 0 {
+		writeError(w, http.StatusBadRequest, "CANNOT_DELETE_ENDPOINT_SERVICE_WITH_ENDPOINTS",
+			fmt.Sprintf("Cannot delete the private endpoint service %s while it has private endpoints.", service.ID))
+		return
+	}
+
+	delete(p.privateEndpoints, service.ID)
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+// addInterfaceEndpoint adds a private endpoint to the service. The endpoint is identified by its ID for AWS and Azure,
+// and by its endpoint group name for GCP
+func (s *Server) addInterfaceEndpoint(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, service, ok := s.privateEndpointService(w, params)
+	if !ok {
+		return
+	}
+
+	request := mongodbatlas.InterfaceEndpointConnection{}
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	endpoint := &interfaceEndpoint{since: time.Now()}
+	var endpointID string
+	switch service.ProviderName {
+	case "AWS":
+		endpointID = request.ID
+		endpoint.InterfaceEndpointID = request.ID
+		endpoint.AWSConnectionStatus = privateEndpointPendingAcceptance
+		endpoint.DeleteRequested = toptr.MakePtr(false)
+	case "AZURE":
+		endpointID = request.ID
+		endpoint.PrivateEndpointResourceID = request.ID
+		endpoint.PrivateEndpointIPAddress = request.PrivateEndpointIPAddress
+		endpoint.PrivateEndpointConnectionName = fmt.Sprintf("%s.%s", request.ID[strings.LastIndex(request.ID, "/")+1:], service.ID)
+		endpoint.Status = privateEndpointInitiating
+		endpoint.DeleteRequested = toptr.MakePtr(false)
+	case "GCP":
+		endpointID = request.EndpointGroupName
+		endpoint.EndpointGroupName = request.EndpointGroupName
+		endpoint.GCPProjectID = request.GCPProjectID
+		endpoint.Endpoints = request.Endpoints
+		for _, gcpEndpoint := range endpoint.Endpoints {
+			gcpEndpoint.Status = privateEndpointInitiating
+		}
+		endpoint.Status = privateEndpointInitiating
+		endpoint.DeleteRequested = toptr.MakePtr(false)
+	}
+
+	if endpointID == "" {
+		writeError(w, http.StatusBadRequest, "MISSING_ATTRIBUTE", "The private endpoint doesn't have an identifier.")
+		return
+	}
+	if _, exists := service.endpoints[endpointID]; exists {
+		writeError(w, http.StatusConflict, "PRIVATE_ENDPOINT_ALREADY_EXISTS", fmt.Sprintf("The private endpoint %s already exists.", endpointID))
+		return
+	}
+	service.endpoints[endpointID] = endpoint
+
+	writeJSON(w, http.StatusCreated, endpoint.InterfaceEndpointConnection)
+}
+
+func (s *Server) getInterfaceEndpoint(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	_, endpoint, ok := s.interfaceEndpoint(w, params)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.interfaceEndpointView(endpoint))
+}
+
+func (s *Server) deleteInterfaceEndpoint(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	service, _, ok := s.interfaceEndpoint(w, params)
+	if !ok {
+		return
+	}
+
+	delete(service.endpoints, params["endpointID"])
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+// privateEndpointService returns the service of the groupID, provider and serviceID path parameters, it replies with an
+// error if the service doesn't exist
+func (s *Server) privateEndpointService(w http.ResponseWriter, params map[string]string) (*project, *privateEndpointService, bool) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+
+	service, ok := p.privateEndpoints[params["serviceID"]]
+	if !ok || service.ProviderName != params["provider"] {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", fmt.Sprintf("No private endpoint service %s exists in group %s.", params["serviceID"], p.ID))
+		return nil, nil, false
+	}
+
+	return p, service, true
+}
+
+func (s *Server) interfaceEndpoint(w http.ResponseWriter, params map[string]string) (*privateEndpointService, *interfaceEndpoint, bool) {
+	_, service, ok := s.privateEndpointService(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+
+	endpoint, ok := service.endpoints[params["endpointID"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", fmt.Sprintf("No private endpoint %s exists for the service %s.", params["endpointID"], service.ID))
+		return nil, nil, false
+	}
+
+	return service, endpoint, true
+}
+
+// privateEndpointServiceView returns the service as Atlas returns it, with the names the provider generates once it's
+// available and the identifiers of its endpoints
+func (s *Server) privateEndpointServiceView(service *privateEndpointService) mongodbatlas.PrivateEndpointConnection {
+	view := service.PrivateEndpointConnection
+	if s.settled(service.since) {
+		view.Status = privateEndpointAvailable
+		switch view.ProviderName {
+		case "AWS":
+			view.EndpointServiceName = fmt.Sprintf("com.amazonaws.vpce.%s.vpce-svc-%s", view.Region, view.ID[len(view.ID)-17:])
+		case "AZURE":
+			view.PrivateLinkServiceName = "pls_" + view.ID
+			view.PrivateLinkServiceResourceID = fmt.Sprintf("/subscriptions/%s/resourceGroups/rg_%s/providers/Microsoft.Network/privateLinkServices/pls_%s", view.ID, view.ID, view.ID)
+		case "GCP":
+			view.ServiceAttachmentNames = []string{fmt.Sprintf("projects/p-%s/regions/%s/serviceAttachments/sa-%s", view.ID[len(view.ID)-12:], view.Region, view.ID)}
+		}
+	}
+
+	for endpointID := range service.endpoints {
+		switch view.ProviderName {
+		case "AWS":
+			view.InterfaceEndpoints = append(view.InterfaceEndpoints, endpointID)
+		case "AZURE":
+			view.PrivateEndpoints = append(view.PrivateEndpoints, endpointID)
+		case "GCP":
+			view.EndpointGroupNames = append(view.EndpointGroupNames, endpointID)
+		}
+	}
+	sort.Strings(view.InterfaceEndpoints)
+	sort.Strings(view.PrivateEndpoints)
+	sort.Strings(view.EndpointGroupNames)
+
+	return view
+}
+
+func (s *Server) interfaceEndpointView(endpoint *interfaceEndpoint) mongodbatlas.InterfaceEndpointConnection {
+	view := endpoint.InterfaceEndpointConnection
+	if !s.settled(endpoint.since) {
+		return view
+	}
+
+	if view.AWSConnectionStatus != "" {
+		view.AWSConnectionStatus = privateEndpointAvailable
+	}
+	if view.Status != "" {
+		view.Status = privateEndpointAvailable
+	}
+	view.Endpoints = make([]*mongodbatlas.GCPEndpoint, 0, len(endpoint.Endpoints))
+	for _, gcpEndpoint := range endpoint.Endpoints {
+		available := *gcpEndpoint
+		available.Status = privateEndpointAvailable
+		view.Endpoints = append(view.Endpoints, &available)
+	}
+
+	return view
+}
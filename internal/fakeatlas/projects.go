@@ -0,0 +1,347 @@
+package fakeatlas
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const projectsPath = "/api/atlas/v1.0/groups"
+
+type project struct {
+	mongodbatlas.Project
+
+	clusters         map[string]*cluster
+	databaseUsers    map[string]*mongodbatlas.DatabaseUser
+	accessList       map[string]*accessListEntry
+	containers       map[string]*mongodbatlas.Container
+	privateEndpoints map[string]*privateEndpointService
+	teams            map[string]*mongodbatlas.Result
+
+	// documents are the project configurations with a single document, like the maintenance window, by path
+	documents map[string]map[string]interface{}
+}
+
+// defaultDocuments returns the project configurations of a new project
+func defaultDocuments() map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"settings": {
+			"isCollectDatabaseSpecificsStatisticsEnabled": true,
+			"isDataExplorerEnabled":                       true,
+			"isExtendedStorageSizesEnabled":               false,
+			"isPerformanceAdvisorEnabled":                 true,
+			"isRealtimePerformancePanelEnabled":           true,
+			"isSchemaAdvisorEnabled":                      true,
+		},
+		"maintenanceWindow": {
+			"hourOfDay":            0,
+			"startASAP":            false,
+			"numberOfDeferrals":    0,
+			"autoDeferOnceEnabled": false,
+		},
+		"auditLog": {
+			"auditAuthorizationSuccess": false,
+			"auditFilter":               "{}",
+			"configurationType":         "NONE",
+			"enabled":                   false,
+		},
+		"encryptionAtRest": {
+			"awsKms":         map[string]interface{}{"enabled": false},
+			"azureKeyVault":  map[string]interface{}{"enabled": false},
+			"googleCloudKms": map[string]interface{}{"enabled": false},
+		},
+		"userSecurity": {
+			"customerX509": map[string]interface{}{},
+		},
+	}
+}
+
+func (s *Server) projectRoutes() {
+	s.handle(http.MethodGet, projectsPath, s.listProjects)
+	s.handle(http.MethodPost, projectsPath, s.createProject)
+	s.handle(http.MethodGet, projectsPath+"/byName/{name}", s.getProjectByName)
+	s.handle(http.MethodGet, projectsPath+"/{groupID}", s.getProject)
+	s.handle(http.MethodDelete, projectsPath+"/{groupID}", s.deleteProject)
+
+	for _, path := range []string{"settings", "maintenanceWindow", "auditLog", "encryptionAtRest", "userSecurity"} {
+		s.handle(http.MethodGet, projectsPath+"/{groupID}/"+path, s.getDocument(path))
+		s.handle(http.MethodPatch, projectsPath+"/{groupID}/"+path, s.patchDocument(path))
+	}
+	s.handle(http.MethodDelete, projectsPath+"/{groupID}/maintenanceWindow", s.resetDocument("maintenanceWindow", ""))
+	s.handle(http.MethodDelete, projectsPath+"/{groupID}/userSecurity/customerX509", s.resetDocument("userSecurity", "customerX509"))
+
+	// The Operator reads these configurations on every reconciliation of a project, the fake doesn't manage them
+	s.handle(http.MethodGet, projectsPath+"/{groupID}/customDBRoles/roles", s.emptyProjectList([]interface{}{}))
+	s.handle(http.MethodGet, projectsPath+"/{groupID}/cloudProviderAccess", s.emptyProjectList(mongodbatlas.CloudProviderAccessRoles{
+		AWSIAMRoles:            []mongodbatlas.CloudProviderAccessRole{},
+		AzureServicePrincipals: []mongodbatlas.CloudProviderAccessRole{},
+	}))
+	for _, path := range []string{"integrations", "alertConfigs", "peers", "serverless"} {
+		s.handle(http.MethodGet, projectsPath+"/{groupID}/"+path, s.emptyProjectPage)
+	}
+	s.handle(http.MethodGet, projectsPath+"/{groupID}/serverless/{name}", s.getServerlessInstance)
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	writePage(w, r, s.projectList())
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	request := mongodbatlas.Project{}
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	if request.Name == "" {
+		writeError(w, http.StatusBadRequest, "MISSING_ATTRIBUTE", "The required attribute name was not specified.")
+		return
+	}
+	if request.OrgID != s.config.OrgID {
+		writeError(w, http.StatusNotFound, "ORG_NOT_FOUND", fmt.Sprintf("No organization with ID %s exists.", request.OrgID))
+		return
+	}
+	if s.projectByName(request.Name) != nil {
+		writeError(w, http.StatusConflict, "GROUP_ALREADY_EXISTS", fmt.Sprintf("A group with name %q already exists.", request.Name))
+		return
+	}
+
+	withDefaultAlertsSettings := true
+	if request.WithDefaultAlertsSettings != nil {
+		withDefaultAlertsSettings = *request.WithDefaultAlertsSettings
+	}
+	regionUsageRestrictions := request.RegionUsageRestrictions
+	if regionUsageRestrictions == "" {
+		regionUsageRestrictions = "NONE"
+	}
+
+	p := &project{
+		Project: mongodbatlas.Project{
+			ID:                        s.newID(),
+			OrgID:                     s.config.OrgID,
+			Name:                      request.Name,
+			Created:                   time.Now().UTC().Format(time.RFC3339),
+			RegionUsageRestrictions:   regionUsageRestrictions,
+			WithDefaultAlertsSettings: &withDefaultAlertsSettings,
+		},
+		clusters:         map[string]*cluster{},
+		databaseUsers:    map[string]*mongodbatlas.DatabaseUser{},
+		accessList:       map[string]*accessListEntry{},
+		containers:       map[string]*mongodbatlas.Container{},
+		privateEndpoints: map[string]*privateEndpointService{},
+		teams:            map[string]*mongodbatlas.Result{},
+		documents:        defaultDocuments(),
+	}
+	s.projects[p.ID] = p
+
+	writeJSON(w, http.StatusCreated, s.projectView(p))
+}
+
+func (s *Server) getProjectByName(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p := s.projectByName(params["name"])
+	if p == nil {
+		writeNotInGroup(w, params["name"])
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.projectView(p))
+}
+
+func (s *Server) getProject(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.projectView(p))
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	if len(s.activeClusters(p)) > 0 {
+		writeError(w, http.StatusConflict, "CANNOT_CLOSE_GROUP_ACTIVE_ATLAS_CLUSTERS", "Cannot close group while it has active Atlas clusters.")
+		return
+	}
+
+	delete(s.projects, p.ID)
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) getDocument(path string) handlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+		p, ok := s.project(w, params)
+		if !ok {
+			return
+		}
+
+		writeJSON(w, http.StatusOK, p.documents[path])
+	}
+}
+
+// patchDocument replaces the fields of the document which are set in the request
+func (s *Server) patchDocument(path string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		p, ok := s.project(w, params)
+		if !ok {
+			return
+		}
+
+		request := map[string]interface{}{}
+		if !readJSON(w, r, &request) {
+			return
+		}
+
+		for key, value := range request {
+			p.documents[path][key] = value
+		}
+
+		writeJSON(w, http.StatusOK, p.documents[path])
+	}
+}
+
+// resetDocument restores the default of a document, or of one of its fields if the field isn't empty
+func (s *Server) resetDocument(path, field string) handlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+		p, ok := s.project(w, params)
+		if !ok {
+			return
+		}
+
+		defaults := defaultDocuments()[path]
+		if field == "" {
+			p.documents[path] = defaults
+		} else {
+			p.documents[path][field] = defaults[field]
+		}
+
+		writeJSON(w, http.StatusNoContent, nil)
+	}
+}
+
+func (s *Server) emptyProjectList(list interface{}) handlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+		if _, ok := s.project(w, params); !ok {
+			return
+		}
+
+		writeJSON(w, http.StatusOK, list)
+	}
+}
+
+func (s *Server) emptyProjectPage(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if _, ok := s.project(w, params); !ok {
+		return
+	}
+
+	writePage(w, r, []interface{}{})
+}
+
+// getServerlessInstance lets the Operator tell that a deployment isn't a serverless instance, the fake doesn't support
+// serverless instances
+func (s *Server) getServerlessInstance(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	if _, ok := s.project(w, params); !ok {
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "SERVERLESS_INSTANCE_NOT_FOUND", fmt.Sprintf("No serverless instance with name %s exists in group %s.", params["name"], params["groupID"]))
+}
+
+// project returns the project of the groupID path parameter, it replies with an error if the project doesn't exist
+func (s *Server) project(w http.ResponseWriter, params map[string]string) (*project, bool) {
+	p, ok := s.projects[params["groupID"]]
+	if !ok {
+		writeNotInGroup(w, params["groupID"])
+		return nil, false
+	}
+
+	return p, true
+}
+
+func (s *Server) projectByName(name string) *project {
+	for _, p := range s.projects {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Server) projectList() []mongodbatlas.Project {
+	projects := make([]mongodbatlas.Project, 0, len(s.projects))
+	for _, p := range sortedValues(s.projects) {
+		projects = append(projects, s.projectView(p))
+	}
+	return projects
+}
+
+func (s *Server) projectView(p *project) mongodbatlas.Project {
+	view := p.Project
+	view.ClusterCount = len(s.activeClusters(p))
+	return view
+}
+
+// writeNotInGroup replies with the error Atlas returns when the project doesn't exist, which is the same as when the
+// API key doesn't have access to it
+func writeNotInGroup(w http.ResponseWriter, group string) {
+	writeError(w, http.StatusUnauthorized, "NOT_IN_GROUP", fmt.Sprintf("The current user is not in the group, or the group %s does not exist.", group))
+}
+
+// sortedValues returns the values of the map sorted by key
+func sortedValues[T any](m map[string]T) []T {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := make([]T, 0, len(m))
+	for _, key := range keys {
+		values = append(values, m[key])
+	}
+	return values
+}
+
+// merge replaces the top level fields of the resource which are set in the JSON body of the request, like the Atlas
+// PATCH requests do. The fields which are objects or arrays are replaced as a whole
+func merge(w http.ResponseWriter, r *http.Request, resource interface{}) bool {
+	request := map[string]json.RawMessage{}
+	if !readJSON(w, r, &request) {
+		return false
+	}
+
+	fields := map[string]json.RawMessage{}
+	current, err := json.Marshal(resource)
+	if err == nil {
+		err = json.Unmarshal(current, &fields)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "UNEXPECTED_ERROR", err.Error())
+		return false
+	}
+
+	for key, value := range request {
+		fields[key] = value
+	}
+
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "UNEXPECTED_ERROR", err.Error())
+		return false
+	}
+
+	value := reflect.ValueOf(resource).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	if err := json.Unmarshal(merged, resource); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", fmt.Sprintf("Received JSON is malformed: %v.", err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,369 @@
+// Package fakeatlas implements an in-memory fake of the Atlas Admin API endpoints the Operator calls.
+//
+// The fake keeps its state in memory, authenticates the requests with HTTP digest authentication like Atlas does and
+// paginates the lists with the pageNum and itemsPerPage query parameters. The asynchronous Atlas operations, like the
+// provisioning of a deployment, go through the same states as in Atlas and settle once Config.TransitionDelay has
+// passed. The endpoints which aren't implemented reply 501 Not Implemented.
+package fakeatlas
+
+import (
+	"crypto/md5" //nolint:gosec // digest authentication uses MD5
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	// Realm is the digest authentication realm of the Atlas Admin API
+	Realm = "MMS Public API"
+
+	defaultItemsPerPage = 100
+	maxItemsPerPage     = 500
+
+	// maxNonces bounds the nonces waiting to be used by the clients which never answer the challenge
+	maxNonces = 10000
+)
+
+// Config configures the fake Atlas server
+type Config struct {
+	// OrgID is the ID of the only organization of the server
+	OrgID string
+
+	// PublicKey and PrivateKey are the only API key accepted by the server
+	PublicKey  string
+	PrivateKey string
+
+	// TransitionDelay is how long the asynchronous operations take, for example the creation of a deployment.
+	// The operations settle on the first request once the delay has passed, with no delay only the response of the
+	// request starting an operation reports it as ongoing
+	TransitionDelay time.Duration
+}
+
+// Server is an in-memory fake of the Atlas Admin API. It is an http.Handler, httptest.NewServer(fakeatlas.New(config))
+// runs it and the URL of the test server is the Atlas domain to use
+type Server struct {
+	config Config
+	routes []route
+
+	mu       sync.Mutex
+	lastID   uint64
+	nonces   map[string]struct{}
+	projects map[string]*project
+	teams    map[string]*team
+	users    map[string]*mongodbatlas.AtlasUser
+}
+
+// New returns a fake Atlas server without any project, team or user
+func New(config Config) *Server {
+	s := &Server{
+		config:   config,
+		nonces:   map[string]struct{}{},
+		projects: map[string]*project{},
+		teams:    map[string]*team{},
+		users:    map[string]*mongodbatlas.AtlasUser{},
+	}
+
+	s.projectRoutes()
+	s.clusterRoutes()
+	s.databaseUserRoutes()
+	s.ipAccessListRoutes()
+	s.containerRoutes()
+	s.privateEndpointRoutes()
+	s.teamRoutes()
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.authenticated(r) {
+		w.Header().Set("WWW-Authenticate", s.challenge())
+		writeError(w, http.StatusUnauthorized, "", "You are not authorized for this resource.")
+		return
+	}
+
+	handle, params := s.match(r)
+	if handle == nil {
+		writeError(w, http.StatusNotImplemented, "NOT_IMPLEMENTED", fmt.Sprintf("The fake Atlas server doesn't implement %s %s.", r.Method, r.URL.Path))
+		return
+	}
+
+	handle(w, r, params)
+}
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+type route struct {
+	method   string
+	segments []string
+	handle   handlerFunc
+}
+
+// handle registers the handler of the requests matching the method and the path pattern. The path segments of the
+// pattern enclosed in braces are parameters, for example /api/atlas/v1.0/groups/{groupID}
+func (s *Server) handle(method, pattern string, handle handlerFunc) {
+	s.routes = append(s.routes, route{method: method, segments: splitPath(pattern), handle: handle})
+}
+
+// match returns the handler of the request and its path parameters. The route with the most literal segments wins,
+// so that /groups/byName/{name} takes precedence over /groups/{groupID}/{resource}
+func (s *Server) match(r *http.Request) (handlerFunc, map[string]string) {
+	segments := splitPath(r.URL.EscapedPath())
+	for i := range segments {
+		if unescaped, err := url.PathUnescape(segments[i]); err == nil {
+			segments[i] = unescaped
+		}
+	}
+
+	var best handlerFunc
+	var bestParams map[string]string
+	bestLiterals := -1
+	for _, rt := range s.routes {
+		if rt.method != r.Method || len(rt.segments) != len(segments) {
+			continue
+		}
+
+		params := map[string]string{}
+		literals := 0
+		matches := true
+		for i, segment := range rt.segments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				params[segment[1:len(segment)-1]] = segments[i]
+				continue
+			}
+			if segment != segments[i] {
+				matches = false
+				break
+			}
+			literals++
+		}
+
+		if matches && literals > bestLiterals {
+			best, bestParams, bestLiterals = rt.handle, params, literals
+		}
+	}
+
+	return best, bestParams
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// challenge issues a new nonce and returns the digest authentication challenge with it
+func (s *Server) challenge() string {
+	if len(s.nonces) >= maxNonces {
+		s.nonces = map[string]struct{}{}
+	}
+
+	nonce := randomHex(16)
+	s.nonces[nonce] = struct{}{}
+
+	return fmt.Sprintf(`Digest realm="%s", domain="", nonce="%s", algorithm=MD5, qop="auth", stale=false`, Realm, nonce)
+}
+
+// authenticated verifies the digest authentication of the request. A nonce can only be used once
+func (s *Server) authenticated(r *http.Request) bool {
+	const prefix = "Digest "
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+
+	params := parseDigestParams(header[len(prefix):])
+	nonce := params["nonce"]
+	if _, ok := s.nonces[nonce]; !ok {
+		return false
+	}
+	delete(s.nonces, nonce)
+
+	if params["username"] != s.config.PublicKey || params["realm"] != Realm || params["qop"] != "auth" || params["uri"] != r.RequestURI {
+		return false
+	}
+	if algorithm, ok := params["algorithm"]; ok && algorithm != "MD5" {
+		return false
+	}
+
+	ha1 := md5Hex(fmt.Sprintf("%s:%s:%s", s.config.PublicKey, Realm, s.config.PrivateKey))
+	ha2 := md5Hex(fmt.Sprintf("%s:%s", r.Method, params["uri"]))
+	expected := md5Hex(fmt.Sprintf("%s:%s:%s:%s:%s:%s", ha1, nonce, params["nc"], params["cnonce"], params["qop"], ha2))
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(params["response"])) == 1
+}
+
+// parseDigestParams parses the comma separated key=value pairs of a digest authorization header. The values can be
+// quoted
+func parseDigestParams(s string) map[string]string {
+	params := map[string]string{}
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			return params
+		}
+		key := strings.TrimSpace(s[:eq])
+		s = s[eq+1:]
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				params[key] = s[1:]
+				return params
+			}
+			value, s = s[1:end+1], s[end+2:]
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end < 0 {
+				end = len(s)
+			}
+			value, s = s[:end], s[end:]
+		}
+		params[key] = strings.TrimSpace(value)
+	}
+}
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s)) //nolint:gosec // digest authentication uses MD5
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate random bytes: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// newID returns a new unique ID with the 24 hexadecimal digits format of the Atlas IDs. The IDs are increasing, so that
+// sorting them sorts the resources by creation
+func (s *Server) newID() string {
+	s.lastID++
+	return fmt.Sprintf("%024x", s.lastID)
+}
+
+// settled tells if an operation which started at the given time is over
+func (s *Server) settled(since time.Time) bool {
+	return !time.Now().Before(since.Add(s.config.TransitionDelay))
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if body != nil {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+// writeError replies with the JSON error format of the Atlas Admin API
+func writeError(w http.ResponseWriter, statusCode int, errorCode, detail string) {
+	writeJSON(w, statusCode, apiError{
+		Detail:    detail,
+		Error:     statusCode,
+		ErrorCode: errorCode,
+		Reason:    http.StatusText(statusCode),
+	})
+}
+
+type apiError struct {
+	Detail    string `json:"detail"`
+	Error     int    `json:"error"`
+	ErrorCode string `json:"errorCode,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// readJSON decodes the body of the request, it replies with an error and returns false if the body is invalid
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", fmt.Sprintf("Received JSON is malformed: %v.", err))
+		return false
+	}
+	return true
+}
+
+type page struct {
+	Links      []*mongodbatlas.Link `json:"links"`
+	Results    interface{}          `json:"results"`
+	TotalCount int                  `json:"totalCount"`
+}
+
+// writePage replies with the page of the items selected by the pageNum and itemsPerPage query parameters, with the
+// link to the next page if there is one
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	pageNum, itemsPerPage, ok := pageOptions(w, r)
+	if !ok {
+		return
+	}
+
+	start := (pageNum - 1) * itemsPerPage
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + itemsPerPage
+	if end > len(items) {
+		end = len(items)
+	}
+
+	links := []*mongodbatlas.Link{{Rel: "self", Href: pageURL(r, pageNum, itemsPerPage)}}
+	if end < len(items) {
+		links = append(links, &mongodbatlas.Link{Rel: "next", Href: pageURL(r, pageNum+1, itemsPerPage)})
+	}
+	if pageNum > 1 {
+		links = append(links, &mongodbatlas.Link{Rel: "previous", Href: pageURL(r, pageNum-1, itemsPerPage)})
+	}
+
+	results := make([]T, end-start)
+	copy(results, items[start:end])
+	writeJSON(w, http.StatusOK, page{Links: links, Results: results, TotalCount: len(items)})
+}
+
+func pageOptions(w http.ResponseWriter, r *http.Request) (pageNum, itemsPerPage int, ok bool) {
+	pageNum, itemsPerPage = 1, defaultItemsPerPage
+	query := r.URL.Query()
+
+	if value := query.Get("pageNum"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER", fmt.Sprintf("Invalid query parameter pageNum=%s.", value))
+			return 0, 0, false
+		}
+		pageNum = n
+	}
+
+	if value := query.Get("itemsPerPage"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxItemsPerPage {
+			writeError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER", fmt.Sprintf("Invalid query parameter itemsPerPage=%s.", value))
+			return 0, 0, false
+		}
+		itemsPerPage = n
+	}
+
+	return pageNum, itemsPerPage, true
+}
+
+func pageURL(r *http.Request, pageNum, itemsPerPage int) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	query := r.URL.Query()
+	query.Set("pageNum", strconv.Itoa(pageNum))
+	query.Set("itemsPerPage", strconv.Itoa(itemsPerPage))
+
+	return (&url.URL{Scheme: scheme, Host: r.Host, Path: r.URL.Path, RawQuery: query.Encode()}).String()
+}
@@ -0,0 +1,239 @@
+package fakeatlas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+)
+
+const testOrgID = "0123456789abcdef01234567"
+
+func newTestClient(t *testing.T, publicKey, privateKey string) mongodbatlas.Client {
+	t.Helper()
+
+	return newTestClientWithDelay(t, publicKey, privateKey, 0)
+}
+
+func newTestClientWithDelay(t *testing.T, publicKey, privateKey string, transitionDelay time.Duration) mongodbatlas.Client {
+	t.Helper()
+
+	server := httptest.NewServer(New(Config{OrgID: testOrgID, PublicKey: "public", PrivateKey: "private", TransitionDelay: transitionDelay}))
+	t.Cleanup(server.Close)
+
+	client, err := atlas.Client(server.URL+"/", atlas.Connection{OrgID: testOrgID, PublicKey: publicKey, PrivateKey: privateKey}, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	return client
+}
+
+func newTestProject(t *testing.T, client mongodbatlas.Client) *mongodbatlas.Project {
+	t.Helper()
+
+	project, _, err := client.Projects.Create(context.Background(), &mongodbatlas.Project{Name: "test-project", OrgID: testOrgID}, nil)
+	require.NoError(t, err)
+	return project
+}
+
+func assertErrorCode(t *testing.T, err error, errorCode string) {
+	t.Helper()
+
+	apiError := &mongodbatlas.ErrorResponse{}
+	require.True(t, errors.As(err, &apiError), "expected an Atlas error, got %v", err)
+	assert.Equal(t, errorCode, apiError.ErrorCode)
+}
+
+func TestServerAuthentication(t *testing.T) {
+	t.Run("Valid API key", func(t *testing.T) {
+		client := newTestClient(t, "public", "private")
+
+		projects, _, err := client.Projects.GetAllProjects(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, projects.TotalCount)
+	})
+
+	t.Run("Invalid API key", func(t *testing.T) {
+		client := newTestClient(t, "public", "wrong")
+
+		_, response, err := client.Projects.GetAllProjects(context.Background(), nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	})
+}
+
+func TestServerProjects(t *testing.T) {
+	client := newTestClient(t, "public", "private")
+	project := newTestProject(t, client)
+
+	byName, _, err := client.Projects.GetOneProjectByName(context.Background(), "test-project")
+	require.NoError(t, err)
+	assert.Equal(t, project.ID, byName.ID)
+
+	_, _, err = client.Projects.Create(context.Background(), &mongodbatlas.Project{Name: "test-project", OrgID: testOrgID}, nil)
+	assertErrorCode(t, err, "GROUP_ALREADY_EXISTS")
+
+	_, err = client.Projects.Delete(context.Background(), project.ID)
+	require.NoError(t, err)
+
+	_, _, err = client.Projects.GetOneProject(context.Background(), project.ID)
+	assertErrorCode(t, err, "NOT_IN_GROUP")
+
+	_, _, err = client.ProjectIPAccessList.List(context.Background(), project.ID, nil)
+	assertErrorCode(t, err, "NOT_IN_GROUP")
+}
+
+func TestServerAdvancedClusterLifecycle(t *testing.T) {
+	client := newTestClient(t, "public", "private")
+	project := newTestProject(t, client)
+
+	created, _, err := client.AdvancedClusters.Create(context.Background(), project.ID, &mongodbatlas.AdvancedCluster{
+		Name: "cluster0",
+		ReplicationSpecs: []*mongodbatlas.AdvancedReplicationSpec{{
+			RegionConfigs: []*mongodbatlas.AdvancedRegionConfig{{ProviderName: "AWS", RegionName: "US_EAST_1"}},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "CREATING", created.StateName)
+
+	cluster, _, err := client.AdvancedClusters.Get(context.Background(), project.ID, "cluster0")
+	require.NoError(t, err)
+	assert.Equal(t, "IDLE", cluster.StateName)
+	assert.NotEmpty(t, cluster.ConnectionStrings.StandardSrv)
+
+	_, err = client.Projects.Delete(context.Background(), project.ID)
+	assertErrorCode(t, err, "CANNOT_CLOSE_GROUP_ACTIVE_ATLAS_CLUSTERS")
+
+	_, err = client.AdvancedClusters.Delete(context.Background(), project.ID, "cluster0", nil)
+	require.NoError(t, err)
+
+	_, _, err = client.AdvancedClusters.Get(context.Background(), project.ID, "cluster0")
+	assertErrorCode(t, err, "CLUSTER_NOT_FOUND")
+}
+
+func TestServerPagination(t *testing.T) {
+	client := newTestClient(t, "public", "private")
+	project := newTestProject(t, client)
+
+	for i := 0; i < 5; i++ {
+		_, _, err := client.DatabaseUsers.Create(context.Background(), project.ID, &mongodbatlas.DatabaseUser{
+			Username:     fmt.Sprintf("user%d", i),
+			DatabaseName: "admin",
+			Password:     "secret",
+		})
+		require.NoError(t, err)
+	}
+
+	var usernames []string
+	err := atlas.TraversePages(func(pageNum int) (atlas.Paginated, error) {
+		users, response, err := client.DatabaseUsers.List(context.Background(), project.ID, &mongodbatlas.ListOptions{PageNum: pageNum, ItemsPerPage: 2})
+		if err != nil {
+			return nil, err
+		}
+		return atlas.NewAtlasPaginated(response, users), nil
+	}, func(entity interface{}) bool {
+		user := entity.(mongodbatlas.DatabaseUser)
+		assert.Empty(t, user.Password)
+		usernames = append(usernames, user.Username)
+		return false
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user0", "user1", "user2", "user3", "user4"}, usernames)
+}
+
+func TestServerIPAccessList(t *testing.T) {
+	for delay, expected := range map[time.Duration]string{0: "ACTIVE", time.Hour: "PENDING"} {
+		client := newTestClientWithDelay(t, "public", "private", delay)
+		project := newTestProject(t, client)
+
+		_, _, err := client.ProjectIPAccessList.Create(context.Background(), project.ID, []*mongodbatlas.ProjectIPAccessList{{CIDRBlock: "not-a-cidr"}})
+		assertErrorCode(t, err, "INVALID_IP_ADDRESS_OR_CIDR_NOTATION")
+
+		_, _, err = client.ProjectIPAccessList.Create(context.Background(), project.ID, []*mongodbatlas.ProjectIPAccessList{{CIDRBlock: "10.0.0.0/24"}})
+		require.NoError(t, err)
+
+		status, err := atlas.CustomIPAccessListStatus(&client)(context.Background(), project.ID, url.QueryEscape("10.0.0.0/24"))
+		require.NoError(t, err)
+		assert.Equal(t, expected, status)
+	}
+}
+
+func TestServerPrivateEndpoints(t *testing.T) {
+	client := newTestClient(t, "public", "private")
+	project := newTestProject(t, client)
+
+	service, _, err := client.PrivateEndpoints.Create(context.Background(), project.ID, &mongodbatlas.PrivateEndpointConnection{ProviderName: "AWS", Region: "us-east-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "INITIATING", service.Status)
+
+	service, _, err = client.PrivateEndpoints.Get(context.Background(), project.ID, "AWS", service.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "AVAILABLE", service.Status)
+	assert.NotEmpty(t, service.EndpointServiceName)
+
+	_, _, err = client.PrivateEndpoints.AddOnePrivateEndpoint(context.Background(), project.ID, "AWS", service.ID, &mongodbatlas.InterfaceEndpointConnection{ID: "vpce-123"})
+	require.NoError(t, err)
+
+	_, err = client.PrivateEndpoints.Delete(context.Background(), project.ID, "AWS", service.ID)
+	assertErrorCode(t, err, "CANNOT_DELETE_ENDPOINT_SERVICE_WITH_ENDPOINTS")
+
+	endpoint, _, err := client.PrivateEndpoints.GetOnePrivateEndpoint(context.Background(), project.ID, "AWS", service.ID, "vpce-123")
+	require.NoError(t, err)
+	assert.Equal(t, "AVAILABLE", endpoint.AWSConnectionStatus)
+
+	_, err = client.PrivateEndpoints.DeleteOnePrivateEndpoint(context.Background(), project.ID, "AWS", service.ID, "vpce-123")
+	require.NoError(t, err)
+	_, err = client.PrivateEndpoints.Delete(context.Background(), project.ID, "AWS", service.ID)
+	require.NoError(t, err)
+}
+
+func TestServerTeams(t *testing.T) {
+	client := newTestClient(t, "public", "private")
+	project := newTestProject(t, client)
+
+	team, _, err := client.Teams.Create(context.Background(), testOrgID, &mongodbatlas.Team{Name: "team0", Usernames: []string{"jane.doe@example.com"}})
+	require.NoError(t, err)
+
+	user, _, err := client.AtlasUsers.GetByName(context.Background(), "john.doe@example.com")
+	require.NoError(t, err)
+	users, _, err := client.Teams.AddUsersToTeam(context.Background(), testOrgID, team.ID, []string{user.ID})
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	_, _, err = client.Projects.AddTeamsToProject(context.Background(), project.ID, []*mongodbatlas.ProjectTeam{{TeamID: team.ID, RoleNames: []string{"GROUP_READ_ONLY"}}})
+	require.NoError(t, err)
+
+	assigned, _, err := client.Projects.GetProjectTeamsAssigned(context.Background(), project.ID)
+	require.NoError(t, err)
+	require.Len(t, assigned.Results, 1)
+	assert.Equal(t, []string{"GROUP_READ_ONLY"}, assigned.Results[0].RoleNames)
+
+	_, err = client.Teams.RemoveTeamFromOrganization(context.Background(), testOrgID, team.ID)
+	assertErrorCode(t, err, "CANNOT_DELETE_TEAM_ASSIGNED_TO_PROJECT")
+
+	_, err = client.Teams.RemoveTeamFromProject(context.Background(), project.ID, team.ID)
+	require.NoError(t, err)
+	_, err = client.Teams.RemoveTeamFromOrganization(context.Background(), testOrgID, team.ID)
+	require.NoError(t, err)
+
+	_, response, err := client.Teams.GetOneTeamByName(context.Background(), testOrgID, "team0")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestServerNotImplemented(t *testing.T) {
+	client := newTestClient(t, "public", "private")
+	project := newTestProject(t, client)
+
+	_, _, err := client.OnlineArchives.List(context.Background(), project.ID, "cluster0", nil)
+	assertErrorCode(t, err, "NOT_IMPLEMENTED")
+}
@@ -0,0 +1,377 @@
+package fakeatlas
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	teamsPath        = "/api/atlas/v1.0/orgs/{orgID}/teams"
+	projectTeamsPath = "/api/atlas/v1.0/groups/{groupID}/teams"
+	usersPath        = "/api/atlas/v1.0/users"
+)
+
+type team struct {
+	mongodbatlas.Team
+
+	// userIDs are the IDs of the users of the team
+	userIDs []string
+}
+
+func (s *Server) teamRoutes() {
+	s.handle(http.MethodGet, teamsPath, s.listTeams)
+	s.handle(http.MethodPost, teamsPath, s.createTeam)
+	s.handle(http.MethodGet, teamsPath+"/byName/{name}", s.getTeamByName)
+	s.handle(http.MethodGet, teamsPath+"/{teamID}", s.getTeam)
+	s.handle(http.MethodPatch, teamsPath+"/{teamID}", s.renameTeam)
+	s.handle(http.MethodDelete, teamsPath+"/{teamID}", s.deleteTeam)
+	s.handle(http.MethodGet, teamsPath+"/{teamID}/users", s.listTeamUsers)
+	s.handle(http.MethodPost, teamsPath+"/{teamID}/users", s.addTeamUsers)
+	s.handle(http.MethodDelete, teamsPath+"/{teamID}/users/{userID}", s.removeTeamUser)
+
+	s.handle(http.MethodGet, projectTeamsPath, s.listProjectTeams)
+	s.handle(http.MethodPost, projectTeamsPath, s.addProjectTeams)
+	s.handle(http.MethodPatch, projectTeamsPath+"/{teamID}", s.updateProjectTeamRoles)
+	s.handle(http.MethodDelete, projectTeamsPath+"/{teamID}", s.removeProjectTeam)
+
+	s.handle(http.MethodGet, usersPath+"/byName/{username}", s.getUserByName)
+}
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if !s.organization(w, params) {
+		return
+	}
+
+	teams := make([]mongodbatlas.Team, 0, len(s.teams))
+	for _, t := range sortedValues(s.teams) {
+		teams = append(teams, t.Team)
+	}
+	writePage(w, r, teams)
+}
+
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if !s.organization(w, params) {
+		return
+	}
+
+	request := mongodbatlas.Team{}
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	if request.Name == "" {
+		writeError(w, http.StatusBadRequest, "MISSING_ATTRIBUTE", "The required attribute name was not specified.")
+		return
+	}
+	if s.teamByName(request.Name) != nil {
+		writeError(w, http.StatusConflict, "DUPLICATE_TEAM_NAME", fmt.Sprintf("A team with name %q already exists.", request.Name))
+		return
+	}
+
+	t := &team{Team: mongodbatlas.Team{ID: s.newID(), Name: request.Name}}
+	for _, username := range request.Usernames {
+		t.userIDs = append(t.userIDs, s.user(username).ID)
+	}
+	s.teams[t.ID] = t
+
+	created := t.Team
+	created.Usernames = request.Usernames
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) getTeamByName(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	if !s.organization(w, params) {
+		return
+	}
+
+	t := s.teamByName(params["name"])
+	if t == nil {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", fmt.Sprintf("No team named %s exists.", params["name"]))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, t.Team)
+}
+
+func (s *Server) getTeam(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	t, ok := s.team(w, params)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, t.Team)
+}
+
+func (s *Server) renameTeam(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	t, ok := s.team(w, params)
+	if !ok {
+		return
+	}
+
+	request := mongodbatlas.Team{}
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	if request.Name == "" {
+		writeError(w, http.StatusBadRequest, "MISSING_ATTRIBUTE", "The required attribute name was not specified.")
+		return
+	}
+	if existing := s.teamByName(request.Name); existing != nil && existing.ID != t.ID {
+		writeError(w, http.StatusConflict, "DUPLICATE_TEAM_NAME", fmt.Sprintf("A team with name %q already exists.", request.Name))
+		return
+	}
+	t.Name = request.Name
+
+	writeJSON(w, http.StatusOK, t.Team)
+}
+
+func (s *Server) deleteTeam(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	t, ok := s.team(w, params)
+	if !ok {
+		return
+	}
+
+	for _, p := range s.projects {
+		if _, assigned := p.teams[t.ID]; assigned {
+			writeError(w, http.StatusConflict, "CANNOT_DELETE_TEAM_ASSIGNED_TO_PROJECT", fmt.Sprintf("Team %s is assigned to project %s.", t.ID, p.ID))
+			return
+		}
+	}
+
+	delete(s.teams, t.ID)
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) listTeamUsers(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	t, ok := s.team(w, params)
+	if !ok {
+		return
+	}
+
+	writePage(w, r, s.teamUsers(t))
+}
+
+// addTeamUsers adds the users of the request, identified by their IDs, to the team and replies with all the users of
+// the team
+func (s *Server) addTeamUsers(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	t, ok := s.team(w, params)
+	if !ok {
+		return
+	}
+
+	var users []mongodbatlas.AtlasUser
+	if !readJSON(w, r, &users) {
+		return
+	}
+
+	for _, user := range users {
+		if s.userByID(user.ID) == nil {
+			writeError(w, http.StatusNotFound, "USER_NOT_FOUND", fmt.Sprintf("No user with ID %s exists.", user.ID))
+			return
+		}
+	}
+
+	for _, user := range users {
+		if !contains(t.userIDs, user.ID) {
+			t.userIDs = append(t.userIDs, user.ID)
+		}
+	}
+
+	writePage(w, r, s.teamUsers(t))
+}
+
+func (s *Server) removeTeamUser(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	t, ok := s.team(w, params)
+	if !ok {
+		return
+	}
+
+	userIDs := make([]string, 0, len(t.userIDs))
+	for _, userID := range t.userIDs {
+		if userID != params["userID"] {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	if len(userIDs) == len(t.userIDs) {
+		writeError(w, http.StatusNotFound, "USER_NOT_FOUND", fmt.Sprintf("No user with ID %s is in the team %s.", params["userID"], t.ID))
+		return
+	}
+	t.userIDs = userIDs
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) listProjectTeams(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	writePage(w, r, projectTeams(p))
+}
+
+// addProjectTeams assigns the teams of the request to the project and replies with all the teams of the project
+func (s *Server) addProjectTeams(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return
+	}
+
+	var request []mongodbatlas.ProjectTeam
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	for _, projectTeam := range request {
+		if _, exists := s.teams[projectTeam.TeamID]; !exists {
+			writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", fmt.Sprintf("No team with ID %s exists.", projectTeam.TeamID))
+			return
+		}
+	}
+
+	for _, projectTeam := range request {
+		p.teams[projectTeam.TeamID] = &mongodbatlas.Result{TeamID: projectTeam.TeamID, RoleNames: projectTeam.RoleNames}
+	}
+
+	writePage(w, r, projectTeams(p))
+}
+
+func (s *Server) updateProjectTeamRoles(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, assigned, ok := s.projectTeam(w, params)
+	if !ok {
+		return
+	}
+
+	request := mongodbatlas.TeamUpdateRoles{}
+	if !readJSON(w, r, &request) {
+		return
+	}
+	assigned.RoleNames = request.RoleNames
+
+	writePage(w, r, projectTeams(p))
+}
+
+func (s *Server) removeProjectTeam(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, assigned, ok := s.projectTeam(w, params)
+	if !ok {
+		return
+	}
+
+	delete(p.teams, assigned.TeamID)
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+// getUserByName returns the Atlas user of the username. All the usernames are users of the organization of the fake,
+// they are created the first time they are read
+func (s *Server) getUserByName(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	writeJSON(w, http.StatusOK, s.user(params["username"]))
+}
+
+// organization tells if the orgID path parameter is the organization of the fake, it replies with an error if it isn't
+func (s *Server) organization(w http.ResponseWriter, params map[string]string) bool {
+	if params["orgID"] != s.config.OrgID {
+		writeError(w, http.StatusNotFound, "ORG_NOT_FOUND", fmt.Sprintf("No organization with ID %s exists.", params["orgID"]))
+		return false
+	}
+	return true
+}
+
+// team returns the team of the orgID and teamID path parameters, it replies with an error if the team doesn't exist
+func (s *Server) team(w http.ResponseWriter, params map[string]string) (*team, bool) {
+	if !s.organization(w, params) {
+		return nil, false
+	}
+
+	t, ok := s.teams[params["teamID"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", fmt.Sprintf("No team with ID %s exists.", params["teamID"]))
+		return nil, false
+	}
+
+	return t, true
+}
+
+// projectTeam returns the assignment of the team of the teamID path parameter to the project of the groupID path
+// parameter, it replies with an error if the team isn't assigned to the project
+func (s *Server) projectTeam(w http.ResponseWriter, params map[string]string) (*project, *mongodbatlas.Result, bool) {
+	p, ok := s.project(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+
+	assigned, ok := p.teams[params["teamID"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", fmt.Sprintf("No team with ID %s is assigned to the group %s.", params["teamID"], p.ID))
+		return nil, nil, false
+	}
+
+	return p, assigned, true
+}
+
+func (s *Server) teamByName(name string) *team {
+	for _, t := range s.teams {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Server) teamUsers(t *team) []mongodbatlas.AtlasUser {
+	users := make([]mongodbatlas.AtlasUser, 0, len(t.userIDs))
+	for _, userID := range t.userIDs {
+		if user := s.userByID(userID); user != nil {
+			users = append(users, *user)
+		}
+	}
+	return users
+}
+
+// user returns the Atlas user of the username, the user is created if it doesn't exist yet
+func (s *Server) user(username string) *mongodbatlas.AtlasUser {
+	if user, ok := s.users[username]; ok {
+		return user
+	}
+
+	firstName, lastName, _ := strings.Cut(strings.Split(username, "@")[0], ".")
+	user := &mongodbatlas.AtlasUser{
+		ID:           s.newID(),
+		Username:     username,
+		EmailAddress: username,
+		FirstName:    firstName,
+		LastName:     lastName,
+		Roles:        []mongodbatlas.AtlasRole{{OrgID: s.config.OrgID, RoleName: "ORG_MEMBER"}},
+	}
+	s.users[username] = user
+	return user
+}
+
+func (s *Server) userByID(userID string) *mongodbatlas.AtlasUser {
+	for _, user := range s.users {
+		if user.ID == userID {
+			return user
+		}
+	}
+	return nil
+}
+
+func projectTeams(p *project) []mongodbatlas.Result {
+	teams := make([]mongodbatlas.Result, 0, len(p.teams))
+	for _, assigned := range sortedValues(p.teams) {
+		teams = append(teams, *assigned)
+	}
+	return teams
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+type CloudProviderSnapshotRestoreJobsClientMock struct {
+	ListFunc     func(projectID, clusterName string) (*mongodbatlas.CloudProviderSnapshotRestoreJobs, *mongodbatlas.Response, error)
+	ListRequests map[string]struct{}
+
+	GetFunc     func(projectID, clusterName, jobID string) (*mongodbatlas.CloudProviderSnapshotRestoreJob, *mongodbatlas.Response, error)
+	GetRequests map[string]struct{}
+
+	CreateFunc     func(projectID, clusterName string, job *mongodbatlas.CloudProviderSnapshotRestoreJob) (*mongodbatlas.CloudProviderSnapshotRestoreJob, *mongodbatlas.Response, error)
+	CreateRequests map[string]*mongodbatlas.CloudProviderSnapshotRestoreJob
+
+	DeleteFunc     func(projectID, clusterName, jobID string) (*mongodbatlas.Response, error)
+	DeleteRequests map[string]struct{}
+}
+
+func (c *CloudProviderSnapshotRestoreJobsClientMock) List(_ context.Context, params *mongodbatlas.SnapshotReqPathParameters, _ *mongodbatlas.ListOptions) (*mongodbatlas.CloudProviderSnapshotRestoreJobs, *mongodbatlas.Response, error) {
+	if c.ListRequests == nil {
+		c.ListRequests = map[string]struct{}{}
+	}
+
+	c.ListRequests[fmt.Sprintf("%s.%s", params.GroupID, params.ClusterName)] = struct{}{}
+
+	if c.ListFunc == nil {
+		return &mongodbatlas.CloudProviderSnapshotRestoreJobs{}, nil, nil
+	}
+	return c.ListFunc(params.GroupID, params.ClusterName)
+}
+
+func (c *CloudProviderSnapshotRestoreJobsClientMock) Get(_ context.Context, params *mongodbatlas.SnapshotReqPathParameters) (*mongodbatlas.CloudProviderSnapshotRestoreJob, *mongodbatlas.Response, error) {
+	if c.GetRequests == nil {
+		c.GetRequests = map[string]struct{}{}
+	}
+
+	c.GetRequests[fmt.Sprintf("%s.%s.%s", params.GroupID, params.ClusterName, params.JobID)] = struct{}{}
+
+	return c.GetFunc(params.GroupID, params.ClusterName, params.JobID)
+}
+
+func (c *CloudProviderSnapshotRestoreJobsClientMock) Create(_ context.Context, params *mongodbatlas.SnapshotReqPathParameters, job *mongodbatlas.CloudProviderSnapshotRestoreJob) (*mongodbatlas.CloudProviderSnapshotRestoreJob, *mongodbatlas.Response, error) {
+	if c.CreateRequests == nil {
+		c.CreateRequests = map[string]*mongodbatlas.CloudProviderSnapshotRestoreJob{}
+	}
+
+	c.CreateRequests[fmt.Sprintf("%s.%s", params.GroupID, params.ClusterName)] = job
+
+	return c.CreateFunc(params.GroupID, params.ClusterName, job)
+}
+
+func (c *CloudProviderSnapshotRestoreJobsClientMock) Delete(_ context.Context, params *mongodbatlas.SnapshotReqPathParameters) (*mongodbatlas.Response, error) {
+	if c.DeleteRequests == nil {
+		c.DeleteRequests = map[string]struct{}{}
+	}
+
+	c.DeleteRequests[fmt.Sprintf("%s.%s.%s", params.GroupID, params.ClusterName, params.JobID)] = struct{}{}
+
+	return c.DeleteFunc(params.GroupID, params.ClusterName, params.JobID)
+}
+
+func (c *CloudProviderSnapshotRestoreJobsClientMock) ListForServerlessBackupRestore(_ context.Context, _, _ string, _ *mongodbatlas.ListOptions) (*mongodbatlas.CloudProviderSnapshotRestoreJobs, *mongodbatlas.Response, error) {
+	return nil, nil, nil
+}
+
+func (c *CloudProviderSnapshotRestoreJobsClientMock) GetForServerlessBackupRestore(_ context.Context, _, _, _ string) (*mongodbatlas.CloudProviderSnapshotRestoreJob, *mongodbatlas.Response, error) {
+	return nil, nil, nil
+}
+
+func (c *CloudProviderSnapshotRestoreJobsClientMock) CreateForServerlessBackupRestore(_ context.Context, _, _ string, _ *mongodbatlas.CloudProviderSnapshotRestoreJob) (*mongodbatlas.CloudProviderSnapshotRestoreJob, *mongodbatlas.Response, error) {
+	return nil, nil, nil
+}
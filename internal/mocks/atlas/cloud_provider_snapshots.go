@@ -0,0 +1,73 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+type CloudProviderSnapshotsClientMock struct {
+	ListFunc     func(projectID, clusterName string) (*mongodbatlas.CloudProviderSnapshots, *mongodbatlas.Response, error)
+	ListRequests map[string]struct{}
+
+	GetFunc     func(projectID, clusterName, snapshotID string) (*mongodbatlas.CloudProviderSnapshot, *mongodbatlas.Response, error)
+	GetRequests map[string]struct{}
+
+	CreateFunc     func(projectID, clusterName string, snapshot *mongodbatlas.CloudProviderSnapshot) (*mongodbatlas.CloudProviderSnapshot, *mongodbatlas.Response, error)
+	CreateRequests map[string]*mongodbatlas.CloudProviderSnapshot
+
+	DeleteFunc     func(projectID, clusterName, snapshotID string) (*mongodbatlas.Response, error)
+	DeleteRequests map[string]struct{}
+}
+
+func (c *CloudProviderSnapshotsClientMock) GetAllCloudProviderSnapshots(_ context.Context, params *mongodbatlas.SnapshotReqPathParameters, _ *mongodbatlas.ListOptions) (*mongodbatlas.CloudProviderSnapshots, *mongodbatlas.Response, error) {
+	if c.ListRequests == nil {
+		c.ListRequests = map[string]struct{}{}
+	}
+
+	c.ListRequests[fmt.Sprintf("%s.%s", params.GroupID, params.ClusterName)] = struct{}{}
+
+	if c.ListFunc == nil {
+		return &mongodbatlas.CloudProviderSnapshots{}, nil, nil
+	}
+	return c.ListFunc(params.GroupID, params.ClusterName)
+}
+
+func (c *CloudProviderSnapshotsClientMock) GetOneCloudProviderSnapshot(_ context.Context, params *mongodbatlas.SnapshotReqPathParameters) (*mongodbatlas.CloudProviderSnapshot, *mongodbatlas.Response, error) {
+	if c.GetRequests == nil {
+		c.GetRequests = map[string]struct{}{}
+	}
+
+	c.GetRequests[fmt.Sprintf("%s.%s.%s", params.GroupID, params.ClusterName, params.SnapshotID)] = struct{}{}
+
+	return c.GetFunc(params.GroupID, params.ClusterName, params.SnapshotID)
+}
+
+func (c *CloudProviderSnapshotsClientMock) Create(_ context.Context, params *mongodbatlas.SnapshotReqPathParameters, snapshot *mongodbatlas.CloudProviderSnapshot) (*mongodbatlas.CloudProviderSnapshot, *mongodbatlas.Response, error) {
+	if c.CreateRequests == nil {
+		c.CreateRequests = map[string]*mongodbatlas.CloudProviderSnapshot{}
+	}
+
+	c.CreateRequests[fmt.Sprintf("%s.%s", params.GroupID, params.ClusterName)] = snapshot
+
+	return c.CreateFunc(params.GroupID, params.ClusterName, snapshot)
+}
+
+func (c *CloudProviderSnapshotsClientMock) Delete(_ context.Context, params *mongodbatlas.SnapshotReqPathParameters) (*mongodbatlas.Response, error) {
+	if c.DeleteRequests == nil {
+		c.DeleteRequests = map[string]struct{}{}
+	}
+
+	c.DeleteRequests[fmt.Sprintf("%s.%s.%s", params.GroupID, params.ClusterName, params.SnapshotID)] = struct{}{}
+
+	return c.DeleteFunc(params.GroupID, params.ClusterName, params.SnapshotID)
+}
+
+func (c *CloudProviderSnapshotsClientMock) GetOneServerlessSnapshot(_ context.Context, _ *mongodbatlas.SnapshotReqPathParameters) (*mongodbatlas.CloudProviderSnapshot, *mongodbatlas.Response, error) {
+	return nil, nil, nil
+}
+
+func (c *CloudProviderSnapshotsClientMock) GetAllServerlessSnapshots(_ context.Context, _ *mongodbatlas.SnapshotReqPathParameters, _ *mongodbatlas.ListOptions) (*mongodbatlas.CloudProviderSnapshots, *mongodbatlas.Response, error) {
+	return nil, nil, nil
+}
@@ -0,0 +1,82 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+type OnlineArchiveClientMock struct {
+	ListFunc     func(projectID, clusterName string, options *mongodbatlas.ListOptions) (*mongodbatlas.OnlineArchives, *mongodbatlas.Response, error)
+	ListRequests map[string]struct{}
+
+	CreateFunc     func(projectID, clusterName string, archive *mongodbatlas.OnlineArchive) (*mongodbatlas.OnlineArchive, *mongodbatlas.Response, error)
+	CreateRequests map[string]*mongodbatlas.OnlineArchive
+
+	UpdateFunc     func(projectID, clusterName, archiveID string, archive *mongodbatlas.OnlineArchive) (*mongodbatlas.OnlineArchive, *mongodbatlas.Response, error)
+	UpdateRequests map[string]*mongodbatlas.OnlineArchive
+
+	DeleteFunc     func(projectID, clusterName, archiveID string) (*mongodbatlas.Response, error)
+	DeleteRequests map[string]struct{}
+}
+
+func (c *OnlineArchiveClientMock) List(_ context.Context, projectID, clusterName string, options *mongodbatlas.ListOptions) (*mongodbatlas.OnlineArchives, *mongodbatlas.Response, error) {
+	if c.ListRequests == nil {
+		c.ListRequests = map[string]struct{}{}
+	}
+
+	c.ListRequests[fmt.Sprintf("%s.%s", projectID, clusterName)] = struct{}{}
+
+	return c.ListFunc(projectID, clusterName, options)
+}
+
+func (c *OnlineArchiveClientMock) Get(_ context.Context, _, _, _ string) (*mongodbatlas.OnlineArchive, *mongodbatlas.Response, error) {
+	return nil, nil, nil
+}
+
+func (c *OnlineArchiveClientMock) Create(_ context.Context, projectID, clusterName string, archive *mongodbatlas.OnlineArchive) (*mongodbatlas.OnlineArchive, *mongodbatlas.Response, error) {
+	if c.CreateRequests == nil {
+		c.CreateRequests = map[string]*mongodbatlas.OnlineArchive{}
+	}
+
+	c.CreateRequests[fmt.Sprintf("%s.%s.%s.%s", projectID, clusterName, archive.DBName, archive.CollName)] = archive
+
+	return c.CreateFunc(projectID, clusterName, archive)
+}
+
+func (c *OnlineArchiveClientMock) Update(_ context.Context, projectID, clusterName, archiveID string, archive *mongodbatlas.OnlineArchive) (*mongodbatlas.OnlineArchive, *mongodbatlas.Response, error) {
+	if c.UpdateRequests == nil {
+		c.UpdateRequests = map[string]*mongodbatlas.OnlineArchive{}
+	}
+
+	c.UpdateRequests[fmt.Sprintf("%s.%s.%s", projectID, clusterName, archiveID)] = archive
+
+	return c.UpdateFunc(projectID, clusterName, archiveID, archive)
+}
+
+func (c *OnlineArchiveClientMock) Delete(_ context.Context, projectID, clusterName, archiveID string) (*mongodbatlas.Response, error) {
+	if c.DeleteRequests == nil {
+		c.DeleteRequests = map[string]struct{}{}
+	}
+
+	c.DeleteRequests[fmt.Sprintf("%s.%s.%s", projectID, clusterName, archiveID)] = struct{}{}
+
+	return c.DeleteFunc(projectID, clusterName, archiveID)
+}
+
+func (c *OnlineArchiveClientMock) CreatePrivateLinkEndpoint(_ context.Context, _ string, _ *mongodbatlas.PrivateLinkEndpointOnlineArchive) (*mongodbatlas.PrivateLinkEndpointOnlineArchiveResponse, *mongodbatlas.Response, error) {
+	return nil, nil, nil
+}
+
+func (c *OnlineArchiveClientMock) GetPrivateLinkEndpoint(_ context.Context, _, _ string) (*mongodbatlas.PrivateLinkEndpointOnlineArchive, *mongodbatlas.Response, error) {
+	return nil, nil, nil
+}
+
+func (c *OnlineArchiveClientMock) ListPrivateLinkEndpoint(_ context.Context, _ string) (*mongodbatlas.PrivateLinkEndpointOnlineArchiveResponse, *mongodbatlas.Response, error) {
+	return nil, nil, nil
+}
+
+func (c *OnlineArchiveClientMock) DeletePrivateLinkEndpoint(_ context.Context, _, _ string) (*mongodbatlas.Response, error) {
+	return nil, nil
+}
@@ -15,20 +15,26 @@ type TestProvider struct {
 	CreateClientFunc     func() (mongodbatlas.Client, error)
 	IsCloudGovFunc       func() bool
 	IsSupportedFunc      func() bool
+
+	AtlasConnectionsEnabledFunc func() bool
 }
 
-func (f *TestProvider) CreateConnection(secretRef *client.ObjectKey, _ *zap.SugaredLogger) (atlas.Connection, error) {
-	return f.CreateConnectionFunc(secretRef)
+func (f *TestProvider) CreateConnection(resource atlas.ConnectionSource, _ *zap.SugaredLogger) (atlas.Connection, error) {
+	return f.CreateConnectionFunc(resource.ConnectionSecretObjectKey())
 }
 
 func (f *TestProvider) CreateClient(_ *atlas.Connection, _ *zap.SugaredLogger, _ ...httputil.ClientOpt) (mongodbatlas.Client, error) {
 	return f.CreateClientFunc()
 }
 
-func (f *TestProvider) IsCloudGov() bool {
+func (f *TestProvider) IsCloudGov(_ *atlas.Connection) bool {
 	return f.IsCloudGovFunc()
 }
 
-func (f *TestProvider) IsResourceSupported(_ mdbv1.AtlasCustomResource) bool {
+func (f *TestProvider) IsResourceSupported(_ mdbv1.AtlasCustomResource, _ *atlas.Connection) bool {
 	return f.IsSupportedFunc()
 }
+
+func (f *TestProvider) AtlasConnectionsEnabled() bool {
+	return f.AtlasConnectionsEnabledFunc != nil && f.AtlasConnectionsEnabledFunc()
+}
@@ -0,0 +1,83 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+type SearchClientMock struct {
+	ListIndexesFunc     func(projectID, clusterName, databaseName, collectionName string) ([]*mongodbatlas.SearchIndex, *mongodbatlas.Response, error)
+	ListIndexesRequests map[string]struct{}
+
+	GetIndexFunc     func(projectID, clusterName, indexID string) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error)
+	GetIndexRequests map[string]struct{}
+
+	CreateIndexFunc     func(projectID, clusterName string, index *mongodbatlas.SearchIndex) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error)
+	CreateIndexRequests map[string]*mongodbatlas.SearchIndex
+
+	UpdateIndexFunc     func(projectID, clusterName, indexID string, index *mongodbatlas.SearchIndex) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error)
+	UpdateIndexRequests map[string]*mongodbatlas.SearchIndex
+
+	DeleteIndexFunc     func(projectID, clusterName, indexID string) (*mongodbatlas.Response, error)
+	DeleteIndexRequests map[string]struct{}
+}
+
+func (c *SearchClientMock) ListIndexes(_ context.Context, projectID, clusterName, databaseName, collectionName string, _ *mongodbatlas.ListOptions) ([]*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+	if c.ListIndexesRequests == nil {
+		c.ListIndexesRequests = map[string]struct{}{}
+	}
+
+	c.ListIndexesRequests[fmt.Sprintf("%s.%s.%s.%s", projectID, clusterName, databaseName, collectionName)] = struct{}{}
+
+	return c.ListIndexesFunc(projectID, clusterName, databaseName, collectionName)
+}
+
+func (c *SearchClientMock) GetIndex(_ context.Context, projectID, clusterName, indexID string) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+	if c.GetIndexRequests == nil {
+		c.GetIndexRequests = map[string]struct{}{}
+	}
+
+	c.GetIndexRequests[fmt.Sprintf("%s.%s.%s", projectID, clusterName, indexID)] = struct{}{}
+
+	return c.GetIndexFunc(projectID, clusterName, indexID)
+}
+
+func (c *SearchClientMock) CreateIndex(_ context.Context, projectID, clusterName string, index *mongodbatlas.SearchIndex) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+	if c.CreateIndexRequests == nil {
+		c.CreateIndexRequests = map[string]*mongodbatlas.SearchIndex{}
+	}
+
+	c.CreateIndexRequests[fmt.Sprintf("%s.%s", projectID, clusterName)] = index
+
+	return c.CreateIndexFunc(projectID, clusterName, index)
+}
+
+func (c *SearchClientMock) UpdateIndex(_ context.Context, projectID, clusterName, indexID string, index *mongodbatlas.SearchIndex) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+	if c.UpdateIndexRequests == nil {
+		c.UpdateIndexRequests = map[string]*mongodbatlas.SearchIndex{}
+	}
+
+	c.UpdateIndexRequests[fmt.Sprintf("%s.%s.%s", projectID, clusterName, indexID)] = index
+
+	return c.UpdateIndexFunc(projectID, clusterName, indexID, index)
+}
+
+func (c *SearchClientMock) DeleteIndex(_ context.Context, projectID, clusterName, indexID string) (*mongodbatlas.Response, error) {
+	if c.DeleteIndexRequests == nil {
+		c.DeleteIndexRequests = map[string]struct{}{}
+	}
+
+	c.DeleteIndexRequests[fmt.Sprintf("%s.%s.%s", projectID, clusterName, indexID)] = struct{}{}
+
+	return c.DeleteIndexFunc(projectID, clusterName, indexID)
+}
+
+func (c *SearchClientMock) ListAnalyzers(_ context.Context, _, _ string, _ *mongodbatlas.ListOptions) ([]*mongodbatlas.SearchAnalyzer, *mongodbatlas.Response, error) {
+	return nil, nil, nil
+}
+
+func (c *SearchClientMock) UpdateAllAnalyzers(_ context.Context, _, _ string, analyzers []*mongodbatlas.SearchAnalyzer) ([]*mongodbatlas.SearchAnalyzer, *mongodbatlas.Response, error) {
+	return analyzers, nil, nil
+}
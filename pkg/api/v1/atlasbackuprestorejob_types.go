@@ -0,0 +1,129 @@
+/*
+Copyright (C) MongoDB, Inc. 2020-present.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may
+not use this file except in compliance with the License. You may obtain
+a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+const (
+	RestoreDeliveryAutomated   = "automated"
+	RestoreDeliveryDownload    = "download"
+	RestoreDeliveryPointInTime = "pointInTime"
+)
+
+// AtlasBackupRestoreJobSpec defines the desired state of AtlasBackupRestoreJob
+type AtlasBackupRestoreJobSpec struct {
+	// DeploymentRef is a reference to the AtlasDeployment resource the snapshot is restored from
+	DeploymentRef common.ResourceRefNamespaced `json:"deploymentRef"`
+
+	// DeliveryType is the type of the restore: "automated" restores a snapshot to the target deployment,
+	// "pointInTime" restores the target deployment to a point in time and "download" provides download links to
+	// a snapshot
+	// +kubebuilder:validation:Enum=automated;download;pointInTime
+	DeliveryType string `json:"deliveryType"`
+
+	// SnapshotRef is a reference to the AtlasBackupSnapshot resource to restore. The "automated" and "download"
+	// restores require either SnapshotRef or SnapshotID
+	// +optional
+	SnapshotRef *common.ResourceRefNamespaced `json:"snapshotRef,omitempty"`
+
+	// SnapshotID is the ID of the Atlas snapshot to restore, e.g. a scheduled snapshot
+	// +optional
+	SnapshotID string `json:"snapshotID,omitempty"`
+
+	// TargetDeploymentRef is a reference to the AtlasDeployment resource the "automated" and "pointInTime" restores
+	// overwrite
+	// +optional
+	TargetDeploymentRef *common.ResourceRefNamespaced `json:"targetDeploymentRef,omitempty"`
+
+	// PointInTimeUTCSeconds is the point in time, in seconds since the UNIX epoch, the "pointInTime" restore restores
+	// the deployment to
+	// +optional
+	PointInTimeUTCSeconds int64 `json:"pointInTimeUTCSeconds,omitempty"`
+
+	// OplogTs is the first part of the oplog timestamp the "pointInTime" restore restores the deployment to, in
+	// seconds since the UNIX epoch. It can be used instead of PointInTimeUTCSeconds together with OplogInc
+	// +optional
+	OplogTs int64 `json:"oplogTs,omitempty"`
+
+	// OplogInc is the second part of the oplog timestamp the "pointInTime" restore restores the deployment to: the
+	// oplog operation number
+	// +optional
+	OplogInc int64 `json:"oplogInc,omitempty"`
+}
+
+// AtlasBackupRestoreJob is the Schema for the atlasbackuprestorejobs API. It runs a Cloud Backup restore job of a
+// deployment. The job runs once: the spec is ignored once the job is started.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=atlasbackuprestorejobs
+// +kubebuilder:printcolumn:name="Deployment",type=string,JSONPath=`.spec.deploymentRef.name`
+// +kubebuilder:printcolumn:name="Delivery",type=string,JSONPath=`.spec.deliveryType`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+type AtlasBackupRestoreJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AtlasBackupRestoreJobSpec          `json:"spec,omitempty"`
+	Status status.AtlasBackupRestoreJobStatus `json:"status,omitempty"`
+}
+
+func (in *AtlasBackupRestoreJob) AtlasDeploymentObjectKey() client.ObjectKey {
+	return *in.Spec.DeploymentRef.GetObject(in.Namespace)
+}
+
+// TargetDeploymentObjectKey returns the key of the target deployment or nil if the restore has none
+func (in *AtlasBackupRestoreJob) TargetDeploymentObjectKey() *client.ObjectKey {
+	return in.Spec.TargetDeploymentRef.GetObject(in.Namespace)
+}
+
+// SnapshotObjectKey returns the key of the referenced AtlasBackupSnapshot or nil if the restore references none
+func (in *AtlasBackupRestoreJob) SnapshotObjectKey() *client.ObjectKey {
+	return in.Spec.SnapshotRef.GetObject(in.Namespace)
+}
+
+// DownloadSecretObjectKey returns the key of the Secret keeping the download links of a "download" restore
+func (in *AtlasBackupRestoreJob) DownloadSecretObjectKey() client.ObjectKey {
+	return kube.ObjectKey(in.Namespace, in.Name+"-download")
+}
+
+func (in *AtlasBackupRestoreJob) GetStatus() status.Status {
+	return in.Status
+}
+
+func (in *AtlasBackupRestoreJob) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	in.Status.Conditions = conditions
+	in.Status.ObservedGeneration = in.ObjectMeta.Generation
+
+	for _, o := range options {
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasBackupRestoreJobStatusOption)
+		v(&in.Status)
+	}
+}
+
+//+kubebuilder:object:root=true
+
+// AtlasBackupRestoreJobList contains a list of AtlasBackupRestoreJob
+type AtlasBackupRestoreJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasBackupRestoreJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AtlasBackupRestoreJob{}, &AtlasBackupRestoreJobList{})
+}
@@ -0,0 +1,94 @@
+/*
+Copyright (C) MongoDB, Inc. 2020-present.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may
+not use this file except in compliance with the License. You may obtain
+a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1
+
+import (
+	"go.mongodb.org/atlas/mongodbatlas"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// AtlasBackupSnapshotSpec defines the desired state of AtlasBackupSnapshot
+type AtlasBackupSnapshotSpec struct {
+	// DeploymentRef is a reference to the AtlasDeployment resource the snapshot is taken of
+	DeploymentRef common.ResourceRefNamespaced `json:"deploymentRef"`
+
+	// Description is the description of the on-demand snapshot
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// RetentionInDays is the number of days Atlas keeps the snapshot before deleting it
+	// +kubebuilder:validation:Minimum=1
+	RetentionInDays int `json:"retentionInDays"`
+}
+
+// ToAtlas converts the spec to the Atlas on-demand snapshot request
+func (s *AtlasBackupSnapshotSpec) ToAtlas() *mongodbatlas.CloudProviderSnapshot {
+	return &mongodbatlas.CloudProviderSnapshot{
+		Description:     s.Description,
+		RetentionInDays: s.RetentionInDays,
+	}
+}
+
+// AtlasBackupSnapshot is the Schema for the atlasbackupsnapshots API. It takes an on-demand Cloud Backup snapshot of a
+// deployment. The snapshot is taken once: the spec is ignored once the snapshot is requested.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=atlasbackupsnapshots
+// +kubebuilder:printcolumn:name="Deployment",type=string,JSONPath=`.spec.deploymentRef.name`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.snapshotStatus`
+// +kubebuilder:printcolumn:name="Expires",type=string,JSONPath=`.status.expiresAt`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+type AtlasBackupSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AtlasBackupSnapshotSpec          `json:"spec,omitempty"`
+	Status status.AtlasBackupSnapshotStatus `json:"status,omitempty"`
+}
+
+func (in *AtlasBackupSnapshot) AtlasDeploymentObjectKey() client.ObjectKey {
+	ns := in.Namespace
+	if in.Spec.DeploymentRef.Namespace != "" {
+		ns = in.Spec.DeploymentRef.Namespace
+	}
+	return kube.ObjectKey(ns, in.Spec.DeploymentRef.Name)
+}
+
+func (in *AtlasBackupSnapshot) GetStatus() status.Status {
+	return in.Status
+}
+
+func (in *AtlasBackupSnapshot) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	in.Status.Conditions = conditions
+	in.Status.ObservedGeneration = in.ObjectMeta.Generation
+
+	for _, o := range options {
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasBackupSnapshotStatusOption)
+		v(&in.Status)
+	}
+}
+
+//+kubebuilder:object:root=true
+
+// AtlasBackupSnapshotList contains a list of AtlasBackupSnapshot
+type AtlasBackupSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasBackupSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AtlasBackupSnapshot{}, &AtlasBackupSnapshotList{})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (C) MongoDB, Inc. 2020-present.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may
+not use this file except in compliance with the License. You may obtain
+a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// AtlasConnectionSpec defines how the Operator connects to an Atlas organization
+type AtlasConnectionSpec struct {
+	// AtlasDomain is the Atlas URL domain name (with slash in the end), e.g. https://cloud.mongodbgov.com/ for Atlas for
+	// Government. The Operator domain is used if not set
+	// +optional
+	AtlasDomain string `json:"atlasDomain,omitempty"`
+
+	// OrgID is the ID of the Atlas organization. It overrides the orgId of the credentials Secret
+	OrgID string `json:"orgId"`
+
+	// CredentialsSecretRef is the Secret with either the API keys (publicApiKey, privateApiKey) or the client
+	// credentials of a service account (clientId, clientSecret). The namespace is required
+	CredentialsSecretRef common.ResourceRefNamespaced `json:"credentialsSecretRef"`
+
+	// AllowedNamespaces are the namespaces of the AtlasProjects and the AtlasFederatedAuths allowed to reference the
+	// connection. Use "*" to allow all the namespaces
+	// +kubebuilder:validation:MinItems=1
+	AllowedNamespaces []string `json:"allowedNamespaces"`
+}
+
+// AtlasConnection is the Schema for the atlasconnections API. It bundles the Atlas domain, the organization and the
+// credentials the AtlasProjects and the AtlasFederatedAuths referencing it connect to Atlas with.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=atlasconnections,scope=Cluster
+// +kubebuilder:printcolumn:name="Atlas Domain",type=string,JSONPath=`.spec.atlasDomain`
+// +kubebuilder:printcolumn:name="Organization",type=string,JSONPath=`.spec.orgId`
+type AtlasConnection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AtlasConnectionSpec `json:"spec,omitempty"`
+}
+
+// CredentialsSecretObjectKey returns the Secret of the credentials of the connection
+func (c *AtlasConnection) CredentialsSecretObjectKey() client.ObjectKey {
+	return kube.ObjectKey(c.Spec.CredentialsSecretRef.Namespace, c.Spec.CredentialsSecretRef.Name)
+}
+
+// AllowsNamespace tells if the resources of the namespace may reference the connection
+func (c *AtlasConnection) AllowsNamespace(namespace string) bool {
+	for _, allowed := range c.Spec.AllowedNamespaces {
+		if allowed == "*" || allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+//+kubebuilder:object:root=true
+
+// AtlasConnectionList contains a list of AtlasConnection
+type AtlasConnectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasConnection `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AtlasConnection{}, &AtlasConnectionList{})
+}
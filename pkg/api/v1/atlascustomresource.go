@@ -26,3 +26,9 @@ var _ AtlasCustomResource = &AtlasDataFederation{}
 var _ AtlasCustomResource = &AtlasBackupSchedule{}
 var _ AtlasCustomResource = &AtlasBackupPolicy{}
 var _ AtlasCustomResource = &AtlasFederatedAuth{}
+var _ AtlasCustomResource = &AtlasIPAccessList{}
+var _ AtlasCustomResource = &AtlasNetworkPeering{}
+var _ AtlasCustomResource = &AtlasPrivateEndpoint{}
+var _ AtlasCustomResource = &AtlasSearchIndex{}
+var _ AtlasCustomResource = &AtlasBackupSnapshot{}
+var _ AtlasCustomResource = &AtlasBackupRestoreJob{}
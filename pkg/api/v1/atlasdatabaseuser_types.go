@@ -18,7 +18,10 @@ package v1
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"text/template"
+	"text/template/parse"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	corev1 "k8s.io/api/core/v1"
@@ -37,6 +40,13 @@ import (
 // 1. Run "make generate" to regenerate code
 // 2. Run "make manifests" to regenerate the CRD
 
+const (
+	// connectionSecretUserField and connectionSecretDeploymentField are the fields of the connection data identifying
+	// the user and the deployment of a connection Secret
+	connectionSecretUserField       = "DBUserName"
+	connectionSecretDeploymentField = "ClusterName"
+)
+
 func init() {
 	SchemeBuilder.Register(&AtlasDatabaseUser{}, &AtlasDatabaseUserList{})
 }
@@ -48,10 +58,27 @@ const (
 	DataLakeScopeType   ScopeType = "DATA_LAKE"
 )
 
+const (
+	// ExternalAuthDatabase is the database Atlas authenticates the users managed outside of Atlas against
+	ExternalAuthDatabase = "$external"
+	// AdminAuthDatabase is the database Atlas authenticates SCRAM users against
+	AdminAuthDatabase = "admin"
+
+	// NoneAuthType is the value Atlas uses for an authentication type that is not enabled
+	NoneAuthType = "NONE"
+)
+
 // AtlasDatabaseUserSpec defines the desired state of Database User in Atlas
 type AtlasDatabaseUserSpec struct {
-	// Project is a reference to AtlasProject resource the user belongs to
-	Project common.ResourceRefNamespaced `json:"projectRef"`
+	// Project is a reference to AtlasProject resource the user belongs to. Either projectRef or projectSelector
+	// must be set.
+	// +optional
+	Project common.ResourceRefNamespaced `json:"projectRef,omitempty"`
+
+	// ProjectSelector selects the AtlasProjects the user is created in. The user is removed from the projects which
+	// stop matching. The selector must not be empty. Either projectRef or projectSelector must be set.
+	// +optional
+	ProjectSelector *metav1.LabelSelector `json:"projectSelector,omitempty"`
 
 	// DatabaseName is a Database against which Atlas authenticates the user. Default value is 'admin'.
 	// +kubebuilder:default=admin
@@ -76,11 +103,75 @@ type AtlasDatabaseUserSpec struct {
 	// PasswordSecret is a reference to the Secret keeping the user password.
 	PasswordSecret *common.ResourceRef `json:"passwordSecretRef,omitempty"`
 
+	// PasswordRotation enables the periodic rotation of the password kept in the PasswordSecret.
+	// +optional
+	PasswordRotation *PasswordRotationSpec `json:"passwordRotation,omitempty"`
+
+	// ConnectionSecretTemplate customizes the connection Secrets created for the user. The default Secret format is
+	// used if not set.
+	// +optional
+	ConnectionSecretTemplate *ConnectionSecretTemplate `json:"connectionSecretTemplate,omitempty"`
+
 	// Username is a username for authenticating to MongoDB.
 	Username string `json:"username"`
 
 	// X509Type is X.509 method by which the database authenticates the provided username
 	X509Type string `json:"x509Type,omitempty"`
+
+	// AWSIAMType is the AWS IAM method by which the database authenticates the provided username.
+	// USER and ROLE users must use the ARN of the IAM user or role as the username.
+	// +kubebuilder:validation:Enum=NONE;USER;ROLE
+	// +optional
+	AWSIAMType string `json:"awsIAMType,omitempty"`
+
+	// LDAPAuthType is the LDAP method by which the database authenticates the provided username.
+	// USER users must use the LDAP distinguished name as the username, GROUP users the LDAP group name.
+	// +kubebuilder:validation:Enum=NONE;USER;GROUP
+	// +optional
+	LDAPAuthType string `json:"ldapAuthType,omitempty"`
+
+	// OIDCAuthType is the OpenID Connect method by which the database authenticates the provided username.
+	// IDP_GROUP users must use "<identity provider id>/<group name>" as the username.
+	// +kubebuilder:validation:Enum=NONE;IDP_GROUP;USER
+	// +optional
+	OIDCAuthType string `json:"oidcAuthType,omitempty"`
+}
+
+// AuthType returns the name of the external authentication type enabled for the user and its value.
+// Both are empty for SCRAM users.
+func (s AtlasDatabaseUserSpec) AuthType() (string, string) {
+	for _, t := range []struct{ name, value string }{
+		{"x509Type", s.X509Type},
+		{"awsIAMType", s.AWSIAMType},
+		{"ldapAuthType", s.LDAPAuthType},
+		{"oidcAuthType", s.OIDCAuthType},
+	} {
+		if t.value != "" && t.value != NoneAuthType {
+			return t.name, t.value
+		}
+	}
+	return "", ""
+}
+
+// AuthDatabase returns the database Atlas authenticates the user against. X.509, AWS IAM, LDAP users and OIDC
+// users are authenticated against "$external", while LDAP and OIDC groups live in "admin" as SCRAM users do.
+func (s AtlasDatabaseUserSpec) AuthDatabase() string {
+	authType, _ := s.AuthType()
+	switch {
+	case authType == "":
+		return s.DatabaseName
+	case s.IsGroup():
+		return AdminAuthDatabase
+	default:
+		return ExternalAuthDatabase
+	}
+}
+
+// IsGroup tells if the user is an LDAP or OIDC group rather than a single principal. Nobody connects as a group, so
+// such users have no connection Secrets.
+func (s AtlasDatabaseUserSpec) IsGroup() bool {
+	authType, value := s.AuthType()
+	return (authType == "ldapAuthType" && value == "GROUP") || (authType == "oidcAuthType" && value == "IDP_GROUP")
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -121,6 +212,114 @@ type RoleSpec struct {
 	CollectionName string `json:"collectionName,omitempty"`
 }
 
+// ConnectionSecretTemplate defines the format of the connection Secrets of a database user. Name and Data are Go
+// templates rendered over the connection data of each deployment, for example "{{ .SrvConnURL }}".
+type ConnectionSecretTemplate struct {
+	// Name is the template of the Secret name. It must reference both the user and the deployment so that every
+	// Secret gets a different name, for example "{{ .ClusterName }}-{{ .DBUserName }}-app-credentials".
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Labels are added to the Secret on top of the labels the operator uses to track connection Secrets.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the Secret.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Data maps the Secret keys to the templates of their values. The default keys are written if empty.
+	// The "username" key is always kept as the operator relies on it.
+	// +optional
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// ParseName parses the template of the Secret name. The template must reference the user and the deployment, the
+// Secrets of other users or deployments would be overwritten otherwise.
+func (t ConnectionSecretTemplate) ParseName() (*template.Template, error) {
+	tmpl, err := parseConnectionSecretTemplate("name", t.Name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection secret name template: %w", err)
+	}
+
+	fields := templateFields(tmpl.Root, map[string]bool{})
+	if !fields[connectionSecretUserField] || !fields[connectionSecretDeploymentField] {
+		return nil, fmt.Errorf("connection secret name template %q must reference both {{ .%s }} and {{ .%s }}", t.Name, connectionSecretUserField, connectionSecretDeploymentField)
+	}
+	return tmpl, nil
+}
+
+// ParseData parses the templates of the Secret keys.
+func (t ConnectionSecretTemplate) ParseData() (map[string]*template.Template, error) {
+	var err error
+	parsed := make(map[string]*template.Template, len(t.Data))
+	for key, text := range t.Data {
+		tmpl, parseErr := parseConnectionSecretTemplate(key, text)
+		if parseErr != nil {
+			err = errors.Join(err, fmt.Errorf("invalid connection secret template for key %s: %w", key, parseErr))
+			continue
+		}
+		parsed[key] = tmpl
+	}
+	return parsed, err
+}
+
+func parseConnectionSecretTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(text)
+}
+
+// templateFields collects the names of the fields referenced by the template node
+func templateFields(node parse.Node, fields map[string]bool) map[string]bool {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n != nil {
+			for _, child := range n.Nodes {
+				templateFields(child, fields)
+			}
+		}
+	case *parse.ActionNode:
+		templateFields(n.Pipe, fields)
+	case *parse.PipeNode:
+		if n != nil {
+			for _, cmd := range n.Cmds {
+				templateFields(cmd, fields)
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			templateFields(arg, fields)
+		}
+	case *parse.ChainNode:
+		templateFields(n.Node, fields)
+		for _, field := range n.Field {
+			fields[field] = true
+		}
+	case *parse.FieldNode:
+		for _, field := range n.Ident {
+			fields[field] = true
+		}
+	case *parse.IfNode:
+		templateFields(&n.BranchNode, fields)
+	case *parse.RangeNode:
+		templateFields(&n.BranchNode, fields)
+	case *parse.WithNode:
+		templateFields(&n.BranchNode, fields)
+	case *parse.BranchNode:
+		templateFields(n.Pipe, fields)
+		templateFields(n.List, fields)
+		templateFields(n.ElseList, fields)
+	}
+	return fields
+}
+
+// PasswordRotationSpec configures the rotation of the database user password by the Atlas Operator.
+// The operator generates a new password, writes it to the password Secret and updates Atlas and all connection
+// Secrets of the user in the same reconciliation.
+type PasswordRotationSpec struct {
+	// Interval is the time between two password rotations, for example "720h".
+	Interval metav1.Duration `json:"interval"`
+}
+
 // ScopeSpec if present a database user only have access to the indicated resource (Cluster or Atlas Data Lake)
 // if none is given then it has access to all.
 // It's highly recommended to restrict the access of the database users only to a limited set of resources.
@@ -132,6 +331,22 @@ type ScopeSpec struct {
 	Type ScopeType `json:"type"`
 }
 
+// SelectsProjects returns true if the user is created in the AtlasProjects matching its projectSelector rather
+// than in the one referenced by its projectRef
+func (p AtlasDatabaseUser) SelectsProjects() bool {
+	return p.Spec.ProjectSelector != nil
+}
+
+// ReadyInProject returns true if the user selecting projects is ready in the Atlas project with the given ID
+func (p AtlasDatabaseUser) ReadyInProject(projectID string) bool {
+	for _, project := range p.Status.Projects {
+		if project.ID != "" && project.ID == projectID {
+			return project.Ready
+		}
+	}
+	return false
+}
+
 func (p AtlasDatabaseUser) AtlasProjectObjectKey() client.ObjectKey {
 	ns := p.Namespace
 	if p.Spec.Project.Namespace != "" {
@@ -191,7 +406,12 @@ func (p AtlasDatabaseUser) ToAtlas(kubeClient client.Client) (*mongodbatlas.Data
 
 	result := &mongodbatlas.DatabaseUser{}
 	err = compat.JSONCopy(result, p.Spec)
-	result.Password = password
+	result.DatabaseName = p.Spec.AuthDatabase()
+	// Only SCRAM users have their password in Atlas. The password of the other users is only used by the connection
+	// Secrets, if at all
+	if authType, _ := p.Spec.AuthType(); authType == "" {
+		result.Password = password
+	}
 
 	return result, err
 }
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+)
+
+func TestAtlasDatabaseUserSpec_AuthDatabase(t *testing.T) {
+	tests := map[string]struct {
+		spec AtlasDatabaseUserSpec
+		want string
+	}{
+		"SCRAM user keeps the database name": {
+			spec: AtlasDatabaseUserSpec{DatabaseName: "admin"},
+			want: "admin",
+		},
+		"disabled authentication types are ignored": {
+			spec: AtlasDatabaseUserSpec{DatabaseName: "admin", X509Type: "NONE", AWSIAMType: "NONE"},
+			want: "admin",
+		},
+		"X.509 user": {
+			spec: AtlasDatabaseUserSpec{DatabaseName: "admin", X509Type: "MANAGED"},
+			want: "$external",
+		},
+		"AWS IAM role": {
+			spec: AtlasDatabaseUserSpec{DatabaseName: "admin", AWSIAMType: "ROLE"},
+			want: "$external",
+		},
+		"LDAP user": {
+			spec: AtlasDatabaseUserSpec{DatabaseName: "admin", LDAPAuthType: "USER"},
+			want: "$external",
+		},
+		"LDAP group": {
+			spec: AtlasDatabaseUserSpec{DatabaseName: "$external", LDAPAuthType: "GROUP"},
+			want: "admin",
+		},
+		"OIDC user": {
+			spec: AtlasDatabaseUserSpec{DatabaseName: "admin", OIDCAuthType: "USER"},
+			want: "$external",
+		},
+		"OIDC identity provider group": {
+			spec: AtlasDatabaseUserSpec{DatabaseName: "admin", OIDCAuthType: "IDP_GROUP"},
+			want: "admin",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.spec.AuthDatabase())
+		})
+	}
+}
+
+func TestAtlasDatabaseUserSpec_IsGroup(t *testing.T) {
+	assert.False(t, AtlasDatabaseUserSpec{}.IsGroup())
+	assert.False(t, AtlasDatabaseUserSpec{LDAPAuthType: "USER"}.IsGroup())
+	assert.False(t, AtlasDatabaseUserSpec{OIDCAuthType: "USER"}.IsGroup())
+	assert.True(t, AtlasDatabaseUserSpec{LDAPAuthType: "GROUP"}.IsGroup())
+	assert.True(t, AtlasDatabaseUserSpec{OIDCAuthType: "IDP_GROUP"}.IsGroup())
+}
+
+func TestAtlasDatabaseUser_ToAtlas(t *testing.T) {
+	dbUser := AtlasDatabaseUser{
+		Spec: AtlasDatabaseUserSpec{
+			DatabaseName: "admin",
+			Username:     "arn:aws:iam::123456789012:role/app",
+			AWSIAMType:   "ROLE",
+			Roles:        []RoleSpec{{RoleName: "readWrite", DatabaseName: "test"}},
+		},
+	}
+
+	atlasUser, err := dbUser.ToAtlas(fake.NewClientBuilder().Build())
+	assert.NoError(t, err)
+	assert.Equal(t, "$external", atlasUser.DatabaseName)
+	assert.Equal(t, "ROLE", atlasUser.AWSIAMType)
+	assert.Empty(t, atlasUser.Password)
+}
+
+func TestAtlasDatabaseUser_ToAtlasKeepsExternalPasswords(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap-password", Namespace: "ns"},
+		Data:       map[string][]byte{"password": []byte("Passw0rd!")},
+	}
+	dbUser := AtlasDatabaseUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap-user", Namespace: "ns"},
+		Spec: AtlasDatabaseUserSpec{
+			Username:       "CN=app,OU=users,DC=example,DC=com",
+			LDAPAuthType:   "USER",
+			PasswordSecret: &common.ResourceRef{Name: "ldap-password"},
+		},
+	}
+
+	atlasUser, err := dbUser.ToAtlas(fake.NewClientBuilder().WithObjects(secret).Build())
+	assert.NoError(t, err)
+	assert.Empty(t, atlasUser.Password, "the LDAP password is only used by the connection secrets")
+}
@@ -104,6 +104,10 @@ type AdvancedDeploymentSpec struct {
 	// Version of the deployment to deploy.
 	MongoDBMajorVersion string `json:"mongoDBMajorVersion,omitempty"`
 	MongoDBVersion      string `json:"mongoDBVersion,omitempty"`
+	// UpgradePolicy runs pre-flight checks before changing mongoDBMajorVersion and restricts when it is changed.
+	// The major version is changed as soon as it is updated if not set.
+	// +optional
+	UpgradePolicy *UpgradePolicy `json:"upgradePolicy,omitempty"`
 	// Name of the advanced deployment as it appears in Atlas.
 	// After Atlas creates the deployment, you can't change its name.
 	// Can only contain ASCII letters, numbers, and hyphens.
@@ -112,6 +116,10 @@ type AdvancedDeploymentSpec struct {
 	Name string `json:"name,omitempty"`
 	// Flag that indicates whether the deployment should be paused.
 	Paused *bool `json:"paused,omitempty"`
+	// PauseSchedule pauses and resumes the deployment following a weekly schedule.
+	// It can't be set together with paused.
+	// +optional
+	PauseSchedule *PauseSchedule `json:"pauseSchedule,omitempty"`
 	// Flag that indicates the deployment uses continuous cloud backups.
 	// +optional
 	PitEnabled *bool `json:"pitEnabled,omitempty"`
@@ -128,6 +136,10 @@ type AdvancedDeploymentSpec struct {
 	CustomZoneMapping []CustomZoneMapping `json:"customZoneMapping,omitempty"`
 	// +optional
 	ManagedNamespaces []ManagedNamespace `json:"managedNamespaces,omitempty"`
+	// Online Archives moving the data of the collections of the deployment to cheaper storage.
+	// The Online Archives are left untouched while the field isn't set.
+	// +optional
+	OnlineArchives []OnlineArchive `json:"onlineArchives,omitempty"`
 }
 
 // ToAtlas converts the AdvancedDeploymentSpec to native Atlas client ToAtlas format.
@@ -23,6 +23,10 @@ type AtlasFederatedAuthSpec struct {
 	// Connection secret with API credentials for configuring the federation.
 	// These credentials must have OrganizationOwner permissions.
 	ConnectionSecretRef common.ResourceRefNamespaced `json:"connectionSecretRef,omitempty"`
+	// AtlasConnectionRef is the name of the cluster scoped AtlasConnection defining the Atlas domain, the organization
+	// and the credentials used instead of the connection secret.
+	// +optional
+	AtlasConnectionRef *common.ResourceRef `json:"atlasConnectionRef,omitempty"`
 	// Approved domains that restrict users who can join the organization based on their email address.
 	// +optional
 	DomainAllowList []string `json:"domainAllowList,omitempty"`
@@ -124,6 +128,14 @@ func (f *AtlasFederatedAuth) ConnectionSecretObjectKey() *client.ObjectKey {
 	return &key
 }
 
+// AtlasConnectionName returns the name of the AtlasConnection of the federated auth, empty if it doesn't reference one
+func (f *AtlasFederatedAuth) AtlasConnectionName() string {
+	if f.Spec.AtlasConnectionRef == nil {
+		return ""
+	}
+	return f.Spec.AtlasConnectionRef.Name
+}
+
 func (f *AtlasFederatedAuth) GetStatus() status.Status {
 	return f.Status
 }
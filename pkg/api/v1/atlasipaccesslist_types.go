@@ -0,0 +1,80 @@
+/*
+Copyright (C) MongoDB, Inc. 2020-present.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may
+not use this file except in compliance with the License. You may obtain
+a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// AtlasIPAccessListSpec defines the desired state of AtlasIPAccessList
+type AtlasIPAccessListSpec struct {
+	// Project is a reference to AtlasProject resource the IP Access List entries belong to
+	Project common.ResourceRefNamespaced `json:"projectRef"`
+
+	// Entries is the list of IP Access List entries managed by the resource. The entries must not be managed by the
+	// AtlasProject or another AtlasIPAccessList
+	// +kubebuilder:validation:MinItems=1
+	Entries []project.IPAccessList `json:"entries"`
+}
+
+// AtlasIPAccessList is the Schema for the atlasipaccesslists API. It manages a part of the IP Access List of an
+// Atlas Project independently of the AtlasProject resource.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Project",type=string,JSONPath=`.spec.projectRef.name`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+type AtlasIPAccessList struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AtlasIPAccessListSpec          `json:"spec,omitempty"`
+	Status status.AtlasIPAccessListStatus `json:"status,omitempty"`
+}
+
+func (in *AtlasIPAccessList) AtlasProjectObjectKey() client.ObjectKey {
+	ns := in.Namespace
+	if in.Spec.Project.Namespace != "" {
+		ns = in.Spec.Project.Namespace
+	}
+	return kube.ObjectKey(ns, in.Spec.Project.Name)
+}
+
+func (in *AtlasIPAccessList) GetStatus() status.Status {
+	return in.Status
+}
+
+func (in *AtlasIPAccessList) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	in.Status.Conditions = conditions
+	in.Status.ObservedGeneration = in.ObjectMeta.Generation
+
+	for _, o := range options {
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasIPAccessListStatusOption)
+		v(&in.Status)
+	}
+}
+
+//+kubebuilder:object:root=true
+
+// AtlasIPAccessListList contains a list of AtlasIPAccessList
+type AtlasIPAccessListList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasIPAccessList `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AtlasIPAccessList{}, &AtlasIPAccessListList{})
+}
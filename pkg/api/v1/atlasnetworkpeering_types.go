@@ -0,0 +1,79 @@
+/*
+Copyright (C) MongoDB, Inc. 2020-present.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may
+not use this file except in compliance with the License. You may obtain
+a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// AtlasNetworkPeeringSpec defines the desired state of AtlasNetworkPeering
+type AtlasNetworkPeeringSpec struct {
+	// Project is a reference to AtlasProject resource the Network Peers belong to
+	Project common.ResourceRefNamespaced `json:"projectRef"`
+
+	// NetworkPeers is the list of Network Peers managed by the resource. The peers must not be managed by the
+	// AtlasProject or another AtlasNetworkPeering
+	// +kubebuilder:validation:MinItems=1
+	NetworkPeers []NetworkPeer `json:"networkPeers"`
+}
+
+// AtlasNetworkPeering is the Schema for the atlasnetworkpeerings API. It manages Network Peers of an Atlas Project
+// independently of the AtlasProject resource.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Project",type=string,JSONPath=`.spec.projectRef.name`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+type AtlasNetworkPeering struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AtlasNetworkPeeringSpec          `json:"spec,omitempty"`
+	Status status.AtlasNetworkPeeringStatus `json:"status,omitempty"`
+}
+
+func (in *AtlasNetworkPeering) AtlasProjectObjectKey() client.ObjectKey {
+	ns := in.Namespace
+	if in.Spec.Project.Namespace != "" {
+		ns = in.Spec.Project.Namespace
+	}
+	return kube.ObjectKey(ns, in.Spec.Project.Name)
+}
+
+func (in *AtlasNetworkPeering) GetStatus() status.Status {
+	return in.Status
+}
+
+func (in *AtlasNetworkPeering) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	in.Status.Conditions = conditions
+	in.Status.ObservedGeneration = in.ObjectMeta.Generation
+
+	for _, o := range options {
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasNetworkPeeringStatusOption)
+		v(&in.Status)
+	}
+}
+
+//+kubebuilder:object:root=true
+
+// AtlasNetworkPeeringList contains a list of AtlasNetworkPeering
+type AtlasNetworkPeeringList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasNetworkPeering `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AtlasNetworkPeering{}, &AtlasNetworkPeeringList{})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright (C) MongoDB, Inc. 2020-present.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may
+not use this file except in compliance with the License. You may obtain
+a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/provider"
+)
+
+// AtlasPolicySpec defines the limits of the Atlas resources of the namespaces the policy selects
+type AtlasPolicySpec struct {
+	// NamespaceSelector selects the namespaces the policy applies to. The policy applies to all the namespaces if not set
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Deployments defines the limits of the AtlasDeployments of the selected namespaces
+	Deployments DeploymentPolicy `json:"deployments"`
+}
+
+// DeploymentPolicy defines the limits of AtlasDeployments. The limits which aren't set aren't enforced
+type DeploymentPolicy struct {
+	// AllowedInstanceSizes is the list of the instance sizes the nodes and the compute autoscaling can use, e.g. M10
+	// +optional
+	AllowedInstanceSizes []string `json:"allowedInstanceSizes,omitempty"`
+
+	// MaxInstanceSize is the largest instance size the nodes and the compute autoscaling can use, e.g. M40
+	// +optional
+	MaxInstanceSize string `json:"maxInstanceSize,omitempty"`
+
+	// MaxNodeCount is the maximum number of nodes of a deployment, counted over all its shards and regions
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxNodeCount *int `json:"maxNodeCount,omitempty"`
+
+	// MaxRegions is the maximum number of regions a deployment spans. 1 forbids multi-region deployments
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRegions *int `json:"maxRegions,omitempty"`
+
+	// AllowedProviders is the list of the cloud providers hosting the deployments. The backing provider of the
+	// tenant and serverless deployments is checked
+	// +optional
+	AllowedProviders []provider.ProviderName `json:"allowedProviders,omitempty"`
+
+	// AllowedRegions is the list of the Atlas regions hosting the deployments, e.g. US_EAST_1
+	// +optional
+	AllowedRegions []string `json:"allowedRegions,omitempty"`
+
+	// AutoScaling defines the mandatory autoscaling of the deployments
+	// +optional
+	AutoScaling *AutoScalingPolicy `json:"autoScaling,omitempty"`
+}
+
+// AutoScalingPolicy defines the mandatory autoscaling of the deployments
+type AutoScalingPolicy struct {
+	// ComputeRequired requires the compute autoscaling of the dedicated deployments to be enabled with both its
+	// minimum and maximum instance sizes
+	// +optional
+	ComputeRequired bool `json:"computeRequired,omitempty"`
+
+	// DiskGBRequired requires the disk autoscaling of the dedicated deployments to be enabled
+	// +optional
+	DiskGBRequired bool `json:"diskGBRequired,omitempty"`
+}
+
+// AtlasPolicy is the Schema for the atlaspolicies API. It limits the Atlas resources platform teams allow in the
+// namespaces it selects.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=atlaspolicies,scope=Cluster
+// +kubebuilder:printcolumn:name="Max Instance Size",type=string,JSONPath=`.spec.deployments.maxInstanceSize`
+type AtlasPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AtlasPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AtlasPolicyList contains a list of AtlasPolicy
+type AtlasPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AtlasPolicy{}, &AtlasPolicyList{})
+}
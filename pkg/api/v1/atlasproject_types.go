@@ -59,6 +59,11 @@ type AtlasProjectSpec struct {
 	// +optional
 	ConnectionSecret *common.ResourceRefNamespaced `json:"connectionSecretRef,omitempty"`
 
+	// AtlasConnectionRef is the name of the cluster scoped AtlasConnection defining the Atlas domain, the organization
+	// and the credentials of the project. Can't be used together with the ConnectionSecret.
+	// +optional
+	AtlasConnectionRef *common.ResourceRef `json:"atlasConnectionRef,omitempty"`
+
 	// ProjectIPAccessList allows to enable the IP Access List for the Project. See more information at
 	// https://docs.atlas.mongodb.com/reference/api/ip-access-list/add-entries-to-access-list/
 	// +optional
@@ -197,6 +202,14 @@ func (p *AtlasProject) ConnectionSecretObjectKey() *client.ObjectKey {
 	return nil
 }
 
+// AtlasConnectionName returns the name of the AtlasConnection of the project, empty if it doesn't reference one
+func (p *AtlasProject) AtlasConnectionName() string {
+	if p.Spec.AtlasConnectionRef == nil {
+		return ""
+	}
+	return p.Spec.AtlasConnectionRef.Name
+}
+
 func (p *AtlasProject) GetStatus() status.Status {
 	return p.Status
 }
@@ -0,0 +1,174 @@
+/*
+Copyright (C) MongoDB, Inc. 2020-present.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may
+not use this file except in compliance with the License. You may obtain
+a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// AtlasSearchIndexSpec defines the desired state of AtlasSearchIndex
+type AtlasSearchIndexSpec struct {
+	// DeploymentRef is a reference to the AtlasDeployment resource the index is created in
+	DeploymentRef common.ResourceRefNamespaced `json:"deploymentRef"`
+
+	// Name is the name of the index. It must be unique within the collection
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Database is the name of the database containing the indexed collection
+	// +kubebuilder:validation:MinLength=1
+	Database string `json:"database"`
+
+	// CollectionName is the name of the indexed collection
+	// +kubebuilder:validation:MinLength=1
+	CollectionName string `json:"collectionName"`
+
+	// Analyzer is the analyzer applied to the string fields when they're indexed. Atlas uses "lucene.standard" if
+	// not set
+	// +optional
+	Analyzer string `json:"analyzer,omitempty"`
+
+	// SearchAnalyzer is the analyzer applied to the query text. The Analyzer is used if not set
+	// +optional
+	SearchAnalyzer string `json:"searchAnalyzer,omitempty"`
+
+	// Mappings defines how the fields of the collection are indexed
+	Mappings SearchIndexMappings `json:"mappings"`
+
+	// Synonyms is the list of synonym mappings of the index
+	// +optional
+	Synonyms []SearchIndexSynonym `json:"synonyms,omitempty"`
+}
+
+// SearchIndexMappings defines how the fields of the collection are indexed
+type SearchIndexMappings struct {
+	// Dynamic enables the dynamic mapping of all the fields of the collection
+	// +optional
+	Dynamic bool `json:"dynamic,omitempty"`
+
+	// Fields is the static mapping of the fields, in the format of the Atlas Search index definition, e.g.
+	// {"title": {"type": "string"}}
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Fields *apiextensionsv1.JSON `json:"fields,omitempty"`
+}
+
+// SearchIndexSynonym is a synonym mapping of the index
+type SearchIndexSynonym struct {
+	// Name is the name of the synonym mapping, referenced by the queries
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Analyzer is the analyzer applied to the synonyms
+	// +kubebuilder:validation:MinLength=1
+	Analyzer string `json:"analyzer"`
+
+	// Source is the collection keeping the synonyms, in the database of the index
+	Source SearchIndexSynonymSource `json:"source"`
+}
+
+// SearchIndexSynonymSource is the collection keeping the synonyms of a synonym mapping
+type SearchIndexSynonymSource struct {
+	// Collection is the name of the collection keeping the synonyms
+	// +kubebuilder:validation:MinLength=1
+	Collection string `json:"collection"`
+}
+
+// ToAtlas converts the spec to the Atlas Search index definition
+func (s *AtlasSearchIndexSpec) ToAtlas() (*mongodbatlas.SearchIndex, error) {
+	index := &mongodbatlas.SearchIndex{
+		Name:           s.Name,
+		Database:       s.Database,
+		CollectionName: s.CollectionName,
+		Analyzer:       s.Analyzer,
+		SearchAnalyzer: s.SearchAnalyzer,
+		Mappings:       &mongodbatlas.IndexMapping{Dynamic: s.Mappings.Dynamic},
+	}
+
+	if s.Mappings.Fields != nil {
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(s.Mappings.Fields.Raw, &fields); err != nil {
+			return nil, fmt.Errorf("mappings.fields must be a JSON object: %w", err)
+		}
+		index.Mappings.Fields = &fields
+	}
+
+	for _, synonym := range s.Synonyms {
+		index.Synonyms = append(index.Synonyms, map[string]interface{}{
+			"name":     synonym.Name,
+			"analyzer": synonym.Analyzer,
+			"source":   map[string]interface{}{"collection": synonym.Source.Collection},
+		})
+	}
+
+	return index, nil
+}
+
+// AtlasSearchIndex is the Schema for the atlassearchindexes API. It manages an Atlas Search index of a collection of
+// a deployment.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=atlassearchindexes
+// +kubebuilder:printcolumn:name="Deployment",type=string,JSONPath=`.spec.deploymentRef.name`
+// +kubebuilder:printcolumn:name="Index",type=string,JSONPath=`.spec.name`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.indexStatus`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+type AtlasSearchIndex struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AtlasSearchIndexSpec          `json:"spec,omitempty"`
+	Status status.AtlasSearchIndexStatus `json:"status,omitempty"`
+}
+
+func (in *AtlasSearchIndex) AtlasDeploymentObjectKey() client.ObjectKey {
+	ns := in.Namespace
+	if in.Spec.DeploymentRef.Namespace != "" {
+		ns = in.Spec.DeploymentRef.Namespace
+	}
+	return kube.ObjectKey(ns, in.Spec.DeploymentRef.Name)
+}
+
+func (in *AtlasSearchIndex) GetStatus() status.Status {
+	return in.Status
+}
+
+func (in *AtlasSearchIndex) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	in.Status.Conditions = conditions
+	in.Status.ObservedGeneration = in.ObjectMeta.Generation
+
+	for _, o := range options {
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasSearchIndexStatusOption)
+		v(&in.Status)
+	}
+}
+
+//+kubebuilder:object:root=true
+
+// AtlasSearchIndexList contains a list of AtlasSearchIndex
+type AtlasSearchIndexList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasSearchIndex `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AtlasSearchIndex{}, &AtlasSearchIndexList{})
+}
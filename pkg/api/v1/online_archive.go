@@ -0,0 +1,156 @@
+package v1
+
+import (
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+const (
+	OnlineArchiveCriteriaDate   = "DATE"
+	OnlineArchiveCriteriaCustom = "CUSTOM"
+)
+
+// OnlineArchive moves the documents of a collection matching the criteria to cheaper storage
+type OnlineArchive struct {
+	// DBName is the name of the database containing the archived collection
+	// +kubebuilder:validation:MinLength=1
+	DBName string `json:"dbName"`
+	// CollName is the name of the archived collection
+	// +kubebuilder:validation:MinLength=1
+	CollName string `json:"collName"`
+	// CollectionType is the type of the archived collection
+	// +kubebuilder:validation:Enum=STANDARD;TIMESERIES
+	// +kubebuilder:default:=STANDARD
+	// +optional
+	CollectionType string `json:"collectionType,omitempty"`
+	// Criteria selects the archived documents
+	Criteria OnlineArchiveCriteria `json:"criteria"`
+	// PartitionFields are the fields, in order, the archived data is partitioned by. They can't be changed once the
+	// archive is created
+	// +kubebuilder:validation:MaxItems=2
+	// +optional
+	PartitionFields []OnlineArchivePartitionField `json:"partitionFields,omitempty"`
+	// Schedule restricts the archiving to a time window. Atlas archives the data every five minutes if not set
+	// +optional
+	Schedule *OnlineArchiveSchedule `json:"schedule,omitempty"`
+	// Paused pauses the archiving of the data
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// OnlineArchiveCriteria selects the archived documents, either by their age or by a custom query
+type OnlineArchiveCriteria struct {
+	// Type is the type of the criteria: DATE to archive the documents older than ExpireAfterDays, CUSTOM to archive
+	// the documents matching Query
+	// +kubebuilder:validation:Enum=DATE;CUSTOM
+	Type string `json:"type"`
+	// DateField is the date field the age of the documents is computed from. Required with the DATE criteria
+	// +optional
+	DateField string `json:"dateField,omitempty"`
+	// DateFormat is the format of the DateField
+	// +kubebuilder:validation:Enum=ISODATE;EPOCH_SECONDS;EPOCH_MILLIS;EPOCH_NANOSECONDS
+	// +optional
+	DateFormat string `json:"dateFormat,omitempty"`
+	// ExpireAfterDays is the age in days of the archived documents. Required with the DATE criteria
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ExpireAfterDays int `json:"expireAfterDays,omitempty"`
+	// Query is the JSON query selecting the archived documents. Required with the CUSTOM criteria
+	// +optional
+	Query string `json:"query,omitempty"`
+}
+
+// OnlineArchivePartitionField is a field the archived data is partitioned by
+type OnlineArchivePartitionField struct {
+	// FieldName is the name of the field
+	// +kubebuilder:validation:MinLength=1
+	FieldName string `json:"fieldName"`
+}
+
+// OnlineArchiveSchedule is the time window in which the data is archived. Hours and minutes are in UTC
+type OnlineArchiveSchedule struct {
+	// Type is the frequency of the archiving
+	// +kubebuilder:validation:Enum=DEFAULT;DAILY;WEEKLY;MONTHLY
+	Type string `json:"type"`
+	// DayOfMonth is the day of the month the data is archived on with the MONTHLY schedule
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=31
+	// +optional
+	DayOfMonth int `json:"dayOfMonth,omitempty"`
+	// DayOfWeek is the day of the week the data is archived on with the WEEKLY schedule, 1 being Monday
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=7
+	// +optional
+	DayOfWeek int `json:"dayOfWeek,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=23
+	// +optional
+	StartHour *int `json:"startHour,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=59
+	// +optional
+	StartMinute *int `json:"startMinute,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=23
+	// +optional
+	EndHour *int `json:"endHour,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=59
+	// +optional
+	EndMinute *int `json:"endMinute,omitempty"`
+}
+
+// ToAtlas converts the OnlineArchive to the Atlas Online Archive format
+func (in *OnlineArchive) ToAtlas() *mongodbatlas.OnlineArchive {
+	archive := &mongodbatlas.OnlineArchive{
+		DBName:         in.DBName,
+		CollName:       in.CollName,
+		CollectionType: in.CollectionType,
+		Criteria: &mongodbatlas.OnlineArchiveCriteria{
+			Type:       in.Criteria.Type,
+			DateField:  in.Criteria.DateField,
+			DateFormat: in.Criteria.DateFormat,
+			Query:      in.Criteria.Query,
+		},
+		Paused: toptr.MakePtr(in.Paused),
+	}
+
+	if in.Criteria.ExpireAfterDays > 0 {
+		archive.Criteria.ExpireAfterDays = toptr.MakePtr(float64(in.Criteria.ExpireAfterDays))
+	}
+
+	for i, field := range in.PartitionFields {
+		archive.PartitionFields = append(archive.PartitionFields, &mongodbatlas.PartitionFields{
+			FieldName: field.FieldName,
+			Order:     toptr.MakePtr(float64(i)),
+		})
+	}
+
+	if in.Schedule != nil {
+		archive.Schedule = &mongodbatlas.OnlineArchiveSchedule{
+			Type:        in.Schedule.Type,
+			DayOfMonth:  int32(in.Schedule.DayOfMonth),
+			DayOfWeek:   int32(in.Schedule.DayOfWeek),
+			StartHour:   int32Ptr(in.Schedule.StartHour),
+			StartMinute: int32Ptr(in.Schedule.StartMinute),
+			EndHour:     int32Ptr(in.Schedule.EndHour),
+			EndMinute:   int32Ptr(in.Schedule.EndMinute),
+		}
+	}
+
+	return archive
+}
+
+// Namespace returns the archived namespace, identifying the archive in the deployment
+func (in *OnlineArchive) Namespace() string {
+	return in.DBName + "." + in.CollName
+}
+
+func int32Ptr(value *int) *int32 {
+	if value == nil {
+		return nil
+	}
+
+	return toptr.MakePtr(int32(*value))
+}
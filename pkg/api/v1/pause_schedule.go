@@ -0,0 +1,30 @@
+package v1
+
+// PauseSchedule pauses the deployment during the windows and resumes it outside of them
+type PauseSchedule struct {
+	// TimeZone is the IANA name of the time zone the windows are expressed in, for example Europe/Paris
+	// +kubebuilder:default:=UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// Windows are the time windows during which the deployment is paused
+	// +kubebuilder:validation:MinItems=1
+	Windows []PauseWindow `json:"windows"`
+}
+
+// PauseWindow is a weekly recurring time window
+type PauseWindow struct {
+	// Days are the days of the week the window starts on. The window starts every day if not set
+	// +optional
+	Days []Weekday `json:"days,omitempty"`
+	// Start is the time of the day the deployment is paused at, in HH:MM format
+	// +kubebuilder:validation:Pattern:=^([01][0-9]|2[0-3]):[0-5][0-9]$
+	Start string `json:"start"`
+	// End is the time of the day the deployment is resumed at, in HH:MM format. 24:00 is the end of the day.
+	// The window ends on the next day if End isn't after Start
+	// +kubebuilder:validation:Pattern:=^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$
+	End string `json:"end"`
+}
+
+// Weekday is a day of the week
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type Weekday string
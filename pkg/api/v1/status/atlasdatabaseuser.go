@@ -17,6 +17,24 @@ func AtlasDatabaseUserNameOption(name string) AtlasDatabaseUserStatusOption {
 	}
 }
 
+func AtlasDatabaseUserLastPasswordRotationOption(lastPasswordRotation string) AtlasDatabaseUserStatusOption {
+	return func(s *AtlasDatabaseUserStatus) {
+		s.LastPasswordRotation = lastPasswordRotation
+	}
+}
+
+func AtlasDatabaseUserPlannedChangesOption(changes []PlannedChange) AtlasDatabaseUserStatusOption {
+	return func(s *AtlasDatabaseUserStatus) {
+		s.PlannedChanges = changes
+	}
+}
+
+func AtlasDatabaseUserProjectsOption(projects []DatabaseUserProject) AtlasDatabaseUserStatusOption {
+	return func(s *AtlasDatabaseUserStatus) {
+		s.Projects = projects
+	}
+}
+
 // AtlasDatabaseUserStatus defines the observed state of AtlasProject
 type AtlasDatabaseUserStatus struct {
 	Common `json:",inline"`
@@ -26,4 +44,38 @@ type AtlasDatabaseUserStatus struct {
 
 	// UserName is the current name of database user.
 	UserName string `json:"name,omitempty"`
+
+	// LastPasswordRotation is the time in ISO 8601 format of the last password rotation done by the Atlas Operator.
+	LastPasswordRotation string `json:"lastPasswordRotation,omitempty"`
+
+	// PlannedChanges is the list of changes to Atlas found by the last reconciliation in dry-run mode.
+	// Nothing is applied to Atlas while dry-run is enabled.
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
+
+	// Projects is the state of the user in each of the projects matching its projectSelector.
+	Projects []DatabaseUserProject `json:"projects,omitempty"`
+}
+
+// DatabaseUserProject is the state of a database user in one of the projects matching its projectSelector
+type DatabaseUserProject struct {
+	// Name is the namespaced name of the AtlasProject, e.g. "ns/my-project".
+	Name string `json:"name"`
+
+	// ID is the ID of the project in Atlas.
+	ID string `json:"id"`
+
+	// Ready is true if the user is up-to-date in the project.
+	Ready bool `json:"ready"`
+
+	// Reason is the reason the user isn't ready in the project.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the details of the reason the user isn't ready in the project.
+	Message string `json:"message,omitempty"`
+
+	// PasswordVersion is the 'ResourceVersion' of the password Secret last applied to the project.
+	PasswordVersion string `json:"passwordVersion,omitempty"`
+
+	// UserName is the name of the database user in the project.
+	UserName string `json:"userName,omitempty"`
 }
@@ -2,6 +2,7 @@ package status
 
 import (
 	"go.mongodb.org/atlas/mongodbatlas"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/compat"
 )
@@ -28,9 +29,84 @@ type AtlasDeploymentStatus struct {
 
 	ManagedNamespaces []ManagedNamespace `json:"managedNamespaces,omitempty"`
 
+	// OnlineArchives is the list of the Online Archives of the deployment managed by the operator
+	OnlineArchives []OnlineArchive `json:"onlineArchives,omitempty"`
+
 	// MongoURIUpdated is a timestamp in ISO 8601 date and time format in UTC when the connection string was last updated.
 	// The connection string changes if you update any of the other values.
 	MongoURIUpdated string `json:"mongoURIUpdated,omitempty"`
+
+	// NextPauseTransition is the time the pause schedule pauses or resumes the deployment next, in ISO 8601 date and time format in UTC.
+	NextPauseTransition string `json:"nextPauseTransition,omitempty"`
+
+	// Upgrade is the state of the last major version upgrade of the deployment run with an upgrade policy.
+	Upgrade *DeploymentUpgrade `json:"upgrade,omitempty"`
+
+	// Operations are the last one-shot operations requested with the annotations of the deployment, one per kind of operation.
+	Operations []DeploymentOperation `json:"operations,omitempty"`
+
+	// PlannedChanges is the list of changes to Atlas found by the last reconciliation in dry-run mode.
+	// Nothing is applied to Atlas while dry-run is enabled.
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
+}
+
+const (
+	DeploymentOperationTestFailover   = "testFailover"
+	DeploymentOperationRollingRestart = "rollingRestart"
+
+	DeploymentOperationStarting   = "Starting"
+	DeploymentOperationRequested  = "Requested"
+	DeploymentOperationInProgress = "InProgress"
+	DeploymentOperationCompleted  = "Completed"
+	DeploymentOperationFailed     = "Failed"
+)
+
+const (
+	DeploymentUpgradePending    = "Pending"
+	DeploymentUpgradeInProgress = "InProgress"
+	DeploymentUpgradeCompleted  = "Completed"
+)
+
+// DeploymentUpgrade is a major version upgrade of the deployment
+type DeploymentUpgrade struct {
+	// FromVersion is the major version the deployment is upgraded from
+	FromVersion string `json:"fromVersion"`
+	// ToVersion is the major version the deployment is upgraded to
+	ToVersion string `json:"toVersion"`
+	// State is the state of the upgrade: Pending until the pre-flight checks pass, InProgress or Completed
+	State string `json:"state"`
+	// Message explains why the upgrade is pending
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the state of the upgrade changed
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// DeploymentOperation is a one-shot operation requested with an annotation of the deployment.
+// An operation runs once per value of its annotation.
+type DeploymentOperation struct {
+	// Name is the kind of the operation: testFailover or rollingRestart
+	Name string `json:"name"`
+	// Request is the value of the annotation the operation was run for
+	Request string `json:"request"`
+	// State is the state of the operation: Starting while it's requested from Atlas, Requested until the deployment is
+	// seen running it, InProgress, Completed or Failed
+	State string `json:"state"`
+	// Message explains the state of the operation
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the state of the operation changed
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// Operation returns the last operation of the given kind, nil if there is none
+func (s *AtlasDeploymentStatus) Operation(name string) *DeploymentOperation {
+	for i := range s.Operations {
+		if s.Operations[i].Name == name {
+			return &s.Operations[i]
+		}
+	}
+	return nil
 }
 
 const (
@@ -145,6 +221,12 @@ func AtlasDeploymentManagedNamespacesOption(namespaces []ManagedNamespace) Atlas
 	}
 }
 
+func AtlasDeploymentOnlineArchivesOption(archives []OnlineArchive) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.OnlineArchives = archives
+	}
+}
+
 func AtlasDeploymentMongoDBVersionOption(mongoDBVersion string) AtlasDeploymentStatusOption {
 	return func(s *AtlasDeploymentStatus) {
 		s.MongoDBVersion = mongoDBVersion
@@ -167,3 +249,32 @@ func AtlasDeploymentMongoURIUpdatedOption(mongoURIUpdated string) AtlasDeploymen
 		s.MongoURIUpdated = mongoURIUpdated
 	}
 }
+
+func AtlasDeploymentNextPauseTransitionOption(nextPauseTransition string) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.NextPauseTransition = nextPauseTransition
+	}
+}
+
+func AtlasDeploymentUpgradeOption(upgrade *DeploymentUpgrade) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.Upgrade = upgrade
+	}
+}
+
+// AtlasDeploymentOperationOption replaces the operation of the same kind
+func AtlasDeploymentOperationOption(operation DeploymentOperation) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		if current := s.Operation(operation.Name); current != nil {
+			*current = operation
+			return
+		}
+		s.Operations = append(s.Operations, operation)
+	}
+}
+
+func AtlasDeploymentPlannedChangesOption(changes []PlannedChange) AtlasDeploymentStatusOption {
+	return func(s *AtlasDeploymentStatus) {
+		s.PlannedChanges = changes
+	}
+}
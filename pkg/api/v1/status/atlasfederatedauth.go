@@ -2,8 +2,18 @@ package status
 
 type AtlasFederatedAuthStatus struct {
 	Common `json:",inline"`
+
+	// PlannedChanges is the list of changes to Atlas found by the last reconciliation in dry-run mode.
+	// Nothing is applied to Atlas while dry-run is enabled.
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
 }
 
 // +k8s:deepcopy-gen=false
 
 type AtlasFederatedAuthStatusOption func(s *AtlasFederatedAuthStatus)
+
+func AtlasFederatedAuthPlannedChangesOption(changes []PlannedChange) AtlasFederatedAuthStatusOption {
+	return func(s *AtlasFederatedAuthStatus) {
+		s.PlannedChanges = changes
+	}
+}
@@ -0,0 +1,35 @@
+package status
+
+import (
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+)
+
+// +k8s:deepcopy-gen=false
+
+// AtlasIPAccessListStatusOption is the option that is applied to Atlas IP Access List Status
+type AtlasIPAccessListStatusOption func(s *AtlasIPAccessListStatus)
+
+func AtlasIPAccessListExpiredOption(lists []project.IPAccessList) AtlasIPAccessListStatusOption {
+	return func(s *AtlasIPAccessListStatus) {
+		s.ExpiredIPAccessList = lists
+	}
+}
+
+func AtlasIPAccessListPlannedChangesOption(changes []PlannedChange) AtlasIPAccessListStatusOption {
+	return func(s *AtlasIPAccessListStatus) {
+		s.PlannedChanges = changes
+	}
+}
+
+// AtlasIPAccessListStatus defines the observed state of AtlasIPAccessList
+type AtlasIPAccessListStatus struct {
+	Common `json:",inline"`
+
+	// The list of IP Access List entries that are expired due to 'deleteAfterDate' being less than the current date.
+	// Note, that this field is updated by the Atlas Operator only after specification changes
+	ExpiredIPAccessList []project.IPAccessList `json:"expiredIpAccessList,omitempty"`
+
+	// PlannedChanges is the list of changes to Atlas found by the last reconciliation in dry-run mode.
+	// Nothing is applied to Atlas while dry-run is enabled.
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
+}
@@ -0,0 +1,30 @@
+package status
+
+// +k8s:deepcopy-gen=false
+
+// AtlasNetworkPeeringStatusOption is the option that is applied to Atlas Network Peering Status
+type AtlasNetworkPeeringStatusOption func(s *AtlasNetworkPeeringStatus)
+
+func AtlasNetworkPeeringSetNetworkPeersOption(networkPeers *[]AtlasNetworkPeer) AtlasNetworkPeeringStatusOption {
+	return func(s *AtlasNetworkPeeringStatus) {
+		s.NetworkPeers = *networkPeers
+	}
+}
+
+func AtlasNetworkPeeringPlannedChangesOption(changes []PlannedChange) AtlasNetworkPeeringStatusOption {
+	return func(s *AtlasNetworkPeeringStatus) {
+		s.PlannedChanges = changes
+	}
+}
+
+// AtlasNetworkPeeringStatus defines the observed state of AtlasNetworkPeering
+type AtlasNetworkPeeringStatus struct {
+	Common `json:",inline"`
+
+	// The list of network peers managed by the resource
+	NetworkPeers []AtlasNetworkPeer `json:"networkPeers,omitempty"`
+
+	// PlannedChanges is the list of changes to Atlas found by the last reconciliation in dry-run mode.
+	// Nothing is applied to Atlas while dry-run is enabled.
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
+}
@@ -0,0 +1,30 @@
+package status
+
+// +k8s:deepcopy-gen=false
+
+// AtlasPrivateEndpointStatusOption is the option that is applied to Atlas Private Endpoint Status
+type AtlasPrivateEndpointStatusOption func(s *AtlasPrivateEndpointStatus)
+
+func AtlasPrivateEndpointSetPrivateEndpointsOption(privateEndpoints []ProjectPrivateEndpoint) AtlasPrivateEndpointStatusOption {
+	return func(s *AtlasPrivateEndpointStatus) {
+		s.PrivateEndpoints = privateEndpoints
+	}
+}
+
+func AtlasPrivateEndpointPlannedChangesOption(changes []PlannedChange) AtlasPrivateEndpointStatusOption {
+	return func(s *AtlasPrivateEndpointStatus) {
+		s.PlannedChanges = changes
+	}
+}
+
+// AtlasPrivateEndpointStatus defines the observed state of AtlasPrivateEndpoint
+type AtlasPrivateEndpointStatus struct {
+	Common `json:",inline"`
+
+	// The list of private endpoints managed by the resource
+	PrivateEndpoints []ProjectPrivateEndpoint `json:"privateEndpoints,omitempty"`
+
+	// PlannedChanges is the list of changes to Atlas found by the last reconciliation in dry-run mode.
+	// Nothing is applied to Atlas while dry-run is enabled.
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
+}
@@ -52,6 +52,12 @@ func AtlasProjectSetAlertConfigOption(alertConfigs *[]AlertConfiguration) AtlasP
 	}
 }
 
+func AtlasProjectPlannedChangesOption(changes []PlannedChange) AtlasProjectStatusOption {
+	return func(s *AtlasProjectStatus) {
+		s.PlannedChanges = changes
+	}
+}
+
 func AtlasProjectCloudIntegrationsOption(cloudIntegrations []CloudProviderIntegration) AtlasProjectStatusOption {
 	return func(s *AtlasProjectStatus) {
 		s.CloudProviderIntegrations = cloudIntegrations
@@ -121,4 +127,8 @@ type AtlasProjectStatus struct {
 	// including the prometheusDiscoveryURL
 	// +optional
 	Prometheus *Prometheus `json:"prometheus,omitempty"`
+
+	// PlannedChanges is the list of changes to Atlas found by the last reconciliation in dry-run mode.
+	// Nothing is applied to Atlas while dry-run is enabled.
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
 }
@@ -0,0 +1,40 @@
+package status
+
+// +k8s:deepcopy-gen=false
+
+// AtlasSearchIndexStatusOption is the option that is applied to Atlas Search Index Status
+type AtlasSearchIndexStatusOption func(s *AtlasSearchIndexStatus)
+
+func AtlasSearchIndexIDOption(indexID string) AtlasSearchIndexStatusOption {
+	return func(s *AtlasSearchIndexStatus) {
+		s.IndexID = indexID
+	}
+}
+
+func AtlasSearchIndexBuildStatusOption(indexStatus string) AtlasSearchIndexStatusOption {
+	return func(s *AtlasSearchIndexStatus) {
+		s.IndexStatus = indexStatus
+	}
+}
+
+func AtlasSearchIndexPlannedChangesOption(changes []PlannedChange) AtlasSearchIndexStatusOption {
+	return func(s *AtlasSearchIndexStatus) {
+		s.PlannedChanges = changes
+	}
+}
+
+// AtlasSearchIndexStatus defines the observed state of AtlasSearchIndex
+type AtlasSearchIndexStatus struct {
+	Common `json:",inline"`
+
+	// IndexID is the ID of the index in Atlas
+	IndexID string `json:"indexID,omitempty"`
+
+	// IndexStatus is the build status of the index reported by Atlas: IN_PROGRESS, STEADY, FAILED, MIGRATING,
+	// STALE or PAUSED
+	IndexStatus string `json:"indexStatus,omitempty"`
+
+	// PlannedChanges is the list of changes to Atlas found by the last reconciliation in dry-run mode.
+	// Nothing is applied to Atlas while dry-run is enabled.
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
+}
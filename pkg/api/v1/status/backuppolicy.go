@@ -22,6 +22,13 @@ func AtlasBackupPolicyUnsetScheduleID(ID string) AtlasBackupPolicyStatusOption {
 	}
 }
 
+// AtlasBackupPolicyScheduleIDsOption sets the backup schedules referencing the backup policy
+func AtlasBackupPolicyScheduleIDsOption(IDs []string) AtlasBackupPolicyStatusOption {
+	return func(s *BackupPolicyStatus) {
+		s.BackupScheduleIDs = IDs
+	}
+}
+
 type BackupPolicyStatus struct {
 	Common `json:",inline"`
 
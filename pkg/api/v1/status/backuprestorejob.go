@@ -0,0 +1,90 @@
+package status
+
+import (
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	RestoreJobStateInProgress = "inProgress"
+	RestoreJobStateCompleted  = "completed"
+	RestoreJobStateFailed     = "failed"
+	RestoreJobStateCancelled  = "cancelled"
+	RestoreJobStateExpired    = "expired"
+)
+
+// +k8s:deepcopy-gen=false
+
+// AtlasBackupRestoreJobStatusOption is the option that is applied to Atlas Backup Restore Job Status
+type AtlasBackupRestoreJobStatusOption func(s *AtlasBackupRestoreJobStatus)
+
+func AtlasBackupRestoreJobOption(job *mongodbatlas.CloudProviderSnapshotRestoreJob) AtlasBackupRestoreJobStatusOption {
+	return func(s *AtlasBackupRestoreJobStatus) {
+		s.JobID = job.ID
+		s.SnapshotID = job.SnapshotID
+		s.State = RestoreJobState(job)
+		s.FinishedAt = job.FinishedAt
+	}
+}
+
+func AtlasBackupRestoreJobDownloadSecretOption(secretName string) AtlasBackupRestoreJobStatusOption {
+	return func(s *AtlasBackupRestoreJobStatus) {
+		s.DownloadSecret = secretName
+	}
+}
+
+func AtlasBackupRestoreJobRequestedAtOption(requestedAt string) AtlasBackupRestoreJobStatusOption {
+	return func(s *AtlasBackupRestoreJobStatus) {
+		s.RequestedAt = requestedAt
+	}
+}
+
+func AtlasBackupRestoreJobPlannedChangesOption(changes []PlannedChange) AtlasBackupRestoreJobStatusOption {
+	return func(s *AtlasBackupRestoreJobStatus) {
+		s.PlannedChanges = changes
+	}
+}
+
+// RestoreJobState returns the state of the restore job from the flags reported by Atlas. A download job is completed
+// once its download links are available.
+func RestoreJobState(job *mongodbatlas.CloudProviderSnapshotRestoreJob) string {
+	switch {
+	case job.Cancelled:
+		return RestoreJobStateCancelled
+	case job.Failed != nil && *job.Failed:
+		return RestoreJobStateFailed
+	case job.Expired:
+		return RestoreJobStateExpired
+	case job.FinishedAt != "" || len(job.DeliveryURL) > 0:
+		return RestoreJobStateCompleted
+	}
+
+	return RestoreJobStateInProgress
+}
+
+// AtlasBackupRestoreJobStatus defines the observed state of AtlasBackupRestoreJob
+type AtlasBackupRestoreJobStatus struct {
+	Common `json:",inline"`
+
+	// JobID is the ID of the restore job in Atlas
+	JobID string `json:"jobID,omitempty"`
+
+	// SnapshotID is the ID of the restored snapshot
+	SnapshotID string `json:"snapshotID,omitempty"`
+
+	// State is the state of the restore job: inProgress, completed, failed, cancelled or expired
+	State string `json:"state,omitempty"`
+
+	// FinishedAt is the time the restore job completed at, in ISO 8601 format
+	FinishedAt string `json:"finishedAt,omitempty"`
+
+	// DownloadSecret is the name of the Secret keeping the download links of a "download" restore
+	DownloadSecret string `json:"downloadSecret,omitempty"`
+
+	// RequestedAt is the time the restore job was requested in Atlas at, in ISO 8601 format. The job is looked up in
+	// Atlas rather than started again if its ID wasn't saved.
+	RequestedAt string `json:"requestedAt,omitempty"`
+
+	// PlannedChanges is the list of changes to Atlas found by the last reconciliation in dry-run mode.
+	// Nothing is applied to Atlas while dry-run is enabled.
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
+}
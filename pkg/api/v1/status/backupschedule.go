@@ -22,8 +22,49 @@ func AtlasBackupScheduleUnsetDeploymentID(ID string) AtlasBackupScheduleStatusOp
 	}
 }
 
+// AtlasBackupScheduleDeploymentsOption sets the deployments referencing the backup schedule. The deployment IDs are
+// kept in sync with them.
+func AtlasBackupScheduleDeploymentsOption(deployments []BackupScheduleDeployment) AtlasBackupScheduleStatusOption {
+	return func(s *BackupScheduleStatus) {
+		s.Deployments = deployments
+		s.DeploymentIDs = nil
+		for _, deployment := range deployments {
+			s.DeploymentIDs = append(s.DeploymentIDs, deployment.DeploymentName)
+		}
+	}
+}
+
+func AtlasBackupSchedulePlannedChangesOption(changes []PlannedChange) AtlasBackupScheduleStatusOption {
+	return func(s *BackupScheduleStatus) {
+		s.PlannedChanges = changes
+	}
+}
+
 type BackupScheduleStatus struct {
 	Common `json:",inline"`
 
 	DeploymentIDs []string `json:"deploymentID,omitempty"`
+
+	// Deployments is the list of the deployments referencing the backup schedule and whether it's applied to them
+	Deployments []BackupScheduleDeployment `json:"deployments,omitempty"`
+
+	// PlannedChanges is the list of changes to Atlas found by the last reconciliation in dry-run mode.
+	// Nothing is applied to Atlas while dry-run is enabled.
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
+}
+
+// BackupScheduleDeployment is the state of the backup schedule in one of the deployments referencing it
+type BackupScheduleDeployment struct {
+	// Resource is the namespaced name of the AtlasDeployment resource
+	Resource string `json:"resource"`
+
+	// DeploymentName is the name of the deployment in Atlas
+	DeploymentName string `json:"deploymentName"`
+
+	// Applied is true if the backup schedule is applied to the deployment in Atlas
+	Applied bool `json:"applied"`
+
+	// Message explains why the backup schedule isn't applied to the deployment
+	// +optional
+	Message string `json:"message,omitempty"`
 }
@@ -0,0 +1,63 @@
+package status
+
+import (
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	BackupSnapshotStatusQueued     = "queued"
+	BackupSnapshotStatusInProgress = "inProgress"
+	BackupSnapshotStatusCompleted  = "completed"
+	BackupSnapshotStatusFailed     = "failed"
+)
+
+// +k8s:deepcopy-gen=false
+
+// AtlasBackupSnapshotStatusOption is the option that is applied to Atlas Backup Snapshot Status
+type AtlasBackupSnapshotStatusOption func(s *AtlasBackupSnapshotStatus)
+
+func AtlasBackupSnapshotOption(snapshot *mongodbatlas.CloudProviderSnapshot) AtlasBackupSnapshotStatusOption {
+	return func(s *AtlasBackupSnapshotStatus) {
+		s.SnapshotID = snapshot.ID
+		s.SnapshotStatus = snapshot.Status
+		s.CreatedAt = snapshot.CreatedAt
+		s.ExpiresAt = snapshot.ExpiresAt
+	}
+}
+
+func AtlasBackupSnapshotRequestedAtOption(requestedAt string) AtlasBackupSnapshotStatusOption {
+	return func(s *AtlasBackupSnapshotStatus) {
+		s.RequestedAt = requestedAt
+	}
+}
+
+func AtlasBackupSnapshotPlannedChangesOption(changes []PlannedChange) AtlasBackupSnapshotStatusOption {
+	return func(s *AtlasBackupSnapshotStatus) {
+		s.PlannedChanges = changes
+	}
+}
+
+// AtlasBackupSnapshotStatus defines the observed state of AtlasBackupSnapshot
+type AtlasBackupSnapshotStatus struct {
+	Common `json:",inline"`
+
+	// SnapshotID is the ID of the snapshot in Atlas
+	SnapshotID string `json:"snapshotID,omitempty"`
+
+	// SnapshotStatus is the status of the snapshot reported by Atlas: queued, inProgress, completed or failed
+	SnapshotStatus string `json:"snapshotStatus,omitempty"`
+
+	// CreatedAt is the time the snapshot was taken at, in ISO 8601 format
+	CreatedAt string `json:"createdAt,omitempty"`
+
+	// ExpiresAt is the time Atlas deletes the snapshot at, in ISO 8601 format
+	ExpiresAt string `json:"expiresAt,omitempty"`
+
+	// RequestedAt is the time the snapshot was requested in Atlas at, in ISO 8601 format. The snapshot is looked up
+	// in Atlas rather than taken again if its ID wasn't saved.
+	RequestedAt string `json:"requestedAt,omitempty"`
+
+	// PlannedChanges is the list of changes to Atlas found by the last reconciliation in dry-run mode.
+	// Nothing is applied to Atlas while dry-run is enabled.
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
+}
@@ -56,6 +56,7 @@ const (
 	ServerlessPrivateEndpointReadyType ConditionType = "ServerlessPrivateEndpointReady"
 	ManagedNamespacesReadyType         ConditionType = "ManagedNamespacesReady"
 	CustomZoneMappingReadyType         ConditionType = "CustomZoneMappingReady"
+	OnlineArchivesReadyType            ConditionType = "OnlineArchivesReady"
 )
 
 // AtlasDatabaseUser condition types
@@ -75,9 +76,24 @@ const (
 	FederatedAuthRolesReadyType ConditionType = "RolesReady"
 )
 
+// Atlas Backup condition types
+const (
+	BackupScheduleReadyType ConditionType = "BackupScheduleReady"
+	BackupPolicyReadyType   ConditionType = "BackupPolicyReady"
+	BackupSnapshotReadyType ConditionType = "BackupSnapshotReady"
+	RestoreJobReadyType     ConditionType = "RestoreJobReady"
+)
+
+// Atlas Search Index condition types
+const (
+	SearchIndexReadyType ConditionType = "SearchIndexReady"
+)
+
 // Generic condition type
 const (
 	ResourceVersionStatus ConditionType = "ResourceVersionIsValid"
+	DriftDetectedType     ConditionType = "DriftDetected"
+	PolicyViolationType   ConditionType = "PolicyViolation"
 )
 
 // Condition describes the state of an Atlas Custom Resource at a certain point.
@@ -5,8 +5,18 @@ type DataFederationStatus struct {
 
 	// MongoDBVersion is the version of MongoDB the cluster runs, in <major version>.<minor version> format.
 	MongoDBVersion string `json:"mongoDBVersion,omitempty"`
+
+	// PlannedChanges is the list of changes to Atlas found by the last reconciliation in dry-run mode.
+	// Nothing is applied to Atlas while dry-run is enabled.
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
 }
 
 // +k8s:deepcopy-gen=false
 
 type DataFederationStatusOption func(s *DataFederationStatus)
+
+func DataFederationPlannedChangesOption(changes []PlannedChange) DataFederationStatusOption {
+	return func(s *DataFederationStatus) {
+		s.PlannedChanges = changes
+	}
+}
@@ -0,0 +1,46 @@
+package status
+
+import (
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+const (
+	OnlineArchiveStatePending  = "PENDING"
+	OnlineArchiveStateActive   = "ACTIVE"
+	OnlineArchiveStatePausing  = "PAUSING"
+	OnlineArchiveStatePaused   = "PAUSED"
+	OnlineArchiveStateOrphaned = "ORPHANED"
+)
+
+// OnlineArchive is the state of an Online Archive of the deployment
+type OnlineArchive struct {
+	// ID is the ID of the Online Archive in Atlas
+	ID string `json:"id,omitempty"`
+	// DBName is the name of the database containing the archived collection
+	DBName string `json:"dbName"`
+	// CollName is the name of the archived collection
+	CollName string `json:"collName"`
+	// State is the state of the Online Archive reported by Atlas: PENDING, ACTIVE, PAUSING, PAUSED or ORPHANED
+	State string `json:"state,omitempty"`
+	// ErrMessage is the error returned by Atlas when the Online Archive failed to be created or updated
+	ErrMessage string `json:"errMessage,omitempty"`
+}
+
+func NewOnlineArchiveStatus(archive *mongodbatlas.OnlineArchive) OnlineArchive {
+	return OnlineArchive{
+		ID:       archive.ID,
+		DBName:   archive.DBName,
+		CollName: archive.CollName,
+		State:    archive.State,
+	}
+}
+
+func NewFailedOnlineArchiveStatus(archive *mongodbatlas.OnlineArchive, err error) OnlineArchive {
+	return OnlineArchive{
+		ID:         archive.ID,
+		DBName:     archive.DBName,
+		CollName:   archive.CollName,
+		State:      StatusFailed,
+		ErrMessage: err.Error(),
+	}
+}
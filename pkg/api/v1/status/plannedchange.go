@@ -0,0 +1,11 @@
+package status
+
+// PlannedChange is a change to Atlas that the Atlas Operator would have made if the resource wasn't in dry-run mode
+type PlannedChange struct {
+	// Action is the kind of the change: Create, Update or Delete
+	Action string `json:"action"`
+
+	// Path is the Atlas Admin API path of the changed Atlas resource, or the namespaced name of the Custom Resource
+	// whose deletion is planned
+	Path string `json:"path"`
+}
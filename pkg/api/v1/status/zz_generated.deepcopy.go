@@ -65,10 +65,62 @@ func (in *AlertConfiguration) DeepCopy() *AlertConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasBackupRestoreJobStatus) DeepCopyInto(out *AtlasBackupRestoreJobStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasBackupRestoreJobStatus.
+func (in *AtlasBackupRestoreJobStatus) DeepCopy() *AtlasBackupRestoreJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasBackupRestoreJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasBackupSnapshotStatus) DeepCopyInto(out *AtlasBackupSnapshotStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasBackupSnapshotStatus.
+func (in *AtlasBackupSnapshotStatus) DeepCopy() *AtlasBackupSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasBackupSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AtlasDatabaseUserStatus) DeepCopyInto(out *AtlasDatabaseUserStatus) {
 	*out = *in
 	in.Common.DeepCopyInto(&out.Common)
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]DatabaseUserProject, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasDatabaseUserStatus.
@@ -112,6 +164,28 @@ func (in *AtlasDeploymentStatus) DeepCopyInto(out *AtlasDeploymentStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OnlineArchives != nil {
+		in, out := &in.OnlineArchives, &out.OnlineArchives
+		*out = make([]OnlineArchive, len(*in))
+		copy(*out, *in)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(DeploymentUpgrade)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]DeploymentOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasDeploymentStatus.
@@ -128,6 +202,11 @@ func (in *AtlasDeploymentStatus) DeepCopy() *AtlasDeploymentStatus {
 func (in *AtlasFederatedAuthStatus) DeepCopyInto(out *AtlasFederatedAuthStatus) {
 	*out = *in
 	in.Common.DeepCopyInto(&out.Common)
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasFederatedAuthStatus.
@@ -140,6 +219,32 @@ func (in *AtlasFederatedAuthStatus) DeepCopy() *AtlasFederatedAuthStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasIPAccessListStatus) DeepCopyInto(out *AtlasIPAccessListStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	if in.ExpiredIPAccessList != nil {
+		in, out := &in.ExpiredIPAccessList, &out.ExpiredIPAccessList
+		*out = make([]project.IPAccessList, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasIPAccessListStatus.
+func (in *AtlasIPAccessListStatus) DeepCopy() *AtlasIPAccessListStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasIPAccessListStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AtlasNetworkPeer) DeepCopyInto(out *AtlasNetworkPeer) {
 	*out = *in
@@ -155,6 +260,60 @@ func (in *AtlasNetworkPeer) DeepCopy() *AtlasNetworkPeer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasNetworkPeeringStatus) DeepCopyInto(out *AtlasNetworkPeeringStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	if in.NetworkPeers != nil {
+		in, out := &in.NetworkPeers, &out.NetworkPeers
+		*out = make([]AtlasNetworkPeer, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasNetworkPeeringStatus.
+func (in *AtlasNetworkPeeringStatus) DeepCopy() *AtlasNetworkPeeringStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasNetworkPeeringStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasPrivateEndpointStatus) DeepCopyInto(out *AtlasPrivateEndpointStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	if in.PrivateEndpoints != nil {
+		in, out := &in.PrivateEndpoints, &out.PrivateEndpoints
+		*out = make([]ProjectPrivateEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasPrivateEndpointStatus.
+func (in *AtlasPrivateEndpointStatus) DeepCopy() *AtlasPrivateEndpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasPrivateEndpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AtlasProjectStatus) DeepCopyInto(out *AtlasProjectStatus) {
 	*out = *in
@@ -210,6 +369,11 @@ func (in *AtlasProjectStatus) DeepCopyInto(out *AtlasProjectStatus) {
 		*out = new(Prometheus)
 		**out = **in
 	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasProjectStatus.
@@ -222,6 +386,27 @@ func (in *AtlasProjectStatus) DeepCopy() *AtlasProjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasSearchIndexStatus) DeepCopyInto(out *AtlasSearchIndexStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasSearchIndexStatus.
+func (in *AtlasSearchIndexStatus) DeepCopy() *AtlasSearchIndexStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasSearchIndexStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupPolicyStatus) DeepCopyInto(out *BackupPolicyStatus) {
 	*out = *in
@@ -243,6 +428,21 @@ func (in *BackupPolicyStatus) DeepCopy() *BackupPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupScheduleDeployment) DeepCopyInto(out *BackupScheduleDeployment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupScheduleDeployment.
+func (in *BackupScheduleDeployment) DeepCopy() *BackupScheduleDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupScheduleDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupScheduleStatus) DeepCopyInto(out *BackupScheduleStatus) {
 	*out = *in
@@ -252,6 +452,16 @@ func (in *BackupScheduleStatus) DeepCopyInto(out *BackupScheduleStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Deployments != nil {
+		in, out := &in.Deployments, &out.Deployments
+		*out = make([]BackupScheduleDeployment, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupScheduleStatus.
@@ -400,6 +610,11 @@ func (in *CustomZoneMapping) DeepCopy() *CustomZoneMapping {
 func (in *DataFederationStatus) DeepCopyInto(out *DataFederationStatus) {
 	*out = *in
 	in.Common.DeepCopyInto(&out.Common)
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFederationStatus.
@@ -412,6 +627,53 @@ func (in *DataFederationStatus) DeepCopy() *DataFederationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseUserProject) DeepCopyInto(out *DatabaseUserProject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseUserProject.
+func (in *DatabaseUserProject) DeepCopy() *DatabaseUserProject {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseUserProject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentOperation) DeepCopyInto(out *DeploymentOperation) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentOperation.
+func (in *DeploymentOperation) DeepCopy() *DeploymentOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentUpgrade) DeepCopyInto(out *DeploymentUpgrade) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentUpgrade.
+func (in *DeploymentUpgrade) DeepCopy() *DeploymentUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 	*out = *in
@@ -552,6 +814,36 @@ func (in *Notification) DeepCopy() *Notification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlineArchive) DeepCopyInto(out *OnlineArchive) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineArchive.
+func (in *OnlineArchive) DeepCopy() *OnlineArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlineArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedChange) DeepCopyInto(out *PlannedChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedChange.
+func (in *PlannedChange) DeepCopy() *PlannedChange {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateEndpoint) DeepCopyInto(out *PrivateEndpoint) {
 	*out = *in
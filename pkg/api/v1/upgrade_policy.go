@@ -0,0 +1,18 @@
+package v1
+
+// UpgradePolicy controls when the major version of the deployment is upgraded
+type UpgradePolicy struct {
+	// MaintenanceWindowOnly only starts the upgrades during the maintenance window of the project
+	// +optional
+	MaintenanceWindowOnly bool `json:"maintenanceWindowOnly,omitempty"`
+	// MaintenanceWindowHours is for how many hours after the start of the maintenance window the upgrades can start
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=24
+	// +kubebuilder:default:=4
+	// +optional
+	MaintenanceWindowHours int `json:"maintenanceWindowHours,omitempty"`
+	// RequireApproval only starts the upgrades approved by setting the mongodb.com/atlas-approve-upgrade annotation
+	// to the target major version
+	// +optional
+	RequireApproval bool `json:"requireApproval,omitempty"`
+}
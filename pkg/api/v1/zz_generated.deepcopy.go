@@ -14,10 +14,13 @@ a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
 package v1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/provider"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -105,11 +108,21 @@ func (in *AdvancedDeploymentSpec) DeepCopyInto(out *AdvancedDeploymentSpec) {
 		*out = make([]common.LabelSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.UpgradePolicy != nil {
+		in, out := &in.UpgradePolicy, &out.UpgradePolicy
+		*out = new(UpgradePolicy)
+		**out = **in
+	}
 	if in.Paused != nil {
 		in, out := &in.Paused, &out.Paused
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PauseSchedule != nil {
+		in, out := &in.PauseSchedule, &out.PauseSchedule
+		*out = new(PauseSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PitEnabled != nil {
 		in, out := &in.PitEnabled, &out.PitEnabled
 		*out = new(bool)
@@ -149,6 +162,13 @@ func (in *AdvancedDeploymentSpec) DeepCopyInto(out *AdvancedDeploymentSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OnlineArchives != nil {
+		in, out := &in.OnlineArchives, &out.OnlineArchives
+		*out = make([]OnlineArchive, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedDeploymentSpec.
@@ -373,6 +393,91 @@ func (in *AtlasBackupPolicySpec) DeepCopy() *AtlasBackupPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasBackupRestoreJob) DeepCopyInto(out *AtlasBackupRestoreJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasBackupRestoreJob.
+func (in *AtlasBackupRestoreJob) DeepCopy() *AtlasBackupRestoreJob {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasBackupRestoreJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasBackupRestoreJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasBackupRestoreJobList) DeepCopyInto(out *AtlasBackupRestoreJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasBackupRestoreJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasBackupRestoreJobList.
+func (in *AtlasBackupRestoreJobList) DeepCopy() *AtlasBackupRestoreJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasBackupRestoreJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasBackupRestoreJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasBackupRestoreJobSpec) DeepCopyInto(out *AtlasBackupRestoreJobSpec) {
+	*out = *in
+	out.DeploymentRef = in.DeploymentRef
+	if in.SnapshotRef != nil {
+		in, out := &in.SnapshotRef, &out.SnapshotRef
+		*out = new(common.ResourceRefNamespaced)
+		**out = **in
+	}
+	if in.TargetDeploymentRef != nil {
+		in, out := &in.TargetDeploymentRef, &out.TargetDeploymentRef
+		*out = new(common.ResourceRefNamespaced)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasBackupRestoreJobSpec.
+func (in *AtlasBackupRestoreJobSpec) DeepCopy() *AtlasBackupRestoreJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasBackupRestoreJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AtlasBackupSchedule) DeepCopyInto(out *AtlasBackupSchedule) {
 	*out = *in
@@ -460,6 +565,160 @@ func (in *AtlasBackupScheduleSpec) DeepCopy() *AtlasBackupScheduleSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasBackupSnapshot) DeepCopyInto(out *AtlasBackupSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasBackupSnapshot.
+func (in *AtlasBackupSnapshot) DeepCopy() *AtlasBackupSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasBackupSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasBackupSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasBackupSnapshotList) DeepCopyInto(out *AtlasBackupSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasBackupSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasBackupSnapshotList.
+func (in *AtlasBackupSnapshotList) DeepCopy() *AtlasBackupSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasBackupSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasBackupSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasBackupSnapshotSpec) DeepCopyInto(out *AtlasBackupSnapshotSpec) {
+	*out = *in
+	out.DeploymentRef = in.DeploymentRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasBackupSnapshotSpec.
+func (in *AtlasBackupSnapshotSpec) DeepCopy() *AtlasBackupSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasBackupSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasConnection) DeepCopyInto(out *AtlasConnection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasConnection.
+func (in *AtlasConnection) DeepCopy() *AtlasConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasConnection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasConnectionList) DeepCopyInto(out *AtlasConnectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasConnection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasConnectionList.
+func (in *AtlasConnectionList) DeepCopy() *AtlasConnectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasConnectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasConnectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasConnectionSpec) DeepCopyInto(out *AtlasConnectionSpec) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasConnectionSpec.
+func (in *AtlasConnectionSpec) DeepCopy() *AtlasConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasConnectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AtlasDataFederation) DeepCopyInto(out *AtlasDataFederation) {
 	*out = *in
@@ -582,6 +841,11 @@ func (in *AtlasDatabaseUserList) DeepCopyObject() runtime.Object {
 func (in *AtlasDatabaseUserSpec) DeepCopyInto(out *AtlasDatabaseUserSpec) {
 	*out = *in
 	out.Project = in.Project
+	if in.ProjectSelector != nil {
+		in, out := &in.ProjectSelector, &out.ProjectSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make([]common.LabelSpec, len(*in))
@@ -602,6 +866,16 @@ func (in *AtlasDatabaseUserSpec) DeepCopyInto(out *AtlasDatabaseUserSpec) {
 		*out = new(common.ResourceRef)
 		**out = **in
 	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(PasswordRotationSpec)
+		**out = **in
+	}
+	if in.ConnectionSecretTemplate != nil {
+		in, out := &in.ConnectionSecretTemplate, &out.ConnectionSecretTemplate
+		*out = new(ConnectionSecretTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasDatabaseUserSpec.
@@ -768,6 +1042,11 @@ func (in *AtlasFederatedAuthList) DeepCopyObject() runtime.Object {
 func (in *AtlasFederatedAuthSpec) DeepCopyInto(out *AtlasFederatedAuthSpec) {
 	*out = *in
 	out.ConnectionSecretRef = in.ConnectionSecretRef
+	if in.AtlasConnectionRef != nil {
+		in, out := &in.AtlasConnectionRef, &out.AtlasConnectionRef
+		*out = new(common.ResourceRef)
+		**out = **in
+	}
 	if in.DomainAllowList != nil {
 		in, out := &in.DomainAllowList, &out.DomainAllowList
 		*out = make([]string, len(*in))
@@ -807,6 +1086,327 @@ func (in *AtlasFederatedAuthSpec) DeepCopy() *AtlasFederatedAuthSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasIPAccessList) DeepCopyInto(out *AtlasIPAccessList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasIPAccessList.
+func (in *AtlasIPAccessList) DeepCopy() *AtlasIPAccessList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasIPAccessList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasIPAccessList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasIPAccessListList) DeepCopyInto(out *AtlasIPAccessListList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasIPAccessList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasIPAccessListList.
+func (in *AtlasIPAccessListList) DeepCopy() *AtlasIPAccessListList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasIPAccessListList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasIPAccessListList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasIPAccessListSpec) DeepCopyInto(out *AtlasIPAccessListSpec) {
+	*out = *in
+	out.Project = in.Project
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]project.IPAccessList, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasIPAccessListSpec.
+func (in *AtlasIPAccessListSpec) DeepCopy() *AtlasIPAccessListSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasIPAccessListSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasNetworkPeering) DeepCopyInto(out *AtlasNetworkPeering) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasNetworkPeering.
+func (in *AtlasNetworkPeering) DeepCopy() *AtlasNetworkPeering {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasNetworkPeering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasNetworkPeering) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasNetworkPeeringList) DeepCopyInto(out *AtlasNetworkPeeringList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasNetworkPeering, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasNetworkPeeringList.
+func (in *AtlasNetworkPeeringList) DeepCopy() *AtlasNetworkPeeringList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasNetworkPeeringList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasNetworkPeeringList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasNetworkPeeringSpec) DeepCopyInto(out *AtlasNetworkPeeringSpec) {
+	*out = *in
+	out.Project = in.Project
+	if in.NetworkPeers != nil {
+		in, out := &in.NetworkPeers, &out.NetworkPeers
+		*out = make([]NetworkPeer, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasNetworkPeeringSpec.
+func (in *AtlasNetworkPeeringSpec) DeepCopy() *AtlasNetworkPeeringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasNetworkPeeringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasPolicy) DeepCopyInto(out *AtlasPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasPolicy.
+func (in *AtlasPolicy) DeepCopy() *AtlasPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasPolicyList) DeepCopyInto(out *AtlasPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasPolicyList.
+func (in *AtlasPolicyList) DeepCopy() *AtlasPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasPolicySpec) DeepCopyInto(out *AtlasPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Deployments.DeepCopyInto(&out.Deployments)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasPolicySpec.
+func (in *AtlasPolicySpec) DeepCopy() *AtlasPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasPrivateEndpoint) DeepCopyInto(out *AtlasPrivateEndpoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasPrivateEndpoint.
+func (in *AtlasPrivateEndpoint) DeepCopy() *AtlasPrivateEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasPrivateEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasPrivateEndpoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasPrivateEndpointList) DeepCopyInto(out *AtlasPrivateEndpointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasPrivateEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasPrivateEndpointList.
+func (in *AtlasPrivateEndpointList) DeepCopy() *AtlasPrivateEndpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasPrivateEndpointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasPrivateEndpointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasPrivateEndpointSpec) DeepCopyInto(out *AtlasPrivateEndpointSpec) {
+	*out = *in
+	out.Project = in.Project
+	if in.PrivateEndpoints != nil {
+		in, out := &in.PrivateEndpoints, &out.PrivateEndpoints
+		*out = make([]PrivateEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasPrivateEndpointSpec.
+func (in *AtlasPrivateEndpointSpec) DeepCopy() *AtlasPrivateEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasPrivateEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AtlasProject) DeepCopyInto(out *AtlasProject) {
 	*out = *in
@@ -874,6 +1474,11 @@ func (in *AtlasProjectSpec) DeepCopyInto(out *AtlasProjectSpec) {
 		*out = new(common.ResourceRefNamespaced)
 		**out = **in
 	}
+	if in.AtlasConnectionRef != nil {
+		in, out := &in.AtlasConnectionRef, &out.AtlasConnectionRef
+		*out = new(common.ResourceRef)
+		**out = **in
+	}
 	if in.ProjectIPAccessList != nil {
 		in, out := &in.ProjectIPAccessList, &out.ProjectIPAccessList
 		*out = make([]project.IPAccessList, len(*in))
@@ -934,28 +1539,109 @@ func (in *AtlasProjectSpec) DeepCopyInto(out *AtlasProjectSpec) {
 		*out = new(ProjectSettings)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.CustomRoles != nil {
-		in, out := &in.CustomRoles, &out.CustomRoles
-		*out = make([]CustomRole, len(*in))
+	if in.CustomRoles != nil {
+		in, out := &in.CustomRoles, &out.CustomRoles
+		*out = make([]CustomRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]Team, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasProjectSpec.
+func (in *AtlasProjectSpec) DeepCopy() *AtlasProjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasProjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasSearchIndex) DeepCopyInto(out *AtlasSearchIndex) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasSearchIndex.
+func (in *AtlasSearchIndex) DeepCopy() *AtlasSearchIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasSearchIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasSearchIndex) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasSearchIndexList) DeepCopyInto(out *AtlasSearchIndexList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasSearchIndex, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Teams != nil {
-		in, out := &in.Teams, &out.Teams
-		*out = make([]Team, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasSearchIndexList.
+func (in *AtlasSearchIndexList) DeepCopy() *AtlasSearchIndexList {
+	if in == nil {
+		return nil
 	}
+	out := new(AtlasSearchIndexList)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasProjectSpec.
-func (in *AtlasProjectSpec) DeepCopy() *AtlasProjectSpec {
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasSearchIndexList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasSearchIndexSpec) DeepCopyInto(out *AtlasSearchIndexSpec) {
+	*out = *in
+	out.DeploymentRef = in.DeploymentRef
+	in.Mappings.DeepCopyInto(&out.Mappings)
+	if in.Synonyms != nil {
+		in, out := &in.Synonyms, &out.Synonyms
+		*out = make([]SearchIndexSynonym, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasSearchIndexSpec.
+func (in *AtlasSearchIndexSpec) DeepCopy() *AtlasSearchIndexSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AtlasProjectSpec)
+	out := new(AtlasSearchIndexSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1034,6 +1720,21 @@ func (in *Auditing) DeepCopy() *Auditing {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoScalingPolicy) DeepCopyInto(out *AutoScalingPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoScalingPolicy.
+func (in *AutoScalingPolicy) DeepCopy() *AutoScalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoScalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoScalingSpec) DeepCopyInto(out *AutoScalingSpec) {
 	*out = *in
@@ -1248,6 +1949,42 @@ func (in *ComputeSpec) DeepCopy() *ComputeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionSecretTemplate) DeepCopyInto(out *ConnectionSecretTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionSecretTemplate.
+func (in *ConnectionSecretTemplate) DeepCopy() *ConnectionSecretTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionSecretTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionStrings) DeepCopyInto(out *ConnectionStrings) {
 	*out = *in
@@ -1474,6 +2211,51 @@ func (in *Database) DeepCopy() *Database {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentPolicy) DeepCopyInto(out *DeploymentPolicy) {
+	*out = *in
+	if in.AllowedInstanceSizes != nil {
+		in, out := &in.AllowedInstanceSizes, &out.AllowedInstanceSizes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxNodeCount != nil {
+		in, out := &in.MaxNodeCount, &out.MaxNodeCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxRegions != nil {
+		in, out := &in.MaxRegions, &out.MaxRegions
+		*out = new(int)
+		**out = **in
+	}
+	if in.AllowedProviders != nil {
+		in, out := &in.AllowedProviders, &out.AllowedProviders
+		*out = make([]provider.ProviderName, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedRegions != nil {
+		in, out := &in.AllowedRegions, &out.AllowedRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoScaling != nil {
+		in, out := &in.AutoScaling, &out.AutoScaling
+		*out = new(AutoScalingPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentPolicy.
+func (in *DeploymentPolicy) DeepCopy() *DeploymentPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskGB) DeepCopyInto(out *DiskGB) {
 	*out = *in
@@ -1698,6 +2480,155 @@ func (in *Notification) DeepCopy() *Notification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlineArchive) DeepCopyInto(out *OnlineArchive) {
+	*out = *in
+	out.Criteria = in.Criteria
+	if in.PartitionFields != nil {
+		in, out := &in.PartitionFields, &out.PartitionFields
+		*out = make([]OnlineArchivePartitionField, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(OnlineArchiveSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineArchive.
+func (in *OnlineArchive) DeepCopy() *OnlineArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlineArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlineArchiveCriteria) DeepCopyInto(out *OnlineArchiveCriteria) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineArchiveCriteria.
+func (in *OnlineArchiveCriteria) DeepCopy() *OnlineArchiveCriteria {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlineArchiveCriteria)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlineArchivePartitionField) DeepCopyInto(out *OnlineArchivePartitionField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineArchivePartitionField.
+func (in *OnlineArchivePartitionField) DeepCopy() *OnlineArchivePartitionField {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlineArchivePartitionField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnlineArchiveSchedule) DeepCopyInto(out *OnlineArchiveSchedule) {
+	*out = *in
+	if in.StartHour != nil {
+		in, out := &in.StartHour, &out.StartHour
+		*out = new(int)
+		**out = **in
+	}
+	if in.StartMinute != nil {
+		in, out := &in.StartMinute, &out.StartMinute
+		*out = new(int)
+		**out = **in
+	}
+	if in.EndHour != nil {
+		in, out := &in.EndHour, &out.EndHour
+		*out = new(int)
+		**out = **in
+	}
+	if in.EndMinute != nil {
+		in, out := &in.EndMinute, &out.EndMinute
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineArchiveSchedule.
+func (in *OnlineArchiveSchedule) DeepCopy() *OnlineArchiveSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(OnlineArchiveSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationSpec) DeepCopyInto(out *PasswordRotationSpec) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationSpec.
+func (in *PasswordRotationSpec) DeepCopy() *PasswordRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseSchedule) DeepCopyInto(out *PauseSchedule) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]PauseWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseSchedule.
+func (in *PauseSchedule) DeepCopy() *PauseSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseWindow) DeepCopyInto(out *PauseWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseWindow.
+func (in *PauseWindow) DeepCopy() *PauseWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateEndpoint) DeepCopyInto(out *PrivateEndpoint) {
 	*out = *in
@@ -1968,6 +2899,57 @@ func (in *ScopeSpec) DeepCopy() *ScopeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchIndexMappings) DeepCopyInto(out *SearchIndexMappings) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchIndexMappings.
+func (in *SearchIndexMappings) DeepCopy() *SearchIndexMappings {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchIndexMappings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchIndexSynonym) DeepCopyInto(out *SearchIndexSynonym) {
+	*out = *in
+	out.Source = in.Source
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchIndexSynonym.
+func (in *SearchIndexSynonym) DeepCopy() *SearchIndexSynonym {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchIndexSynonym)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchIndexSynonymSource) DeepCopyInto(out *SearchIndexSynonymSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchIndexSynonymSource.
+func (in *SearchIndexSynonymSource) DeepCopy() *SearchIndexSynonymSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchIndexSynonymSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerlessBackupOptions) DeepCopyInto(out *ServerlessBackupOptions) {
 	*out = *in
@@ -2180,6 +3162,21 @@ func (in *Threshold) DeepCopy() *Threshold {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePolicy) DeepCopyInto(out *UpgradePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePolicy.
+func (in *UpgradePolicy) DeepCopy() *UpgradePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *View) DeepCopyInto(out *View) {
 	*out = *in
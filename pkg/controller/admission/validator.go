@@ -0,0 +1,237 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/policy"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
+)
+
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasproject,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasprojects,verbs=create;update,versions=v1,name=vatlasproject.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasdeployment,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasdeployments,verbs=create;update,versions=v1,name=vatlasdeployment.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasdatabaseuser,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasdatabaseusers,verbs=create;update,versions=v1,name=vatlasdatabaseuser.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasdatafederation,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasdatafederations,verbs=create;update,versions=v1,name=vatlasdatafederation.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasteam,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasteams,verbs=create;update,versions=v1,name=vatlasteam.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasbackupschedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasbackupschedules,verbs=create;update,versions=v1,name=vatlasbackupschedule.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasbackuppolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasbackuppolicies,verbs=create;update,versions=v1,name=vatlasbackuppolicy.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasfederatedauth,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasfederatedauths,verbs=create;update,versions=v1,name=vatlasfederatedauth.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasconnection,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasconnections,verbs=create;update,versions=v1,name=vatlasconnection.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlaspolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlaspolicies,verbs=create;update,versions=v1,name=vatlaspolicy.atlas.mongodb.com,admissionReviewVersions=v1
+
+// Validator rejects Atlas Custom Resources at admission time using the same validation the controllers perform
+// during reconciliation
+type Validator struct {
+	Client         client.Client
+	AtlasDomain    string
+	AtlasProvider  atlas.Provider
+	EnablePolicies bool
+}
+
+var _ admission.CustomValidator = &Validator{}
+
+// SetupWebhooksWithManager registers the validating webhooks for all Atlas Custom Resources in the manager webhook server
+func SetupWebhooksWithManager(mgr ctrl.Manager, atlasDomain string, atlasProvider atlas.Provider, enablePolicies bool) error {
+	validator := &Validator{Client: mgr.GetClient(), AtlasDomain: atlasDomain, AtlasProvider: atlasProvider, EnablePolicies: enablePolicies}
+
+	resources := []runtime.Object{
+		&mdbv1.AtlasProject{},
+		&mdbv1.AtlasDeployment{},
+		&mdbv1.AtlasDatabaseUser{},
+		&mdbv1.AtlasDataFederation{},
+		&mdbv1.AtlasTeam{},
+		&mdbv1.AtlasBackupSchedule{},
+		&mdbv1.AtlasBackupPolicy{},
+		&mdbv1.AtlasFederatedAuth{},
+		&mdbv1.AtlasPolicy{},
+		&mdbv1.AtlasConnection{},
+	}
+	for _, resource := range resources {
+		if err := ctrl.NewWebhookManagedBy(mgr).For(resource).WithValidator(validator).Complete(); err != nil {
+			return fmt.Errorf("unable to create the validating webhook for %T: %w", resource, err)
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return v.validate(ctx, obj)
+}
+
+func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	if isDeleting(newObj) || !specChanged(oldObj, newObj) {
+		return nil
+	}
+	return v.validate(ctx, newObj)
+}
+
+func (v *Validator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func (v *Validator) validate(ctx context.Context, obj runtime.Object) error {
+	switch resource := obj.(type) {
+	case *mdbv1.AtlasProject:
+		return v.validateProject(ctx, resource)
+	case *mdbv1.AtlasDeployment:
+		return v.validateDeployment(ctx, resource)
+	case *mdbv1.AtlasDatabaseUser:
+		return validate.DatabaseUser(resource)
+	case *mdbv1.AtlasDataFederation:
+		return validate.DataFederation(resource)
+	case *mdbv1.AtlasTeam:
+		return validate.Team(resource)
+	case *mdbv1.AtlasBackupSchedule:
+		// the checks depending on the deployments referencing the schedule are done during reconciliation, so that
+		// the admission doesn't depend on the order the resources are applied in
+		return validate.BackupSchedule(resource, nil)
+	case *mdbv1.AtlasBackupPolicy:
+		return validate.BackupPolicy(resource)
+	case *mdbv1.AtlasFederatedAuth:
+		return v.validateFederatedAuth(ctx, resource)
+	case *mdbv1.AtlasPolicy:
+		return validate.AtlasPolicy(resource)
+	case *mdbv1.AtlasConnection:
+		return validate.AtlasConnection(resource)
+	}
+
+	return fmt.Errorf("unexpected resource type %T", obj)
+}
+
+// isDeleting tells if the resource is being deleted. Its finalizers are removed with updates which must not be
+// rejected, even if the resource became invalid under a newer validation.
+func isDeleting(obj runtime.Object) bool {
+	resource, ok := obj.(client.Object)
+	return ok && !resource.GetDeletionTimestamp().IsZero()
+}
+
+// specChanged tells if the update changes the spec of the resource. The updates of the metadata only, like the
+// annotations or the finalizers, aren't validated again.
+func specChanged(oldObj, newObj runtime.Object) bool {
+	oldResource, oldOk := oldObj.(client.Object)
+	newResource, newOk := newObj.(client.Object)
+	if !oldOk || !newOk || oldResource.GetGeneration() != newResource.GetGeneration() {
+		return true
+	}
+
+	oldSpec, oldErr := specOf(oldObj)
+	newSpec, newErr := specOf(newObj)
+	return oldErr != nil || newErr != nil || !equality.Semantic.DeepEqual(oldSpec, newSpec)
+}
+
+func specOf(obj runtime.Object) (interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	return content["spec"], nil
+}
+
+func (v *Validator) validateProject(ctx context.Context, project *mdbv1.AtlasProject) error {
+	domain, err := v.domainOf(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	return validate.Project(project, customresource.IsGov(domain))
+}
+
+// domainOf returns the Atlas domain of the project, the one of its AtlasConnection if it sets one
+func (v *Validator) domainOf(ctx context.Context, project *mdbv1.AtlasProject) (string, error) {
+	atlasConnection, err := v.atlasConnectionOf(ctx, project)
+	if err != nil {
+		return "", err
+	}
+
+	if atlasConnection == nil || atlasConnection.Spec.AtlasDomain == "" {
+		return v.AtlasDomain, nil
+	}
+	return atlasConnection.Spec.AtlasDomain, nil
+}
+
+func (v *Validator) validateFederatedAuth(ctx context.Context, fedAuth *mdbv1.AtlasFederatedAuth) error {
+	if _, err := v.atlasConnectionOf(ctx, fedAuth); err != nil {
+		return err
+	}
+
+	return validate.FederatedAuth(fedAuth)
+}
+
+// atlasConnectionOf returns the AtlasConnection the resource references, and rejects the resource if the connection
+// doesn't allow its namespace. The AtlasConnection may not exist yet, in this case nil is returned and the resource is
+// checked again during reconciliation. The AtlasConnections aren't read unless they are enabled, as it requires the
+// cluster wide permissions.
+func (v *Validator) atlasConnectionOf(ctx context.Context, resource atlas.ConnectionSource) (*mdbv1.AtlasConnection, error) {
+	name := resource.AtlasConnectionName()
+	if name == "" || !v.AtlasProvider.AtlasConnectionsEnabled() {
+		return nil, nil
+	}
+
+	atlasConnection := &mdbv1.AtlasConnection{}
+	err := v.Client.Get(ctx, client.ObjectKey{Name: name}, atlasConnection)
+	if apiErrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the AtlasConnection %s: %w", name, err)
+	}
+
+	if !atlasConnection.AllowsNamespace(resource.GetNamespace()) {
+		return nil, fmt.Errorf("the AtlasConnection %s doesn't allow the namespace %s", name, resource.GetNamespace())
+	}
+	return atlasConnection, nil
+}
+
+// validateDeployment validates the deployment against the region restrictions of its project and the AtlasPolicies
+// selecting its namespace. The project may not exist yet (resources are often applied together), in this case the
+// restrictions are checked during reconciliation.
+func (v *Validator) validateDeployment(ctx context.Context, deployment *mdbv1.AtlasDeployment) error {
+	if err := v.validateDeploymentSpec(ctx, deployment); err != nil {
+		return err
+	}
+
+	// the deployments violating the policies can still be deleted, as the controller does
+	if !v.EnablePolicies || isDeleting(deployment) {
+		return nil
+	}
+
+	violations, err := policy.DeploymentViolations(ctx, v.Client, deployment)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("the deployment violates the AtlasPolicies: %s", strings.Join(violations, "; "))
+	}
+
+	return nil
+}
+
+func (v *Validator) validateDeploymentSpec(ctx context.Context, deployment *mdbv1.AtlasDeployment) error {
+	project := &mdbv1.AtlasProject{}
+	err := v.Client.Get(ctx, deployment.AtlasProjectObjectKey(), project)
+	if apiErrors.IsNotFound(err) {
+		return validate.DeploymentSpec(&deployment.Spec, false, "")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the project %s: %w", deployment.AtlasProjectObjectKey(), err)
+	}
+
+	domain, err := v.domainOf(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	return validate.DeploymentSpec(&deployment.Spec, customresource.IsGov(domain), project.Spec.RegionUsageRestrictions)
+}
@@ -0,0 +1,288 @@
+package admission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+func newValidator(t *testing.T, atlasDomain string, objects ...client.Object) *Validator {
+	sch := runtime.NewScheme()
+	require.NoError(t, mdbv1.AddToScheme(sch))
+	require.NoError(t, corev1.AddToScheme(sch))
+
+	kubeClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(objects...).Build()
+	return &Validator{
+		Client:        kubeClient,
+		AtlasDomain:   atlasDomain,
+		AtlasProvider: atlas.NewProductionProvider(atlasDomain, client.ObjectKey{}, kubeClient),
+	}
+}
+
+func TestValidateProject(t *testing.T) {
+	validator := newValidator(t, "https://cloud.mongodb.com/")
+
+	atlasProject := mdbv1.DefaultProject("test-ns", "connection").
+		WithIPAccessList(project.IPAccessList{IPAddress: "192.168.0.300"})
+	assert.ErrorContains(t, validator.ValidateCreate(context.Background(), atlasProject), "invalid ipAddress: 192.168.0.300")
+
+	atlasProject = mdbv1.DefaultProject("test-ns", "connection").
+		WithIPAccessList(project.IPAccessList{IPAddress: "192.168.0.1"})
+	assert.NoError(t, validator.ValidateUpdate(context.Background(), nil, atlasProject))
+	assert.NoError(t, validator.ValidateDelete(context.Background(), atlasProject))
+}
+
+func TestValidateProjectWithAtlasConnection(t *testing.T) {
+	gov := &mdbv1.AtlasConnection{
+		ObjectMeta: metav1.ObjectMeta{Name: "gov"},
+		Spec: mdbv1.AtlasConnectionSpec{
+			AtlasDomain:          "https://cloud.mongodbgov.com/",
+			OrgID:                "org",
+			CredentialsSecretRef: common.ResourceRefNamespaced{Name: "credentials", Namespace: "ns"},
+			AllowedNamespaces:    []string{"test-ns"},
+		},
+	}
+	validator := newValidator(t, "https://cloud.mongodb.com/", gov)
+
+	atlasProject := mdbv1.DefaultProject("test-ns", "")
+	atlasProject.Spec.ConnectionSecret = nil
+	atlasProject.Spec.AtlasConnectionRef = &common.ResourceRef{Name: "gov"}
+	atlasProject.Spec.RegionUsageRestrictions = "GOV_REGIONS_ONLY"
+
+	t.Run("connections disabled", func(t *testing.T) {
+		assert.ErrorContains(t, validator.ValidateCreate(context.Background(), atlasProject), "regionUsageRestriction can be used only with Atlas for government")
+	})
+
+	validator.AtlasProvider = atlas.NewProductionProvider(validator.AtlasDomain, client.ObjectKey{}, validator.Client, atlas.WithAtlasConnections(true))
+
+	t.Run("gov connection", func(t *testing.T) {
+		assert.NoError(t, validator.ValidateCreate(context.Background(), atlasProject))
+	})
+
+	t.Run("namespace not allowed", func(t *testing.T) {
+		otherNamespace := atlasProject.DeepCopy()
+		otherNamespace.Namespace = "other-ns"
+		assert.EqualError(t, validator.ValidateCreate(context.Background(), otherNamespace), "the AtlasConnection gov doesn't allow the namespace other-ns")
+
+		fedAuth := &mdbv1.AtlasFederatedAuth{
+			ObjectMeta: metav1.ObjectMeta{Name: "fed-auth", Namespace: "other-ns"},
+			Spec:       mdbv1.AtlasFederatedAuthSpec{AtlasConnectionRef: &common.ResourceRef{Name: "gov"}},
+		}
+		assert.EqualError(t, validator.ValidateCreate(context.Background(), fedAuth), "the AtlasConnection gov doesn't allow the namespace other-ns")
+		fedAuth.Namespace = "test-ns"
+		assert.NoError(t, validator.ValidateCreate(context.Background(), fedAuth))
+	})
+
+	t.Run("connection doesn't exist yet", func(t *testing.T) {
+		missing := atlasProject.DeepCopy()
+		missing.Spec.AtlasConnectionRef.Name = "missing"
+		assert.ErrorContains(t, validator.ValidateCreate(context.Background(), missing), "regionUsageRestriction can be used only with Atlas for government")
+	})
+
+	t.Run("connection secret set too", func(t *testing.T) {
+		both := atlasProject.DeepCopy()
+		both.Spec.ConnectionSecret = &common.ResourceRefNamespaced{Name: "credentials"}
+		assert.ErrorContains(t, validator.ValidateCreate(context.Background(), both), "connectionSecretRef and atlasConnectionRef can't be used together")
+	})
+}
+
+func TestValidateAtlasConnection(t *testing.T) {
+	validator := newValidator(t, "https://cloud.mongodb.com/")
+
+	atlasConnection := &mdbv1.AtlasConnection{
+		Spec: mdbv1.AtlasConnectionSpec{CredentialsSecretRef: common.ResourceRefNamespaced{Name: "credentials", Namespace: "ns"}},
+	}
+	assert.ErrorContains(t, validator.ValidateCreate(context.Background(), atlasConnection), "orgId must be set")
+
+	atlasConnection.Spec.OrgID = "org"
+	assert.ErrorContains(t, validator.ValidateCreate(context.Background(), atlasConnection), "allowedNamespaces must list at least one namespace")
+
+	atlasConnection.Spec.AllowedNamespaces = []string{"*"}
+	assert.NoError(t, validator.ValidateUpdate(context.Background(), nil, atlasConnection))
+}
+
+func TestValidateDeployment(t *testing.T) {
+	deployment := mdbv1.DefaultAWSDeployment("test-ns", "my-project")
+	deployment.Spec.DeploymentSpec.ReplicationSpecs[0].RegionConfigs[0].RegionName = "EU_WEST_1"
+
+	t.Run("project doesn't exist yet", func(t *testing.T) {
+		validator := newValidator(t, "https://cloud.mongodbgov.com/")
+		assert.NoError(t, validator.ValidateCreate(context.Background(), deployment))
+	})
+
+	t.Run("region is restricted by the project", func(t *testing.T) {
+		atlasProject := mdbv1.DefaultProject("test-ns", "connection")
+		atlasProject.Name = "my-project"
+		atlasProject.Spec.RegionUsageRestrictions = "GOV_REGIONS_ONLY"
+		validator := newValidator(t, "https://cloud.mongodbgov.com/", atlasProject)
+
+		assert.ErrorContains(t, validator.ValidateCreate(context.Background(), deployment), "support a restricted set of regions")
+	})
+
+	t.Run("neither deployment nor serverless spec", func(t *testing.T) {
+		validator := newValidator(t, "https://cloud.mongodb.com/")
+		invalid := deployment.DeepCopy()
+		invalid.Spec.DeploymentSpec = nil
+
+		assert.ErrorContains(t, validator.ValidateUpdate(context.Background(), deployment, invalid), "expected exactly one of spec.deploymentSpec or spec.serverlessSpec")
+	})
+}
+
+func TestValidateBackupSchedule(t *testing.T) {
+	bSchedule := &mdbv1.AtlasBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "schedule", Namespace: "test-ns"},
+		Spec: mdbv1.AtlasBackupScheduleSpec{
+			CopySettings: []mdbv1.CopySetting{
+				{
+					RegionName:       toptr.MakePtr("US_WEST_1"),
+					CloudProvider:    toptr.MakePtr("AWS"),
+					ShouldCopyOplogs: toptr.MakePtr(true),
+				},
+			},
+		},
+	}
+
+	t.Run("not referenced by any deployment", func(t *testing.T) {
+		validator := newValidator(t, "https://cloud.mongodb.com/")
+		assert.NoError(t, validator.ValidateCreate(context.Background(), bSchedule))
+	})
+
+	t.Run("deployments referencing the schedule aren't checked", func(t *testing.T) {
+		deployment := mdbv1.DefaultAWSDeployment("other-ns", "my-project").
+			WithBackupScheduleRef(common.ResourceRefNamespaced{Name: "schedule", Namespace: "test-ns"})
+		validator := newValidator(t, "https://cloud.mongodb.com/", deployment)
+
+		assert.NoError(t, validator.ValidateUpdate(context.Background(), bSchedule, bSchedule))
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		validator := newValidator(t, "https://cloud.mongodb.com/")
+		invalid := bSchedule.DeepCopy()
+		invalid.Spec.CopySettings[0].RegionName = nil
+
+		assert.ErrorContains(t, validator.ValidateCreate(context.Background(), invalid), "copy setting at position 0: you must set a region name")
+	})
+}
+
+func TestValidateOtherResources(t *testing.T) {
+	validator := newValidator(t, "https://cloud.mongodb.com/")
+
+	for _, resource := range []runtime.Object{
+		mdbv1.DefaultDBUser("test-ns", "user", "my-project"),
+		&mdbv1.AtlasDataFederation{},
+		&mdbv1.AtlasTeam{},
+		&mdbv1.AtlasBackupPolicy{},
+		&mdbv1.AtlasFederatedAuth{},
+		&mdbv1.AtlasPolicy{},
+	} {
+		assert.NoError(t, validator.ValidateCreate(context.Background(), resource))
+	}
+
+	team := &mdbv1.AtlasTeam{Spec: mdbv1.TeamSpec{Usernames: []mdbv1.TeamUser{"user", "user"}}}
+	assert.ErrorContains(t, validator.ValidateCreate(context.Background(), team), "is duplicate")
+}
+
+func TestValidateUpdate(t *testing.T) {
+	validator := newValidator(t, "https://cloud.mongodb.com/")
+	// a team which became invalid under a newer validation
+	team := &mdbv1.AtlasTeam{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "test-ns", Generation: 1, Finalizers: []string{"mongodbatlas/finalizer"}},
+		Spec:       mdbv1.TeamSpec{Usernames: []mdbv1.TeamUser{"user", "user"}},
+	}
+
+	t.Run("metadata only update", func(t *testing.T) {
+		annotated := team.DeepCopy()
+		annotated.Annotations = map[string]string{"mongodb.com/atlas-resource-policy": "keep"}
+
+		assert.NoError(t, validator.ValidateUpdate(context.Background(), team, annotated))
+	})
+
+	t.Run("finalizer removal of a deleted resource", func(t *testing.T) {
+		deleted := team.DeepCopy()
+		deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		withoutFinalizer := deleted.DeepCopy()
+		withoutFinalizer.Finalizers = nil
+
+		assert.NoError(t, validator.ValidateUpdate(context.Background(), deleted, withoutFinalizer))
+	})
+
+	t.Run("spec update", func(t *testing.T) {
+		updated := team.DeepCopy()
+		updated.Generation = 2
+		updated.Spec.Name = "renamed"
+
+		assert.ErrorContains(t, validator.ValidateUpdate(context.Background(), team, updated), "is duplicate")
+	})
+}
+
+func TestValidatePolicies(t *testing.T) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"environment": "dev"}}}
+	devLimits := &mdbv1.AtlasPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev-limits"},
+		Spec: mdbv1.AtlasPolicySpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"environment": "dev"}},
+			Deployments:       mdbv1.DeploymentPolicy{MaxInstanceSize: "M40"},
+		},
+	}
+	deployment := mdbv1.DefaultAWSDeployment("dev", "my-project")
+	deployment.Spec.DeploymentSpec.ReplicationSpecs[0].RegionConfigs[0].ElectableSpecs.InstanceSize = "M50"
+
+	t.Run("a deployment violating a policy", func(t *testing.T) {
+		validator := newValidator(t, "https://cloud.mongodb.com/", namespace, devLimits)
+		validator.EnablePolicies = true
+
+		assert.ErrorContains(t, validator.ValidateCreate(context.Background(), deployment),
+			"the deployment violates the AtlasPolicies: AtlasPolicy dev-limits: the instance size M50 of the electable nodes in US_EAST_1 is above the maximum M40")
+	})
+
+	t.Run("a deployment violating a policy added later", func(t *testing.T) {
+		validator := newValidator(t, "https://cloud.mongodb.com/", namespace, devLimits)
+		validator.EnablePolicies = true
+		existing := deployment.DeepCopy()
+		existing.Generation = 1
+		existing.Finalizers = []string{"mongodbatlas/finalizer"}
+
+		annotated := existing.DeepCopy()
+		annotated.Annotations = map[string]string{"mongodb.com/atlas-resource-policy": "keep"}
+		assert.NoError(t, validator.ValidateUpdate(context.Background(), existing, annotated))
+
+		deleted := existing.DeepCopy()
+		deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		withoutFinalizer := deleted.DeepCopy()
+		withoutFinalizer.Finalizers = nil
+		assert.NoError(t, validator.ValidateUpdate(context.Background(), deleted, withoutFinalizer))
+
+		updated := existing.DeepCopy()
+		updated.Generation = 2
+		updated.Spec.DeploymentSpec.ReplicationSpecs[0].RegionConfigs[0].ElectableSpecs.InstanceSize = "M60"
+		assert.ErrorContains(t, validator.ValidateUpdate(context.Background(), existing, updated), "is above the maximum M40")
+	})
+
+	t.Run("the policies aren't enforced unless enabled", func(t *testing.T) {
+		validator := newValidator(t, "https://cloud.mongodb.com/", namespace, devLimits)
+
+		assert.NoError(t, validator.ValidateCreate(context.Background(), deployment))
+	})
+
+	t.Run("an invalid policy", func(t *testing.T) {
+		validator := newValidator(t, "https://cloud.mongodb.com/")
+		invalid := devLimits.DeepCopy()
+		invalid.Spec.Deployments.MaxInstanceSize = "large"
+
+		assert.ErrorContains(t, validator.ValidateCreate(context.Background(), invalid), "the maximum instance size \"large\" is invalid")
+	})
+}
@@ -0,0 +1,51 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
+)
+
+// readAtlasConnection reads the Atlas domain and the organization of the AtlasConnection together with the credentials
+// of its Secret for a resource of the namespace. The Secret is read from the credentials directory instead if one is set
+func (f *ProductionProvider) readAtlasConnection(log *zap.SugaredLogger, name, namespace string) (Connection, error) {
+	atlasConnection := &mdbv1.AtlasConnection{}
+	if err := f.k8sClient.Get(context.Background(), client.ObjectKey{Name: name}, atlasConnection); err != nil {
+		return Connection{}, fmt.Errorf("can't read the AtlasConnection %s: %w", name, err)
+	}
+	if err := validate.AtlasConnection(atlasConnection); err != nil {
+		return Connection{}, fmt.Errorf("the AtlasConnection %s is invalid: %w", name, err)
+	}
+	if !atlasConnection.AllowsNamespace(namespace) {
+		return Connection{}, fmt.Errorf("the AtlasConnection %s doesn't allow the namespace %s", name, namespace)
+	}
+
+	secretRef := atlasConnection.CredentialsSecretObjectKey()
+	source := fmt.Sprintf("the Secret %v", secretRef)
+	readData := func() (map[string]string, error) { return readSecretData(f.k8sClient, secretRef) }
+	if f.credentialsDir != "" {
+		path := CredentialsPath(f.credentialsDir, &secretRef)
+		source = fmt.Sprintf("the directory %s", path)
+		readData = func() (map[string]string, error) { return readCredentialsFiles(path) }
+	}
+
+	log.Debugf("Reading Atlas API credentials of the AtlasConnection %s from %s", name, source)
+	data, err := readData()
+	if err != nil {
+		return Connection{}, fmt.Errorf("can't read Atlas API credentials from %s: %w", source, err)
+	}
+	data[orgIDKey] = atlasConnection.Spec.OrgID
+
+	if err = validateCredentials(source, data); err != nil {
+		return Connection{}, err
+	}
+
+	connection := connectionFromData(data)
+	connection.Domain = atlasConnection.Spec.AtlasDomain
+	return connection, nil
+}
@@ -0,0 +1,131 @@
+package atlas
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+func atlasConnectionClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, mdbv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func TestCreateConnection(t *testing.T) {
+	gov := &mdbv1.AtlasConnection{
+		ObjectMeta: metav1.ObjectMeta{Name: "gov"},
+		Spec: mdbv1.AtlasConnectionSpec{
+			AtlasDomain:          "https://cloud.mongodbgov.com/",
+			OrgID:                "gov-org",
+			CredentialsSecretRef: common.ResourceRefNamespaced{Name: "gov-credentials", Namespace: "operator"},
+			AllowedNamespaces:    []string{"gov-projects"},
+		},
+	}
+	govCredentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gov-credentials", Namespace: "operator"},
+		Data:       map[string][]byte{"orgId": []byte("ignored-org"), "publicApiKey": []byte("public"), "privateApiKey": []byte("private")},
+	}
+	globalCredentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: "operator"},
+		Data:       map[string][]byte{"orgId": []byte("global-org"), "clientId": []byte("id"), "clientSecret": []byte("secret")},
+	}
+	kubeClient := atlasConnectionClient(t, gov, govCredentials, globalCredentials)
+	log := zap.NewNop().Sugar()
+	globalSecretRef := kube.ObjectKey("operator", "global")
+	provider := NewProductionProvider("", globalSecretRef, kubeClient, WithAtlasConnections(true))
+
+	withConnection := &mdbv1.AtlasProject{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "gov-projects"},
+		Spec:       mdbv1.AtlasProjectSpec{AtlasConnectionRef: &common.ResourceRef{Name: "gov"}},
+	}
+	withGlobalCredentials := &mdbv1.AtlasProject{}
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := NewProductionProvider("", globalSecretRef, kubeClient).CreateConnection(withConnection, log)
+		assert.EqualError(t, err, "can't use the AtlasConnection gov: the AtlasConnections are disabled, start the Operator with --enable-atlas-connections")
+	})
+
+	t.Run("atlas connection", func(t *testing.T) {
+		connection, err := provider.CreateConnection(withConnection, log)
+		require.NoError(t, err)
+		assert.Equal(t, Connection{OrgID: "gov-org", PublicKey: "public", PrivateKey: "private", Domain: "https://cloud.mongodbgov.com/"}, connection)
+		assert.Equal(t, "https://cloud.mongodbgov.com/", connection.DomainOr("https://cloud.mongodb.com/"))
+	})
+
+	t.Run("namespace not allowed", func(t *testing.T) {
+		otherNamespace := withConnection.DeepCopy()
+		otherNamespace.Namespace = "other"
+		_, err := provider.CreateConnection(otherNamespace, log)
+		assert.EqualError(t, err, "the AtlasConnection gov doesn't allow the namespace other")
+	})
+
+	t.Run("connection secret", func(t *testing.T) {
+		connection, err := provider.CreateConnection(withGlobalCredentials, log)
+		require.NoError(t, err)
+		assert.Equal(t, Connection{OrgID: "global-org", ClientID: "id", ClientSecret: "secret"}, connection)
+		assert.Equal(t, "https://cloud.mongodb.com/", connection.DomainOr("https://cloud.mongodb.com/"))
+	})
+
+	t.Run("missing connection", func(t *testing.T) {
+		missing := &mdbv1.AtlasProject{Spec: mdbv1.AtlasProjectSpec{AtlasConnectionRef: &common.ResourceRef{Name: "missing"}}}
+		_, err := provider.CreateConnection(missing, log)
+		assert.ErrorContains(t, err, "can't read the AtlasConnection missing")
+	})
+}
+
+func TestReadAtlasConnection(t *testing.T) {
+	log := zap.NewNop().Sugar()
+
+	t.Run("invalid connection", func(t *testing.T) {
+		invalid := &mdbv1.AtlasConnection{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+			Spec:       mdbv1.AtlasConnectionSpec{CredentialsSecretRef: common.ResourceRefNamespaced{Name: "credentials", Namespace: "ns"}},
+		}
+		_, err := NewProductionProvider("", client.ObjectKey{}, atlasConnectionClient(t, invalid)).readAtlasConnection(log, "invalid", "ns")
+		assert.ErrorContains(t, err, "the AtlasConnection invalid is invalid")
+	})
+
+	t.Run("missing credentials", func(t *testing.T) {
+		incomplete := &mdbv1.AtlasConnection{
+			ObjectMeta: metav1.ObjectMeta{Name: "incomplete"},
+			Spec:       mdbv1.AtlasConnectionSpec{OrgID: "org", CredentialsSecretRef: common.ResourceRefNamespaced{Name: "credentials", Namespace: "ns"}, AllowedNamespaces: []string{"*"}},
+		}
+		credentials := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "ns"},
+			Data:       map[string][]byte{"publicApiKey": []byte("public")},
+		}
+		_, err := NewProductionProvider("", client.ObjectKey{}, atlasConnectionClient(t, incomplete, credentials)).readAtlasConnection(log, "incomplete", "ns")
+		assert.EqualError(t, err, "the following fields are missing in the Secret ns/credentials: [privateApiKey]")
+	})
+
+	t.Run("credentials directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeCredentials(t, filepath.Join(dir, "ns", "credentials"), map[string]string{"clientId": "id", "clientSecret": "secret"})
+
+		mounted := &mdbv1.AtlasConnection{
+			ObjectMeta: metav1.ObjectMeta{Name: "mounted"},
+			Spec:       mdbv1.AtlasConnectionSpec{OrgID: "org", CredentialsSecretRef: common.ResourceRefNamespaced{Name: "credentials", Namespace: "ns"}, AllowedNamespaces: []string{"*"}},
+		}
+		provider := NewProductionProvider("", client.ObjectKey{}, atlasConnectionClient(t, mounted), WithCredentialsDir(dir))
+		connection, err := provider.readAtlasConnection(log, "mounted", "ns")
+		require.NoError(t, err)
+		assert.Equal(t, Connection{OrgID: "org", ClientID: "id", ClientSecret: "secret"}, connection)
+	})
+}
@@ -3,6 +3,7 @@ package atlas
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"runtime"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/version"
@@ -13,12 +14,25 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/httputil"
 )
 
-// Client is the central place to create a client for Atlas using specified API keys and a server URL.
+// serviceAccountTokenPath is the path of the Atlas endpoint issuing the access tokens of the service accounts
+const serviceAccountTokenPath = "api/oauth/token"
+
+// Client is the central place to create a client for Atlas using the specified credentials and a server URL.
+// The domain of the connection, if any, takes precedence over the given one.
 // Note, that the default HTTP transport is reused globally by Go so all caching, keep-alive etc will be in action.
 func Client(atlasDomain string, connection Connection, log *zap.SugaredLogger, opts ...httputil.ClientOpt) (mongodbatlas.Client, error) {
-	withDigest := httputil.Digest(connection.PublicKey, connection.PrivateKey)
+	atlasDomain = connection.DomainOr(atlasDomain)
+	withAuth := httputil.Digest(connection.PublicKey, connection.PrivateKey)
+	if connection.IsServiceAccount() {
+		tokenURL, err := url.JoinPath(atlasDomain, serviceAccountTokenPath)
+		if err != nil {
+			return mongodbatlas.Client{}, fmt.Errorf("invalid Atlas domain %q: %w", atlasDomain, err)
+		}
+		withAuth = httputil.OAuth2ClientCredentials(tokenURL, connection.ClientID, connection.ClientSecret)
+	}
 	withLogging := httputil.LoggingTransport(log)
-	allOptions := []httputil.ClientOpt{withDigest, withLogging}
+	withMetrics := httputil.MetricsTransport()
+	allOptions := []httputil.ClientOpt{withAuth, withLogging, withMetrics}
 	allOptions = append(allOptions, opts...)
 
 	httpClient, err := httputil.DecorateClient(basicClient(), allOptions...)
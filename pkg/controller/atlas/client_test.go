@@ -3,11 +3,13 @@ package atlas_test
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/httputil"
@@ -22,6 +24,16 @@ func TestClientUserAgent(t *testing.T) {
 	r.Contains(c.UserAgent, version.Version)
 }
 
+func TestClientConnectionDomain(t *testing.T) {
+	c, err := atlas.Client("https://cloud.mongodb.com/", atlas.Connection{Domain: "https://cloud.mongodbgov.com/"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cloud.mongodbgov.com/", c.BaseURL.String())
+
+	c, err = atlas.Client("https://cloud.mongodb.com/", atlas.Connection{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cloud.mongodb.com/", c.BaseURL.String())
+}
+
 // RoundTrip implements http.RoundTripper registering if it got used
 type testTransport struct {
 	used bool
@@ -40,3 +52,28 @@ func TestCustomTransport(t *testing.T) {
 	client.Projects.GetAllProjects(context.Background(), &mongodbatlas.ListOptions{})
 	assert.True(t, tt.used)
 }
+
+func TestServiceAccountClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/oauth/token" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"service-account-token","token_type":"Bearer","expires_in":3600}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer service-account-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[],"totalCount":0}`))
+	}))
+	defer server.Close()
+
+	connection := atlas.Connection{OrgID: "org", ClientID: "mdb_sa_id", ClientSecret: "mdb_sa_sk"}
+	require.True(t, connection.IsServiceAccount())
+	client, err := atlas.Client(server.URL+"/", connection, zap.NewNop().Sugar())
+	require.NoError(t, err)
+
+	_, _, err = client.Projects.GetAllProjects(context.Background(), &mongodbatlas.ListOptions{})
+	assert.NoError(t, err)
+}
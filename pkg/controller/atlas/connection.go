@@ -7,59 +7,150 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
 )
 
 const (
-	orgIDKey      = "orgId"
-	publicAPIKey  = "publicApiKey"
-	privateAPIKey = "privateApiKey"
+	orgIDKey        = "orgId"
+	publicAPIKey    = "publicApiKey"
+	privateAPIKey   = "privateApiKey"
+	clientIDKey     = "clientId"
+	clientSecretKey = "clientSecret"
 )
 
-// Connection encapsulates Atlas connectivity information that is necessary to perform API requests
+// Connection encapsulates Atlas connectivity information that is necessary to perform API requests. The requests are
+// authenticated either with the programmatic API keys or with the client credentials of a service account
 type Connection struct {
 	OrgID      string
 	PublicKey  string
 	PrivateKey string
+
+	ClientID     string
+	ClientSecret string
+
+	// Domain is the Atlas domain of the AtlasConnection the connection is read from, empty for the Operator one
+	Domain string
+}
+
+// ConnectionSource is a resource configuring its connection to Atlas either with a Secret or with an AtlasConnection
+type ConnectionSource interface {
+	GetNamespace() string
+	ConnectionSecretObjectKey() *client.ObjectKey
+	AtlasConnectionName() string
+}
+
+// IsServiceAccount tells if the connection authenticates with the client credentials of a service account
+func (c Connection) IsServiceAccount() bool {
+	return c.ClientID != ""
+}
+
+// DomainOr returns the Atlas domain of the connection, the given Operator domain if the connection doesn't have one
+func (c Connection) DomainOr(operatorDomain string) string {
+	if c.Domain != "" {
+		return c.Domain
+	}
+	return operatorDomain
+}
+
+// UsesGlobalAPISecret tells if the resource connects to Atlas with the global API Secret, either because it doesn't
+// configure its connection or because it references the global Secret
+func UsesGlobalAPISecret(resource ConnectionSource, globalAPISecret client.ObjectKey) bool {
+	if resource.AtlasConnectionName() != "" {
+		return false
+	}
+	secretRef := resource.ConnectionSecretObjectKey()
+	return secretRef == nil || *secretRef == globalAPISecret
+}
+
+// ProjectsUsingGlobalAPISecret returns the AtlasProjects connecting to Atlas with the global API Secret
+func ProjectsUsingGlobalAPISecret(ctx context.Context, kubeClient client.Client, globalAPISecret client.ObjectKey) (map[client.ObjectKey]bool, error) {
+	projects := &mdbv1.AtlasProjectList{}
+	if err := kubeClient.List(ctx, projects); err != nil {
+		return nil, fmt.Errorf("failed to list the AtlasProjects: %w", err)
+	}
+
+	usingGlobal := map[client.ObjectKey]bool{}
+	for i := range projects.Items {
+		if UsesGlobalAPISecret(&projects.Items[i], globalAPISecret) {
+			usingGlobal[kube.ObjectKeyFromObject(&projects.Items[i])] = true
+		}
+	}
+	return usingGlobal, nil
 }
 
 // ReadConnection reads Atlas API connection parameters from AtlasProject Secret or from the default Operator one if the
-// former is not specified
-func ReadConnection(log *zap.SugaredLogger, kubeClient client.Client, operatorAPISecret client.ObjectKey, projectOverrideSecretRef *client.ObjectKey) (Connection, error) {
+// former is not specified. The Secrets are read from the credentials directory instead if one is set
+func (f *ProductionProvider) ReadConnection(log *zap.SugaredLogger, projectOverrideSecretRef *client.ObjectKey) (Connection, error) {
+	if f.credentialsDir != "" {
+		path := CredentialsPath(f.credentialsDir, projectOverrideSecretRef)
+		log.Debugf("Reading Atlas API credentials from the directory %s", path)
+		return readConnectionFromDir(path)
+	}
+
 	if projectOverrideSecretRef != nil {
 		// TODO is it possible that part of connection (like orgID is still in the Operator level secret and needs to get merged?)
 		log.Infof("Reading Atlas API credentials from the AtlasProject Secret %s", projectOverrideSecretRef)
-		return readAtlasConnectionFromSecret(kubeClient, *projectOverrideSecretRef)
+		return readAtlasConnectionFromSecret(f.k8sClient, *projectOverrideSecretRef)
 	}
 
-	log.Debugf("AtlasProject connection Secret is not specified - using the Operator one: %v", operatorAPISecret)
-	return readAtlasConnectionFromSecret(kubeClient, operatorAPISecret)
+	log.Debugf("AtlasProject connection Secret is not specified - using the Operator one: %v", f.globalSecretRef)
+	return readAtlasConnectionFromSecret(f.k8sClient, f.globalSecretRef)
 }
 
 func readAtlasConnectionFromSecret(kubeClient client.Client, secretRef client.ObjectKey) (Connection, error) {
+	secretData, err := readSecretData(kubeClient, secretRef)
+	if err != nil {
+		return Connection{}, fmt.Errorf("can't read Atlas API credentials from the Secret %v: %w", secretRef, err)
+	}
+
+	if err = validateConnectionSecret(secretRef, secretData); err != nil {
+		return Connection{}, err
+	}
+
+	return connectionFromData(secretData), nil
+}
+
+func readSecretData(kubeClient client.Client, secretRef client.ObjectKey) (map[string]string, error) {
 	secret := &corev1.Secret{}
 	if err := kubeClient.Get(context.Background(), secretRef, secret); err != nil {
-		return Connection{}, fmt.Errorf("can't read Atlas API credentials from the Secret %v: %w", secretRef, err)
+		return nil, err
 	}
 	secretData := make(map[string]string)
 	for k, v := range secret.Data {
 		secretData[k] = string(v)
 	}
+	return secretData, nil
+}
 
-	if err := validateConnectionSecret(secretRef, secretData); err != nil {
-		return Connection{}, err
-	}
-
+func connectionFromData(data map[string]string) Connection {
 	return Connection{
-		OrgID:      secretData["orgId"],
-		PublicKey:  secretData["publicApiKey"],
-		PrivateKey: secretData["privateApiKey"],
-	}, nil
+		OrgID:        data[orgIDKey],
+		PublicKey:    data[publicAPIKey],
+		PrivateKey:   data[privateAPIKey],
+		ClientID:     data[clientIDKey],
+		ClientSecret: data[clientSecretKey],
+	}
 }
 
 func validateConnectionSecret(secretRef client.ObjectKey, secretData map[string]string) error {
+	return validateCredentials(fmt.Sprintf("the Secret %v", secretRef), secretData)
+}
+
+// validateCredentials checks the credentials have the organization ID and either the API keys or the service account
+// client credentials. The source describes where the credentials come from in the errors
+func validateCredentials(source string, secretData map[string]string) error {
 	var missingFields []string
 	requiredKeys := []string{orgIDKey, publicAPIKey, privateAPIKey}
 
+	if hasAnyKey(secretData, clientIDKey, clientSecretKey) {
+		if hasAnyKey(secretData, publicAPIKey, privateAPIKey) {
+			return fmt.Errorf("%s must have either the API keys or the service account credentials, not both", source)
+		}
+		requiredKeys = []string{orgIDKey, clientIDKey, clientSecretKey}
+	}
+
 	for _, key := range requiredKeys {
 		if _, ok := secretData[key]; !ok {
 			missingFields = append(missingFields, key)
@@ -67,7 +158,16 @@ func validateConnectionSecret(secretRef client.ObjectKey, secretData map[string]
 	}
 
 	if len(missingFields) > 0 {
-		return fmt.Errorf("the following fields are missing in the Secret %v: %v", secretRef, missingFields)
+		return fmt.Errorf("the following fields are missing in %s: %v", source, missingFields)
 	}
 	return nil
 }
+
+func hasAnyKey(secretData map[string]string, keys ...string) bool {
+	for _, key := range keys {
+		if _, ok := secretData[key]; ok {
+			return true
+		}
+	}
+	return false
+}
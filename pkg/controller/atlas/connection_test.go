@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
 )
 
@@ -20,3 +22,34 @@ func Test_validateConnectionSecret(t *testing.T) {
 
 	assert.NoError(t, validateConnectionSecret(kube.ObjectKey("testNs", "testSecret"), map[string]string{"orgId": "some", "publicApiKey": "foo", "privateApiKey": "bla"}))
 }
+
+func Test_validateConnectionSecretServiceAccount(t *testing.T) {
+	err := validateConnectionSecret(kube.ObjectKey("testNs", "testSecret"), map[string]string{"clientId": "foo"})
+	assert.EqualError(t, err, "the following fields are missing in the Secret testNs/testSecret: [orgId clientSecret]")
+
+	err = validateConnectionSecret(kube.ObjectKey("testNs", "testSecret"), map[string]string{"orgId": "some", "clientId": "foo", "clientSecret": "bla", "privateApiKey": "bla"})
+	assert.EqualError(t, err, "the Secret testNs/testSecret must have either the API keys or the service account credentials, not both")
+
+	assert.NoError(t, validateConnectionSecret(kube.ObjectKey("testNs", "testSecret"), map[string]string{"orgId": "some", "clientId": "foo", "clientSecret": "bla"}))
+}
+
+func TestUsesGlobalAPISecret(t *testing.T) {
+	globalSecretRef := kube.ObjectKey("operator", "global")
+
+	for name, tc := range map[string]struct {
+		project  *mdbv1.AtlasProject
+		expected bool
+	}{
+		"without connection":        {project: mdbv1.NewProject("ns", "project", "project"), expected: true},
+		"referencing global secret": {project: mdbv1.NewProject("ns", "project", "project").WithConnectionSecretNamespaced("global", "operator"), expected: true},
+		"own secret":                {project: mdbv1.NewProject("ns", "project", "project").WithConnectionSecret("own"), expected: false},
+		"atlas connection": {
+			project:  &mdbv1.AtlasProject{Spec: mdbv1.AtlasProjectSpec{AtlasConnectionRef: &common.ResourceRef{Name: "gov"}}},
+			expected: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, UsesGlobalAPISecret(tc.project, globalSecretRef))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package atlas
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CredentialsPath returns the directory of the credentials referenced as the Secret, the global ones if nil
+func CredentialsPath(dir string, secretRef *client.ObjectKey) string {
+	if secretRef == nil {
+		return dir
+	}
+	return filepath.Join(dir, secretRef.Namespace, secretRef.Name)
+}
+
+// readConnectionFromDir reads the connection from the files named after the keys of the connection Secret. The files
+// are read on every call, so that the credentials rotated on disk are used right away
+func readConnectionFromDir(dir string) (Connection, error) {
+	data, err := readCredentialsFiles(dir)
+	if err != nil {
+		return Connection{}, fmt.Errorf("can't read Atlas API credentials from the directory %s: %w", dir, err)
+	}
+
+	if err = validateCredentials(fmt.Sprintf("the directory %s", dir), data); err != nil {
+		return Connection{}, err
+	}
+
+	return connectionFromData(data), nil
+}
+
+func readCredentialsFiles(dir string) (map[string]string, error) {
+	data := map[string]string{}
+	for _, key := range []string{orgIDKey, publicAPIKey, privateAPIKey, clientIDKey, clientSecretKey} {
+		value, err := os.ReadFile(filepath.Join(dir, key))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		data[key] = strings.TrimSpace(string(value))
+	}
+	return data, nil
+}
@@ -0,0 +1,100 @@
+package atlas
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+func writeCredentials(t *testing.T, dir string, credentials map[string]string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	for key, value := range credentials {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, key), []byte(value+"\n"), 0o600))
+	}
+}
+
+func TestReadConnectionFromCredentialsDir(t *testing.T) {
+	dir := t.TempDir()
+	writeCredentials(t, dir, map[string]string{"orgId": "global-org", "publicApiKey": "public", "privateApiKey": "private"})
+	writeCredentials(t, filepath.Join(dir, "ns", "project-credentials"), map[string]string{"orgId": "project-org", "clientId": "id", "clientSecret": "secret"})
+	writeCredentials(t, filepath.Join(dir, "ns", "incomplete"), map[string]string{"orgId": "project-org"})
+
+	provider := NewProductionProvider("", kube.ObjectKey("operator", "global"), nil, WithCredentialsDir(dir))
+	log := zap.NewNop().Sugar()
+
+	connection, err := provider.ReadConnection(log, nil)
+	require.NoError(t, err)
+	assert.Equal(t, Connection{OrgID: "global-org", PublicKey: "public", PrivateKey: "private"}, connection)
+
+	projectSecretRef := kube.ObjectKey("ns", "project-credentials")
+	connection, err = provider.ReadConnection(log, &projectSecretRef)
+	require.NoError(t, err)
+	assert.Equal(t, Connection{OrgID: "project-org", ClientID: "id", ClientSecret: "secret"}, connection)
+
+	incompleteSecretRef := kube.ObjectKey("ns", "incomplete")
+	_, err = provider.ReadConnection(log, &incompleteSecretRef)
+	assert.EqualError(t, err, "the following fields are missing in the directory "+filepath.Join(dir, "ns", "incomplete")+": [publicApiKey privateApiKey]")
+
+	writeCredentials(t, dir, map[string]string{"privateApiKey": "rotated"})
+	connection, err = provider.ReadConnection(log, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", connection.PrivateKey)
+}
+
+func TestCredentialsWatcher(t *testing.T) {
+	dir := t.TempDir()
+	writeCredentials(t, dir, map[string]string{"orgId": "global-org", "publicApiKey": "public", "privateApiKey": "private"})
+	writeCredentials(t, filepath.Join(dir, "ns", "project-credentials"), map[string]string{"orgId": "project-org", "publicApiKey": "public", "privateApiKey": "private"})
+
+	globalSecretRef := kube.ObjectKey("operator", "global")
+	watcher := NewCredentialsWatcher(dir, globalSecretRef, 10*time.Millisecond, zap.NewNop().Sugar())
+	events := watcher.Events()
+
+	watcher.fingerprints = watcher.scan()
+
+	nextChange := func() client.ObjectKey {
+		watcher.poll()
+		select {
+		case e := <-events:
+			return kube.ObjectKeyFromObject(e.Object)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no credentials change was notified")
+			return client.ObjectKey{}
+		}
+	}
+
+	writeCredentials(t, filepath.Join(dir, "ns", "project-credentials"), map[string]string{"privateApiKey": "rotated"})
+	assert.Equal(t, kube.ObjectKey("ns", "project-credentials"), nextChange())
+
+	writeCredentials(t, dir, map[string]string{"privateApiKey": "rotated"})
+	assert.Equal(t, globalSecretRef, nextChange())
+
+	t.Run("slow subscriber", func(t *testing.T) {
+		for i := 0; i < credentialsEventsBuffer+1; i++ {
+			writeCredentials(t, filepath.Join(dir, "ns", fmt.Sprintf("credentials-%d", i)), map[string]string{"privateApiKey": "private"})
+		}
+		watcher.poll()
+		assert.Len(t, events, credentialsEventsBuffer)
+		assert.Len(t, watcher.subscribers[0].pending, 1)
+
+		// the pending change is sent once the subscriber catches up, along with the new ones
+		for len(events) > 0 {
+			<-events
+		}
+		writeCredentials(t, filepath.Join(dir, "ns", "credentials-0"), map[string]string{"privateApiKey": "rotated"})
+		watcher.poll()
+		assert.Len(t, events, 2)
+		assert.Empty(t, watcher.subscribers[0].pending)
+	})
+}
@@ -0,0 +1,170 @@
+package atlas
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const (
+	DefaultCredentialsPollInterval = 10 * time.Second
+
+	// credentialsEventsBuffer is the number of the credentials changes a subscriber may not have received yet
+	credentialsEventsBuffer = 16
+)
+
+// CredentialsWatcher polls the credentials directory and sends an event when the credentials of a Secret reference
+// change on disk, so that the resources using them are reconciled with the rotated credentials. The event object is
+// a Secret named after the reference, the global Secret for the credentials at the top of the directory.
+// A slow subscriber doesn't block the watcher: its changes are kept once per Secret reference until it can receive them.
+// It's a manager.Runnable.
+type CredentialsWatcher struct {
+	dir             string
+	globalSecretRef client.ObjectKey
+	interval        time.Duration
+	log             *zap.SugaredLogger
+
+	subscribers  []*credentialsSubscriber
+	fingerprints map[client.ObjectKey]string
+}
+
+// credentialsSubscriber is a consumer of the changes, with the ones its channel couldn't take yet
+type credentialsSubscriber struct {
+	events  chan event.GenericEvent
+	pending map[client.ObjectKey]bool
+}
+
+func NewCredentialsWatcher(dir string, globalSecretRef client.ObjectKey, interval time.Duration, log *zap.SugaredLogger) *CredentialsWatcher {
+	return &CredentialsWatcher{
+		dir:             dir,
+		globalSecretRef: globalSecretRef,
+		interval:        interval,
+		log:             log,
+	}
+}
+
+// Events returns a new channel receiving the changes of the credentials. Supposed to be called before the watcher
+// starts, once per consumer
+func (w *CredentialsWatcher) Events() <-chan event.GenericEvent {
+	events := make(chan event.GenericEvent, credentialsEventsBuffer)
+	w.subscribers = append(w.subscribers, &credentialsSubscriber{events: events, pending: map[client.ObjectKey]bool{}})
+	return events
+}
+
+func (w *CredentialsWatcher) Start(ctx context.Context) error {
+	w.fingerprints = w.scan()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// poll sends an event for each Secret reference whose credentials were added, changed or removed since the last poll.
+// The changes are coalesced with the ones a subscriber didn't receive yet
+func (w *CredentialsWatcher) poll() {
+	fingerprints := w.scan()
+
+	var changed []client.ObjectKey
+	for secretRef, fingerprint := range fingerprints {
+		if w.fingerprints[secretRef] != fingerprint {
+			changed = append(changed, secretRef)
+		}
+	}
+	for secretRef := range w.fingerprints {
+		if _, ok := fingerprints[secretRef]; !ok {
+			changed = append(changed, secretRef)
+		}
+	}
+	w.fingerprints = fingerprints
+
+	for _, secretRef := range changed {
+		w.log.Infof("the Atlas credentials of %s changed in the directory %s", secretRef, w.dir)
+		for _, subscriber := range w.subscribers {
+			subscriber.pending[secretRef] = true
+		}
+	}
+	for _, subscriber := range w.subscribers {
+		subscriber.flush()
+	}
+}
+
+// flush sends the pending changes without blocking, the ones the channel can't take are sent on the next poll
+func (s *credentialsSubscriber) flush() {
+	for secretRef := range s.pending {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: secretRef.Namespace, Name: secretRef.Name}}
+		select {
+		case s.events <- event.GenericEvent{Object: secret}:
+			delete(s.pending, secretRef)
+		default:
+			return
+		}
+	}
+}
+
+// scan returns the fingerprints of the credentials in the directory by Secret reference
+func (w *CredentialsWatcher) scan() map[client.ObjectKey]string {
+	fingerprints := map[client.ObjectKey]string{}
+	w.addFingerprint(fingerprints, w.globalSecretRef, w.dir)
+
+	for _, namespace := range subdirectories(w.dir) {
+		for _, name := range subdirectories(filepath.Join(w.dir, namespace)) {
+			w.addFingerprint(fingerprints, client.ObjectKey{Namespace: namespace, Name: name}, filepath.Join(w.dir, namespace, name))
+		}
+	}
+
+	return fingerprints
+}
+
+func (w *CredentialsWatcher) addFingerprint(fingerprints map[client.ObjectKey]string, secretRef client.ObjectKey, dir string) {
+	data, err := readCredentialsFiles(dir)
+	if err != nil {
+		w.log.Warnf("failed to read the Atlas credentials from the directory %s: %s", dir, err)
+		return
+	}
+	if len(data) == 0 {
+		return
+	}
+
+	hash := sha256.New()
+	for _, key := range []string{orgIDKey, publicAPIKey, privateAPIKey, clientIDKey, clientSecretKey} {
+		hash.Write([]byte(key + "=" + data[key] + "\n"))
+	}
+	fingerprints[secretRef] = hex.EncodeToString(hash.Sum(nil))
+}
+
+// subdirectories returns the names of the subdirectories, following the symbolic links and skipping the hidden ones
+// like the ..data directories of the atomic writes of the mounted volumes
+func subdirectories(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(dir, entry.Name())); err == nil && info.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
@@ -0,0 +1,40 @@
+package atlas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+type FeatureCompatibilityVersion func(ctx context.Context, projectID, clusterName string) (string, error)
+
+// CustomFeatureCompatibilityVersion reads the feature compatibility version of a cluster, which is only returned by
+// the v2 Atlas Admin API
+func CustomFeatureCompatibilityVersion(client *mongodbatlas.Client) FeatureCompatibilityVersion {
+	type clusterDescription struct {
+		FeatureCompatibilityVersion string `json:"featureCompatibilityVersion"`
+	}
+
+	return func(ctx context.Context, projectID, clusterName string) (string, error) {
+		urlStr := fmt.Sprintf("/api/atlas/v2/groups/%s/clusters/%s", projectID, clusterName)
+		req, err := client.NewRequest(ctx, http.MethodGet, urlStr, nil)
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Accept", "application/vnd.atlas.2023-02-01+json")
+
+		cluster := clusterDescription{}
+		_, err = client.Do(ctx, req, &cluster)
+		if err != nil {
+			return "", err
+		}
+
+		if cluster.FeatureCompatibilityVersion == "" {
+			return "", errors.New("the feature compatibility version of the cluster wasn't returned by Atlas")
+		}
+		return cluster.FeatureCompatibilityVersion, nil
+	}
+}
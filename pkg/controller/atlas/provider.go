@@ -1,6 +1,7 @@
 package atlas
 
 import (
+	"fmt"
 	"net/url"
 	"strings"
 
@@ -14,39 +15,103 @@ import (
 
 const govAtlasDomain = "mongodbgov.com"
 
+// Provider creates the connections and the clients of the resources. The Atlas domain is the one of the connection,
+// the Operator one if the connection is nil or doesn't have a domain
 type Provider interface {
-	CreateConnection(secretRef *client.ObjectKey, log *zap.SugaredLogger) (Connection, error)
+	CreateConnection(resource ConnectionSource, log *zap.SugaredLogger) (Connection, error)
 	CreateClient(connection *Connection, log *zap.SugaredLogger, opts ...httputil.ClientOpt) (mongodbatlas.Client, error)
-	IsCloudGov() bool
-	IsResourceSupported(resource mdbv1.AtlasCustomResource) bool
+	IsCloudGov(connection *Connection) bool
+	IsResourceSupported(resource mdbv1.AtlasCustomResource, connection *Connection) bool
+	AtlasConnectionsEnabled() bool
 }
 
 type ProductionProvider struct {
-	k8sClient       client.Client
-	domain          string
-	globalSecretRef client.ObjectKey
+	k8sClient               client.Client
+	domain                  string
+	globalSecretRef         client.ObjectKey
+	credentialsDir          string
+	atlasConnectionsEnabled bool
+	requests                *requestBudgets
 }
 
-func NewProductionProvider(atlasDomain string, globalSecretRef client.ObjectKey, k8sClient client.Client) *ProductionProvider {
-	return &ProductionProvider{
+// ProviderOption configures the ProductionProvider on Operator start
+type ProviderOption func(*ProductionProvider)
+
+// WithCredentialsDir makes the provider read the Atlas credentials from the files of the directory instead of the
+// Secrets, as mounted by the Secrets Store CSI driver or the Vault agent. The global credentials are at the top of the
+// directory and the credentials a resource references as a Secret are in the <namespace>/<name> subdirectory.
+// An empty directory reads the credentials from the Secrets.
+func WithCredentialsDir(dir string) ProviderOption {
+	return func(f *ProductionProvider) {
+		f.credentialsDir = dir
+	}
+}
+
+// WithAtlasConnections allows the resources to reference AtlasConnections. Reading the cluster scoped
+// AtlasConnections requires the cluster wide permissions, so they are disabled by default.
+func WithAtlasConnections(enabled bool) ProviderOption {
+	return func(f *ProductionProvider) {
+		f.atlasConnectionsEnabled = enabled
+	}
+}
+
+// WithRequestPolicy configures retries and request budgets for all the Atlas clients the provider creates
+func WithRequestPolicy(policy RequestPolicy) ProviderOption {
+	return func(f *ProductionProvider) {
+		f.requests = newRequestBudgets(policy)
+	}
+}
+
+func NewProductionProvider(atlasDomain string, globalSecretRef client.ObjectKey, k8sClient client.Client, opts ...ProviderOption) *ProductionProvider {
+	provider := &ProductionProvider{
 		k8sClient:       k8sClient,
 		domain:          atlasDomain,
 		globalSecretRef: globalSecretRef,
+		requests:        newRequestBudgets(RequestPolicy{MaxRetries: DefaultMaxRetries}),
 	}
+	for _, opt := range opts {
+		opt(provider)
+	}
+	return provider
 }
 
-func (f *ProductionProvider) CreateConnection(secretRef *client.ObjectKey, log *zap.SugaredLogger) (Connection, error) {
-	//TODO move implementation here once all controllers are using the manager
-	return ReadConnection(log, f.k8sClient, f.globalSecretRef, secretRef)
+// CreateConnection reads the connection of the AtlasConnection the resource references, or the one of its Secret
+// as ReadConnection does otherwise
+func (f *ProductionProvider) CreateConnection(resource ConnectionSource, log *zap.SugaredLogger) (Connection, error) {
+	if name := resource.AtlasConnectionName(); name != "" {
+		if !f.atlasConnectionsEnabled {
+			return Connection{}, fmt.Errorf("can't use the AtlasConnection %s: the AtlasConnections are disabled, start the Operator with --enable-atlas-connections", name)
+		}
+		return f.readAtlasConnection(log, name, resource.GetNamespace())
+	}
+	return f.ReadConnection(log, resource.ConnectionSecretObjectKey())
 }
 
 func (f *ProductionProvider) CreateClient(connection *Connection, log *zap.SugaredLogger, opts ...httputil.ClientOpt) (mongodbatlas.Client, error) {
-	//TODO move implementation here once all controllers are using the manager
-	return Client(f.domain, *connection, log, opts...)
+	allOptions := f.requests.options(connection.OrgID)
+	allOptions = append(allOptions, opts...)
+	return Client(f.domain, *connection, log, allOptions...)
+}
+
+// AtlasConnectionsEnabled tells if the resources can reference AtlasConnections
+func (f *ProductionProvider) AtlasConnectionsEnabled() bool {
+	return f.atlasConnectionsEnabled
+}
+
+func (f *ProductionProvider) IsCloudGov(connection *Connection) bool {
+	return IsCloudGovDomain(f.domainOf(connection))
+}
+
+func (f *ProductionProvider) domainOf(connection *Connection) string {
+	if connection == nil {
+		return f.domain
+	}
+	return connection.DomainOr(f.domain)
 }
 
-func (f *ProductionProvider) IsCloudGov() bool {
-	domainURL, err := url.Parse(f.domain)
+// IsCloudGovDomain tells if the domain is the one of Atlas for Government
+func IsCloudGovDomain(domain string) bool {
+	domainURL, err := url.Parse(domain)
 	if err != nil {
 		return false
 	}
@@ -54,8 +119,8 @@ func (f *ProductionProvider) IsCloudGov() bool {
 	return strings.HasSuffix(domainURL.Hostname(), govAtlasDomain)
 }
 
-func (f *ProductionProvider) IsResourceSupported(resource mdbv1.AtlasCustomResource) bool {
-	if !f.IsCloudGov() {
+func (f *ProductionProvider) IsResourceSupported(resource mdbv1.AtlasCustomResource, connection *Connection) bool {
+	if !f.IsCloudGov(connection) {
 		return true
 	}
 
@@ -64,8 +129,14 @@ func (f *ProductionProvider) IsResourceSupported(resource mdbv1.AtlasCustomResou
 		*mdbv1.AtlasTeam,
 		*mdbv1.AtlasBackupSchedule,
 		*mdbv1.AtlasBackupPolicy,
+		*mdbv1.AtlasBackupSnapshot,
+		*mdbv1.AtlasBackupRestoreJob,
 		*mdbv1.AtlasDatabaseUser,
-		*mdbv1.AtlasFederatedAuth:
+		*mdbv1.AtlasFederatedAuth,
+		*mdbv1.AtlasIPAccessList,
+		*mdbv1.AtlasNetworkPeering,
+		*mdbv1.AtlasPrivateEndpoint,
+		*mdbv1.AtlasSearchIndex:
 		return true
 	case *mdbv1.AtlasDataFederation:
 		return false
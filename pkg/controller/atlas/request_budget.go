@@ -0,0 +1,123 @@
+package atlas
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/httputil"
+)
+
+// RequestPolicy configures how the clients created by the Operator send requests to Atlas
+type RequestPolicy struct {
+	// MaxRetries is the number of times a request failed with HTTP 429 or a server error is retried. 0 disables retries
+	MaxRetries int
+	// OrgRequestsPerSecond is the request budget shared by all the controllers for a single Atlas organization.
+	// 0 disables the budget
+	OrgRequestsPerSecond float64
+	// OrgBurst is the number of requests that can be sent at once within the organization budget
+	OrgBurst int
+	// ProjectRequestsPerSecond limits the part of the organization budget that a single project can consume,
+	// so that one noisy project doesn't starve the others. 0 means no per project limit
+	ProjectRequestsPerSecond float64
+}
+
+const (
+	DefaultMaxRetries = 3
+	DefaultOrgBurst   = 10
+)
+
+// requestBudgets applies the request policy to the clients of a provider, the organizations share a budget across
+// the clients
+type requestBudgets struct {
+	policy RequestPolicy
+
+	mu      sync.Mutex
+	budgets map[string]*requestBudget
+}
+
+func newRequestBudgets(policy RequestPolicy) *requestBudgets {
+	if policy.OrgBurst <= 0 {
+		policy.OrgBurst = DefaultOrgBurst
+	}
+	return &requestBudgets{policy: policy, budgets: map[string]*requestBudget{}}
+}
+
+// options returns the retry and rate limiting options for the client talking to the Atlas organization
+func (b *requestBudgets) options(orgID string) []httputil.ClientOpt {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// order matters: every retry must wait for the budget once again
+	opts := []httputil.ClientOpt{}
+	if b.policy.OrgRequestsPerSecond > 0 {
+		budget, ok := b.budgets[orgID]
+		if !ok {
+			budget = newRequestBudget(b.policy)
+			b.budgets[orgID] = budget
+		}
+		opts = append(opts, httputil.RateLimit(budget))
+	}
+	opts = append(opts, httputil.Retry(b.policy.MaxRetries, httputil.DefaultRetryBaseDelay, httputil.DefaultRetryMaxDelay))
+
+	return opts
+}
+
+// requestBudget is the rate limiter shared by all the clients of a single Atlas organization.
+// Each project also gets its own share of the budget, the requests not bound to a project only consume the
+// organization budget.
+type requestBudget struct {
+	org *rate.Limiter
+
+	projectRate  rate.Limit
+	projectBurst int
+	mu           sync.Mutex
+	projects     map[string]*rate.Limiter
+}
+
+func newRequestBudget(policy RequestPolicy) *requestBudget {
+	return &requestBudget{
+		org:          rate.NewLimiter(rate.Limit(policy.OrgRequestsPerSecond), policy.OrgBurst),
+		projectRate:  rate.Limit(policy.ProjectRequestsPerSecond),
+		projectBurst: max(1, int(policy.ProjectRequestsPerSecond)),
+		projects:     map[string]*rate.Limiter{},
+	}
+}
+
+func (b *requestBudget) Wait(request *http.Request) error {
+	if projectLimiter := b.projectLimiter(projectIDFromPath(request.URL.Path)); projectLimiter != nil {
+		if err := projectLimiter.Wait(request.Context()); err != nil {
+			return err
+		}
+	}
+	return b.org.Wait(request.Context())
+}
+
+func (b *requestBudget) projectLimiter(projectID string) *rate.Limiter {
+	if projectID == "" || b.projectRate <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	limiter, ok := b.projects[projectID]
+	if !ok {
+		limiter = rate.NewLimiter(b.projectRate, b.projectBurst)
+		b.projects[projectID] = limiter
+	}
+	return limiter
+}
+
+// projectIDFromPath extracts the project ID from Atlas API paths like "/api/atlas/v1.0/groups/{GROUP-ID}/clusters"
+func projectIDFromPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == "groups" {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
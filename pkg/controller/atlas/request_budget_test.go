@@ -0,0 +1,50 @@
+package atlas
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_projectIDFromPath(t *testing.T) {
+	assert.Equal(t, "5f1b", projectIDFromPath("/api/atlas/v1.0/groups/5f1b/clusters/test"))
+	assert.Equal(t, "5f1b", projectIDFromPath("/api/atlas/v1.5/groups/5f1b"))
+	assert.Equal(t, "", projectIDFromPath("/api/atlas/v1.0/orgs/5f1b/teams"))
+	assert.Equal(t, "", projectIDFromPath("/api/atlas/v1.0/groups"))
+}
+
+func Test_requestBudgetsOptions(t *testing.T) {
+	withoutBudget := newRequestBudgets(RequestPolicy{MaxRetries: 1})
+	assert.Len(t, withoutBudget.options("org"), 1)
+	assert.Empty(t, withoutBudget.budgets)
+
+	withBudget := newRequestBudgets(RequestPolicy{MaxRetries: 1, OrgRequestsPerSecond: 1})
+	assert.Len(t, withBudget.options("org"), 2)
+	withBudget.options("org")
+	withBudget.options("other-org")
+	assert.Len(t, withBudget.budgets, 2)
+	assert.Equal(t, DefaultOrgBurst, withBudget.budgets["org"].org.Burst())
+}
+
+func Test_requestBudgetIsSharedPerProject(t *testing.T) {
+	budget := newRequestBudget(RequestPolicy{OrgRequestsPerSecond: 1000, OrgBurst: 100, ProjectRequestsPerSecond: 1})
+
+	request := func(path string) *http.Request {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		t.Cleanup(cancel)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://cloud.mongodb.com"+path, nil)
+		require.NoError(t, err)
+		return req
+	}
+
+	assert.NoError(t, budget.Wait(request("/api/atlas/v1.0/groups/noisy/clusters")))
+	// the burst of the noisy project is exhausted, the request can't be sent before the deadline
+	assert.Error(t, budget.Wait(request("/api/atlas/v1.0/groups/noisy/clusters")))
+	// other projects and organization requests are not affected
+	assert.NoError(t, budget.Wait(request("/api/atlas/v1.0/groups/quiet/clusters")))
+	assert.NoError(t, budget.Wait(request("/api/atlas/v1.0/orgs/org/teams")))
+}
@@ -0,0 +1,225 @@
+package atlasbackuppolicy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// AtlasBackupPolicyReconciler reconciles an AtlasBackupPolicy object. It tracks the backup schedules referencing the
+// policy and keeps the policy from being deleted while it's referenced. The policy is applied to Atlas by the
+// AtlasBackupSchedule controller, which only plans the changes of a policy in dry-run mode.
+type AtlasBackupPolicyReconciler struct {
+	Client                  client.Client
+	Log                     *zap.SugaredLogger
+	Scheme                  *runtime.Scheme
+	GlobalPredicates        []predicate.Predicate
+	EventRecorder           record.EventRecorder
+	AtlasProvider           atlas.Provider
+	DryRun                  bool
+	MaxConcurrentReconciles int
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasbackuppolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasbackuppolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasbackuppolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasbackuppolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *AtlasBackupPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasbackuppolicy", req.NamespacedName)
+
+	bPolicy := &mdbv1.AtlasBackupPolicy{}
+	result := customresource.PrepareResource(r.Client, req, bPolicy, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if customresource.ReconciliationShouldBeSkipped(bPolicy) {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasBackupPolicy reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", bPolicy.Spec)
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	workflowCtx := customresource.MarkReconciliationStarted(r.Client, bPolicy, log, ctx)
+	log.Infow("-> Starting AtlasBackupPolicy reconciliation", "spec", bPolicy.Spec)
+	if customresource.IsDryRun(bPolicy, r.DryRun) {
+		log.Infow("-> Dry-run is enabled, the backup schedules referencing the policy only plan its changes to Atlas", "annotation", customresource.DryRunAnnotation)
+	}
+
+	defer statushandler.Update(workflowCtx, r.Client, r.EventRecorder, bPolicy)
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(workflowCtx, bPolicy, log)
+	if !resourceVersionIsValid.IsOk() {
+		log.Debugf("backup policy validation result: %v", resourceVersionIsValid)
+		return resourceVersionIsValid.ReconcileResult(), nil
+	}
+
+	if err := validate.BackupPolicy(bPolicy); err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result.ReconcileResult(), nil
+	}
+	workflowCtx.SetConditionTrue(status.ValidationSucceeded)
+
+	// the deployments check the policy against the domain of their connection as well
+	if !r.AtlasProvider.IsResourceSupported(bPolicy, nil) {
+		result = workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasBackupPolicy is not supported by Atlas for government").
+			WithoutRetry()
+		workflowCtx.SetConditionFromResult(status.BackupPolicyReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	scheduleIDs, err := r.referencingSchedules(ctx, bPolicy)
+	if err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.BackupPolicyReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	workflowCtx.EnsureStatusOption(status.AtlasBackupPolicyScheduleIDsOption(scheduleIDs))
+
+	if !bPolicy.GetDeletionTimestamp().IsZero() {
+		return r.handleDeletion(workflowCtx, bPolicy, scheduleIDs).ReconcileResult(), nil
+	}
+
+	if result = r.ensureFinalizer(ctx, bPolicy, len(scheduleIDs) > 0); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.BackupPolicyReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	workflowCtx.SetConditionTrue(status.BackupPolicyReadyType)
+	workflowCtx.SetConditionTrue(status.ReadyType)
+	return workflow.OK().ReconcileResult(), nil
+}
+
+// handleDeletion removes the finalizer of the backup policy once no backup schedule references it anymore.
+func (r *AtlasBackupPolicyReconciler) handleDeletion(workflowCtx *workflow.Context, bPolicy *mdbv1.AtlasBackupPolicy, scheduleIDs []string) workflow.Result {
+	if len(scheduleIDs) > 0 {
+		result := workflow.Terminate(
+			workflow.BackupPolicyReferenced,
+			fmt.Sprintf("the backup policy is referenced by the backup schedules %s. Remove it from all backup schedules before deleting it", strings.Join(scheduleIDs, ", ")),
+		)
+		workflowCtx.SetConditionFromResult(status.BackupPolicyReadyType, result)
+		workflowCtx.Log.Warn(result.GetMessage())
+		return result
+	}
+
+	if customresource.HaveFinalizer(bPolicy, customresource.FinalizerLabel) {
+		if err := customresource.ManageFinalizer(workflowCtx.Context, r.Client, bPolicy, customresource.UnsetFinalizer); err != nil {
+			result := workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
+			workflowCtx.SetConditionFromResult(status.BackupPolicyReadyType, result)
+			return result
+		}
+	}
+
+	return workflow.OK()
+}
+
+// ensureFinalizer sets the finalizer of the backup policy while it's referenced, so that it can't be deleted from
+// under the backup schedules.
+func (r *AtlasBackupPolicyReconciler) ensureFinalizer(ctx context.Context, bPolicy *mdbv1.AtlasBackupPolicy, referenced bool) workflow.Result {
+	if referenced == customresource.HaveFinalizer(bPolicy, customresource.FinalizerLabel) {
+		return workflow.OK()
+	}
+
+	if referenced {
+		if err := customresource.ManageFinalizer(ctx, r.Client, bPolicy, customresource.SetFinalizer); err != nil {
+			return workflow.Terminate(workflow.AtlasFinalizerNotSet, err.Error())
+		}
+		return workflow.OK()
+	}
+
+	if err := customresource.ManageFinalizer(ctx, r.Client, bPolicy, customresource.UnsetFinalizer); err != nil {
+		return workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
+	}
+	return workflow.OK()
+}
+
+// referencingSchedules returns the namespaced names of the backup schedules referencing the backup policy which are
+// used by deployments. The policy of a schedule no deployment uses can be deleted.
+func (r *AtlasBackupPolicyReconciler) referencingSchedules(ctx context.Context, bPolicy *mdbv1.AtlasBackupPolicy) ([]string, error) {
+	schedules := &mdbv1.AtlasBackupScheduleList{}
+	if err := r.Client.List(ctx, schedules); err != nil {
+		return nil, fmt.Errorf("failed to retrieve list of backup schedules: %w", err)
+	}
+
+	policyKey := kube.ObjectKeyFromObject(bPolicy)
+	var scheduleIDs []string
+	for i := range schedules.Items {
+		bSchedule := &schedules.Items[i]
+		if len(bSchedule.Status.DeploymentIDs) > 0 && *bSchedule.Spec.PolicyRef.GetObject(bSchedule.Namespace) == policyKey {
+			scheduleIDs = append(scheduleIDs, kube.ObjectKeyFromObject(bSchedule).String())
+		}
+	}
+	sort.Strings(scheduleIDs)
+
+	return scheduleIDs, nil
+}
+
+func (r *AtlasBackupPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("AtlasBackupPolicy").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		For(&mdbv1.AtlasBackupPolicy{}, builder.WithPredicates(r.GlobalPredicates...)).
+		Watches(&source.Kind{Type: &mdbv1.AtlasBackupSchedule{}}, backupScheduleHandler()).
+		Complete(r)
+}
+
+// backupScheduleHandler enqueues the backup policies referenced by a backup schedule. On updates both the previous
+// and the current policies are enqueued, so that a policy stops tracking a schedule which no longer references it or
+// which stopped being used by deployments.
+func backupScheduleHandler() handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+			enqueueBackupPolicy(e.Object, q)
+		},
+		UpdateFunc: func(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			if e.ObjectOld.GetGeneration() == e.ObjectNew.GetGeneration() && inUse(e.ObjectOld) == inUse(e.ObjectNew) {
+				return
+			}
+			enqueueBackupPolicy(e.ObjectOld, q)
+			enqueueBackupPolicy(e.ObjectNew, q)
+		},
+		DeleteFunc: func(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			enqueueBackupPolicy(e.Object, q)
+		},
+	}
+}
+
+func enqueueBackupPolicy(obj client.Object, q workqueue.RateLimitingInterface) {
+	bSchedule, ok := obj.(*mdbv1.AtlasBackupSchedule)
+	if !ok {
+		return
+	}
+
+	q.Add(reconcile.Request{NamespacedName: *bSchedule.Spec.PolicyRef.GetObject(bSchedule.Namespace)})
+}
+
+// inUse tells if the backup schedule is used by deployments
+func inUse(obj client.Object) bool {
+	bSchedule, ok := obj.(*mdbv1.AtlasBackupSchedule)
+	return ok && len(bSchedule.Status.DeploymentIDs) > 0
+}
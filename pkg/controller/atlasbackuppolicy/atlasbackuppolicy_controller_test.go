@@ -0,0 +1,146 @@
+package atlasbackuppolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/controllertest"
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+func TestReconcile(t *testing.T) {
+	t.Run("the referencing schedules are tracked", func(t *testing.T) {
+		policy := testBackupPolicy()
+		schedule1 := testBackupSchedule("schedule1", policy)
+		schedule2 := testBackupSchedule("schedule2", policy)
+		unrelated := testBackupSchedule("unrelated", &mdbv1.AtlasBackupPolicy{ObjectMeta: metav1.ObjectMeta{Name: "other-policy"}})
+		r := testReconciler(t, policy, schedule2, schedule1, unrelated)
+
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(policy)})
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+
+		endPolicy := controllertest.Read(t, r.Client, policy)
+		assert.Equal(t, []string{customresource.FinalizerLabel}, endPolicy.Finalizers)
+		assert.Equal(t, []string{controllertest.Namespace + "/schedule1", controllertest.Namespace + "/schedule2"}, endPolicy.Status.BackupScheduleIDs)
+		controllertest.AssertCondition(t, endPolicy, status.ReadyType, corev1.ConditionTrue, "")
+	})
+
+	t.Run("the schedules not used by deployments aren't tracked", func(t *testing.T) {
+		policy := testBackupPolicy()
+		policy.Finalizers = []string{customresource.FinalizerLabel}
+		unused := testBackupSchedule("unused", policy)
+		unused.Status.DeploymentIDs = nil
+		r := testReconciler(t, policy, testBackupSchedule("schedule1", policy), unused)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(policy)})
+		require.NoError(t, err)
+
+		endPolicy := controllertest.Read(t, r.Client, policy)
+		assert.Equal(t, []string{customresource.FinalizerLabel}, endPolicy.Finalizers)
+		assert.Equal(t, []string{controllertest.Namespace + "/schedule1"}, endPolicy.Status.BackupScheduleIDs)
+	})
+
+	t.Run("the finalizer is removed once the policy isn't referenced", func(t *testing.T) {
+		policy := testBackupPolicy()
+		policy.Finalizers = []string{customresource.FinalizerLabel}
+		policy.Status.BackupScheduleIDs = []string{controllertest.Namespace + "/removed"}
+		r := testReconciler(t, policy)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(policy)})
+		require.NoError(t, err)
+
+		endPolicy := controllertest.Read(t, r.Client, policy)
+		assert.Empty(t, endPolicy.Finalizers)
+		assert.Empty(t, endPolicy.Status.BackupScheduleIDs)
+	})
+
+	t.Run("the deletion is blocked while the policy is referenced", func(t *testing.T) {
+		policy := testBackupPolicy()
+		policy.Finalizers = []string{customresource.FinalizerLabel}
+		policy.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		r := testReconciler(t, policy, testBackupSchedule("schedule1", policy))
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(policy)})
+		require.NoError(t, err)
+
+		endPolicy := controllertest.Read(t, r.Client, policy)
+		assert.Equal(t, []string{customresource.FinalizerLabel}, endPolicy.Finalizers)
+		controllertest.AssertCondition(t, endPolicy, status.BackupPolicyReadyType, corev1.ConditionFalse, string(workflow.BackupPolicyReferenced))
+	})
+
+	t.Run("the deletion proceeds once the policy isn't referenced", func(t *testing.T) {
+		policy := testBackupPolicy()
+		policy.Finalizers = []string{customresource.FinalizerLabel}
+		policy.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		r := testReconciler(t, policy)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(policy)})
+		require.NoError(t, err)
+
+		err = r.Client.Get(context.Background(), kube.ObjectKeyFromObject(policy), &mdbv1.AtlasBackupPolicy{})
+		assert.True(t, apiErrors.IsNotFound(err), "the policy should be deleted once the finalizer is removed")
+	})
+
+	t.Run("an invalid policy is reported", func(t *testing.T) {
+		policy := testBackupPolicy()
+		policy.Spec.Items[0].RetentionValue = 0
+		r := testReconciler(t, policy)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(policy)})
+		require.NoError(t, err)
+
+		endPolicy := controllertest.Read(t, r.Client, policy)
+		controllertest.AssertCondition(t, endPolicy, status.ValidationSucceeded, corev1.ConditionFalse, string(workflow.Internal))
+	})
+}
+
+func testReconciler(t *testing.T, objects ...client.Object) *AtlasBackupPolicyReconciler {
+	t.Helper()
+
+	return &AtlasBackupPolicyReconciler{
+		Client:        controllertest.NewKubeClient(objects...),
+		Log:           zaptest.NewLogger(t).Sugar(),
+		EventRecorder: record.NewFakeRecorder(10),
+		AtlasProvider: controllertest.NewProvider(mongodbatlas.Client{}),
+	}
+}
+
+func testBackupSchedule(name string, policy *mdbv1.AtlasBackupPolicy) *mdbv1.AtlasBackupSchedule {
+	return &mdbv1.AtlasBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: controllertest.Namespace},
+		Spec: mdbv1.AtlasBackupScheduleSpec{
+			PolicyRef: common.ResourceRefNamespaced{Name: policy.Name},
+		},
+		Status: status.BackupScheduleStatus{
+			DeploymentIDs: []string{"test-deployment-id"},
+		},
+	}
+}
+
+func testBackupPolicy() *mdbv1.AtlasBackupPolicy {
+	return &mdbv1.AtlasBackupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-policy", Namespace: controllertest.Namespace},
+		Spec: mdbv1.AtlasBackupPolicySpec{
+			Items: []mdbv1.AtlasBackupPolicyItem{
+				{FrequencyType: "weekly", FrequencyInterval: 1, RetentionUnit: "days", RetentionValue: 7},
+			},
+		},
+	}
+}
@@ -0,0 +1,229 @@
+package atlasbackupschedule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/watch"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// AtlasBackupScheduleReconciler reconciles an AtlasBackupSchedule object. It applies the backup schedule to every
+// deployment referencing it and keeps the schedule from being deleted while it's referenced.
+type AtlasBackupScheduleReconciler struct {
+	Client                  client.Client
+	Log                     *zap.SugaredLogger
+	Scheme                  *runtime.Scheme
+	GlobalPredicates        []predicate.Predicate
+	EventRecorder           record.EventRecorder
+	AtlasProvider           atlas.Provider
+	DryRun                  bool
+	MaxConcurrentReconciles int
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasbackupschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasbackupschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasbackupschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasbackupschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasdeployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasdeployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *AtlasBackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasbackupschedule", req.NamespacedName)
+
+	bSchedule := &mdbv1.AtlasBackupSchedule{}
+	result := customresource.PrepareResource(r.Client, req, bSchedule, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if customresource.ReconciliationShouldBeSkipped(bSchedule) {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasBackupSchedule reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", bSchedule.Spec)
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	workflowCtx := customresource.MarkReconciliationStarted(r.Client, bSchedule, log, ctx)
+	log.Infow("-> Starting AtlasBackupSchedule reconciliation", "spec", bSchedule.Spec)
+	plan := dryrun.PlanFor(bSchedule, r.DryRun, log)
+	defer func() {
+		workflowCtx.EnsureStatusOption(status.AtlasBackupSchedulePlannedChangesOption(plan.Changes()))
+		plan.Emit(r.EventRecorder, bSchedule)
+		statushandler.Update(workflowCtx, r.Client, r.EventRecorder, bSchedule)
+	}()
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(workflowCtx, bSchedule, log)
+	if !resourceVersionIsValid.IsOk() {
+		log.Debugf("backup schedule validation result: %v", resourceVersionIsValid)
+		return resourceVersionIsValid.ReconcileResult(), nil
+	}
+
+	if err := validate.BackupSchedule(bSchedule, nil); err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result.ReconcileResult(), nil
+	}
+	workflowCtx.SetConditionTrue(status.ValidationSucceeded)
+
+	// the schedule is checked against the domain of the connection of each deployment as well
+	if !r.AtlasProvider.IsResourceSupported(bSchedule, nil) {
+		result = workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasBackupSchedule is not supported by Atlas for government").
+			WithoutRetry()
+		workflowCtx.SetConditionFromResult(status.BackupScheduleReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	deployments, err := r.referencingDeployments(ctx, bSchedule)
+	if err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.BackupScheduleReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if !bSchedule.GetDeletionTimestamp().IsZero() {
+		return r.handleDeletion(workflowCtx, bSchedule, deployments).ReconcileResult(), nil
+	}
+
+	if result = r.ensureFinalizer(ctx, bSchedule, len(deployments) > 0); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.BackupScheduleReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if len(deployments) == 0 {
+		workflowCtx.EnsureStatusOption(status.AtlasBackupScheduleDeploymentsOption(nil))
+		workflowCtx.SetConditionTrue(status.BackupScheduleReadyType)
+		workflowCtx.SetConditionTrue(status.ReadyType)
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	bPolicy, result := r.readBackupPolicy(ctx, bSchedule)
+	if !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.BackupScheduleReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	result = r.applyToDeployments(workflowCtx, bSchedule, bPolicy, deployments, plan)
+	if !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.BackupScheduleReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	workflowCtx.SetConditionTrue(status.BackupScheduleReadyType)
+	workflowCtx.SetConditionTrue(status.ReadyType)
+	return workflow.OK().ReconcileResult(), nil
+}
+
+// handleDeletion removes the finalizer of the backup schedule once no deployment references it anymore.
+func (r *AtlasBackupScheduleReconciler) handleDeletion(workflowCtx *workflow.Context, bSchedule *mdbv1.AtlasBackupSchedule, deployments []mdbv1.AtlasDeployment) workflow.Result {
+	if len(deployments) > 0 {
+		result := workflow.Terminate(
+			workflow.BackupScheduleReferenced,
+			fmt.Sprintf("the backup schedule is referenced by the deployments %s. Remove it from all deployments before deleting it", strings.Join(resourceNames(deployments), ", ")),
+		)
+		workflowCtx.SetConditionFromResult(status.BackupScheduleReadyType, result)
+		workflowCtx.Log.Warn(result.GetMessage())
+		return result
+	}
+
+	if customresource.HaveFinalizer(bSchedule, customresource.FinalizerLabel) {
+		if err := customresource.ManageFinalizer(workflowCtx.Context, r.Client, bSchedule, customresource.UnsetFinalizer); err != nil {
+			result := workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
+			workflowCtx.SetConditionFromResult(status.BackupScheduleReadyType, result)
+			return result
+		}
+	}
+
+	return workflow.OK()
+}
+
+// ensureFinalizer sets the finalizer of the backup schedule while it's referenced, so that it can't be deleted
+// from under the deployments.
+func (r *AtlasBackupScheduleReconciler) ensureFinalizer(ctx context.Context, bSchedule *mdbv1.AtlasBackupSchedule, referenced bool) workflow.Result {
+	if referenced == customresource.HaveFinalizer(bSchedule, customresource.FinalizerLabel) {
+		return workflow.OK()
+	}
+
+	if referenced {
+		if err := customresource.ManageFinalizer(ctx, r.Client, bSchedule, customresource.SetFinalizer); err != nil {
+			return workflow.Terminate(workflow.AtlasFinalizerNotSet, err.Error())
+		}
+		return workflow.OK()
+	}
+
+	if err := customresource.ManageFinalizer(ctx, r.Client, bSchedule, customresource.UnsetFinalizer); err != nil {
+		return workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
+	}
+	return workflow.OK()
+}
+
+func (r *AtlasBackupScheduleReconciler) readBackupPolicy(ctx context.Context, bSchedule *mdbv1.AtlasBackupSchedule) (*mdbv1.AtlasBackupPolicy, workflow.Result) {
+	bPolicyRef := *bSchedule.Spec.PolicyRef.GetObject(bSchedule.Namespace)
+	bPolicy := &mdbv1.AtlasBackupPolicy{}
+	if err := r.Client.Get(ctx, bPolicyRef, bPolicy); err != nil {
+		return nil, workflow.Terminate(workflow.BackupPolicyNotFound, fmt.Sprintf("unable to get AtlasBackupPolicy resource %s: %s", bPolicyRef, err))
+	}
+
+	if err := validate.BackupPolicy(bPolicy); err != nil {
+		return nil, workflow.Terminate(workflow.BackupScheduleNotApplied, fmt.Sprintf("the AtlasBackupPolicy %s is invalid: %s", bPolicyRef, err))
+	}
+
+	return bPolicy, workflow.OK()
+}
+
+// referencingDeployments returns the deployments referencing the backup schedule which aren't being deleted
+func (r *AtlasBackupScheduleReconciler) referencingDeployments(ctx context.Context, bSchedule *mdbv1.AtlasBackupSchedule) ([]mdbv1.AtlasDeployment, error) {
+	deployments := &mdbv1.AtlasDeploymentList{}
+	if err := r.Client.List(ctx, deployments); err != nil {
+		return nil, fmt.Errorf("failed to retrieve list of deployments: %w", err)
+	}
+
+	scheduleKey := kube.ObjectKeyFromObject(bSchedule)
+	referencing := make([]mdbv1.AtlasDeployment, 0, len(deployments.Items))
+	for _, deployment := range deployments.Items {
+		if !deployment.GetDeletionTimestamp().IsZero() || deployment.Spec.BackupScheduleRef.Name == "" {
+			continue
+		}
+		if *deployment.Spec.BackupScheduleRef.GetObject(deployment.Namespace) == scheduleKey {
+			referencing = append(referencing, deployment)
+		}
+	}
+
+	return referencing, nil
+}
+
+func (r *AtlasBackupScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("AtlasBackupSchedule").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		For(&mdbv1.AtlasBackupSchedule{}, builder.WithPredicates(r.GlobalPredicates...)).
+		Watches(&source.Kind{Type: &mdbv1.AtlasBackupPolicy{}}, r.backupPolicyHandler(), builder.WithPredicates(watch.CommonPredicates())).
+		Watches(&source.Kind{Type: &mdbv1.AtlasDeployment{}}, deploymentHandler(), builder.WithPredicates(r.GlobalPredicates...)).
+		Complete(r)
+}
+
+func resourceNames(deployments []mdbv1.AtlasDeployment) []string {
+	names := make([]string, 0, len(deployments))
+	for i := range deployments {
+		names = append(names, kube.ObjectKeyFromObject(&deployments[i]).String())
+	}
+	return names
+}
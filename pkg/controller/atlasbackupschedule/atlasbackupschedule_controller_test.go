@@ -0,0 +1,270 @@
+package atlasbackupschedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/controllertest"
+	atlas_mock "github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/mocks/atlas"
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+func TestReconcile(t *testing.T) {
+	t.Run("the schedule is applied to every deployment referencing it", func(t *testing.T) {
+		policy := testBackupPolicy()
+		schedule := testBackupSchedule(policy)
+		deployment1 := testDeployment("deployment1", schedule)
+		deployment2 := testDeployment("deployment2", schedule)
+		unrelated := testDeployment("unrelated", schedule)
+		unrelated.Spec.BackupScheduleRef = common.ResourceRefNamespaced{}
+		backupClient := testBackupClient()
+		r := testReconciler(t, backupClient, controllertest.Project(), policy, schedule, deployment1, deployment2, unrelated)
+
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(schedule)})
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+
+		assert.Len(t, backupClient.UpdateRequests, 2)
+		assert.Contains(t, backupClient.UpdateRequests, controllertest.ProjectID+".deployment1")
+		assert.Contains(t, backupClient.UpdateRequests, controllertest.ProjectID+".deployment2")
+
+		endSchedule := controllertest.Read(t, r.Client, schedule)
+		assert.Equal(t, []string{customresource.FinalizerLabel}, endSchedule.Finalizers)
+		assert.Equal(t, []status.BackupScheduleDeployment{
+			{Resource: controllertest.Namespace + "/deployment1", DeploymentName: "deployment1", Applied: true},
+			{Resource: controllertest.Namespace + "/deployment2", DeploymentName: "deployment2", Applied: true},
+		}, endSchedule.Status.Deployments)
+		assert.Equal(t, []string{"deployment1", "deployment2"}, endSchedule.Status.DeploymentIDs)
+		controllertest.AssertCondition(t, endSchedule, status.BackupScheduleReadyType, corev1.ConditionTrue, "")
+		controllertest.AssertCondition(t, endSchedule, status.ReadyType, corev1.ConditionTrue, "")
+	})
+
+	t.Run("the apply status is reported per deployment", func(t *testing.T) {
+		policy := testBackupPolicy()
+		schedule := testBackupSchedule(policy)
+		applied := testDeployment("applied", schedule)
+		disabled := testDeployment("disabled", schedule)
+		disabled.Spec.DeploymentSpec.BackupEnabled = toptr.MakePtr(false)
+		failing := testDeployment("failing", schedule)
+		backupClient := testBackupClient()
+		backupClient.UpdateFunc = func(projectID string, clusterName string, backup *mongodbatlas.CloudProviderSnapshotBackupPolicy) (*mongodbatlas.CloudProviderSnapshotBackupPolicy, *mongodbatlas.Response, error) {
+			if clusterName == "failing" {
+				return nil, nil, errors.New("atlas is unavailable")
+			}
+			return backup, nil, nil
+		}
+		r := testReconciler(t, backupClient, controllertest.Project(), policy, schedule, applied, disabled, failing)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(schedule)})
+		require.NoError(t, err)
+
+		endSchedule := controllertest.Read(t, r.Client, schedule)
+		require.Len(t, endSchedule.Status.Deployments, 3)
+		assert.True(t, endSchedule.Status.Deployments[0].Applied)
+		assert.False(t, endSchedule.Status.Deployments[1].Applied)
+		assert.Equal(t, "backups are not enabled for the deployment", endSchedule.Status.Deployments[1].Message)
+		assert.False(t, endSchedule.Status.Deployments[2].Applied)
+		assert.Contains(t, endSchedule.Status.Deployments[2].Message, "atlas is unavailable")
+		controllertest.AssertCondition(t, endSchedule, status.BackupScheduleReadyType, corev1.ConditionFalse, string(workflow.BackupScheduleNotApplied))
+	})
+
+	t.Run("the finalizer is removed once the schedule isn't referenced", func(t *testing.T) {
+		policy := testBackupPolicy()
+		schedule := testBackupSchedule(policy)
+		schedule.Finalizers = []string{customresource.FinalizerLabel}
+		backupClient := testBackupClient()
+		r := testReconciler(t, backupClient, controllertest.Project(), policy, schedule)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(schedule)})
+		require.NoError(t, err)
+
+		endSchedule := controllertest.Read(t, r.Client, schedule)
+		assert.Empty(t, endSchedule.Finalizers)
+		assert.Empty(t, endSchedule.Status.Deployments)
+		assert.Empty(t, backupClient.UpdateRequests)
+		controllertest.AssertCondition(t, endSchedule, status.ReadyType, corev1.ConditionTrue, "")
+	})
+
+	t.Run("the schedule is applied to a deployment without a policy in Atlas", func(t *testing.T) {
+		policy := testBackupPolicy()
+		schedule := testBackupSchedule(policy)
+		deployment := testDeployment("deployment1", schedule)
+		backupClient := testBackupClient()
+		backupClient.GetFunc = func(projectID string, clusterName string) (*mongodbatlas.CloudProviderSnapshotBackupPolicy, *mongodbatlas.Response, error) {
+			return &mongodbatlas.CloudProviderSnapshotBackupPolicy{ClusterID: "123789", ClusterName: clusterName}, nil, nil
+		}
+		r := testReconciler(t, backupClient, controllertest.Project(), policy, schedule, deployment)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(schedule)})
+		require.NoError(t, err)
+
+		assert.Len(t, backupClient.UpdateRequests, 1)
+		assert.Contains(t, backupClient.UpdateRequests, controllertest.ProjectID+".deployment1")
+		endSchedule := controllertest.Read(t, r.Client, schedule)
+		controllertest.AssertCondition(t, endSchedule, status.BackupScheduleReadyType, corev1.ConditionTrue, "")
+	})
+
+	t.Run("the schedule is released once its last deployment is deleted", func(t *testing.T) {
+		policy := testBackupPolicy()
+		schedule := testBackupSchedule(policy)
+		schedule.Finalizers = []string{customresource.FinalizerLabel}
+		schedule.Status.DeploymentIDs = []string{"deployment1"}
+		deleted := testDeployment("deployment1", schedule)
+		deleted.Finalizers = []string{customresource.FinalizerLabel}
+		deleted.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		backupClient := testBackupClient()
+		r := testReconciler(t, backupClient, controllertest.Project(), policy, schedule, deleted)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(schedule)})
+		require.NoError(t, err)
+
+		endSchedule := controllertest.Read(t, r.Client, schedule)
+		assert.Empty(t, endSchedule.Finalizers, "schedule should end up with no finalizer")
+		assert.Empty(t, endSchedule.Status.DeploymentIDs, "the policy is released once the schedule isn't used by deployments")
+		assert.Empty(t, backupClient.UpdateRequests)
+	})
+
+	t.Run("the schedule is kept while another deployment references it", func(t *testing.T) {
+		policy := testBackupPolicy()
+		schedule := testBackupSchedule(policy)
+		schedule.Finalizers = []string{customresource.FinalizerLabel}
+		schedule.Status.DeploymentIDs = []string{"deployment1", "deployment2"}
+		deleted := testDeployment("deployment1", schedule)
+		deleted.Finalizers = []string{customresource.FinalizerLabel}
+		deleted.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		remaining := testDeployment("deployment2", schedule)
+		r := testReconciler(t, testBackupClient(), controllertest.Project(), policy, schedule, deleted, remaining)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(schedule)})
+		require.NoError(t, err)
+
+		endSchedule := controllertest.Read(t, r.Client, schedule)
+		assert.Equal(t, []string{customresource.FinalizerLabel}, endSchedule.Finalizers, "schedule should keep the finalizer")
+		assert.Equal(t, []string{"deployment2"}, endSchedule.Status.DeploymentIDs)
+	})
+
+	t.Run("the deletion is blocked while the schedule is referenced", func(t *testing.T) {
+		policy := testBackupPolicy()
+		schedule := testBackupSchedule(policy)
+		schedule.Finalizers = []string{customresource.FinalizerLabel}
+		schedule.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		deployment := testDeployment("deployment1", schedule)
+		backupClient := testBackupClient()
+		r := testReconciler(t, backupClient, controllertest.Project(), policy, schedule, deployment)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(schedule)})
+		require.NoError(t, err)
+
+		endSchedule := controllertest.Read(t, r.Client, schedule)
+		assert.Equal(t, []string{customresource.FinalizerLabel}, endSchedule.Finalizers)
+		controllertest.AssertCondition(t, endSchedule, status.BackupScheduleReadyType, corev1.ConditionFalse, string(workflow.BackupScheduleReferenced))
+		assert.Empty(t, backupClient.UpdateRequests)
+	})
+
+	t.Run("the deletion proceeds once the schedule isn't referenced", func(t *testing.T) {
+		policy := testBackupPolicy()
+		schedule := testBackupSchedule(policy)
+		schedule.Finalizers = []string{customresource.FinalizerLabel}
+		schedule.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		deleted := testDeployment("deployment1", schedule)
+		deleted.Finalizers = []string{customresource.FinalizerLabel}
+		deleted.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		r := testReconciler(t, testBackupClient(), controllertest.Project(), policy, schedule, deleted)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(schedule)})
+		require.NoError(t, err)
+
+		err = r.Client.Get(context.Background(), kube.ObjectKeyFromObject(schedule), &mdbv1.AtlasBackupSchedule{})
+		assert.True(t, apiErrors.IsNotFound(err), "the schedule should be deleted once the finalizer is removed")
+	})
+
+	t.Run("a missing policy is reported", func(t *testing.T) {
+		schedule := testBackupSchedule(testBackupPolicy())
+		deployment := testDeployment("deployment1", schedule)
+		r := testReconciler(t, testBackupClient(), controllertest.Project(), schedule, deployment)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(schedule)})
+		require.NoError(t, err)
+
+		endSchedule := controllertest.Read(t, r.Client, schedule)
+		controllertest.AssertCondition(t, endSchedule, status.BackupScheduleReadyType, corev1.ConditionFalse, string(workflow.BackupPolicyNotFound))
+	})
+}
+
+func testReconciler(t *testing.T, backupClient *atlas_mock.CloudProviderSnapshotBackupPoliciesClientMock, objects ...client.Object) *AtlasBackupScheduleReconciler {
+	t.Helper()
+
+	return &AtlasBackupScheduleReconciler{
+		Client:        controllertest.NewKubeClient(objects...),
+		Log:           zaptest.NewLogger(t).Sugar(),
+		EventRecorder: record.NewFakeRecorder(10),
+		AtlasProvider: controllertest.NewProvider(mongodbatlas.Client{CloudProviderSnapshotBackupPolicies: backupClient}),
+	}
+}
+
+func testBackupClient() *atlas_mock.CloudProviderSnapshotBackupPoliciesClientMock {
+	return &atlas_mock.CloudProviderSnapshotBackupPoliciesClientMock{
+		GetFunc: func(projectID string, clusterName string) (*mongodbatlas.CloudProviderSnapshotBackupPolicy, *mongodbatlas.Response, error) {
+			return &mongodbatlas.CloudProviderSnapshotBackupPolicy{
+				ClusterID:          "123789",
+				ClusterName:        clusterName,
+				ReferenceHourOfDay: toptr.MakePtr(int64(1)),
+				Policies:           []mongodbatlas.Policy{{ID: "456987"}},
+			}, nil, nil
+		},
+		UpdateFunc: func(projectID string, clusterName string, backup *mongodbatlas.CloudProviderSnapshotBackupPolicy) (*mongodbatlas.CloudProviderSnapshotBackupPolicy, *mongodbatlas.Response, error) {
+			return backup, nil, nil
+		},
+	}
+}
+
+func testDeployment(name string, schedule *mdbv1.AtlasBackupSchedule) *mdbv1.AtlasDeployment {
+	deployment := mdbv1.DefaultAwsAdvancedDeployment(controllertest.Namespace, controllertest.ProjectName)
+	deployment.Name = name
+	deployment.Spec.DeploymentSpec.Name = name
+	deployment.Spec.DeploymentSpec.BackupEnabled = toptr.MakePtr(true)
+	deployment.Spec.BackupScheduleRef = common.ResourceRefNamespaced{Name: schedule.Name, Namespace: schedule.Namespace}
+	deployment.Status.StateName = "IDLE"
+	return deployment
+}
+
+func testBackupSchedule(policy *mdbv1.AtlasBackupPolicy) *mdbv1.AtlasBackupSchedule {
+	return &mdbv1.AtlasBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-schedule", Namespace: controllertest.Namespace},
+		Spec: mdbv1.AtlasBackupScheduleSpec{
+			PolicyRef:             common.ResourceRefNamespaced{Name: policy.Name, Namespace: policy.Namespace},
+			ReferenceHourOfDay:    20,
+			ReferenceMinuteOfHour: 30,
+			RestoreWindowDays:     7,
+		},
+	}
+}
+
+func testBackupPolicy() *mdbv1.AtlasBackupPolicy {
+	return &mdbv1.AtlasBackupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-policy", Namespace: controllertest.Namespace},
+		Spec: mdbv1.AtlasBackupPolicySpec{
+			Items: []mdbv1.AtlasBackupPolicyItem{
+				{FrequencyType: "weekly", FrequencyInterval: 1, RetentionUnit: "days", RetentionValue: 7},
+			},
+		},
+	}
+}
@@ -0,0 +1,212 @@
+package atlasbackupschedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/compat"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// applyToDeployments applies the backup schedule to each deployment and records the outcome in the status. A
+// failure for one deployment doesn't prevent the schedule from being applied to the others. The changes are only
+// planned for a deployment if the schedule, its policy or the deployment itself is in dry-run mode: the changes
+// planned because of the policy or the deployment are reported as events of the deployment.
+func (r *AtlasBackupScheduleReconciler) applyToDeployments(
+	workflowCtx *workflow.Context,
+	bSchedule *mdbv1.AtlasBackupSchedule,
+	bPolicy *mdbv1.AtlasBackupPolicy,
+	deployments []mdbv1.AtlasDeployment,
+	plan *dryrun.Plan,
+) workflow.Result {
+	deploymentStatuses := make([]status.BackupScheduleDeployment, 0, len(deployments))
+	failed := 0
+	for i := range deployments {
+		deployment := &deployments[i]
+		deploymentStatus := status.BackupScheduleDeployment{
+			Resource:       kube.ObjectKeyFromObject(deployment).String(),
+			DeploymentName: deployment.GetDeploymentName(),
+		}
+
+		deploymentPlan := plan
+		if deploymentPlan == nil && (customresource.IsDryRun(bPolicy, r.DryRun) || customresource.IsDryRun(deployment, r.DryRun)) {
+			deploymentPlan = dryrun.NewPlan()
+		}
+
+		err := r.applyToDeployment(workflowCtx, bSchedule, bPolicy, deployment, deploymentPlan)
+		switch {
+		case err != nil:
+			workflowCtx.Log.Errorw("failed to apply the backup schedule", "deployment", deploymentStatus.Resource, "error", err)
+			deploymentStatus.Message = err.Error()
+			failed++
+		case deploymentPlan != nil:
+			deploymentStatus.Message = "dry-run mode: the changes to the backup schedule are only planned"
+		default:
+			deploymentStatus.Applied = true
+		}
+		if deploymentPlan != plan {
+			deploymentPlan.Emit(r.EventRecorder, deployment)
+		}
+		deploymentStatuses = append(deploymentStatuses, deploymentStatus)
+	}
+	workflowCtx.EnsureStatusOption(status.AtlasBackupScheduleDeploymentsOption(deploymentStatuses))
+
+	if failed > 0 {
+		return workflow.Terminate(
+			workflow.BackupScheduleNotApplied,
+			fmt.Sprintf("the backup schedule is not applied to %d of %d deployments", failed, len(deployments)),
+		)
+	}
+	return workflow.OK()
+}
+
+func (r *AtlasBackupScheduleReconciler) applyToDeployment(
+	workflowCtx *workflow.Context,
+	bSchedule *mdbv1.AtlasBackupSchedule,
+	bPolicy *mdbv1.AtlasBackupPolicy,
+	deployment *mdbv1.AtlasDeployment,
+	plan *dryrun.Plan,
+) error {
+	if !deployment.IsAdvancedDeployment() {
+		return errors.New("backup schedules can only be applied to advanced deployments")
+	}
+
+	if deployment.Spec.DeploymentSpec.BackupEnabled == nil || !*deployment.Spec.DeploymentSpec.BackupEnabled {
+		return errors.New("backups are not enabled for the deployment")
+	}
+
+	if deployment.Status.StateName == "" {
+		return errors.New("the deployment is not created in Atlas yet")
+	}
+
+	if err := validate.BackupSchedule(bSchedule, deployment); err != nil {
+		return err
+	}
+
+	project := &mdbv1.AtlasProject{}
+	if err := r.Client.Get(workflowCtx.Context, deployment.AtlasProjectObjectKey(), project); err != nil {
+		return fmt.Errorf("unable to get the project of the deployment: %w", err)
+	}
+	if project.ID() == "" {
+		return errors.New("the project of the deployment is not created in Atlas yet")
+	}
+
+	connection, err := r.AtlasProvider.CreateConnection(project, workflowCtx.Log)
+	if err != nil {
+		return err
+	}
+
+	if !r.AtlasProvider.IsResourceSupported(bSchedule, &connection) {
+		return errors.New("the AtlasBackupSchedule is not supported by Atlas for government")
+	}
+
+	atlasClient, err := r.AtlasProvider.CreateClient(&connection, workflowCtx.Log, plan.ClientOpts()...)
+	if err != nil {
+		return err
+	}
+
+	return applyBackupSchedule(workflowCtx.Context, atlasClient, project.ID(), deployment, bSchedule, bPolicy, workflowCtx.Log)
+}
+
+// applyBackupSchedule updates the backup schedule of the deployment in Atlas unless it's already up to date.
+func applyBackupSchedule(
+	ctx context.Context,
+	atlasClient mongodbatlas.Client,
+	projectID string,
+	deployment *mdbv1.AtlasDeployment,
+	bSchedule *mdbv1.AtlasBackupSchedule,
+	bPolicy *mdbv1.AtlasBackupPolicy,
+	log *zap.SugaredLogger,
+) error {
+	clusterName := deployment.GetDeploymentName()
+	currentSchedule, response, err := atlasClient.CloudProviderSnapshotBackupPolicies.Get(ctx, projectID, clusterName)
+	if err != nil {
+		errMessage := "unable to get current backup configuration for project"
+		log.Debugf("%s: %s:%s, %v", errMessage, projectID, clusterName, err)
+		return fmt.Errorf("%s: %s:%s, %w", errMessage, projectID, clusterName, err)
+	}
+
+	if currentSchedule == nil && response != nil {
+		return fmt.Errorf("can not get сurrent backup configuration. response status: %s", response.Status)
+	}
+
+	log.Debugf("successfully received backup configuration: %v", currentSchedule)
+
+	log.Debugf("updating backup configuration for the atlas deployment: %v", clusterName)
+
+	apiScheduleReq := bSchedule.ToAtlas(currentSchedule.ClusterID, clusterName, deployment.GetReplicationSetID(), bPolicy)
+
+	// There is only one policy, always
+	if len(apiScheduleReq.Policies) > 0 && len(currentSchedule.Policies) > 0 {
+		apiScheduleReq.Policies[0].ID = currentSchedule.Policies[0].ID
+	}
+
+	equal, err := backupSchedulesAreEqual(currentSchedule, apiScheduleReq)
+	if err != nil {
+		return fmt.Errorf("can not compare BackupSchedule resources: %w", err)
+	}
+
+	if equal {
+		log.Debug("backup schedules are equal, nothing to change")
+		return nil
+	}
+
+	log.Debugf("applying backup configuration: %v", *bSchedule)
+	if _, _, err := atlasClient.CloudProviderSnapshotBackupPolicies.Update(ctx, projectID, clusterName, apiScheduleReq); err != nil {
+		return fmt.Errorf("unable to create backup schedule %s. e: %w", client.ObjectKeyFromObject(bSchedule).String(), err)
+	}
+	log.Infof("successfully updated backup configuration for deployment %v", clusterName)
+	return nil
+}
+
+func backupSchedulesAreEqual(currentSchedule *mongodbatlas.CloudProviderSnapshotBackupPolicy, newSchedule *mongodbatlas.CloudProviderSnapshotBackupPolicy) (bool, error) {
+	currentCopy := mongodbatlas.CloudProviderSnapshotBackupPolicy{}
+	err := compat.JSONCopy(&currentCopy, currentSchedule)
+	if err != nil {
+		return false, err
+	}
+
+	newCopy := mongodbatlas.CloudProviderSnapshotBackupPolicy{}
+	err = compat.JSONCopy(&newCopy, newSchedule)
+	if err != nil {
+		return false, err
+	}
+
+	normalizeBackupSchedule(&currentCopy)
+	normalizeBackupSchedule(&newCopy)
+	d := cmp.Diff(&currentCopy, &newCopy, cmpopts.EquateEmpty())
+	if d != "" {
+		return false, nil
+	}
+	return true, nil
+}
+
+func normalizeBackupSchedule(s *mongodbatlas.CloudProviderSnapshotBackupPolicy) {
+	s.Links = nil
+	s.NextSnapshot = ""
+	if len(s.Policies) > 0 && len(s.Policies[0].PolicyItems) > 0 {
+		for i := range s.Policies[0].PolicyItems {
+			s.Policies[0].PolicyItems[i].ID = ""
+		}
+	}
+	s.UpdateSnapshots = nil
+
+	if len(s.CopySettings) > 0 {
+		for i := range s.CopySettings {
+			s.CopySettings[i].ReplicationSpecID = nil
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package atlasbackupschedule
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// deploymentHandler enqueues the backup schedules referenced by a deployment. On updates both the previous and the
+// current backup schedules are enqueued, so that a schedule stops tracking a deployment which no longer references it.
+// The status updates of the deployments are filtered out by the predicates of the watch: the schedules retry on their
+// own until the deployments are created in Atlas.
+func deploymentHandler() handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+			enqueueBackupSchedule(e.Object, q)
+		},
+		UpdateFunc: func(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			enqueueBackupSchedule(e.ObjectOld, q)
+			enqueueBackupSchedule(e.ObjectNew, q)
+		},
+		DeleteFunc: func(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			enqueueBackupSchedule(e.Object, q)
+		},
+	}
+}
+
+func enqueueBackupSchedule(obj client.Object, q workqueue.RateLimitingInterface) {
+	deployment, ok := obj.(*mdbv1.AtlasDeployment)
+	if !ok || deployment.Spec.BackupScheduleRef.Name == "" {
+		return
+	}
+
+	q.Add(reconcile.Request{NamespacedName: *deployment.Spec.BackupScheduleRef.GetObject(deployment.Namespace)})
+}
+
+// backupPolicyHandler enqueues the backup schedules referencing a backup policy, so that the changes of the policy
+// are applied to every deployment using it.
+func (r *AtlasBackupScheduleReconciler) backupPolicyHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		schedules := &mdbv1.AtlasBackupScheduleList{}
+		if err := r.Client.List(context.Background(), schedules); err != nil {
+			zap.S().Errorf("failed to list the backup schedules referencing the backup policy %s: %s", kube.ObjectKeyFromObject(obj), err)
+			return nil
+		}
+
+		policyKey := kube.ObjectKeyFromObject(obj)
+		var requests []reconcile.Request
+		for i := range schedules.Items {
+			bSchedule := &schedules.Items[i]
+			if *bSchedule.Spec.PolicyRef.GetObject(bSchedule.Namespace) == policyKey {
+				requests = append(requests, reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(bSchedule)})
+			}
+		}
+		return requests
+	})
+}
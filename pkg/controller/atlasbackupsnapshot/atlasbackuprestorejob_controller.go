@@ -0,0 +1,378 @@
+package atlasbackupsnapshot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/timeutil"
+)
+
+// DownloadURLsKey is the key of the download links in the Secret of a "download" restore, one link per line
+const DownloadURLsKey = "urls"
+
+// AtlasBackupRestoreJobReconciler reconciles an AtlasBackupRestoreJob object. It starts a restore job of the
+// deployment referenced by the resource and reports its progress until it completes.
+type AtlasBackupRestoreJobReconciler struct {
+	Client                   client.Client
+	Log                      *zap.SugaredLogger
+	Scheme                   *runtime.Scheme
+	GlobalPredicates         []predicate.Predicate
+	EventRecorder            record.EventRecorder
+	AtlasProvider            atlas.Provider
+	ObjectDeletionProtection bool
+	DryRun                   bool
+	MaxConcurrentReconciles  int
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasbackuprestorejobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasbackuprestorejobs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasbackuprestorejobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasbackuprestorejobs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasbackupsnapshots,verbs=get;list;watch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasbackupsnapshots,verbs=get;list;watch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasdeployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasdeployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",namespace=default,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *AtlasBackupRestoreJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasbackuprestorejob", req.NamespacedName)
+
+	restoreJob := &mdbv1.AtlasBackupRestoreJob{}
+	result := customresource.PrepareResource(r.Client, req, restoreJob, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if customresource.ReconciliationShouldBeSkipped(restoreJob) {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasBackupRestoreJob reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", restoreJob.Spec)
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	workflowCtx := customresource.MarkReconciliationStarted(r.Client, restoreJob, log, ctx)
+	log.Infow("-> Starting AtlasBackupRestoreJob reconciliation", "spec", restoreJob.Spec)
+	plan := dryrun.PlanFor(restoreJob, r.DryRun, log)
+	defer func() {
+		workflowCtx.EnsureStatusOption(status.AtlasBackupRestoreJobPlannedChangesOption(plan.Changes()))
+		plan.Emit(r.EventRecorder, restoreJob)
+		statushandler.Update(workflowCtx, r.Client, r.EventRecorder, restoreJob)
+	}()
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(workflowCtx, restoreJob, log)
+	if !resourceVersionIsValid.IsOk() {
+		log.Debugf("restore job validation result: %v", resourceVersionIsValid)
+		return resourceVersionIsValid.ReconcileResult(), nil
+	}
+
+	if err := validate.BackupRestoreJob(restoreJob); err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result.ReconcileResult(), nil
+	}
+	workflowCtx.SetConditionTrue(status.ValidationSucceeded)
+
+	deleting := !restoreJob.GetDeletionTimestamp().IsZero()
+	deployment, project, result := readDeployment(ctx, r.Client, restoreJob.AtlasDeploymentObjectKey())
+	if !result.IsOk() {
+		if deleting {
+			// The job can't be reached without its deployment
+			return r.removeFinalizer(workflowCtx, restoreJob, plan).ReconcileResult(), nil
+		}
+		workflowCtx.SetConditionFromResult(status.RestoreJobReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if result = connect(workflowCtx, r.AtlasProvider, project, plan); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.RestoreJobReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if !r.AtlasProvider.IsResourceSupported(restoreJob, &workflowCtx.Connection) {
+		result = workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasBackupRestoreJob is not supported by Atlas for government").
+			WithoutRetry()
+		workflowCtx.SetConditionFromResult(status.RestoreJobReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	params := &mongodbatlas.SnapshotReqPathParameters{GroupID: project.ID(), ClusterName: deployment.GetDeploymentName()}
+	if deleting {
+		return r.handleDeletion(workflowCtx, restoreJob, params, plan).ReconcileResult(), nil
+	}
+
+	if !customresource.HaveFinalizer(restoreJob, customresource.FinalizerLabel) {
+		if err := customresource.ManageFinalizer(ctx, r.Client, restoreJob, customresource.SetFinalizer); err != nil {
+			result = workflow.Terminate(workflow.AtlasFinalizerNotSet, err.Error())
+			workflowCtx.SetConditionFromResult(status.RestoreJobReadyType, result)
+			return result.ReconcileResult(), nil
+		}
+	}
+
+	if result = r.ensureRestoreJob(workflowCtx, restoreJob, params, plan != nil); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.RestoreJobReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if err := customresource.ApplyLastConfigApplied(ctx, restoreJob, r.Client); err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.RestoreJobReadyType, result)
+		log.Error(result.GetMessage())
+		return result.ReconcileResult(), nil
+	}
+
+	workflowCtx.SetConditionTrue(status.RestoreJobReadyType)
+	workflowCtx.SetConditionTrue(status.ReadyType)
+	return workflow.OK().ReconcileResult(), nil
+}
+
+// ensureRestoreJob starts the restore job once and then follows its progress. The result is OK once the job is
+// completed.
+func (r *AtlasBackupRestoreJobReconciler) ensureRestoreJob(workflowCtx *workflow.Context, restoreJob *mdbv1.AtlasBackupRestoreJob, params *mongodbatlas.SnapshotReqPathParameters, dryRun bool) workflow.Result {
+	var current *mongodbatlas.CloudProviderSnapshotRestoreJob
+	var err error
+
+	if restoreJob.Status.JobID == "" {
+		desired, result := r.restoreRequest(workflowCtx.Context, restoreJob)
+		if !result.IsOk() {
+			return result
+		}
+
+		if restoreJob.Status.RequestedAt != "" {
+			// A previous reconciliation requested the job but failed to save its ID
+			if current, err = r.findRequestedRestoreJob(workflowCtx, restoreJob, desired, params); err != nil {
+				return workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to look up the requested restore job in Atlas: %s", err))
+			}
+		}
+
+		if current != nil {
+			workflowCtx.Log.Infow("Found the restore job requested previously", "cluster", params.ClusterName, "jobID", current.ID)
+		} else {
+			if !dryRun {
+				// the request is saved before starting the job, so the job is looked up rather than started twice if its
+				// ID fails to be saved
+				workflowCtx.EnsureStatusOption(status.AtlasBackupRestoreJobRequestedAtOption(timeutil.FormatISO8601(time.Now())))
+				if err = statushandler.Persist(workflowCtx, r.Client, restoreJob); err != nil {
+					return workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to save the restore job request before starting it: %s", err))
+				}
+			}
+
+			workflowCtx.Log.Infow("Starting the restore job", "cluster", params.ClusterName, "deliveryType", desired.DeliveryType)
+			current, _, err = workflowCtx.Client.CloudProviderSnapshotRestoreJobs.Create(workflowCtx.Context, params, desired)
+			if err != nil {
+				return workflow.Terminate(workflow.RestoreJobNotCreatedInAtlas, fmt.Sprintf("failed to start the restore job in Atlas: %s", err))
+			}
+
+			// The dry-run client doesn't start the job, so there's no progress to report
+			if dryRun {
+				return workflow.OK()
+			}
+
+			// the job is followed by its ID from now on, the lookup is only left for a failure to save it
+			workflowCtx.EnsureStatusOption(status.AtlasBackupRestoreJobOption(current))
+			if err = statushandler.Persist(workflowCtx, r.Client, restoreJob); err != nil {
+				return workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to save the ID of the started restore job %s: %s", current.ID, err))
+			}
+		}
+	} else {
+		jobParams := *params
+		jobParams.JobID = restoreJob.Status.JobID
+		current, _, err = workflowCtx.Client.CloudProviderSnapshotRestoreJobs.Get(workflowCtx.Context, &jobParams)
+		if err != nil {
+			return workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to get the restore job from Atlas: %s", err))
+		}
+	}
+
+	workflowCtx.EnsureStatusOption(status.AtlasBackupRestoreJobOption(current))
+
+	if len(current.DeliveryURL) > 0 {
+		if err = r.ensureDownloadSecret(workflowCtx.Context, restoreJob, current.DeliveryURL); err != nil {
+			return workflow.Terminate(workflow.RestoreJobDownloadSecretNotSaved, fmt.Sprintf("failed to save the download links: %s", err))
+		}
+		workflowCtx.EnsureStatusOption(status.AtlasBackupRestoreJobDownloadSecretOption(restoreJob.DownloadSecretObjectKey().Name))
+	}
+
+	switch state := status.RestoreJobState(current); state {
+	case status.RestoreJobStateCompleted:
+		return workflow.OK()
+	case status.RestoreJobStateInProgress:
+		return workflow.InProgress(workflow.RestoreJobInProgress, fmt.Sprintf("the restore job %s is in progress", current.ID))
+	default:
+		return workflow.Terminate(workflow.RestoreJobFailed, fmt.Sprintf("the restore job %s is %s", current.ID, state)).WithoutRetry()
+	}
+}
+
+// findRequestedRestoreJob returns the restore job of the deployment matching the desired one requested by the resource,
+// or nil if Atlas didn't start it. The jobs whose ID is recorded in the status of another AtlasBackupRestoreJob belong
+// to that resource and are never adopted.
+func (r *AtlasBackupRestoreJobReconciler) findRequestedRestoreJob(workflowCtx *workflow.Context, restoreJob *mdbv1.AtlasBackupRestoreJob, desired *mongodbatlas.CloudProviderSnapshotRestoreJob, params *mongodbatlas.SnapshotReqPathParameters) (*mongodbatlas.CloudProviderSnapshotRestoreJob, error) {
+	restoreJobs := &mdbv1.AtlasBackupRestoreJobList{}
+	if err := r.Client.List(workflowCtx.Context, restoreJobs); err != nil {
+		return nil, fmt.Errorf("failed to list the AtlasBackupRestoreJob resources: %w", err)
+	}
+	claimed := map[string]struct{}{}
+	for i := range restoreJobs.Items {
+		item := &restoreJobs.Items[i]
+		if item.Status.JobID != "" && (item.Name != restoreJob.Name || item.Namespace != restoreJob.Namespace) {
+			claimed[item.Status.JobID] = struct{}{}
+		}
+	}
+
+	jobs, _, err := workflowCtx.Client.CloudProviderSnapshotRestoreJobs.List(workflowCtx.Context, params, &mongodbatlas.ListOptions{ItemsPerPage: requestLookupPageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range jobs.Results {
+		if candidate == nil {
+			continue
+		}
+		if _, ok := claimed[candidate.ID]; ok {
+			continue
+		}
+		if sameRestoreRequest(desired, candidate, restoreJob.Status.RequestedAt) {
+			return candidate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// restoreRequest returns the Atlas restore job of the resource, resolving the referenced snapshot and target
+// deployment
+func (r *AtlasBackupRestoreJobReconciler) restoreRequest(ctx context.Context, restoreJob *mdbv1.AtlasBackupRestoreJob) (*mongodbatlas.CloudProviderSnapshotRestoreJob, workflow.Result) {
+	desired := &mongodbatlas.CloudProviderSnapshotRestoreJob{
+		DeliveryType:          restoreJob.Spec.DeliveryType,
+		SnapshotID:            restoreJob.Spec.SnapshotID,
+		PointInTimeUTCSeconds: restoreJob.Spec.PointInTimeUTCSeconds,
+		OplogTs:               restoreJob.Spec.OplogTs,
+		OplogInc:              restoreJob.Spec.OplogInc,
+	}
+
+	if snapshotKey := restoreJob.SnapshotObjectKey(); snapshotKey != nil {
+		snapshot := &mdbv1.AtlasBackupSnapshot{}
+		if err := r.Client.Get(ctx, *snapshotKey, snapshot); err != nil {
+			if apiErrors.IsNotFound(err) {
+				return nil, workflow.Terminate(workflow.BackupSnapshotNotReady, fmt.Sprintf("the AtlasBackupSnapshot %s doesn't exist", snapshotKey))
+			}
+			return nil, workflow.Terminate(workflow.Internal, fmt.Sprintf("unable to get AtlasBackupSnapshot resource %s: %s", snapshotKey, err))
+		}
+
+		if snapshot.AtlasDeploymentObjectKey() != restoreJob.AtlasDeploymentObjectKey() {
+			return nil, workflow.Terminate(workflow.BackupSnapshotNotReady, fmt.Sprintf("the AtlasBackupSnapshot %s is not a snapshot of the AtlasDeployment %s", snapshotKey, restoreJob.AtlasDeploymentObjectKey())).
+				WithoutRetry()
+		}
+
+		if snapshot.Status.SnapshotStatus != status.BackupSnapshotStatusCompleted {
+			return nil, workflow.Terminate(workflow.BackupSnapshotNotReady, fmt.Sprintf("the AtlasBackupSnapshot %s is not completed yet", snapshotKey))
+		}
+		desired.SnapshotID = snapshot.Status.SnapshotID
+	}
+
+	if targetKey := restoreJob.TargetDeploymentObjectKey(); targetKey != nil {
+		target, targetProject, result := readDeployment(ctx, r.Client, *targetKey)
+		if !result.IsOk() {
+			return nil, result
+		}
+		desired.TargetClusterName = target.GetDeploymentName()
+		desired.TargetGroupID = targetProject.ID()
+	}
+
+	return desired, workflow.OK()
+}
+
+// ensureDownloadSecret keeps the download links of the restore job in a Secret owned by the resource, as they give
+// access to the data of the snapshot
+func (r *AtlasBackupRestoreJobReconciler) ensureDownloadSecret(ctx context.Context, restoreJob *mdbv1.AtlasBackupRestoreJob, urls []string) error {
+	secretKey := restoreJob.DownloadSecretObjectKey()
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretKey.Name, Namespace: secretKey.Namespace}}
+	getErr := r.Client.Get(ctx, secretKey, secret)
+	if getErr != nil && !apiErrors.IsNotFound(getErr) {
+		return getErr
+	}
+
+	if err := controllerutil.SetOwnerReference(restoreJob, secret, r.Client.Scheme()); err != nil {
+		return err
+	}
+	secret.Data = map[string][]byte{DownloadURLsKey: []byte(strings.Join(urls, "\n"))}
+
+	if getErr != nil {
+		return r.Client.Create(ctx, secret)
+	}
+	return r.Client.Update(ctx, secret)
+}
+
+// handleDeletion cancels the restore job in Atlas if it is still running, unless the resource is protected, then
+// removes its finalizer
+func (r *AtlasBackupRestoreJobReconciler) handleDeletion(workflowCtx *workflow.Context, restoreJob *mdbv1.AtlasBackupRestoreJob, params *mongodbatlas.SnapshotReqPathParameters, plan *dryrun.Plan) workflow.Result {
+	if !customresource.HaveFinalizer(restoreJob, customresource.FinalizerLabel) {
+		return workflow.OK()
+	}
+
+	running := restoreJob.Status.JobID != "" && restoreJob.Status.State == status.RestoreJobStateInProgress
+	if customresource.IsResourceProtected(restoreJob, r.ObjectDeletionProtection) {
+		workflowCtx.Log.Info("Not cancelling the restore job in Atlas as per configuration")
+	} else if running {
+		jobParams := *params
+		jobParams.JobID = restoreJob.Status.JobID
+		workflowCtx.Log.Infow("Cancelling the restore job in Atlas", "jobID", jobParams.JobID)
+		if _, err := workflowCtx.Client.CloudProviderSnapshotRestoreJobs.Delete(workflowCtx.Context, &jobParams); err != nil && !isNotFound(err) {
+			result := workflow.Terminate(workflow.RestoreJobNotCancelledInAtlas, fmt.Sprintf("failed to cancel the restore job in Atlas: %s", err))
+			workflowCtx.SetConditionFromResult(status.RestoreJobReadyType, result)
+			return result
+		}
+	}
+
+	return r.removeFinalizer(workflowCtx, restoreJob, plan)
+}
+
+func (r *AtlasBackupRestoreJobReconciler) removeFinalizer(workflowCtx *workflow.Context, restoreJob *mdbv1.AtlasBackupRestoreJob, plan *dryrun.Plan) workflow.Result {
+	if !customresource.HaveFinalizer(restoreJob, customresource.FinalizerLabel) {
+		return workflow.OK()
+	}
+
+	if plan.KeepFinalizer(restoreJob) {
+		result := dryrun.DeletionPlanned()
+		workflowCtx.SetConditionFromResult(status.RestoreJobReadyType, result)
+		return result
+	}
+
+	if err := customresource.ManageFinalizer(workflowCtx.Context, r.Client, restoreJob, customresource.UnsetFinalizer); err != nil {
+		result := workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
+		workflowCtx.SetConditionFromResult(status.RestoreJobReadyType, result)
+		return result
+	}
+
+	return workflow.OK()
+}
+
+func (r *AtlasBackupRestoreJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("AtlasBackupRestoreJob").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		For(&mdbv1.AtlasBackupRestoreJob{}, builder.WithPredicates(r.GlobalPredicates...)).
+		Complete(r)
+}
@@ -0,0 +1,261 @@
+package atlasbackupsnapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/controllertest"
+	atlas_mock "github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/mocks/atlas"
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+func TestAtlasBackupRestoreJobReconcile(t *testing.T) {
+	t.Run("an invalid spec is reported", func(t *testing.T) {
+		restoreJob := testRestoreJob()
+		restoreJob.Spec.TargetDeploymentRef = nil
+		r := testRestoreJobReconciler(t, mongodbatlas.Client{}, restoreJob)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(restoreJob)})
+		require.NoError(t, err)
+
+		controllertest.AssertCondition(t, controllertest.Read(t, r.Client, restoreJob), status.ValidationSucceeded, corev1.ConditionFalse, string(workflow.Internal))
+	})
+
+	t.Run("the restore waits for the snapshot to complete", func(t *testing.T) {
+		restoreJob := testRestoreJob()
+		snapshot := testSnapshot()
+		snapshot.Status.SnapshotID = "my-snapshot-id"
+		snapshot.Status.SnapshotStatus = status.BackupSnapshotStatusInProgress
+		restoreJobsAPI := &atlas_mock.CloudProviderSnapshotRestoreJobsClientMock{}
+		r := testRestoreJobReconciler(t, mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: restoreJobsAPI},
+			controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), snapshot, restoreJob)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(restoreJob)})
+		require.NoError(t, err)
+
+		assert.Empty(t, restoreJobsAPI.CreateRequests)
+		controllertest.AssertCondition(t, controllertest.Read(t, r.Client, restoreJob), status.RestoreJobReadyType, corev1.ConditionFalse, string(workflow.BackupSnapshotNotReady))
+	})
+
+	t.Run("the automated restore targets the referenced snapshot and deployment", func(t *testing.T) {
+		restoreJob := testRestoreJob()
+		snapshot := testSnapshot()
+		snapshot.Status.SnapshotID = "my-snapshot-id"
+		snapshot.Status.SnapshotStatus = status.BackupSnapshotStatusCompleted
+		restoreJobsAPI := &atlas_mock.CloudProviderSnapshotRestoreJobsClientMock{
+			CreateFunc: func(projectID, clusterName string, job *mongodbatlas.CloudProviderSnapshotRestoreJob) (*mongodbatlas.CloudProviderSnapshotRestoreJob, *mongodbatlas.Response, error) {
+				created := *job
+				created.ID = "my-job-id"
+				return &created, nil, nil
+			},
+		}
+		r := testRestoreJobReconciler(t, mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: restoreJobsAPI},
+			controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), controllertest.Deployment("my-target", "my-target-cluster"), snapshot, restoreJob)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(restoreJob)})
+		require.NoError(t, err)
+
+		require.Contains(t, restoreJobsAPI.CreateRequests, "my-project-id.my-cluster")
+		assert.Equal(t, &mongodbatlas.CloudProviderSnapshotRestoreJob{
+			DeliveryType:      mdbv1.RestoreDeliveryAutomated,
+			SnapshotID:        "my-snapshot-id",
+			TargetClusterName: "my-target-cluster",
+			TargetGroupID:     "my-project-id",
+		}, restoreJobsAPI.CreateRequests["my-project-id.my-cluster"])
+
+		endRestoreJob := controllertest.Read(t, r.Client, restoreJob)
+		controllertest.AssertCondition(t, endRestoreJob, status.RestoreJobReadyType, corev1.ConditionFalse, string(workflow.RestoreJobInProgress))
+		assert.Equal(t, "my-job-id", endRestoreJob.Status.JobID)
+		assert.Equal(t, status.RestoreJobStateInProgress, endRestoreJob.Status.State)
+		assert.NotEmpty(t, endRestoreJob.Status.RequestedAt)
+		assert.Equal(t, []string{customresource.FinalizerLabel}, endRestoreJob.Finalizers)
+	})
+
+	t.Run("the restore job requested previously is found instead of started twice", func(t *testing.T) {
+		restoreJob := testRestoreJob()
+		restoreJob.Status.RequestedAt = "2026-10-18T10:00:00.000Z"
+		snapshot := testSnapshot()
+		snapshot.Status.SnapshotID = "my-snapshot-id"
+		snapshot.Status.SnapshotStatus = status.BackupSnapshotStatusCompleted
+		restoreJobsAPI := &atlas_mock.CloudProviderSnapshotRestoreJobsClientMock{
+			ListFunc: func(projectID, clusterName string) (*mongodbatlas.CloudProviderSnapshotRestoreJobs, *mongodbatlas.Response, error) {
+				return &mongodbatlas.CloudProviderSnapshotRestoreJobs{Results: []*mongodbatlas.CloudProviderSnapshotRestoreJob{
+					{ID: "other-target-id", DeliveryType: mdbv1.RestoreDeliveryAutomated, SnapshotID: "my-snapshot-id", TargetClusterName: "other-cluster", TargetGroupID: "my-project-id", CreatedAt: "2026-10-18T10:00:10Z"},
+					{ID: "older-id", DeliveryType: mdbv1.RestoreDeliveryAutomated, SnapshotID: "my-snapshot-id", TargetClusterName: "my-target-cluster", TargetGroupID: "my-project-id", CreatedAt: "2026-10-17T10:00:00Z"},
+					{ID: "my-job-id", DeliveryType: mdbv1.RestoreDeliveryAutomated, SnapshotID: "my-snapshot-id", TargetClusterName: "my-target-cluster", TargetGroupID: "my-project-id", CreatedAt: "2026-10-18T10:00:10Z"},
+				}}, nil, nil
+			},
+		}
+		r := testRestoreJobReconciler(t, mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: restoreJobsAPI},
+			controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), controllertest.Deployment("my-target", "my-target-cluster"), snapshot, restoreJob)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(restoreJob)})
+		require.NoError(t, err)
+
+		assert.Contains(t, restoreJobsAPI.ListRequests, "my-project-id.my-cluster")
+		assert.Empty(t, restoreJobsAPI.CreateRequests)
+		assert.Equal(t, "my-job-id", controllertest.Read(t, r.Client, restoreJob).Status.JobID)
+	})
+
+	t.Run("the restore job of another resource isn't adopted", func(t *testing.T) {
+		restoreJob := testRestoreJob()
+		restoreJob.Status.RequestedAt = "2026-10-18T10:00:00.000Z"
+		otherRestoreJob := testRestoreJob()
+		otherRestoreJob.Name = "other-restore"
+		otherRestoreJob.Status.JobID = "other-job-id"
+		snapshot := testSnapshot()
+		snapshot.Status.SnapshotID = "my-snapshot-id"
+		snapshot.Status.SnapshotStatus = status.BackupSnapshotStatusCompleted
+		restoreJobsAPI := &atlas_mock.CloudProviderSnapshotRestoreJobsClientMock{
+			ListFunc: func(projectID, clusterName string) (*mongodbatlas.CloudProviderSnapshotRestoreJobs, *mongodbatlas.Response, error) {
+				return &mongodbatlas.CloudProviderSnapshotRestoreJobs{Results: []*mongodbatlas.CloudProviderSnapshotRestoreJob{
+					{ID: "other-job-id", DeliveryType: mdbv1.RestoreDeliveryAutomated, SnapshotID: "my-snapshot-id", TargetClusterName: "my-target-cluster", TargetGroupID: "my-project-id", CreatedAt: "2026-10-18T10:00:10Z"},
+				}}, nil, nil
+			},
+			CreateFunc: func(projectID, clusterName string, job *mongodbatlas.CloudProviderSnapshotRestoreJob) (*mongodbatlas.CloudProviderSnapshotRestoreJob, *mongodbatlas.Response, error) {
+				created := *job
+				created.ID = "my-job-id"
+				return &created, nil, nil
+			},
+		}
+		r := testRestoreJobReconciler(t, mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: restoreJobsAPI},
+			controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), controllertest.Deployment("my-target", "my-target-cluster"), snapshot, restoreJob, otherRestoreJob)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(restoreJob)})
+		require.NoError(t, err)
+
+		assert.Len(t, restoreJobsAPI.CreateRequests, 1)
+		assert.Equal(t, "my-job-id", controllertest.Read(t, r.Client, restoreJob).Status.JobID)
+	})
+
+	t.Run("the download links of a completed download are kept in a secret", func(t *testing.T) {
+		restoreJob := testRestoreJob()
+		restoreJob.Spec.DeliveryType = mdbv1.RestoreDeliveryDownload
+		restoreJob.Spec.TargetDeploymentRef = nil
+		restoreJob.Status.JobID = "my-job-id"
+		restoreJobsAPI := &atlas_mock.CloudProviderSnapshotRestoreJobsClientMock{
+			GetFunc: func(projectID, clusterName, jobID string) (*mongodbatlas.CloudProviderSnapshotRestoreJob, *mongodbatlas.Response, error) {
+				return &mongodbatlas.CloudProviderSnapshotRestoreJob{
+					ID:          jobID,
+					DeliveryURL: []string{"https://restore.example.com/shard-0.tar.gz", "https://restore.example.com/shard-1.tar.gz"},
+				}, nil, nil
+			},
+		}
+		r := testRestoreJobReconciler(t, mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: restoreJobsAPI},
+			controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), restoreJob)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(restoreJob)})
+		require.NoError(t, err)
+
+		endRestoreJob := controllertest.Read(t, r.Client, restoreJob)
+		controllertest.AssertCondition(t, endRestoreJob, status.ReadyType, corev1.ConditionTrue, "")
+		assert.Equal(t, status.RestoreJobStateCompleted, endRestoreJob.Status.State)
+		assert.Equal(t, "my-restore-download", endRestoreJob.Status.DownloadSecret)
+
+		secret := &corev1.Secret{}
+		require.NoError(t, r.Client.Get(context.Background(), kube.ObjectKey("test-namespace", "my-restore-download"), secret))
+		assert.Equal(t, "https://restore.example.com/shard-0.tar.gz\nhttps://restore.example.com/shard-1.tar.gz", string(secret.Data[DownloadURLsKey]))
+		require.Len(t, secret.OwnerReferences, 1)
+		assert.Equal(t, "my-restore", secret.OwnerReferences[0].Name)
+	})
+
+	t.Run("a failed restore is reported", func(t *testing.T) {
+		restoreJob := testRestoreJob()
+		restoreJob.Status.JobID = "my-job-id"
+		restoreJobsAPI := &atlas_mock.CloudProviderSnapshotRestoreJobsClientMock{
+			GetFunc: func(projectID, clusterName, jobID string) (*mongodbatlas.CloudProviderSnapshotRestoreJob, *mongodbatlas.Response, error) {
+				return &mongodbatlas.CloudProviderSnapshotRestoreJob{ID: jobID, Failed: toptr.MakePtr(true)}, nil, nil
+			},
+		}
+		r := testRestoreJobReconciler(t, mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: restoreJobsAPI},
+			controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), restoreJob)
+
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(restoreJob)})
+		require.NoError(t, err)
+
+		assert.Equal(t, ctrl.Result{}, result, "a failed restore is not retried")
+		endRestoreJob := controllertest.Read(t, r.Client, restoreJob)
+		controllertest.AssertCondition(t, endRestoreJob, status.RestoreJobReadyType, corev1.ConditionFalse, string(workflow.RestoreJobFailed))
+		assert.Equal(t, status.RestoreJobStateFailed, endRestoreJob.Status.State)
+	})
+
+	t.Run("the deletion cancels a running restore", func(t *testing.T) {
+		restoreJob := testRestoreJob()
+		restoreJob.Status.JobID = "my-job-id"
+		restoreJob.Status.State = status.RestoreJobStateInProgress
+		restoreJob.Finalizers = []string{customresource.FinalizerLabel}
+		restoreJob.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		restoreJobsAPI := &atlas_mock.CloudProviderSnapshotRestoreJobsClientMock{
+			DeleteFunc: func(projectID, clusterName, jobID string) (*mongodbatlas.Response, error) {
+				return nil, nil
+			},
+		}
+		r := testRestoreJobReconciler(t, mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: restoreJobsAPI},
+			controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), restoreJob)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(restoreJob)})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]struct{}{"my-project-id.my-cluster.my-job-id": {}}, restoreJobsAPI.DeleteRequests)
+		err = r.Client.Get(context.Background(), kube.ObjectKeyFromObject(restoreJob), &mdbv1.AtlasBackupRestoreJob{})
+		assert.True(t, apiErrors.IsNotFound(err), "the resource should be deleted once the finalizer is removed")
+	})
+
+	t.Run("the deletion of a completed restore leaves Atlas untouched", func(t *testing.T) {
+		restoreJob := testRestoreJob()
+		restoreJob.Status.JobID = "my-job-id"
+		restoreJob.Status.State = status.RestoreJobStateCompleted
+		restoreJob.Finalizers = []string{customresource.FinalizerLabel}
+		restoreJob.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		restoreJobsAPI := &atlas_mock.CloudProviderSnapshotRestoreJobsClientMock{}
+		r := testRestoreJobReconciler(t, mongodbatlas.Client{CloudProviderSnapshotRestoreJobs: restoreJobsAPI},
+			controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), restoreJob)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(restoreJob)})
+		require.NoError(t, err)
+
+		assert.Empty(t, restoreJobsAPI.DeleteRequests)
+		err = r.Client.Get(context.Background(), kube.ObjectKeyFromObject(restoreJob), &mdbv1.AtlasBackupRestoreJob{})
+		assert.True(t, apiErrors.IsNotFound(err), "the resource should be deleted once the finalizer is removed")
+	})
+}
+
+func testRestoreJobReconciler(t *testing.T, atlasClient mongodbatlas.Client, objects ...client.Object) *AtlasBackupRestoreJobReconciler {
+	t.Helper()
+
+	return &AtlasBackupRestoreJobReconciler{
+		Client:        controllertest.NewKubeClient(objects...),
+		Log:           zaptest.NewLogger(t).Sugar(),
+		EventRecorder: record.NewFakeRecorder(10),
+		AtlasProvider: controllertest.NewProvider(atlasClient),
+	}
+}
+
+func testRestoreJob() *mdbv1.AtlasBackupRestoreJob {
+	return &mdbv1.AtlasBackupRestoreJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-restore", Namespace: controllertest.Namespace},
+		Spec: mdbv1.AtlasBackupRestoreJobSpec{
+			DeploymentRef:       common.ResourceRefNamespaced{Name: "my-deployment"},
+			DeliveryType:        mdbv1.RestoreDeliveryAutomated,
+			SnapshotRef:         &common.ResourceRefNamespaced{Name: "my-snapshot"},
+			TargetDeploymentRef: &common.ResourceRefNamespaced{Name: "my-target"},
+		},
+	}
+}
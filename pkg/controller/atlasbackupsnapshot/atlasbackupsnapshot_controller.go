@@ -0,0 +1,259 @@
+package atlasbackupsnapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/timeutil"
+)
+
+// AtlasBackupSnapshotReconciler reconciles an AtlasBackupSnapshot object. It takes an on-demand snapshot of the
+// deployment referenced by the resource and reports its progress until it completes.
+type AtlasBackupSnapshotReconciler struct {
+	Client                   client.Client
+	Log                      *zap.SugaredLogger
+	Scheme                   *runtime.Scheme
+	GlobalPredicates         []predicate.Predicate
+	EventRecorder            record.EventRecorder
+	AtlasProvider            atlas.Provider
+	ObjectDeletionProtection bool
+	DryRun                   bool
+	MaxConcurrentReconciles  int
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasbackupsnapshots,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasbackupsnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasbackupsnapshots,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasbackupsnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasdeployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasdeployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *AtlasBackupSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasbackupsnapshot", req.NamespacedName)
+
+	snapshot := &mdbv1.AtlasBackupSnapshot{}
+	result := customresource.PrepareResource(r.Client, req, snapshot, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if customresource.ReconciliationShouldBeSkipped(snapshot) {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasBackupSnapshot reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", snapshot.Spec)
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	workflowCtx := customresource.MarkReconciliationStarted(r.Client, snapshot, log, ctx)
+	log.Infow("-> Starting AtlasBackupSnapshot reconciliation", "spec", snapshot.Spec)
+	plan := dryrun.PlanFor(snapshot, r.DryRun, log)
+	defer func() {
+		workflowCtx.EnsureStatusOption(status.AtlasBackupSnapshotPlannedChangesOption(plan.Changes()))
+		plan.Emit(r.EventRecorder, snapshot)
+		statushandler.Update(workflowCtx, r.Client, r.EventRecorder, snapshot)
+	}()
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(workflowCtx, snapshot, log)
+	if !resourceVersionIsValid.IsOk() {
+		log.Debugf("backup snapshot validation result: %v", resourceVersionIsValid)
+		return resourceVersionIsValid.ReconcileResult(), nil
+	}
+
+	deleting := !snapshot.GetDeletionTimestamp().IsZero()
+	deployment, project, result := readDeployment(ctx, r.Client, snapshot.AtlasDeploymentObjectKey())
+	if !result.IsOk() {
+		if deleting {
+			// The snapshot can't be reached without its deployment, Atlas deletes it once it expires
+			return r.removeFinalizer(workflowCtx, snapshot, plan).ReconcileResult(), nil
+		}
+		workflowCtx.SetConditionFromResult(status.BackupSnapshotReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if result = connect(workflowCtx, r.AtlasProvider, project, plan); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.BackupSnapshotReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if !r.AtlasProvider.IsResourceSupported(snapshot, &workflowCtx.Connection) {
+		result = workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasBackupSnapshot is not supported by Atlas for government").
+			WithoutRetry()
+		workflowCtx.SetConditionFromResult(status.BackupSnapshotReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	params := &mongodbatlas.SnapshotReqPathParameters{GroupID: project.ID(), ClusterName: deployment.GetDeploymentName()}
+	if deleting {
+		return r.handleDeletion(workflowCtx, snapshot, params, plan).ReconcileResult(), nil
+	}
+
+	if !customresource.HaveFinalizer(snapshot, customresource.FinalizerLabel) {
+		if err := customresource.ManageFinalizer(ctx, r.Client, snapshot, customresource.SetFinalizer); err != nil {
+			result = workflow.Terminate(workflow.AtlasFinalizerNotSet, err.Error())
+			workflowCtx.SetConditionFromResult(status.BackupSnapshotReadyType, result)
+			return result.ReconcileResult(), nil
+		}
+	}
+
+	if result = r.ensureSnapshot(workflowCtx, snapshot, params, plan != nil); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.BackupSnapshotReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if err := customresource.ApplyLastConfigApplied(ctx, snapshot, r.Client); err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.BackupSnapshotReadyType, result)
+		log.Error(result.GetMessage())
+		return result.ReconcileResult(), nil
+	}
+
+	workflowCtx.SetConditionTrue(status.BackupSnapshotReadyType)
+	workflowCtx.SetConditionTrue(status.ReadyType)
+	return workflow.OK().ReconcileResult(), nil
+}
+
+// ensureSnapshot requests the snapshot once and then follows its progress. The result is OK once the snapshot is
+// completed.
+func (r *AtlasBackupSnapshotReconciler) ensureSnapshot(workflowCtx *workflow.Context, snapshot *mdbv1.AtlasBackupSnapshot, params *mongodbatlas.SnapshotReqPathParameters, dryRun bool) workflow.Result {
+	var current *mongodbatlas.CloudProviderSnapshot
+	var err error
+
+	if snapshot.Status.SnapshotID == "" && snapshot.Status.RequestedAt != "" {
+		// A previous reconciliation requested the snapshot but failed to save its ID
+		if current, err = findRequestedSnapshot(workflowCtx, snapshot, params); err != nil {
+			return workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to look up the requested snapshot in Atlas: %s", err))
+		}
+	}
+
+	switch {
+	case current != nil:
+		workflowCtx.Log.Infow("Found the on-demand snapshot requested previously", "cluster", params.ClusterName, "snapshotID", current.ID)
+	case snapshot.Status.SnapshotID == "":
+		if !dryRun {
+			// the request is saved before taking the snapshot, so the snapshot is looked up rather than taken twice if
+			// its ID fails to be saved
+			workflowCtx.EnsureStatusOption(status.AtlasBackupSnapshotRequestedAtOption(timeutil.FormatISO8601(time.Now())))
+			if err = statushandler.Persist(workflowCtx, r.Client, snapshot); err != nil {
+				return workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to save the snapshot request before taking it: %s", err))
+			}
+		}
+
+		workflowCtx.Log.Infow("Taking an on-demand snapshot", "cluster", params.ClusterName)
+		current, _, err = workflowCtx.Client.CloudProviderSnapshots.Create(workflowCtx.Context, params, snapshot.Spec.ToAtlas())
+		if err != nil {
+			return workflow.Terminate(workflow.BackupSnapshotNotCreatedInAtlas, fmt.Sprintf("failed to take the snapshot in Atlas: %s", err))
+		}
+
+		// The dry-run client doesn't take the snapshot, so there's no progress to report
+		if dryRun {
+			return workflow.OK()
+		}
+	default:
+		snapshotParams := *params
+		snapshotParams.SnapshotID = snapshot.Status.SnapshotID
+		current, _, err = workflowCtx.Client.CloudProviderSnapshots.GetOneCloudProviderSnapshot(workflowCtx.Context, &snapshotParams)
+		if err != nil {
+			if isNotFound(err) {
+				return workflow.Terminate(workflow.BackupSnapshotFailed, fmt.Sprintf("the snapshot %s doesn't exist in Atlas anymore, it may have expired", snapshot.Status.SnapshotID)).
+					WithoutRetry()
+			}
+			return workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to get the snapshot from Atlas: %s", err))
+		}
+	}
+
+	workflowCtx.EnsureStatusOption(status.AtlasBackupSnapshotOption(current))
+
+	switch current.Status {
+	case status.BackupSnapshotStatusCompleted:
+		return workflow.OK()
+	case status.BackupSnapshotStatusFailed:
+		return workflow.Terminate(workflow.BackupSnapshotFailed, fmt.Sprintf("the snapshot %s failed", current.ID)).WithoutRetry()
+	default:
+		return workflow.InProgress(workflow.BackupSnapshotInProgress, fmt.Sprintf("the snapshot %s is %s", current.ID, current.Status))
+	}
+}
+
+// findRequestedSnapshot returns the on-demand snapshot of the deployment matching the request of the resource, or nil if
+// Atlas didn't take it
+func findRequestedSnapshot(workflowCtx *workflow.Context, snapshot *mdbv1.AtlasBackupSnapshot, params *mongodbatlas.SnapshotReqPathParameters) (*mongodbatlas.CloudProviderSnapshot, error) {
+	snapshots, _, err := workflowCtx.Client.CloudProviderSnapshots.GetAllCloudProviderSnapshots(workflowCtx.Context, params, &mongodbatlas.ListOptions{ItemsPerPage: requestLookupPageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	desired := snapshot.Spec.ToAtlas()
+	for _, candidate := range snapshots.Results {
+		if candidate != nil && sameSnapshotRequest(desired, candidate, snapshot.Status.RequestedAt) {
+			return candidate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// handleDeletion deletes the snapshot from Atlas, unless the resource is protected, then removes its finalizer
+func (r *AtlasBackupSnapshotReconciler) handleDeletion(workflowCtx *workflow.Context, snapshot *mdbv1.AtlasBackupSnapshot, params *mongodbatlas.SnapshotReqPathParameters, plan *dryrun.Plan) workflow.Result {
+	if !customresource.HaveFinalizer(snapshot, customresource.FinalizerLabel) {
+		return workflow.OK()
+	}
+
+	if customresource.IsResourceProtected(snapshot, r.ObjectDeletionProtection) {
+		workflowCtx.Log.Info("Not deleting the snapshot from Atlas as per configuration")
+	} else if snapshot.Status.SnapshotID != "" {
+		snapshotParams := *params
+		snapshotParams.SnapshotID = snapshot.Status.SnapshotID
+		workflowCtx.Log.Infow("Deleting the snapshot from Atlas", "snapshotID", snapshotParams.SnapshotID)
+		if _, err := workflowCtx.Client.CloudProviderSnapshots.Delete(workflowCtx.Context, &snapshotParams); err != nil && !isNotFound(err) {
+			result := workflow.Terminate(workflow.BackupSnapshotNotDeletedInAtlas, fmt.Sprintf("failed to delete the snapshot from Atlas: %s", err))
+			workflowCtx.SetConditionFromResult(status.BackupSnapshotReadyType, result)
+			return result
+		}
+	}
+
+	return r.removeFinalizer(workflowCtx, snapshot, plan)
+}
+
+func (r *AtlasBackupSnapshotReconciler) removeFinalizer(workflowCtx *workflow.Context, snapshot *mdbv1.AtlasBackupSnapshot, plan *dryrun.Plan) workflow.Result {
+	if !customresource.HaveFinalizer(snapshot, customresource.FinalizerLabel) {
+		return workflow.OK()
+	}
+
+	if plan.KeepFinalizer(snapshot) {
+		result := dryrun.DeletionPlanned()
+		workflowCtx.SetConditionFromResult(status.BackupSnapshotReadyType, result)
+		return result
+	}
+
+	if err := customresource.ManageFinalizer(workflowCtx.Context, r.Client, snapshot, customresource.UnsetFinalizer); err != nil {
+		result := workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
+		workflowCtx.SetConditionFromResult(status.BackupSnapshotReadyType, result)
+		return result
+	}
+
+	return workflow.OK()
+}
+
+func (r *AtlasBackupSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("AtlasBackupSnapshot").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		For(&mdbv1.AtlasBackupSnapshot{}, builder.WithPredicates(r.GlobalPredicates...)).
+		Complete(r)
+}
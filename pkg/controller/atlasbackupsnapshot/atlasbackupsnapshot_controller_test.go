@@ -0,0 +1,203 @@
+package atlasbackupsnapshot
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/controllertest"
+	atlas_mock "github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/mocks/atlas"
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+func TestAtlasBackupSnapshotReconcile(t *testing.T) {
+	t.Run("the deployment must be created in Atlas", func(t *testing.T) {
+		deployment := controllertest.Deployment("my-deployment", "my-cluster")
+		deployment.Status.StateName = status.StateCREATING
+		snapshot := testSnapshot()
+		r := testSnapshotReconciler(t, mongodbatlas.Client{}, controllertest.Project(), deployment, snapshot)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(snapshot)})
+		require.NoError(t, err)
+
+		endSnapshot := controllertest.Read(t, r.Client, snapshot)
+		controllertest.AssertCondition(t, endSnapshot, status.BackupSnapshotReadyType, corev1.ConditionFalse, string(workflow.BackupDeploymentNotReady))
+		assert.Empty(t, endSnapshot.Finalizers)
+	})
+
+	t.Run("the snapshot is taken and reported as in progress", func(t *testing.T) {
+		snapshot := testSnapshot()
+		snapshotsAPI := &atlas_mock.CloudProviderSnapshotsClientMock{
+			CreateFunc: func(projectID, clusterName string, request *mongodbatlas.CloudProviderSnapshot) (*mongodbatlas.CloudProviderSnapshot, *mongodbatlas.Response, error) {
+				return &mongodbatlas.CloudProviderSnapshot{ID: "my-snapshot-id", Status: status.BackupSnapshotStatusQueued}, nil, nil
+			},
+		}
+		r := testSnapshotReconciler(t, mongodbatlas.Client{CloudProviderSnapshots: snapshotsAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), snapshot)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(snapshot)})
+		require.NoError(t, err)
+
+		require.Contains(t, snapshotsAPI.CreateRequests, "my-project-id.my-cluster")
+		assert.Equal(t, &mongodbatlas.CloudProviderSnapshot{Description: "before migration", RetentionInDays: 7}, snapshotsAPI.CreateRequests["my-project-id.my-cluster"])
+
+		endSnapshot := controllertest.Read(t, r.Client, snapshot)
+		controllertest.AssertCondition(t, endSnapshot, status.BackupSnapshotReadyType, corev1.ConditionFalse, string(workflow.BackupSnapshotInProgress))
+		assert.Equal(t, "my-snapshot-id", endSnapshot.Status.SnapshotID)
+		assert.Equal(t, status.BackupSnapshotStatusQueued, endSnapshot.Status.SnapshotStatus)
+		assert.NotEmpty(t, endSnapshot.Status.RequestedAt)
+		assert.Equal(t, []string{customresource.FinalizerLabel}, endSnapshot.Finalizers)
+	})
+
+	t.Run("the snapshot requested previously is found instead of taken twice", func(t *testing.T) {
+		snapshot := testSnapshot()
+		snapshot.Status.RequestedAt = "2026-10-18T10:00:00.000Z"
+		snapshotsAPI := &atlas_mock.CloudProviderSnapshotsClientMock{
+			ListFunc: func(projectID, clusterName string) (*mongodbatlas.CloudProviderSnapshots, *mongodbatlas.Response, error) {
+				return &mongodbatlas.CloudProviderSnapshots{Results: []*mongodbatlas.CloudProviderSnapshot{
+					{ID: "scheduled-id", SnapshotType: "scheduled", CreatedAt: "2026-10-18T10:00:30Z", Status: status.BackupSnapshotStatusCompleted},
+					{ID: "older-id", SnapshotType: "onDemand", Description: "before migration", CreatedAt: "2026-10-17T10:00:00Z", Status: status.BackupSnapshotStatusCompleted},
+					{ID: "my-snapshot-id", SnapshotType: "onDemand", Description: "before migration", Status: status.BackupSnapshotStatusQueued},
+				}}, nil, nil
+			},
+		}
+		r := testSnapshotReconciler(t, mongodbatlas.Client{CloudProviderSnapshots: snapshotsAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), snapshot)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(snapshot)})
+		require.NoError(t, err)
+
+		assert.Contains(t, snapshotsAPI.ListRequests, "my-project-id.my-cluster")
+		assert.Empty(t, snapshotsAPI.CreateRequests)
+		endSnapshot := controllertest.Read(t, r.Client, snapshot)
+		assert.Equal(t, "my-snapshot-id", endSnapshot.Status.SnapshotID)
+		assert.Equal(t, "2026-10-18T10:00:00.000Z", endSnapshot.Status.RequestedAt)
+	})
+
+	t.Run("the snapshot is taken if the previous request didn't reach Atlas", func(t *testing.T) {
+		snapshot := testSnapshot()
+		snapshot.Status.RequestedAt = "2026-10-18T10:00:00.000Z"
+		snapshotsAPI := &atlas_mock.CloudProviderSnapshotsClientMock{
+			CreateFunc: func(projectID, clusterName string, request *mongodbatlas.CloudProviderSnapshot) (*mongodbatlas.CloudProviderSnapshot, *mongodbatlas.Response, error) {
+				return &mongodbatlas.CloudProviderSnapshot{ID: "my-snapshot-id", Status: status.BackupSnapshotStatusQueued}, nil, nil
+			},
+		}
+		r := testSnapshotReconciler(t, mongodbatlas.Client{CloudProviderSnapshots: snapshotsAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), snapshot)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(snapshot)})
+		require.NoError(t, err)
+
+		assert.Contains(t, snapshotsAPI.CreateRequests, "my-project-id.my-cluster")
+		assert.Equal(t, "my-snapshot-id", controllertest.Read(t, r.Client, snapshot).Status.SnapshotID)
+	})
+
+	t.Run("a completed snapshot is ready", func(t *testing.T) {
+		snapshot := testSnapshot()
+		snapshot.Status.SnapshotID = "my-snapshot-id"
+		snapshotsAPI := &atlas_mock.CloudProviderSnapshotsClientMock{
+			GetFunc: func(projectID, clusterName, snapshotID string) (*mongodbatlas.CloudProviderSnapshot, *mongodbatlas.Response, error) {
+				return &mongodbatlas.CloudProviderSnapshot{ID: snapshotID, Status: status.BackupSnapshotStatusCompleted, ExpiresAt: "2026-10-25T10:00:00Z"}, nil, nil
+			},
+		}
+		r := testSnapshotReconciler(t, mongodbatlas.Client{CloudProviderSnapshots: snapshotsAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), snapshot)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(snapshot)})
+		require.NoError(t, err)
+
+		assert.Empty(t, snapshotsAPI.CreateRequests)
+		endSnapshot := controllertest.Read(t, r.Client, snapshot)
+		controllertest.AssertCondition(t, endSnapshot, status.BackupSnapshotReadyType, corev1.ConditionTrue, "")
+		controllertest.AssertCondition(t, endSnapshot, status.ReadyType, corev1.ConditionTrue, "")
+		assert.Equal(t, "2026-10-25T10:00:00Z", endSnapshot.Status.ExpiresAt)
+	})
+
+	t.Run("a failed snapshot is reported", func(t *testing.T) {
+		snapshot := testSnapshot()
+		snapshot.Status.SnapshotID = "my-snapshot-id"
+		snapshotsAPI := &atlas_mock.CloudProviderSnapshotsClientMock{
+			GetFunc: func(projectID, clusterName, snapshotID string) (*mongodbatlas.CloudProviderSnapshot, *mongodbatlas.Response, error) {
+				return &mongodbatlas.CloudProviderSnapshot{ID: snapshotID, Status: status.BackupSnapshotStatusFailed}, nil, nil
+			},
+		}
+		r := testSnapshotReconciler(t, mongodbatlas.Client{CloudProviderSnapshots: snapshotsAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), snapshot)
+
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(snapshot)})
+		require.NoError(t, err)
+
+		assert.Equal(t, ctrl.Result{}, result, "a failed snapshot is not retried")
+		controllertest.AssertCondition(t, controllertest.Read(t, r.Client, snapshot), status.BackupSnapshotReadyType, corev1.ConditionFalse, string(workflow.BackupSnapshotFailed))
+	})
+
+	t.Run("the deletion deletes the snapshot from Atlas", func(t *testing.T) {
+		snapshot := testSnapshot()
+		snapshot.Status.SnapshotID = "my-snapshot-id"
+		snapshot.Finalizers = []string{customresource.FinalizerLabel}
+		snapshot.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		snapshotsAPI := &atlas_mock.CloudProviderSnapshotsClientMock{
+			DeleteFunc: func(projectID, clusterName, snapshotID string) (*mongodbatlas.Response, error) {
+				return nil, &mongodbatlas.ErrorResponse{HTTPCode: http.StatusNotFound}
+			},
+		}
+		r := testSnapshotReconciler(t, mongodbatlas.Client{CloudProviderSnapshots: snapshotsAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), snapshot)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(snapshot)})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]struct{}{"my-project-id.my-cluster.my-snapshot-id": {}}, snapshotsAPI.DeleteRequests)
+		err = r.Client.Get(context.Background(), kube.ObjectKeyFromObject(snapshot), &mdbv1.AtlasBackupSnapshot{})
+		assert.True(t, apiErrors.IsNotFound(err), "the resource should be deleted once the finalizer is removed")
+	})
+
+	t.Run("the snapshot is kept in Atlas when the resource is protected", func(t *testing.T) {
+		snapshot := testSnapshot()
+		snapshot.Status.SnapshotID = "my-snapshot-id"
+		snapshot.Finalizers = []string{customresource.FinalizerLabel}
+		snapshot.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		snapshot.Annotations = map[string]string{customresource.ResourcePolicyAnnotation: customresource.ResourcePolicyKeep}
+		snapshotsAPI := &atlas_mock.CloudProviderSnapshotsClientMock{}
+		r := testSnapshotReconciler(t, mongodbatlas.Client{CloudProviderSnapshots: snapshotsAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), snapshot)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(snapshot)})
+		require.NoError(t, err)
+
+		assert.Empty(t, snapshotsAPI.DeleteRequests)
+		err = r.Client.Get(context.Background(), kube.ObjectKeyFromObject(snapshot), &mdbv1.AtlasBackupSnapshot{})
+		assert.True(t, apiErrors.IsNotFound(err), "the resource should be deleted once the finalizer is removed")
+	})
+}
+
+func testSnapshotReconciler(t *testing.T, atlasClient mongodbatlas.Client, objects ...client.Object) *AtlasBackupSnapshotReconciler {
+	t.Helper()
+
+	return &AtlasBackupSnapshotReconciler{
+		Client:        controllertest.NewKubeClient(objects...),
+		Log:           zaptest.NewLogger(t).Sugar(),
+		EventRecorder: record.NewFakeRecorder(10),
+		AtlasProvider: controllertest.NewProvider(atlasClient),
+	}
+}
+
+func testSnapshot() *mdbv1.AtlasBackupSnapshot {
+	return &mdbv1.AtlasBackupSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-snapshot", Namespace: controllertest.Namespace},
+		Spec: mdbv1.AtlasBackupSnapshotSpec{
+			DeploymentRef:   common.ResourceRefNamespaced{Name: "my-deployment"},
+			Description:     "before migration",
+			RetentionInDays: 7,
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package atlasbackupsnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+// readDeployment returns the deployment and its project once the deployment is created in Atlas
+func readDeployment(ctx context.Context, kubeClient client.Client, deploymentKey client.ObjectKey) (*mdbv1.AtlasDeployment, *mdbv1.AtlasProject, workflow.Result) {
+	deployment := &mdbv1.AtlasDeployment{}
+	if err := kubeClient.Get(ctx, deploymentKey, deployment); err != nil {
+		if apiErrors.IsNotFound(err) {
+			return nil, nil, workflow.Terminate(workflow.BackupDeploymentNotReady, fmt.Sprintf("the AtlasDeployment %s doesn't exist", deploymentKey))
+		}
+		return nil, nil, workflow.Terminate(workflow.Internal, fmt.Sprintf("unable to get AtlasDeployment resource %s: %s", deploymentKey, err))
+	}
+
+	if !deployment.GetDeletionTimestamp().IsZero() {
+		return nil, nil, workflow.Terminate(workflow.BackupDeploymentNotReady, fmt.Sprintf("the AtlasDeployment %s is being deleted", deploymentKey))
+	}
+
+	if deployment.Status.StateName == "" || deployment.Status.StateName == status.StateCREATING {
+		return nil, nil, workflow.Terminate(workflow.BackupDeploymentNotReady, fmt.Sprintf("the AtlasDeployment %s is not created in Atlas yet", deploymentKey))
+	}
+
+	project := &mdbv1.AtlasProject{}
+	if err := kubeClient.Get(ctx, deployment.AtlasProjectObjectKey(), project); err != nil {
+		return nil, nil, workflow.Terminate(workflow.Internal, fmt.Sprintf("unable to get AtlasProject resource %s: %s", deployment.AtlasProjectObjectKey(), err))
+	}
+
+	if project.ID() == "" {
+		return nil, nil, workflow.Terminate(workflow.ProjectNotCreatedInAtlas, fmt.Sprintf("the AtlasProject %s is not created in Atlas yet", deployment.AtlasProjectObjectKey()))
+	}
+
+	return deployment, project, workflow.OK()
+}
+
+func connect(workflowCtx *workflow.Context, provider atlas.Provider, project *mdbv1.AtlasProject, plan *dryrun.Plan) workflow.Result {
+	connection, err := provider.CreateConnection(project, workflowCtx.Log)
+	if err != nil {
+		return workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+	}
+	workflowCtx.Connection = connection
+
+	atlasClient, err := provider.CreateClient(&connection, workflowCtx.Log, plan.ClientOpts()...)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	workflowCtx.Client = atlasClient
+
+	return workflow.OK()
+}
+
+func isNotFound(err error) bool {
+	var apiError *mongodbatlas.ErrorResponse
+	return errors.As(err, &apiError) && apiError.HTTPCode == http.StatusNotFound
+}
@@ -0,0 +1,59 @@
+package atlasbackupsnapshot
+
+import (
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/timeutil"
+)
+
+const (
+	// requestClockSkew is the margin given to the clocks of the operator and Atlas when looking up a snapshot or a
+	// restore job created after it was requested
+	requestClockSkew = time.Minute
+
+	// requestLookupPageSize is the number of the latest snapshots or restore jobs looked up
+	requestLookupPageSize = 500
+
+	onDemandSnapshotType = "onDemand"
+)
+
+// requestedSince returns true if a snapshot or a restore job created in Atlas at createdAt may be the one requested at
+// requestedAt. The time of creation isn't known while a snapshot is queued.
+func requestedSince(createdAt, requestedAt string) bool {
+	if createdAt == "" {
+		return true
+	}
+
+	created, err := timeutil.ParseISO8601(createdAt)
+	if err != nil {
+		return false
+	}
+	requested, err := timeutil.ParseISO8601(requestedAt)
+	if err != nil {
+		return false
+	}
+
+	return !created.Before(requested.Add(-requestClockSkew))
+}
+
+// sameSnapshotRequest returns true if the snapshot in Atlas is the on-demand snapshot requested at requestedAt
+func sameSnapshotRequest(desired, snapshot *mongodbatlas.CloudProviderSnapshot, requestedAt string) bool {
+	return snapshot.SnapshotType == onDemandSnapshotType &&
+		snapshot.Description == desired.Description &&
+		requestedSince(snapshot.CreatedAt, requestedAt)
+}
+
+// sameRestoreRequest returns true if the restore job in Atlas is the one requested at requestedAt. The snapshot of a
+// point in time restore is chosen by Atlas, so it's only compared when it was requested.
+func sameRestoreRequest(desired, job *mongodbatlas.CloudProviderSnapshotRestoreJob, requestedAt string) bool {
+	return job.DeliveryType == desired.DeliveryType &&
+		(desired.SnapshotID == "" || job.SnapshotID == desired.SnapshotID) &&
+		job.TargetClusterName == desired.TargetClusterName &&
+		job.TargetGroupID == desired.TargetGroupID &&
+		job.PointInTimeUTCSeconds == desired.PointInTimeUTCSeconds &&
+		job.OplogTs == desired.OplogTs &&
+		job.OplogInc == desired.OplogInc &&
+		requestedSince(job.CreatedAt, requestedAt)
+}
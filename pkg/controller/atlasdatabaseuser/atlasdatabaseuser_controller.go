@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.uber.org/zap"
@@ -29,6 +30,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -37,24 +40,37 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/connectionsecret"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/statushandler"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
 )
 
 // AtlasDatabaseUserReconciler reconciles an AtlasDatabaseUser object
+// PasswordSecretIgnoredEvent is emitted for the X.509 users referencing a password Secret, which Atlas doesn't use
+const PasswordSecretIgnoredEvent = "PasswordSecretIgnored"
+
 type AtlasDatabaseUserReconciler struct {
 	watch.ResourceWatcher
 	Client                      client.Client
 	Log                         *zap.SugaredLogger
 	Scheme                      *runtime.Scheme
 	AtlasDomain                 string
+	AtlasProvider               atlas.Provider
 	GlobalAPISecret             client.ObjectKey
 	EventRecorder               record.EventRecorder
 	GlobalPredicates            []predicate.Predicate
 	ObjectDeletionProtection    bool
 	SubObjectDeletionProtection bool
+	DryRun                      bool
+	ResyncInterval              time.Duration
+	DriftPolicy                 drift.Policy
+	MaxConcurrentReconciles     int
+	// CredentialsEvents receives the Secret references whose credentials changed in the credentials directory
+	CredentialsEvents <-chan event.GenericEvent
 }
 
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasdatabaseusers,verbs=get;list;watch;create;update;patch;delete
@@ -81,12 +97,16 @@ func (r *AtlasDatabaseUserReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return workflow.OK().ReconcileResult(), nil
 	}
 
+	checkDrift := drift.ShouldCheck(databaseUser, r.ResyncInterval)
 	workflowCtx := customresource.MarkReconciliationStarted(r.Client, databaseUser, log, ctx)
 	log.Infow("-> Starting AtlasDatabaseUser reconciliation", "spec", databaseUser.Spec, "status", databaseUser.Status)
 	if databaseUser.Spec.PasswordSecret != nil {
 		workflowCtx.AddResourcesToWatch(watch.WatchedObject{ResourceKind: "Secret", Resource: *databaseUser.PasswordSecretObjectKey()})
 	}
+	plan := dryrun.PlanFor(databaseUser, r.DryRun, log)
 	defer func() {
+		workflowCtx.EnsureStatusOption(status.AtlasDatabaseUserPlannedChangesOption(plan.Changes()))
+		plan.Emit(r.EventRecorder, databaseUser)
 		statushandler.Update(workflowCtx, r.Client, r.EventRecorder, databaseUser)
 		r.EnsureMultiplesResourcesAreWatched(req.NamespacedName, log, workflowCtx.ListResourcesToWatch()...)
 	}()
@@ -106,10 +126,21 @@ func (r *AtlasDatabaseUserReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 	workflowCtx.SetConditionTrue(status.ValidationSucceeded)
 
-	if !customresource.IsResourceSupportedInDomain(databaseUser, r.AtlasDomain) {
-		result := workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasDatabaseUser is not supported by Atlas for government").
-			WithoutRetry()
-		workflowCtx.SetConditionFromResult(status.DatabaseUserReadyType, result)
+	if authType, _ := databaseUser.Spec.AuthType(); authType == "x509Type" && databaseUser.Spec.PasswordSecret != nil && databaseUser.Spec.PasswordSecret.Name != "" {
+		log.Warnw("The password Secret of an X.509 user is not used by Atlas", "secret", databaseUser.Spec.PasswordSecret.Name)
+		r.EventRecorder.Eventf(databaseUser, "Warning", PasswordSecretIgnoredEvent, "The password Secret %s is not used by Atlas as the user is authenticated with X.509", databaseUser.Spec.PasswordSecret.Name)
+	}
+
+	if databaseUser.SelectsProjects() {
+		if result = r.ensureSelectedProjects(workflowCtx, databaseUser, plan); !result.IsOk() {
+			workflowCtx.SetConditionFromResult(status.DatabaseUserReadyType, result)
+
+			return result.ReconcileResult(), nil
+		}
+
+		workflowCtx.SetConditionTrue(status.DatabaseUserReadyType)
+		workflowCtx.SetConditionTrue(status.ReadyType)
+
 		return result.ReconcileResult(), nil
 	}
 
@@ -120,7 +151,7 @@ func (r *AtlasDatabaseUserReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return result.ReconcileResult(), nil
 	}
 
-	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
+	connection, err := r.AtlasProvider.CreateConnection(project, log)
 	if err != nil {
 		result = workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
 		workflowCtx.SetConditionFromResult(status.DatabaseUserReadyType, result)
@@ -129,7 +160,14 @@ func (r *AtlasDatabaseUserReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 	workflowCtx.Connection = connection
 
-	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
+	if !customresource.IsResourceSupportedInDomain(databaseUser, connection.DomainOr(r.AtlasDomain)) {
+		result := workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasDatabaseUser is not supported by Atlas for government").
+			WithoutRetry()
+		workflowCtx.SetConditionFromResult(status.DatabaseUserReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	atlasClient, err := r.AtlasProvider.CreateClient(&connection, log, plan.ClientOpts()...)
 	if err != nil {
 		result = workflow.Terminate(workflow.Internal, err.Error())
 		workflowCtx.SetConditionFromResult(status.DatabaseUserReadyType, result)
@@ -158,11 +196,17 @@ func (r *AtlasDatabaseUserReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return result.ReconcileResult(), nil
 	}
 
-	deletionRequest, result := r.handleDeletion(ctx, databaseUser, project, atlasClient, log)
+	deletionRequest, result := r.handleDeletion(ctx, databaseUser, project, atlasClient, log, plan)
 	if deletionRequest {
 		return result.ReconcileResult(), nil
 	}
 
+	if result = r.checkDrift(workflowCtx, project, databaseUser, checkDrift); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.DatabaseUserReadyType, result)
+
+		return drift.ResyncResult(result, r.ResyncInterval).ReconcileResult(), nil
+	}
+
 	err = customresource.ApplyLastConfigApplied(ctx, databaseUser, r.Client)
 	if err != nil {
 		result = workflow.Terminate(workflow.Internal, err.Error())
@@ -172,7 +216,7 @@ func (r *AtlasDatabaseUserReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return result.ReconcileResult(), nil
 	}
 
-	result = r.ensureDatabaseUser(workflowCtx, *project, *databaseUser)
+	_, result = r.ensureDatabaseUser(workflowCtx, *project, *databaseUser, nil)
 	if !result.IsOk() {
 		workflowCtx.SetConditionFromResult(status.DatabaseUserReadyType, result)
 
@@ -191,7 +235,7 @@ func (r *AtlasDatabaseUserReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	workflowCtx.SetConditionTrue(status.DatabaseUserReadyType)
 	workflowCtx.SetConditionTrue(status.ReadyType)
 
-	return result.ReconcileResult(), nil
+	return drift.ResyncResult(result, resyncInterval(*databaseUser, r.ResyncInterval)).ReconcileResult(), nil
 }
 
 func (r *AtlasDatabaseUserReconciler) readProjectResource(user *mdbv1.AtlasDatabaseUser, project *mdbv1.AtlasProject) workflow.Result {
@@ -207,12 +251,14 @@ func (r *AtlasDatabaseUserReconciler) handleDeletion(
 	project *mdbv1.AtlasProject,
 	atlasClient mongodbatlas.Client,
 	log *zap.SugaredLogger,
+	plan *dryrun.Plan,
 ) (bool, workflow.Result) {
 	if dbUser.GetDeletionTimestamp().IsZero() {
 		return false, workflow.OK()
 	}
 
-	if customresource.HaveFinalizer(dbUser, customresource.FinalizerLabel) {
+	// the connection secrets are kept with the user while its deletion is only planned
+	if customresource.HaveFinalizer(dbUser, customresource.FinalizerLabel) && plan == nil {
 		err := connectionsecret.RemoveStaleSecretsByUserName(r.Client, project.ID(), dbUser.Spec.Username, *dbUser, log)
 		if err != nil {
 			return true, workflow.Terminate(workflow.DatabaseUserConnectionSecretsNotDeleted, err.Error())
@@ -222,6 +268,10 @@ func (r *AtlasDatabaseUserReconciler) handleDeletion(
 	if customresource.IsResourceProtected(dbUser, r.ObjectDeletionProtection) {
 		log.Info("Not removing Atlas database user from Atlas as per configuration")
 
+		if plan.KeepFinalizer(dbUser) {
+			return true, dryrun.DeletionPlanned()
+		}
+
 		err := customresource.ManageFinalizer(ctx, r.Client, dbUser, customresource.UnsetFinalizer)
 		if err != nil {
 			return true, workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
@@ -230,7 +280,7 @@ func (r *AtlasDatabaseUserReconciler) handleDeletion(
 		return true, workflow.OK()
 	}
 
-	_, err := atlasClient.DatabaseUsers.Delete(context.Background(), dbUser.Spec.DatabaseName, project.ID(), dbUser.Spec.Username)
+	_, err := atlasClient.DatabaseUsers.Delete(context.Background(), dbUser.Spec.AuthDatabase(), project.ID(), dbUser.Spec.Username)
 	if err != nil {
 		var apiError *mongodbatlas.ErrorResponse
 		if errors.As(err, &apiError) && apiError.ErrorCode != atlas.UsernameNotFound {
@@ -240,6 +290,10 @@ func (r *AtlasDatabaseUserReconciler) handleDeletion(
 		log.Info("Database user doesn't exist or is already deleted")
 	}
 
+	if plan.KeepFinalizer(dbUser) {
+		return true, dryrun.DeletionPlanned()
+	}
+
 	err = customresource.ManageFinalizer(ctx, r.Client, dbUser, customresource.UnsetFinalizer)
 	if err != nil {
 		return true, workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
@@ -249,11 +303,59 @@ func (r *AtlasDatabaseUserReconciler) handleDeletion(
 }
 
 func (r *AtlasDatabaseUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named("AtlasDatabaseUser").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		For(&mdbv1.AtlasDatabaseUser{}, builder.WithPredicates(r.GlobalPredicates...)).
-		Watches(&source.Kind{Type: &corev1.Secret{}}, watch.NewSecretHandler(r.WatchedResources)).
-		Complete(r)
+		Watches(&source.Kind{Type: &corev1.Secret{}}, r.SecretHandler()).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, r.globalSecretHandler()).
+		Watches(&source.Kind{Type: &mdbv1.AtlasProject{}}, r.projectSelectorHandler(), builder.WithPredicates(projectSelectorPredicate()))
+	if r.CredentialsEvents != nil {
+		b = b.Watches(&source.Channel{Source: r.CredentialsEvents}, r.globalSecretHandler())
+	}
+	return b.Complete(r)
+}
+
+// globalSecretHandler enqueues the users of the projects using the global connection Secret once it changes, the users
+// selecting projects are enqueued if any of them uses it
+func (r *AtlasDatabaseUserReconciler) globalSecretHandler() *watch.GlobalSecretHandler {
+	return watch.NewGlobalSecretHandler(r.GlobalAPISecret, func(ctx context.Context) ([]client.ObjectKey, error) {
+		projects, err := atlas.ProjectsUsingGlobalAPISecret(ctx, r.Client, r.GlobalAPISecret)
+		if err != nil {
+			return nil, err
+		}
+
+		users := &mdbv1.AtlasDatabaseUserList{}
+		if err = r.Client.List(ctx, users); err != nil {
+			return nil, fmt.Errorf("failed to list the AtlasDatabaseUsers: %w", err)
+		}
+
+		var dependants []client.ObjectKey
+		for i := range users.Items {
+			if r.usesAnyProject(ctx, &users.Items[i], projects) {
+				dependants = append(dependants, kube.ObjectKeyFromObject(&users.Items[i]))
+			}
+		}
+		return dependants, nil
+	})
+}
+
+func (r *AtlasDatabaseUserReconciler) usesAnyProject(ctx context.Context, dbUser *mdbv1.AtlasDatabaseUser, projects map[client.ObjectKey]bool) bool {
+	if !dbUser.SelectsProjects() {
+		return projects[dbUser.AtlasProjectObjectKey()]
+	}
+
+	selected, err := r.selectedProjects(ctx, dbUser)
+	if err != nil {
+		r.Log.Warnf("failed to list the projects of the AtlasDatabaseUser %s: %s", kube.ObjectKeyFromObject(dbUser), err)
+		return false
+	}
+	for _, project := range selected {
+		if projects[kube.ObjectKeyFromObject(project)] {
+			return true
+		}
+	}
+	return false
 }
 
 func managedByAtlas(ctx context.Context, atlasClient mongodbatlas.Client, projectID string, log *zap.SugaredLogger) customresource.AtlasChecker {
@@ -263,7 +365,7 @@ func managedByAtlas(ctx context.Context, atlasClient mongodbatlas.Client, projec
 			return false, errors.New("failed to match resource type as AtlasDatabaseUser")
 		}
 
-		atlasDBUser, _, err := atlasClient.DatabaseUsers.Get(ctx, dbUser.Spec.DatabaseName, projectID, dbUser.Spec.Username)
+		atlasDBUser, _, err := atlasClient.DatabaseUsers.Get(ctx, dbUser.Spec.AuthDatabase(), projectID, dbUser.Spec.Username)
 		if err != nil {
 			var apiError *mongodbatlas.ErrorResponse
 			if errors.As(err, &apiError) && apiError.ErrorCode == atlas.UsernameNotFound {
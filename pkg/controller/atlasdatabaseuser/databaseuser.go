@@ -4,9 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.uber.org/zap"
@@ -18,46 +18,66 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasdeployment"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/compat"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/timeutil"
 )
 
-func (r *AtlasDatabaseUserReconciler) ensureDatabaseUser(ctx *workflow.Context, project mdbv1.AtlasProject, dbUser mdbv1.AtlasDatabaseUser) workflow.Result {
-	apiUser, err := dbUser.ToAtlas(r.Client)
+// ensureDatabaseUser creates or updates the user and its connection Secrets in the project. The password Secret updated
+// by a rotation is used instead of the cached one, it's returned so that the other projects of the user get the new
+// password as well. The password is only rotated if no rotated Secret is passed.
+func (r *AtlasDatabaseUserReconciler) ensureDatabaseUser(ctx *workflow.Context, project mdbv1.AtlasProject, dbUser mdbv1.AtlasDatabaseUser, rotatedSecret *corev1.Secret) (*corev1.Secret, workflow.Result) {
+	// The password must be rotated first so that the new one gets to the connection secrets below
+	if rotatedSecret == nil {
+		secret, result := r.rotatePassword(ctx, &dbUser, time.Now().UTC(), func() workflow.Result {
+			return checkDeploymentsHaveReachedGoalState(ctx, project.ID(), dbUser)
+		})
+		if !result.IsOk() {
+			return nil, result
+		}
+		rotatedSecret = secret
+	}
+
+	var kubeClient client.Client = r.Client
+	if rotatedSecret != nil {
+		kubeClient = rotatedPasswordClient{Client: r.Client, secret: rotatedSecret}
+	}
+
+	apiUser, err := dbUser.ToAtlas(kubeClient)
 	if err != nil {
-		return workflow.Terminate(workflow.Internal, err.Error())
+		return rotatedSecret, workflow.Terminate(workflow.Internal, err.Error())
 	}
 
-	if result := checkUserExpired(ctx.Log, r.Client, project.ID(), dbUser); !result.IsOk() {
-		return result
+	if result := checkUserExpired(ctx.Log, kubeClient, project.ID(), dbUser); !result.IsOk() {
+		return rotatedSecret, result
 	}
 
 	if err = validateScopes(ctx, project.ID(), dbUser); err != nil {
-		return workflow.Terminate(workflow.DatabaseUserInvalidSpec, err.Error())
+		return rotatedSecret, workflow.Terminate(workflow.DatabaseUserInvalidSpec, err.Error())
 	}
 
-	if result := performUpdateInAtlas(ctx, r.Client, project, dbUser, apiUser); !result.IsOk() {
-		return result
+	if result := performUpdateInAtlas(ctx, kubeClient, project, dbUser, apiUser); !result.IsOk() {
+		return rotatedSecret, result
 	}
 
 	if result := checkDeploymentsHaveReachedGoalState(ctx, project.ID(), dbUser); !result.IsOk() {
-		return result
+		return rotatedSecret, result
 	}
 
-	if result := connectionsecret.CreateOrUpdateConnectionSecrets(ctx, r.Client, r.EventRecorder, project, dbUser); !result.IsOk() {
-		return result
+	if result := connectionsecret.CreateOrUpdateConnectionSecrets(ctx, kubeClient, r.EventRecorder, project, dbUser); !result.IsOk() {
+		return rotatedSecret, result
 	}
 
 	// We need to remove the old Atlas User right after all the connection secrets are ensured if username has changed.
 	if result := handleUserNameChange(ctx, project.ID(), dbUser); !result.IsOk() {
-		return result
+		return rotatedSecret, result
 	}
 
 	// We mark the status.Username only when everything is finished including connection secrets
 	ctx.EnsureStatusOption(status.AtlasDatabaseUserNameOption(dbUser.Spec.Username))
 
-	return workflow.OK()
+	return rotatedSecret, workflow.OK()
 }
 
 func handleUserNameChange(ctx *workflow.Context, projectID string, dbUser mdbv1.AtlasDatabaseUser) workflow.Result {
@@ -66,7 +86,7 @@ func handleUserNameChange(ctx *workflow.Context, projectID string, dbUser mdbv1.
 
 		deleteAttempts := 3
 		for i := 1; i <= deleteAttempts; i++ {
-			_, err := ctx.Client.DatabaseUsers.Delete(context.Background(), dbUser.Spec.DatabaseName, projectID, dbUser.Status.UserName)
+			_, err := ctx.Client.DatabaseUsers.Delete(context.Background(), dbUser.Spec.AuthDatabase(), projectID, dbUser.Status.UserName)
 			if err == nil {
 				break
 			}
@@ -113,7 +133,7 @@ func performUpdateInAtlas(ctx *workflow.Context, k8sClient client.Client, projec
 	retryAfterUpdate := workflow.InProgress(workflow.DatabaseUserDeploymentAppliedChanges, "Clusters are scheduled to handle database users updates")
 
 	// Try to find the user
-	u, _, err := ctx.Client.DatabaseUsers.Get(context.Background(), dbUser.Spec.DatabaseName, project.ID(), dbUser.Spec.Username)
+	u, _, err := ctx.Client.DatabaseUsers.Get(context.Background(), dbUser.Spec.AuthDatabase(), project.ID(), dbUser.Spec.Username)
 	if err != nil {
 		var apiError *mongodbatlas.ErrorResponse
 		if errors.As(err, &apiError) && apiError.ErrorCode == atlas.UsernameNotFound {
@@ -236,34 +256,46 @@ func shouldUpdate(log *zap.SugaredLogger, atlasSpec *mongodbatlas.DatabaseUser,
 
 // TODO move to a separate utils (reuse from deployments)
 func userMatchesSpec(log *zap.SugaredLogger, atlasSpec *mongodbatlas.DatabaseUser, operatorSpec mdbv1.AtlasDatabaseUserSpec) (bool, error) {
+	fields, err := userDiff(atlasSpec, operatorSpec)
+	if err != nil {
+		return false, err
+	}
+	if len(fields) > 0 {
+		log.Debugf("Users differs from spec: %s", strings.Join(fields, ", "))
+	}
+
+	return len(fields) == 0, nil
+}
+
+// userDiff returns the fields of the database user spec that differ from the user in Atlas
+func userDiff(atlasSpec *mongodbatlas.DatabaseUser, operatorSpec mdbv1.AtlasDatabaseUserSpec) ([]string, error) {
 	userMerged := mongodbatlas.DatabaseUser{}
 	if err := compat.JSONCopy(&userMerged, atlasSpec); err != nil {
-		return false, err
+		return nil, err
 	}
 
 	if err := compat.JSONCopy(&userMerged, operatorSpec); err != nil {
-		return false, err
+		return nil, err
+	}
+	if authDatabase := operatorSpec.AuthDatabase(); authDatabase != "" {
+		userMerged.DatabaseName = authDatabase
 	}
 
 	// performing some normalization of dates
 	if atlasSpec.DeleteAfterDate != "" {
 		atlasDeleteDate, err := timeutil.ParseISO8601(atlasSpec.DeleteAfterDate)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 		atlasSpec.DeleteAfterDate = timeutil.FormatISO8601(atlasDeleteDate)
 	}
 	if operatorSpec.DeleteAfterDate != "" {
 		operatorDeleteDate, err := timeutil.ParseISO8601(operatorSpec.DeleteAfterDate)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 		userMerged.DeleteAfterDate = timeutil.FormatISO8601(operatorDeleteDate)
 	}
-	d := cmp.Diff(*atlasSpec, userMerged, cmpopts.EquateEmpty())
-	if d != "" {
-		log.Debugf("Users differs from spec: %s", d)
-	}
 
-	return d == "", nil
+	return drift.Fields(*atlasSpec, userMerged, cmpopts.EquateEmpty()), nil
 }
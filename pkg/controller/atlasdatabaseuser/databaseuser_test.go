@@ -62,10 +62,10 @@ func TestCheckUserExpired(t *testing.T) {
 	t.Run("User Marked Expired", func(t *testing.T) {
 		data := dataForSecret()
 		// Create a connection secret
-		_, err := connectionsecret.Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", data)
+		_, err := connectionsecret.Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", data, nil)
 		assert.NoError(t, err)
 		// The secret for the other project
-		_, err = connectionsecret.Ensure(fakeClient, "testNs", "project2", "dsfsdf234234sdfdsf23423", "cluster1", data)
+		_, err = connectionsecret.Ensure(fakeClient, "testNs", "project2", "dsfsdf234234sdfdsf23423", "cluster1", data, nil)
 		assert.NoError(t, err)
 
 		before := time.Now().UTC().Add(time.Minute * -1).Format("2006-01-02T15:04:05.999Z")
@@ -88,7 +88,7 @@ func TestCheckUserExpired(t *testing.T) {
 	t.Run("No expiration happened", func(t *testing.T) {
 		data := dataForSecret()
 		// Create a connection secret
-		_, err := connectionsecret.Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", data)
+		_, err := connectionsecret.Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", data, nil)
 		assert.NoError(t, err)
 		after := time.Now().UTC().Add(time.Minute * 1).Format("2006-01-02T15:04:05")
 		result := checkUserExpired(zap.S(), fakeClient, "603e7bf38a94956835659ae5", *mdbv1.DefaultDBUser("testNs", data.DBUserName, "").WithDeleteAfterDate(after))
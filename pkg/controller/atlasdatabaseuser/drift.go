@@ -0,0 +1,35 @@
+package atlasdatabaseuser
+
+import (
+	"errors"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+// checkDrift reports the differences between the database user in Atlas and its spec. The password can't be read
+// from Atlas, so its changes are never reported.
+func (r *AtlasDatabaseUserReconciler) checkDrift(ctx *workflow.Context, project *mdbv1.AtlasProject, dbUser *mdbv1.AtlasDatabaseUser, enabled bool) workflow.Result {
+	if !enabled {
+		ctx.UnsetCondition(status.DriftDetectedType)
+		return workflow.OK()
+	}
+
+	fields := []string{"user was deleted"}
+	atlasDBUser, _, err := ctx.Client.DatabaseUsers.Get(ctx.Context, dbUser.Spec.AuthDatabase(), project.ID(), dbUser.Spec.Username)
+	if err != nil {
+		var apiError *mongodbatlas.ErrorResponse
+		if !errors.As(err, &apiError) || apiError.ErrorCode != atlas.UsernameNotFound {
+			return workflow.Terminate(workflow.DatabaseUserNotUpdatedInAtlas, err.Error())
+		}
+	} else if fields, err = userDiff(atlasDBUser, dbUser.Spec); err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	return drift.Report(ctx, r.EventRecorder, dbUser, drift.PolicyFor(dbUser, r.DriftPolicy), fields)
+}
@@ -0,0 +1,406 @@
+package atlasdatabaseuser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// ensureSelectedProjects creates the user in every AtlasProject matching its projectSelector, and removes it from the
+// projects it was created in which don't match anymore. The state of the user in each project is reported in
+// status.projects. The user is removed from all the projects when it's being deleted.
+func (r *AtlasDatabaseUserReconciler) ensureSelectedProjects(workflowCtx *workflow.Context, dbUser *mdbv1.AtlasDatabaseUser, plan *dryrun.Plan) workflow.Result {
+	deleting := !dbUser.GetDeletionTimestamp().IsZero()
+	if deleting && !customresource.HaveFinalizer(dbUser, customresource.FinalizerLabel) {
+		return workflow.OK()
+	}
+
+	var projects []*mdbv1.AtlasProject
+	if !deleting {
+		var err error
+		if projects, err = r.selectedProjects(workflowCtx.Context, dbUser); err != nil {
+			return workflow.Terminate(workflow.Internal, err.Error())
+		}
+
+		if err = customresource.ApplyLastConfigApplied(workflowCtx.Context, dbUser, r.Client); err != nil {
+			return workflow.Terminate(workflow.Internal, err.Error())
+		}
+	}
+
+	previous := map[string]*status.DatabaseUserProject{}
+	for i := range dbUser.Status.Projects {
+		previous[dbUser.Status.Projects[i].Name] = &dbUser.Status.Projects[i]
+	}
+
+	var projectStatuses []status.DatabaseUserProject
+	var failures []string
+	result := workflow.OK()
+	fail := func(projectStatus status.DatabaseUserProject, projectResult workflow.Result) {
+		projectStatus.Reason = string(projectResult.GetReason())
+		projectStatus.Message = projectResult.GetMessage()
+		projectStatuses = append(projectStatuses, projectStatus)
+		failures = append(failures, fmt.Sprintf("%s: %s", projectStatus.Name, projectResult.GetMessage()))
+		if result.IsOk() || (result.IsInProgress() && projectResult.IsWarning()) {
+			result = projectResult
+		}
+	}
+
+	// The password is rotated once for all the projects, the rotated Secret is then passed to each of them so that
+	// they all get the same new password even if the cache hasn't caught up with it yet
+	rotatedSecret, rotationResult := r.rotateSelectedProjectsPassword(workflowCtx, dbUser, projects, previous, plan, time.Now().UTC())
+	if !rotationResult.IsOk() {
+		failures = append(failures, fmt.Sprintf("password rotation: %s", rotationResult.GetMessage()))
+		result = rotationResult
+	}
+
+	selected := map[string]bool{}
+	for _, project := range projects {
+		name := kube.ObjectKeyFromObject(project).String()
+		selected[name] = true
+
+		projectStatus, projectResult := r.ensureInProject(workflowCtx, dbUser, project, previous[name], rotatedSecret, plan)
+		if !projectResult.IsOk() {
+			fail(projectStatus, projectResult)
+			continue
+		}
+		projectStatuses = append(projectStatuses, projectStatus)
+	}
+
+	for name, projectStatus := range previous {
+		if selected[name] {
+			continue
+		}
+
+		if projectResult := r.removeFromProject(workflowCtx, dbUser, *projectStatus, plan); !projectResult.IsOk() {
+			fail(status.DatabaseUserProject{Name: name, ID: projectStatus.ID, UserName: projectStatus.UserName}, projectResult)
+		}
+	}
+
+	sort.Slice(projectStatuses, func(i, j int) bool {
+		return projectStatuses[i].Name < projectStatuses[j].Name
+	})
+	workflowCtx.EnsureStatusOption(status.AtlasDatabaseUserProjectsOption(projectStatuses))
+
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return result.WithMessage(strings.Join(failures, "; "))
+	}
+
+	if deleting {
+		if plan.KeepFinalizer(dbUser) {
+			return dryrun.DeletionPlanned()
+		}
+		if err := customresource.ManageFinalizer(workflowCtx.Context, r.Client, dbUser, customresource.UnsetFinalizer); err != nil {
+			return workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
+		}
+		return workflow.OK()
+	}
+
+	if err := customresource.ManageFinalizer(workflowCtx.Context, r.Client, dbUser, customresource.SetFinalizer); err != nil {
+		return workflow.Terminate(workflow.AtlasFinalizerNotSet, err.Error())
+	}
+	workflowCtx.EnsureStatusOption(status.AtlasDatabaseUserNameOption(dbUser.Spec.Username))
+
+	if len(projects) == 0 {
+		workflowCtx.Log.Infow("No AtlasProject matches the projectSelector of the database user", "selector", dbUser.Spec.ProjectSelector)
+	}
+
+	return drift.ResyncResult(workflow.OK(), resyncInterval(*dbUser, r.ResyncInterval))
+}
+
+// selectedProjects returns the AtlasProjects matching the projectSelector of the user, except the ones being deleted
+func (r *AtlasDatabaseUserReconciler) selectedProjects(ctx context.Context, dbUser *mdbv1.AtlasDatabaseUser) ([]*mdbv1.AtlasProject, error) {
+	selector, err := metav1.LabelSelectorAsSelector(dbUser.Spec.ProjectSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid projectSelector: %w", err)
+	}
+
+	projectList := &mdbv1.AtlasProjectList{}
+	if err = r.Client.List(ctx, projectList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list the AtlasProjects matching the projectSelector: %w", err)
+	}
+
+	projects := make([]*mdbv1.AtlasProject, 0, len(projectList.Items))
+	for i := range projectList.Items {
+		if projectList.Items[i].GetDeletionTimestamp().IsZero() {
+			projects = append(projects, &projectList.Items[i])
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return kube.ObjectKeyFromObject(projects[i]).String() < kube.ObjectKeyFromObject(projects[j]).String()
+	})
+
+	return projects, nil
+}
+
+// ensureInProject creates or updates the user and its connection Secrets in the project. The user is handled as if it
+// referenced the project, except for the password rotation which is done at most once per reconciliation. The password
+// Secret updated by the rotation is returned, or the one passed if the password was rotated in a previous project.
+func (r *AtlasDatabaseUserReconciler) ensureInProject(
+	workflowCtx *workflow.Context,
+	dbUser *mdbv1.AtlasDatabaseUser,
+	project *mdbv1.AtlasProject,
+	previous *status.DatabaseUserProject,
+	rotatedSecret *corev1.Secret,
+	plan *dryrun.Plan,
+) (status.DatabaseUserProject, workflow.Result) {
+	projectStatus := status.DatabaseUserProject{Name: kube.ObjectKeyFromObject(project).String(), ID: project.ID()}
+	if previous != nil {
+		projectStatus.PasswordVersion = previous.PasswordVersion
+		projectStatus.UserName = previous.UserName
+	}
+
+	if project.ID() == "" {
+		return projectStatus, workflow.Terminate(workflow.ProjectNotCreatedInAtlas, "the project is not created in Atlas yet")
+	}
+
+	projectCtx, result := r.projectContext(workflowCtx, dbUser, project, plan)
+	if !result.IsOk() {
+		return projectStatus, result
+	}
+
+	// The user is owned in the projects it was already created in. In the other ones, it must not exist in Atlas
+	// with a different spec when the deletion protection is enabled.
+	owner, err := customresource.IsOwner(
+		dbUser,
+		r.ObjectDeletionProtection,
+		func(mdbv1.AtlasCustomResource) (bool, error) { return previous != nil, nil },
+		managedByAtlas(workflowCtx.Context, projectCtx.Client, project.ID(), projectCtx.Log),
+	)
+	if err != nil {
+		return projectStatus, workflow.Terminate(workflow.Internal, fmt.Sprintf("enable to resolve ownership for deletion protection: %s", err))
+	}
+	if !owner {
+		return projectStatus, workflow.Terminate(
+			workflow.AtlasDeletionProtection,
+			"the database user already exists in the project, it was not previously managed by the operator, and the deletion protection is enabled",
+		)
+	}
+
+	projectUser := *dbUser
+	projectUser.Spec.Project = common.ResourceRefNamespaced{Name: project.Name, Namespace: project.Namespace}
+	projectUser.Status.PasswordVersion = projectStatus.PasswordVersion
+	projectUser.Status.UserName = projectStatus.UserName
+	// The password is rotated for all the projects at once before
+	projectUser.Spec.PasswordRotation = nil
+
+	_, result = r.ensureDatabaseUser(projectCtx, *project, projectUser, rotatedSecret)
+
+	// The status options of the project are applied to its own status
+	applied := status.AtlasDatabaseUserStatus{}
+	for _, option := range projectCtx.StatusOptions() {
+		option.(status.AtlasDatabaseUserStatusOption)(&applied)
+	}
+	if applied.PasswordVersion != "" {
+		projectStatus.PasswordVersion = applied.PasswordVersion
+	}
+	if applied.UserName != "" {
+		projectStatus.UserName = applied.UserName
+	}
+
+	projectStatus.Ready = result.IsOk()
+	return projectStatus, result
+}
+
+// rotateSelectedProjectsPassword rotates the password of the user once for all the selected projects. The current
+// password counts as applied in Atlas only if all the projects have the same password version, and the rotation is
+// postponed until the deployments of all the projects reach the goal state.
+func (r *AtlasDatabaseUserReconciler) rotateSelectedProjectsPassword(
+	workflowCtx *workflow.Context,
+	dbUser *mdbv1.AtlasDatabaseUser,
+	projects []*mdbv1.AtlasProject,
+	previous map[string]*status.DatabaseUserProject,
+	plan *dryrun.Plan,
+	now time.Time,
+) (*corev1.Secret, workflow.Result) {
+	if len(projects) == 0 {
+		return nil, workflow.OK()
+	}
+
+	rotationUser := dbUser.DeepCopy()
+	rotationUser.Status.PasswordVersion = ""
+	for i, project := range projects {
+		projectStatus := previous[kube.ObjectKeyFromObject(project).String()]
+		if projectStatus == nil || (i > 0 && projectStatus.PasswordVersion != rotationUser.Status.PasswordVersion) {
+			rotationUser.Status.PasswordVersion = ""
+			break
+		}
+		rotationUser.Status.PasswordVersion = projectStatus.PasswordVersion
+	}
+
+	return r.rotatePassword(workflowCtx, rotationUser, now, func() workflow.Result {
+		for _, project := range projects {
+			if project.ID() == "" {
+				return workflow.Terminate(workflow.ProjectNotCreatedInAtlas, "the project is not created in Atlas yet")
+			}
+			projectCtx, result := r.projectContext(workflowCtx, dbUser, project, plan)
+			if !result.IsOk() {
+				return result
+			}
+			if result = checkDeploymentsHaveReachedGoalState(projectCtx, project.ID(), *dbUser); !result.IsOk() {
+				return result
+			}
+		}
+		return workflow.OK()
+	})
+}
+
+// removeFromProject removes the connection Secrets of a project the user isn't created in anymore, and removes the
+// user from the project in Atlas unless it's protected. Nothing is removed from Atlas if the AtlasProject doesn't
+// exist anymore, as the project is then deleted together with its users.
+func (r *AtlasDatabaseUserReconciler) removeFromProject(workflowCtx *workflow.Context, dbUser *mdbv1.AtlasDatabaseUser, projectStatus status.DatabaseUserProject, plan *dryrun.Plan) workflow.Result {
+	userName := projectStatus.UserName
+	if userName == "" {
+		userName = dbUser.Spec.Username
+	}
+
+	// the connection secrets are kept while the removal of the user is only planned
+	if projectStatus.ID != "" && plan == nil {
+		if err := connectionsecret.RemoveStaleSecretsByUserName(r.Client, projectStatus.ID, userName, *dbUser, workflowCtx.Log); err != nil {
+			return workflow.Terminate(workflow.DatabaseUserConnectionSecretsNotDeleted, err.Error())
+		}
+	}
+
+	if customresource.IsResourceProtected(dbUser, r.ObjectDeletionProtection) {
+		workflowCtx.Log.Infow("Not removing the database user from the project in Atlas as per configuration", "project", projectStatus.Name)
+		return workflow.OK()
+	}
+
+	project := &mdbv1.AtlasProject{}
+	namespace, name, _ := strings.Cut(projectStatus.Name, "/")
+	if err := r.Client.Get(workflowCtx.Context, types.NamespacedName{Namespace: namespace, Name: name}, project); err != nil {
+		if apiErrors.IsNotFound(err) {
+			return workflow.OK()
+		}
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	if project.ID() == "" || project.ID() != projectStatus.ID {
+		return workflow.OK()
+	}
+
+	projectCtx, result := r.projectContext(workflowCtx, dbUser, project, plan)
+	if !result.IsOk() {
+		return result
+	}
+
+	_, err := projectCtx.Client.DatabaseUsers.Delete(workflowCtx.Context, dbUser.Spec.AuthDatabase(), project.ID(), userName)
+	if err != nil {
+		var apiError *mongodbatlas.ErrorResponse
+		if !errors.As(err, &apiError) || apiError.ErrorCode != atlas.UsernameNotFound {
+			return workflow.Terminate(workflow.DatabaseUserNotDeletedInAtlas, err.Error())
+		}
+	}
+	workflowCtx.Log.Infow("Removed the database user from the project", "project", projectStatus.Name, "name", userName)
+
+	return workflow.OK()
+}
+
+// projectContext returns a workflow context connected to the Atlas project with its own credentials
+func (r *AtlasDatabaseUserReconciler) projectContext(workflowCtx *workflow.Context, dbUser *mdbv1.AtlasDatabaseUser, project *mdbv1.AtlasProject, plan *dryrun.Plan) (*workflow.Context, workflow.Result) {
+	log := workflowCtx.Log.With("atlasproject", kube.ObjectKeyFromObject(project))
+	projectCtx := workflow.NewContext(log, nil, workflowCtx.Context)
+
+	connection, err := r.AtlasProvider.CreateConnection(project, log)
+	if err != nil {
+		return nil, workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+	}
+	projectCtx.Connection = connection
+
+	if !customresource.IsResourceSupportedInDomain(dbUser, connection.DomainOr(r.AtlasDomain)) {
+		return nil, workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasDatabaseUser is not supported by Atlas for government").WithoutRetry()
+	}
+
+	atlasClient, err := r.AtlasProvider.CreateClient(&connection, log, plan.ClientOpts()...)
+	if err != nil {
+		return nil, workflow.Terminate(workflow.Internal, err.Error())
+	}
+	projectCtx.Client = atlasClient
+
+	return projectCtx, workflow.OK()
+}
+
+// projectSelectorHandler enqueues the users selecting projects when an AtlasProject changes, so that they're created in
+// the projects which start matching their projectSelector and removed from the ones which stop matching it.
+func (r *AtlasDatabaseUserReconciler) projectSelectorHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		project, ok := obj.(*mdbv1.AtlasProject)
+		if !ok {
+			return nil
+		}
+
+		users := &mdbv1.AtlasDatabaseUserList{}
+		if err := r.Client.List(context.Background(), users); err != nil {
+			r.Log.Errorf("failed to list the AtlasDatabaseUsers selecting the project %s: %s", kube.ObjectKeyFromObject(project), err)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range users.Items {
+			if selectsProject(&users.Items[i], project) {
+				requests = append(requests, reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(&users.Items[i])})
+			}
+		}
+		return requests
+	})
+}
+
+// selectsProject returns true if the user selects the project or was created in it
+func selectsProject(dbUser *mdbv1.AtlasDatabaseUser, project *mdbv1.AtlasProject) bool {
+	if !dbUser.SelectsProjects() {
+		return false
+	}
+
+	name := kube.ObjectKeyFromObject(project).String()
+	for _, projectStatus := range dbUser.Status.Projects {
+		if projectStatus.Name == name {
+			return true
+		}
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(dbUser.Spec.ProjectSelector)
+	return err == nil && selector.Matches(labels.Set(project.GetLabels()))
+}
+
+// projectSelectorPredicate filters out the project changes which don't affect the users selecting projects: the ones
+// which neither change the labels of the project, create it in Atlas nor start its deletion.
+func projectSelectorPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldProject, okOld := e.ObjectOld.(*mdbv1.AtlasProject)
+			newProject, okNew := e.ObjectNew.(*mdbv1.AtlasProject)
+			if !okOld || !okNew {
+				return false
+			}
+
+			return oldProject.ID() != newProject.ID() ||
+				oldProject.GetDeletionTimestamp().IsZero() != newProject.GetDeletionTimestamp().IsZero() ||
+				!labels.Equals(oldProject.GetLabels(), newProject.GetLabels())
+		},
+	}
+}
@@ -0,0 +1,248 @@
+package atlasdatabaseuser
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/timeutil"
+)
+
+func TestSelectsProject(t *testing.T) {
+	project := selectorTestProject("prod-project", "projectID", map[string]string{"env": "prod"})
+
+	t.Run("a user selecting the labels of the project selects it", func(t *testing.T) {
+		assert.True(t, selectsProject(selectorTestUser(), project))
+	})
+
+	t.Run("a user selecting other labels doesn't select the project", func(t *testing.T) {
+		dbUser := selectorTestUser()
+		dbUser.Spec.ProjectSelector.MatchLabels["env"] = "dev"
+		assert.False(t, selectsProject(dbUser, project))
+	})
+
+	t.Run("a user created in the project selects it until it's removed from it", func(t *testing.T) {
+		dbUser := selectorTestUser()
+		dbUser.Spec.ProjectSelector.MatchLabels["env"] = "dev"
+		dbUser.Status.Projects = []status.DatabaseUserProject{{Name: "ns/prod-project", ID: "projectID", Ready: true}}
+		assert.True(t, selectsProject(dbUser, project))
+	})
+
+	t.Run("a user referencing a project doesn't select projects", func(t *testing.T) {
+		assert.False(t, selectsProject(mdbv1.DefaultDBUser("ns", "user", "prod-project"), project))
+	})
+}
+
+func TestProjectSelectorPredicate(t *testing.T) {
+	project := selectorTestProject("my-project", "projectID", map[string]string{"env": "prod"})
+	update := func(change func(project *mdbv1.AtlasProject)) bool {
+		updated := project.DeepCopy()
+		change(updated)
+		return projectSelectorPredicate().Update(event.UpdateEvent{ObjectOld: project, ObjectNew: updated})
+	}
+
+	assert.True(t, update(func(project *mdbv1.AtlasProject) { project.Labels["team"] = "a" }))
+	assert.True(t, update(func(project *mdbv1.AtlasProject) { project.Status.ID = "otherID" }))
+	assert.True(t, update(func(project *mdbv1.AtlasProject) {
+		now := metav1.Now()
+		project.DeletionTimestamp = &now
+	}))
+	assert.False(t, update(func(project *mdbv1.AtlasProject) { project.Status.ObservedGeneration = 2 }))
+}
+
+func TestEnsureSelectedProjects(t *testing.T) {
+	t.Run("the user is removed from the projects which stop matching", func(t *testing.T) {
+		dbUser := selectorTestUser()
+		dbUser.Status.Projects = []status.DatabaseUserProject{{Name: "ns/dev-project", ID: "devID", Ready: true, UserName: "user"}}
+		reconciler, deleted := selectorTestReconciler(t, dbUser, selectorTestProject("dev-project", "devID", map[string]string{"env": "dev"}))
+
+		workflowCtx := workflow.NewContext(zap.S(), []status.Condition{}, context.Background())
+		result := reconciler.ensureSelectedProjects(workflowCtx, dbUser, nil)
+		require.True(t, result.IsOk(), result.GetMessage())
+
+		assert.Equal(t, []string{"/api/atlas/v1.0/groups/devID/databaseUsers/admin/user"}, *deleted)
+		dbUser.UpdateStatus(workflowCtx.Conditions(), workflowCtx.StatusOptions()...)
+		assert.Empty(t, dbUser.Status.Projects)
+		assert.True(t, customresource.HaveFinalizer(dbUser, customresource.FinalizerLabel))
+	})
+
+	t.Run("the user is kept in Atlas when it's protected", func(t *testing.T) {
+		dbUser := selectorTestUser()
+		dbUser.Status.Projects = []status.DatabaseUserProject{{Name: "ns/dev-project", ID: "devID", Ready: true, UserName: "user"}}
+		reconciler, deleted := selectorTestReconciler(t, dbUser, selectorTestProject("dev-project", "devID", map[string]string{"env": "dev"}))
+		reconciler.ObjectDeletionProtection = true
+
+		workflowCtx := workflow.NewContext(zap.S(), []status.Condition{}, context.Background())
+		result := reconciler.ensureSelectedProjects(workflowCtx, dbUser, nil)
+		require.True(t, result.IsOk(), result.GetMessage())
+
+		assert.Empty(t, *deleted)
+		dbUser.UpdateStatus(workflowCtx.Conditions(), workflowCtx.StatusOptions()...)
+		assert.Empty(t, dbUser.Status.Projects)
+	})
+
+	t.Run("the projects which aren't created in Atlas yet are reported", func(t *testing.T) {
+		dbUser := selectorTestUser()
+		reconciler, _ := selectorTestReconciler(t, dbUser, selectorTestProject("prod-project", "", map[string]string{"env": "prod"}))
+
+		workflowCtx := workflow.NewContext(zap.S(), []status.Condition{}, context.Background())
+		result := reconciler.ensureSelectedProjects(workflowCtx, dbUser, nil)
+		require.False(t, result.IsOk())
+		assert.Equal(t, "ns/prod-project: the project is not created in Atlas yet", result.GetMessage())
+
+		dbUser.UpdateStatus(workflowCtx.Conditions(), workflowCtx.StatusOptions()...)
+		assert.Equal(t, []status.DatabaseUserProject{{
+			Name:    "ns/prod-project",
+			Reason:  string(workflow.ProjectNotCreatedInAtlas),
+			Message: "the project is not created in Atlas yet",
+		}}, dbUser.Status.Projects)
+		assert.False(t, dbUser.ReadyInProject(""))
+	})
+}
+
+func TestRotateSelectedProjectsPassword(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	rotate := func(t *testing.T, prodID string, devVersion func(version string) string) (*corev1.Secret, *corev1.Secret) {
+		dbUser := selectorTestUser().WithPasswordSecret("user-password")
+		dbUser.Spec.ProjectSelector = &metav1.LabelSelector{}
+		dbUser.Spec.PasswordRotation = &mdbv1.PasswordRotationSpec{Interval: metav1.Duration{Duration: 24 * time.Hour}}
+		dbUser.Status.LastPasswordRotation = timeutil.FormatISO8601(now.Add(-25 * time.Hour))
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "user-password", Namespace: "ns"},
+			Data:       map[string][]byte{"password": []byte("initial")},
+		}
+		projects := []*mdbv1.AtlasProject{
+			selectorTestProject("dev-project", "devID", nil),
+			selectorTestProject("prod-project", prodID, nil),
+		}
+		reconciler, _ := selectorTestReconciler(t, dbUser, secret, projects[0], projects[1])
+		reconciler.EventRecorder = record.NewFakeRecorder(10)
+
+		require.NoError(t, reconciler.Client.Get(context.Background(), kube.ObjectKeyFromObject(secret), secret))
+		previous := map[string]*status.DatabaseUserProject{
+			"ns/dev-project":  {Name: "ns/dev-project", ID: "devID", PasswordVersion: devVersion(secret.ResourceVersion)},
+			"ns/prod-project": {Name: "ns/prod-project", ID: prodID, PasswordVersion: secret.ResourceVersion},
+		}
+
+		workflowCtx := workflow.NewContext(zap.S(), []status.Condition{}, context.Background())
+		rotated, result := reconciler.rotateSelectedProjectsPassword(workflowCtx, dbUser, projects, previous, nil, now)
+		require.True(t, result.IsOk(), result.GetMessage())
+
+		updated := &corev1.Secret{}
+		require.NoError(t, reconciler.Client.Get(context.Background(), kube.ObjectKeyFromObject(secret), updated))
+		return rotated, updated
+	}
+	sameVersion := func(version string) string { return version }
+
+	t.Run("the password is rotated once for all the projects", func(t *testing.T) {
+		rotated, updated := rotate(t, "prodID", sameVersion)
+		require.NotNil(t, rotated)
+		assert.Equal(t, updated.Data, rotated.Data)
+		assert.Equal(t, "initial", string(updated.Data[previousPasswordKey]))
+	})
+
+	t.Run("the rotation waits for all the projects to apply the current password", func(t *testing.T) {
+		rotated, updated := rotate(t, "prodID", func(string) string { return "stale" })
+		assert.Nil(t, rotated)
+		assert.Equal(t, "initial", string(updated.Data["password"]))
+	})
+
+	t.Run("the rotation waits for all the projects to be created in Atlas", func(t *testing.T) {
+		rotated, updated := rotate(t, "", sameVersion)
+		assert.Nil(t, rotated)
+		assert.Equal(t, "initial", string(updated.Data["password"]))
+	})
+}
+
+func TestGlobalSecretDependants(t *testing.T) {
+	globalProject := selectorTestProject("prod-project", "projectID", map[string]string{"env": "prod"})
+	ownProject := selectorTestProject("own-project", "ownProjectID", map[string]string{"env": "dev"}).WithConnectionSecret("own-credentials")
+
+	selecting := selectorTestUser()
+	selectingOwn := selectorTestUser().WithName("selecting-own")
+	selectingOwn.Spec.ProjectSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}}
+	referencing := mdbv1.DefaultDBUser("ns", "referencing", "prod-project")
+	referencingOwn := mdbv1.DefaultDBUser("ns", "referencing-own", "own-project")
+
+	reconciler, _ := selectorTestReconciler(t, globalProject, ownProject, selecting, selectingOwn, referencing, referencingOwn)
+	dependants, err := reconciler.globalSecretHandler().Dependants(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []client.ObjectKey{kube.ObjectKeyFromObject(selecting), kube.ObjectKeyFromObject(referencing)}, dependants)
+}
+
+func selectorTestReconciler(t *testing.T, objects ...client.Object) (*AtlasDatabaseUserReconciler, *[]string) {
+	t.Helper()
+
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodDelete {
+			deleted = append(deleted, req.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if strings.HasSuffix(req.URL.Path, "/clusters") {
+			fmt.Fprint(w, `{"results": [], "totalCount": 0}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(mdbv1.AddToScheme(scheme))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "global-secret", Namespace: "operator"},
+		Data: map[string][]byte{
+			"orgId":         []byte("orgID"),
+			"publicApiKey":  []byte("public"),
+			"privateApiKey": []byte("private"),
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, secret)...).Build()
+
+	return &AtlasDatabaseUserReconciler{
+		Client:          k8sClient,
+		Log:             zap.S(),
+		AtlasDomain:     server.URL + "/",
+		AtlasProvider:   atlas.NewProductionProvider(server.URL+"/", kube.ObjectKey("operator", "global-secret"), k8sClient),
+		GlobalAPISecret: kube.ObjectKey("operator", "global-secret"),
+	}, &deleted
+}
+
+func selectorTestUser() *mdbv1.AtlasDatabaseUser {
+	dbUser := mdbv1.DefaultDBUser("ns", "user", "")
+	dbUser.Spec.DatabaseName = mdbv1.AdminAuthDatabase
+	dbUser.Spec.ProjectSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+	return dbUser
+}
+
+func selectorTestProject(name, id string, labels map[string]string) *mdbv1.AtlasProject {
+	project := mdbv1.DefaultProject("ns", "")
+	project.Name = name
+	project.Labels = labels
+	project.Status.ID = id
+	return project
+}
@@ -0,0 +1,199 @@
+package atlasdatabaseuser
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/timeutil"
+)
+
+const (
+	PasswordRotatedEvent         = "PasswordRotated"
+	PasswordRotationRefusedEvent = "PasswordRotationRefused"
+
+	previousPasswordKey = "previousPassword"
+
+	generatedPasswordLength = 32
+	generatedPasswordChars  = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// rotatePassword writes a newly generated password to the password Secret if the rotation is due. The password in use
+// is kept under the previousPassword key so that the clients can fall back to it until Atlas gets the new one, which
+// happens in the regular update of the user as the Secret changed. The rotation is persisted in the status right away,
+// so that a failure later in the reconciliation doesn't rotate the password once again. The rotation is postponed
+// while the deployments haven't reached the goal state or the current password isn't applied in Atlas yet, and
+// Secrets shared with other users are never rotated. The updated Secret is returned if the password was rotated.
+func (r *AtlasDatabaseUserReconciler) rotatePassword(ctx *workflow.Context, dbUser *mdbv1.AtlasDatabaseUser, now time.Time, deploymentsReady func() workflow.Result) (*corev1.Secret, workflow.Result) {
+	if dbUser.Spec.PasswordRotation == nil || dbUser.Spec.PasswordSecret == nil {
+		return nil, workflow.OK()
+	}
+
+	if dbUser.Status.LastPasswordRotation == "" {
+		// The password the user started with counts as the first one, so the rotation clock starts now
+		ctx.EnsureStatusOption(status.AtlasDatabaseUserLastPasswordRotationOption(timeutil.FormatISO8601(now)))
+		return nil, workflow.OK()
+	}
+
+	if nextPasswordRotation(*dbUser, now) > 0 {
+		return nil, workflow.OK()
+	}
+
+	if customresource.IsDryRun(dbUser, r.DryRun) {
+		ctx.Log.Infow("Not rotating the database user password in dry-run mode", "name", dbUser.Spec.Username)
+		return nil, workflow.OK()
+	}
+
+	sharedWith, err := r.usersSharingPasswordSecret(ctx.Context, dbUser)
+	if err != nil {
+		return nil, workflow.Terminate(workflow.Internal, err.Error())
+	}
+	if len(sharedWith) > 0 {
+		msg := fmt.Sprintf("The password Secret %s is not rotated as it's also referenced by the database users %s", dbUser.Spec.PasswordSecret.Name, strings.Join(sharedWith, ", "))
+		r.EventRecorder.Event(dbUser, "Warning", PasswordRotationRefusedEvent, msg)
+		return nil, workflow.Terminate(workflow.DatabaseUserInvalidSpec, msg)
+	}
+
+	if result := deploymentsReady(); !result.IsOk() {
+		ctx.Log.Infow("Postponing the database user password rotation until deployments reach the goal state", "name", dbUser.Spec.Username)
+		return nil, workflow.OK()
+	}
+
+	secret := &corev1.Secret{}
+	if err = r.Client.Get(ctx.Context, *dbUser.PasswordSecretObjectKey(), secret); err != nil {
+		return nil, workflow.Terminate(workflow.Internal, err.Error())
+	}
+	if dbUser.Status.PasswordVersion == "" || dbUser.Status.PasswordVersion != secret.ResourceVersion {
+		// The previous password must be the one Atlas knows about
+		ctx.Log.Infow("Postponing the database user password rotation until the current password is applied in Atlas", "name", dbUser.Spec.Username)
+		return nil, workflow.OK()
+	}
+
+	password, err := generatePassword()
+	if err != nil {
+		return nil, workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	// The Secret is updated before Atlas, so the new password is never lost: if Atlas can't be updated now, the
+	// regular update of the user retries with the password of the Secret
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[previousPasswordKey] = secret.Data["password"]
+	secret.Data["password"] = []byte(password)
+	if err = r.Client.Update(ctx.Context, secret); err != nil {
+		return nil, workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	lastRotation := timeutil.FormatISO8601(now)
+	if err = r.persistPasswordRotation(ctx.Context, dbUser, lastRotation); err != nil {
+		return nil, workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to save the password rotation: %s", err))
+	}
+	ctx.EnsureStatusOption(status.AtlasDatabaseUserLastPasswordRotationOption(lastRotation))
+
+	r.EventRecorder.Eventf(dbUser, "Normal", PasswordRotatedEvent, "Password of the database user %s was rotated", dbUser.Spec.Username)
+	ctx.Log.Infow("Rotated the database user password", "name", dbUser.Spec.Username, "secret", secret.Name)
+
+	return secret, workflow.OK()
+}
+
+// usersSharingPasswordSecret returns the names of the other database users referencing the password Secret of the user.
+func (r *AtlasDatabaseUserReconciler) usersSharingPasswordSecret(ctx context.Context, dbUser *mdbv1.AtlasDatabaseUser) ([]string, error) {
+	users := &mdbv1.AtlasDatabaseUserList{}
+	if err := r.Client.List(ctx, users, client.InNamespace(dbUser.Namespace)); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, user := range users.Items {
+		if user.Name == dbUser.Name || user.Spec.PasswordSecret == nil {
+			continue
+		}
+		if user.Spec.PasswordSecret.Name == dbUser.Spec.PasswordSecret.Name {
+			names = append(names, user.Name)
+		}
+	}
+	return names, nil
+}
+
+// rotatedPasswordClient serves the password Secret updated by a rotation instead of reading it from the cache, which
+// might not have caught up with the update yet. The new password is then used right away in Atlas and in the connection
+// Secrets of all the projects of the user.
+type rotatedPasswordClient struct {
+	client.Client
+	secret *corev1.Secret
+}
+
+func (c rotatedPasswordClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if secret, ok := obj.(*corev1.Secret); ok && key == kube.ObjectKeyFromObject(c.secret) {
+		c.secret.DeepCopyInto(secret)
+		return nil
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+// persistPasswordRotation saves the time of the rotation to the status of the user without waiting for the end of the
+// reconciliation. Only this field is patched, the rest of the status is left to the final status update.
+func (r *AtlasDatabaseUserReconciler) persistPasswordRotation(ctx context.Context, dbUser *mdbv1.AtlasDatabaseUser, lastRotation string) error {
+	persisted := &mdbv1.AtlasDatabaseUser{}
+	if err := r.Client.Get(ctx, kube.ObjectKeyFromObject(dbUser), persisted); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(persisted.DeepCopy())
+	persisted.Status.LastPasswordRotation = lastRotation
+	return r.Client.Status().Patch(ctx, persisted, patch)
+}
+
+// nextPasswordRotation returns the time left until the next password rotation. It is zero or negative if the rotation
+// is due and the full interval if the rotation has never happened.
+func nextPasswordRotation(dbUser mdbv1.AtlasDatabaseUser, now time.Time) time.Duration {
+	interval := dbUser.Spec.PasswordRotation.Interval.Duration
+	lastRotation, err := timeutil.ParseISO8601(dbUser.Status.LastPasswordRotation)
+	if err != nil {
+		return interval
+	}
+
+	return lastRotation.Add(interval).Sub(now)
+}
+
+// resyncInterval returns the time after which the user must be reconciled again so that the periodic resync and the
+// next password rotation both happen in time.
+func resyncInterval(dbUser mdbv1.AtlasDatabaseUser, resync time.Duration) time.Duration {
+	if dbUser.Spec.PasswordRotation == nil {
+		return resync
+	}
+
+	left := nextPasswordRotation(dbUser, time.Now())
+	if left <= 0 {
+		left = dbUser.Spec.PasswordRotation.Interval.Duration
+	}
+	if resync > 0 && resync < left {
+		return resync
+	}
+	return left
+}
+
+func generatePassword() (string, error) {
+	password := make([]byte, generatedPasswordLength)
+	charCount := big.NewInt(int64(len(generatedPasswordChars)))
+	for i := range password {
+		n, err := rand.Int(rand.Reader, charCount)
+		if err != nil {
+			return "", err
+		}
+		password[i] = generatedPasswordChars[n.Int64()]
+	}
+	return string(password), nil
+}
@@ -0,0 +1,215 @@
+package atlasdatabaseuser
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/timeutil"
+)
+
+func TestRotatePassword(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		lastRotation  string
+		changeStatus  mongodbatlas.ChangeStatus
+		outOfSync     bool
+		sharedSecret  bool
+		wantRotated   bool
+		wantStatusSet bool
+		wantPersisted bool
+		wantOk        bool
+	}{
+		"first reconciliation starts the rotation clock": {
+			changeStatus:  mongodbatlas.ChangeStatusApplied,
+			wantStatusSet: true,
+			wantOk:        true,
+		},
+		"rotation is not due": {
+			lastRotation: timeutil.FormatISO8601(now.Add(-time.Hour)),
+			changeStatus: mongodbatlas.ChangeStatusApplied,
+			wantOk:       true,
+		},
+		"rotation is due": {
+			lastRotation:  timeutil.FormatISO8601(now.Add(-25 * time.Hour)),
+			changeStatus:  mongodbatlas.ChangeStatusApplied,
+			wantRotated:   true,
+			wantStatusSet: true,
+			wantPersisted: true,
+			wantOk:        true,
+		},
+		"rotation is postponed while deployments apply changes": {
+			lastRotation: timeutil.FormatISO8601(now.Add(-25 * time.Hour)),
+			changeStatus: mongodbatlas.ChangeStatusPending,
+			wantOk:       true,
+		},
+		"rotation is postponed while the password isn't applied in Atlas": {
+			lastRotation: timeutil.FormatISO8601(now.Add(-25 * time.Hour)),
+			changeStatus: mongodbatlas.ChangeStatusApplied,
+			outOfSync:    true,
+			wantOk:       true,
+		},
+		"Secret shared with another user is not rotated": {
+			lastRotation: timeutil.FormatISO8601(now.Add(-25 * time.Hour)),
+			changeStatus: mongodbatlas.ChangeStatusApplied,
+			sharedSecret: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dbUser := mdbv1.DefaultDBUser("ns", "user", "project").WithPasswordSecret("user-password")
+			dbUser.Spec.Scopes = nil
+			dbUser.Spec.PasswordRotation = &mdbv1.PasswordRotationSpec{Interval: metav1.Duration{Duration: 24 * time.Hour}}
+			dbUser.Status.LastPasswordRotation = tt.lastRotation
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "user-password", Namespace: "ns"},
+				Data:       map[string][]byte{"password": []byte("initial")},
+			}
+			scheme := runtime.NewScheme()
+			utilruntime.Must(corev1.AddToScheme(scheme))
+			utilruntime.Must(mdbv1.AddToScheme(scheme))
+			objects := []client.Object{secret, dbUser.DeepCopy()}
+			if tt.sharedSecret {
+				objects = append(objects, mdbv1.DefaultDBUser("ns", "other-user", "project").WithPasswordSecret("user-password"))
+			}
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+			if !tt.outOfSync {
+				applied := &corev1.Secret{}
+				require.NoError(t, k8sClient.Get(context.Background(), kube.ObjectKeyFromObject(secret), applied))
+				dbUser.Status.PasswordVersion = applied.ResourceVersion
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				switch req.URL.Path {
+				case "/api/atlas/v1.5/groups/projectID/clusters":
+					fmt.Fprint(w, `{"results": [{"name": "cluster1"}], "totalCount": 1}`)
+				case "/api/atlas/v1.0/groups/projectID/clusters/cluster1/status":
+					fmt.Fprintf(w, `{"changeStatus": %q}`, tt.changeStatus)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+			atlasClient, err := mongodbatlas.New(server.Client(), mongodbatlas.SetBaseURL(server.URL+"/"))
+			require.NoError(t, err)
+
+			ctx := workflow.NewContext(zap.S(), []status.Condition{}, context.Background())
+			ctx.Client = *atlasClient
+			reconciler := &AtlasDatabaseUserReconciler{Client: k8sClient, EventRecorder: record.NewFakeRecorder(10)}
+
+			rotated, result := reconciler.rotatePassword(ctx, dbUser, now, func() workflow.Result {
+				return checkDeploymentsHaveReachedGoalState(ctx, "projectID", *dbUser)
+			})
+			assert.Equal(t, tt.wantOk, result.IsOk())
+
+			updated := &corev1.Secret{}
+			require.NoError(t, k8sClient.Get(context.Background(), kube.ObjectKey("ns", "user-password"), updated))
+			if tt.wantRotated {
+				require.NotNil(t, rotated)
+				assert.Equal(t, updated.Data, rotated.Data)
+				assert.Len(t, updated.Data["password"], generatedPasswordLength)
+				assert.Equal(t, "initial", string(updated.Data[previousPasswordKey]))
+				// Atlas gets the new password from the regular update of the user as the Secret changed
+				assert.NotEqual(t, updated.ResourceVersion, dbUser.Status.PasswordVersion)
+			} else {
+				assert.Nil(t, rotated)
+				assert.Equal(t, "initial", string(updated.Data["password"]))
+			}
+
+			persisted := &mdbv1.AtlasDatabaseUser{}
+			require.NoError(t, k8sClient.Get(context.Background(), kube.ObjectKeyFromObject(dbUser), persisted))
+			if tt.wantPersisted {
+				assert.Equal(t, timeutil.FormatISO8601(now), persisted.Status.LastPasswordRotation)
+			} else {
+				assert.Equal(t, tt.lastRotation, persisted.Status.LastPasswordRotation)
+			}
+
+			dbUser.UpdateStatus(ctx.Conditions(), ctx.StatusOptions()...)
+			if tt.wantStatusSet {
+				assert.Equal(t, timeutil.FormatISO8601(now), dbUser.Status.LastPasswordRotation)
+			} else {
+				assert.Equal(t, tt.lastRotation, dbUser.Status.LastPasswordRotation)
+			}
+		})
+	}
+}
+
+func TestResyncInterval(t *testing.T) {
+	dbUser := mdbv1.DefaultDBUser("ns", "user", "project")
+	assert.Equal(t, time.Duration(0), resyncInterval(*dbUser, 0))
+	assert.Equal(t, time.Hour, resyncInterval(*dbUser, time.Hour))
+
+	dbUser.Spec.PasswordRotation = &mdbv1.PasswordRotationSpec{Interval: metav1.Duration{Duration: 24 * time.Hour}}
+	assert.Equal(t, 24*time.Hour, resyncInterval(*dbUser, 0))
+	assert.Equal(t, time.Hour, resyncInterval(*dbUser, time.Hour))
+
+	dbUser.Status.LastPasswordRotation = timeutil.FormatISO8601(time.Now().UTC().Add(-23 * time.Hour))
+	assert.InDelta(t, time.Hour, resyncInterval(*dbUser, 0), float64(time.Minute))
+}
+
+func TestGeneratePassword(t *testing.T) {
+	first, err := generatePassword()
+	require.NoError(t, err)
+	second, err := generatePassword()
+	require.NoError(t, err)
+
+	assert.Len(t, first, generatedPasswordLength)
+	assert.NotEqual(t, first, second)
+}
+
+func TestRotatedPasswordClient(t *testing.T) {
+	stale := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "user-password", Namespace: "ns", ResourceVersion: "1"},
+		Data:       map[string][]byte{"password": []byte("initial")},
+	}
+	other := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"},
+		Data:       map[string][]byte{"password": []byte("other")},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(mdbv1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stale, other).Build()
+
+	rotated := stale.DeepCopy()
+	rotated.ResourceVersion = "2"
+	rotated.Data["password"] = []byte("rotated")
+	kubeClient := rotatedPasswordClient{Client: k8sClient, secret: rotated}
+
+	dbUser := mdbv1.DefaultDBUser("ns", "user", "project").WithPasswordSecret("user-password")
+	password, err := dbUser.ReadPassword(kubeClient)
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", password)
+
+	secret := &corev1.Secret{}
+	require.NoError(t, kubeClient.Get(context.Background(), kube.ObjectKey("ns", "user-password"), secret))
+	assert.Equal(t, "2", secret.ResourceVersion)
+
+	// The returned Secret is a copy, and the other Secrets are read from the client
+	secret.Data["password"] = []byte("changed")
+	assert.Equal(t, "rotated", string(rotated.Data["password"]))
+	require.NoError(t, kubeClient.Get(context.Background(), kube.ObjectKey("ns", "other"), secret))
+	assert.Equal(t, "other", string(secret.Data["password"]))
+}
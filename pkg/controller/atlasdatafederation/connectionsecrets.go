@@ -37,6 +37,11 @@ func (r *AtlasDataFederationReconciler) ensureConnectionSecrets(ctx *workflow.Co
 			continue
 		}
 
+		// LDAP and OIDC groups have no connection Secrets
+		if dbUser.Spec.IsGroup() {
+			continue
+		}
+
 		found := false
 		for _, c := range dbUser.Status.Conditions {
 			if c.Type == status.ReadyType && c.Status == v1.ConditionTrue {
@@ -45,7 +50,7 @@ func (r *AtlasDataFederationReconciler) ensureConnectionSecrets(ctx *workflow.Co
 			}
 		}
 
-		if !found {
+		if !found && !dbUser.SelectsProjects() {
 			ctx.Log.Debugw("AtlasDatabaseUser not ready - not creating connection secret", "user.name", dbUser.Name)
 			continue
 		}
@@ -60,20 +65,27 @@ func (r *AtlasDataFederationReconciler) ensureConnectionSecrets(ctx *workflow.Co
 			return workflow.Terminate(workflow.DeploymentConnectionSecretsNotCreated, err.Error())
 		}
 
+		authMechanism := connectionsecret.AuthMechanism(dbUser)
 		var connURLs []string
-		for _, host := range connectionHosts {
-			connURLs = append(connURLs, fmt.Sprintf("mongodb://%s:%s@%s?ssl=true", dbUser.Spec.Username, password, host))
+		if authMechanism != "" {
+			// users authenticated outside of Atlas have no credentials in the URL, so all hosts can share a single one
+			connURLs = append(connURLs, fmt.Sprintf("mongodb://%s/?ssl=true", strings.Join(connectionHosts, ",")))
+		} else {
+			for _, host := range connectionHosts {
+				connURLs = append(connURLs, fmt.Sprintf("mongodb://%s:%s@%s?ssl=true", dbUser.Spec.Username, password, host))
+			}
 		}
 
 		data := connectionsecret.ConnectionData{
-			DBUserName: dbUser.Spec.Username,
-			Password:   password,
-			ConnURL:    strings.Join(connURLs, ","),
+			DBUserName:    dbUser.Spec.Username,
+			Password:      password,
+			AuthMechanism: authMechanism,
+			ConnURL:       strings.Join(connURLs, ","),
 		}
 
 		ctx.Log.Debugw("Creating a connection Secret", "data", data)
 
-		secretName, err := connectionsecret.Ensure(r.Client, dbUser.Namespace, project.Spec.Name, project.ID(), df.Spec.Name, data)
+		secretName, err := connectionsecret.Ensure(r.Client, dbUser.Namespace, project.Spec.Name, project.ID(), df.Spec.Name, data, dbUser.Spec.ConnectionSecretTemplate)
 		if err != nil {
 			return workflow.Terminate(workflow.DeploymentConnectionSecretsNotCreated, err.Error())
 		}
@@ -88,6 +100,11 @@ func (r *AtlasDataFederationReconciler) ensureConnectionSecrets(ctx *workflow.Co
 }
 
 func dbUserBelongsToProject(dbUser *mdbv1.AtlasDatabaseUser, project *mdbv1.AtlasProject) bool {
+	// The users selecting projects belong to the projects they're ready in, regardless of their overall readiness
+	if dbUser.SelectsProjects() {
+		return dbUser.ReadyInProject(project.ID())
+	}
+
 	if dbUser.Spec.Project.Name != project.Name {
 		return false
 	}
@@ -12,6 +12,7 @@ import (
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/compat"
 )
@@ -100,6 +101,21 @@ func getMergedSpec(atlasSpec, operatorSpec mdbv1.DataFederationSpec) (mdbv1.Data
 	return mergedSpec, nil
 }
 
+// dataFederationDiff returns the fields of the Data Federation spec that differ from the one in Atlas
+func dataFederationDiff(atlasSpec *mongodbatlas.DataFederationInstance, operatorSpec *mdbv1.AtlasDataFederation) ([]string, error) {
+	newAtlasSpec, err := DataFederationFromAtlas(atlasSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	mergedSpec, err := getMergedSpec(*newAtlasSpec, operatorSpec.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return drift.Fields(*newAtlasSpec, mergedSpec, cmpopts.EquateEmpty()), nil
+}
+
 func dataFederationMatchesSpec(log *zap.SugaredLogger, atlasSpec *mongodbatlas.DataFederationInstance, operatorSpec *mdbv1.AtlasDataFederation) (bool, error) {
 	newAtlasSpec, err := DataFederationFromAtlas(atlasSpec)
 	if err != nil {
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -20,13 +22,17 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
@@ -39,11 +45,18 @@ type AtlasDataFederationReconciler struct {
 	Log                         *zap.SugaredLogger
 	Scheme                      *runtime.Scheme
 	AtlasDomain                 string
+	AtlasProvider               atlas.Provider
 	GlobalAPISecret             client.ObjectKey
 	GlobalPredicates            []predicate.Predicate
 	EventRecorder               record.EventRecorder
 	ObjectDeletionProtection    bool
 	SubObjectDeletionProtection bool
+	ResyncInterval              time.Duration
+	DriftPolicy                 drift.Policy
+	MaxConcurrentReconciles     int
+	DryRun                      bool
+	// CredentialsEvents receives the Secret references whose credentials changed in the credentials directory
+	CredentialsEvents <-chan event.GenericEvent
 }
 
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasdatafederations,verbs=get;list;watch;create;update;patch;delete
@@ -74,9 +87,15 @@ func (r *AtlasDataFederationReconciler) Reconcile(context context.Context, req c
 		return workflow.OK().ReconcileResult(), nil
 	}
 
+	checkDrift := drift.ShouldCheck(dataFederation, r.ResyncInterval)
 	ctx := customresource.MarkReconciliationStarted(r.Client, dataFederation, log, context)
 	log.Infow("-> Starting AtlasDataFederation reconciliation", "spec", dataFederation.Spec, "status", dataFederation.Status)
-	defer statushandler.Update(ctx, r.Client, r.EventRecorder, dataFederation)
+	plan := dryrun.PlanFor(dataFederation, r.DryRun, log)
+	defer func() {
+		ctx.EnsureStatusOption(status.DataFederationPlannedChangesOption(plan.Changes()))
+		plan.Emit(r.EventRecorder, dataFederation)
+		statushandler.Update(ctx, r.Client, r.EventRecorder, dataFederation)
+	}()
 
 	resourceVersionIsValid := customresource.ValidateResourceVersion(ctx, dataFederation, r.Log)
 	if !resourceVersionIsValid.IsOk() {
@@ -84,12 +103,12 @@ func (r *AtlasDataFederationReconciler) Reconcile(context context.Context, req c
 		return resourceVersionIsValid.ReconcileResult(), nil
 	}
 
-	if !customresource.IsResourceSupportedInDomain(dataFederation, r.AtlasDomain) {
-		result := workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasDataFederation is not supported by Atlas for government").
-			WithoutRetry()
-		ctx.SetConditionFromResult(status.DataFederationReadyType, result)
+	if err := validate.DataFederation(dataFederation); err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
 		return result.ReconcileResult(), nil
 	}
+	ctx.SetConditionTrue(status.ValidationSucceeded)
 
 	project := &mdbv1.AtlasProject{}
 	if result := r.readProjectResource(context, dataFederation, project); !result.IsOk() {
@@ -97,7 +116,7 @@ func (r *AtlasDataFederationReconciler) Reconcile(context context.Context, req c
 		return result.ReconcileResult(), nil
 	}
 
-	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
+	connection, err := r.AtlasProvider.CreateConnection(project, log)
 	if err != nil {
 		result := workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
 		ctx.SetConditionFromResult(status.DataFederationReadyType, result)
@@ -109,7 +128,14 @@ func (r *AtlasDataFederationReconciler) Reconcile(context context.Context, req c
 	}
 	ctx.Connection = connection
 
-	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
+	if !customresource.IsResourceSupportedInDomain(dataFederation, connection.DomainOr(r.AtlasDomain)) {
+		result := workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasDataFederation is not supported by Atlas for government").
+			WithoutRetry()
+		ctx.SetConditionFromResult(status.DataFederationReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	atlasClient, err := r.AtlasProvider.CreateClient(&connection, log, plan.ClientOpts()...)
 	if err != nil {
 		result := workflow.Terminate(workflow.Internal, err.Error())
 		ctx.SetConditionFromResult(status.DataFederationReadyType, result)
@@ -137,6 +163,11 @@ func (r *AtlasDataFederationReconciler) Reconcile(context context.Context, req c
 		return result.ReconcileResult(), nil
 	}
 
+	if result = r.checkDrift(ctx, project, dataFederation, checkDrift); !result.IsOk() {
+		ctx.SetConditionFromResult(status.DataFederationReadyType, result)
+		return drift.ResyncResult(result, r.ResyncInterval).ReconcileResult(), nil
+	}
+
 	if result = r.ensureDataFederation(ctx, project, dataFederation); !result.IsOk() {
 		ctx.SetConditionFromResult(status.DataFederationReadyType, result)
 		return result.ReconcileResult(), nil
@@ -179,6 +210,11 @@ func (r *AtlasDataFederationReconciler) Reconcile(context context.Context, req c
 					return result.ReconcileResult(), nil
 				}
 			}
+			if plan.KeepFinalizer(dataFederation) {
+				result = dryrun.DeletionPlanned()
+				ctx.SetConditionFromResult(status.DataFederationReadyType, result)
+				return result.ReconcileResult(), nil
+			}
 			if err = customresource.ManageFinalizer(context, r.Client, dataFederation, customresource.UnsetFinalizer); err != nil {
 				result = workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
 				log.Errorw("failed to remove finalizer", "error", err)
@@ -199,7 +235,7 @@ func (r *AtlasDataFederationReconciler) Reconcile(context context.Context, req c
 	}
 
 	ctx.SetConditionTrue(status.ReadyType)
-	return workflow.OK().ReconcileResult(), nil
+	return drift.ResyncResult(workflow.OK(), r.ResyncInterval).ReconcileResult(), nil
 }
 
 func (r *AtlasDataFederationReconciler) deleteDataFederationFromAtlas(ctx context.Context, client *mongodbatlas.Client, df *mdbv1.AtlasDataFederation, project *mdbv1.AtlasProject, log *zap.SugaredLogger) error {
@@ -229,11 +265,39 @@ func (r *AtlasDataFederationReconciler) readProjectResource(ctx context.Context,
 }
 
 func (r *AtlasDataFederationReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named("AtlasDataFederation").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Watches(&source.Kind{Type: &mdbv1.AtlasDataFederation{}}, &watch.EventHandlerWithDelete{Controller: r}, builder.WithPredicates(r.GlobalPredicates...)).
 		For(&mdbv1.AtlasDataFederation{}, builder.WithPredicates(r.GlobalPredicates...)).
-		Complete(r)
+		Watches(&source.Kind{Type: &corev1.Secret{}}, r.globalSecretHandler())
+	if r.CredentialsEvents != nil {
+		b = b.Watches(&source.Channel{Source: r.CredentialsEvents}, r.globalSecretHandler())
+	}
+	return b.Complete(r)
+}
+
+// globalSecretHandler enqueues the data federations of the projects using the global connection Secret once it changes
+func (r *AtlasDataFederationReconciler) globalSecretHandler() *watch.GlobalSecretHandler {
+	return watch.NewGlobalSecretHandler(r.GlobalAPISecret, func(ctx context.Context) ([]client.ObjectKey, error) {
+		projects, err := atlas.ProjectsUsingGlobalAPISecret(ctx, r.Client, r.GlobalAPISecret)
+		if err != nil {
+			return nil, err
+		}
+
+		dataFederations := &mdbv1.AtlasDataFederationList{}
+		if err = r.Client.List(ctx, dataFederations); err != nil {
+			return nil, fmt.Errorf("failed to list the AtlasDataFederations: %w", err)
+		}
+
+		var dependants []client.ObjectKey
+		for i := range dataFederations.Items {
+			if projects[dataFederations.Items[i].AtlasProjectObjectKey()] {
+				dependants = append(dependants, kube.ObjectKeyFromObject(&dataFederations.Items[i]))
+			}
+		}
+		return dependants, nil
+	})
 }
 
 // Delete implements a handler for the Delete event
@@ -0,0 +1,35 @@
+package atlasdatafederation
+
+import (
+	"errors"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+// checkDrift reports the differences between the Data Federation in Atlas and its spec. The fields left empty in the
+// spec keep the values set by Atlas and are not reported.
+func (r *AtlasDataFederationReconciler) checkDrift(ctx *workflow.Context, project *mdbv1.AtlasProject, dataFederation *mdbv1.AtlasDataFederation, enabled bool) workflow.Result {
+	if !enabled {
+		ctx.UnsetCondition(status.DriftDetectedType)
+		return workflow.OK()
+	}
+
+	fields := []string{"data federation was deleted"}
+	atlasDataFederation, _, err := ctx.Client.DataFederation.Get(ctx.Context, project.ID(), dataFederation.Spec.Name)
+	if err != nil {
+		var apiError *mongodbatlas.ErrorResponse
+		if !errors.As(err, &apiError) || (apiError.ErrorCode != atlas.DataFederationTenantNotFound && apiError.ErrorCode != atlas.ResourceNotFound) {
+			return workflow.Terminate(workflow.DataFederationNotUpdatedInAtlas, err.Error())
+		}
+	} else if fields, err = dataFederationDiff(atlasDataFederation, dataFederation); err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	return drift.Report(ctx, r.EventRecorder, dataFederation, drift.PolicyFor(dataFederation, r.DriftPolicy), fields)
+}
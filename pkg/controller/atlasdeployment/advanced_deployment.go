@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -15,6 +16,7 @@ import (
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/connectionsecret"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/compat"
@@ -83,8 +85,13 @@ func advancedDeploymentIdle(ctx *workflow.Context, project *mdbv1.AtlasProject,
 		return atlasDeploymentAsAtlas, workflow.Terminate(workflow.Internal, err.Error())
 	}
 
+	upgradeResult := workflow.OK()
+	if deployment.Spec.DeploymentSpec.UpgradePolicy != nil {
+		specDeployment.MongoDBMajorVersion, upgradeResult = ensureMajorVersionUpgrade(ctx, project, deployment, specDeployment, atlasDeployment, atlas.CustomFeatureCompatibilityVersion(&ctx.Client), time.Now())
+	}
+
 	if areEqual, _ := AdvancedDeploymentsEqual(ctx.Log, specDeployment, atlasDeployment); areEqual {
-		return atlasDeploymentAsAtlas, workflow.OK()
+		return atlasDeploymentAsAtlas, upgradeResult
 	}
 
 	if specDeployment.Paused != nil {
@@ -153,6 +160,11 @@ func MergedAdvancedDeployment(atlasDeploymentAsAtlas mongodbatlas.AdvancedCluste
 	atlasDeployment.MongoDBVersion = ""
 	mergedDeployment.MongoDBVersion = ""
 
+	// Online Archives are managed apart from the deployment
+	mergedDeployment.OnlineArchives = nil
+	// the upgrade policy only controls when the major version is changed
+	mergedDeployment.UpgradePolicy = nil
+
 	return
 }
 
@@ -270,6 +282,11 @@ func (r *AtlasDeploymentReconciler) ensureConnectionSecrets(ctx *workflow.Contex
 			continue
 		}
 
+		// LDAP and OIDC groups have no connection Secrets
+		if dbUser.Spec.IsGroup() {
+			continue
+		}
+
 		found := false
 		for _, c := range dbUser.Status.Conditions {
 			if c.Type == status.ReadyType && c.Status == v1.ConditionTrue {
@@ -278,7 +295,7 @@ func (r *AtlasDeploymentReconciler) ensureConnectionSecrets(ctx *workflow.Contex
 			}
 		}
 
-		if !found {
+		if !found && !dbUser.SelectsProjects() {
 			ctx.Log.Debugw("AtlasDatabaseUser not ready - not creating connection secret", "user.name", dbUser.Name)
 			continue
 		}
@@ -294,16 +311,17 @@ func (r *AtlasDeploymentReconciler) ensureConnectionSecrets(ctx *workflow.Contex
 		}
 
 		data := connectionsecret.ConnectionData{
-			DBUserName: dbUser.Spec.Username,
-			Password:   password,
-			ConnURL:    connectionStrings.Standard,
-			SrvConnURL: connectionStrings.StandardSrv,
+			DBUserName:    dbUser.Spec.Username,
+			Password:      password,
+			AuthMechanism: connectionsecret.AuthMechanism(dbUser),
+			ConnURL:       connectionStrings.Standard,
+			SrvConnURL:    connectionStrings.StandardSrv,
 		}
 		connectionsecret.FillPrivateConnStrings(connectionStrings, &data)
 
 		ctx.Log.Debugw("Creating a connection Secret", "data", data)
 
-		secretName, err := connectionsecret.Ensure(r.Client, dbUser.Namespace, project.Spec.Name, project.ID(), name, data)
+		secretName, err := connectionsecret.Ensure(r.Client, dbUser.Namespace, project.Spec.Name, project.ID(), name, data, dbUser.Spec.ConnectionSecretTemplate)
 		if err != nil {
 			return workflow.Terminate(workflow.DeploymentConnectionSecretsNotCreated, err.Error())
 		}
@@ -318,6 +336,11 @@ func (r *AtlasDeploymentReconciler) ensureConnectionSecrets(ctx *workflow.Contex
 }
 
 func dbUserBelongsToProject(dbUser *mdbv1.AtlasDatabaseUser, project *mdbv1.AtlasProject) bool {
+	// The users selecting projects belong to the projects they're ready in, regardless of their overall readiness
+	if dbUser.SelectsProjects() {
+		return dbUser.ReadyInProject(project.ID())
+	}
+
 	if dbUser.Spec.Project.Name != project.Name {
 		return false
 	}
@@ -21,22 +21,24 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
@@ -45,6 +47,9 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/connectionsecret"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/policy"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/statushandler"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/watch"
@@ -64,6 +69,14 @@ type AtlasDeploymentReconciler struct {
 	AtlasProvider               atlas.Provider
 	ObjectDeletionProtection    bool
 	SubObjectDeletionProtection bool
+	DryRun                      bool
+	ResyncInterval              time.Duration
+	DriftPolicy                 drift.Policy
+	MaxConcurrentReconciles     int
+	EnablePolicies              bool
+	GlobalAPISecret             client.ObjectKey
+	// CredentialsEvents receives the Secret references whose credentials changed in the credentials directory
+	CredentialsEvents <-chan event.GenericEvent
 }
 
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasdeployments,verbs=get;list;watch;create;update;patch;delete
@@ -81,6 +94,9 @@ type AtlasDeploymentReconciler struct {
 // +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasbackuppolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasbackuppolicies/status,verbs=get;update;patch
 
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlaspolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
 // +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
 
 func (r *AtlasDeploymentReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -106,9 +122,13 @@ func (r *AtlasDeploymentReconciler) Reconcile(context context.Context, req ctrl.
 		return workflow.OK().ReconcileResult(), nil
 	}
 
+	checkDrift := drift.ShouldCheck(deployment, r.ResyncInterval)
 	workflowCtx := customresource.MarkReconciliationStarted(r.Client, deployment, log, context)
 	log.Infow("-> Starting AtlasDeployment reconciliation", "spec", deployment.Spec, "status", deployment.Status)
+	plan := dryrun.PlanFor(deployment, r.DryRun, log)
 	defer func() {
+		workflowCtx.EnsureStatusOption(status.AtlasDeploymentPlannedChangesOption(plan.Changes()))
+		plan.Emit(r.EventRecorder, deployment)
 		statushandler.Update(workflowCtx, r.Client, r.EventRecorder, deployment)
 		r.EnsureMultiplesResourcesAreWatched(req.NamespacedName, log, workflowCtx.ListResourcesToWatch()...)
 	}()
@@ -125,21 +145,8 @@ func (r *AtlasDeploymentReconciler) Reconcile(context context.Context, req ctrl.
 		return result.ReconcileResult(), nil
 	}
 
-	if err := validate.DeploymentSpec(&deployment.Spec, r.AtlasProvider.IsCloudGov(), project.Spec.RegionUsageRestrictions); err != nil {
-		result := workflow.Terminate(workflow.Internal, err.Error())
-		workflowCtx.SetConditionFromResult(status.ValidationSucceeded, result)
-		return result.ReconcileResult(), nil
-	}
-	workflowCtx.SetConditionTrue(status.ValidationSucceeded)
-
-	if !r.AtlasProvider.IsResourceSupported(deployment) {
-		result := workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasDeployment is not supported by Atlas for government").
-			WithoutRetry()
-		workflowCtx.SetConditionFromResult(status.DeploymentReadyType, result)
-		return result.ReconcileResult(), nil
-	}
-
-	connection, err := r.AtlasProvider.CreateConnection(project.ConnectionSecretObjectKey(), log)
+	// the Atlas domain, hence the validation for Atlas for Government, depends on the connection of the project
+	connection, err := r.AtlasProvider.CreateConnection(project, log)
 	if err != nil {
 		result := workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
 		workflowCtx.SetConditionFromResult(status.DeploymentReadyType, result)
@@ -147,7 +154,7 @@ func (r *AtlasDeploymentReconciler) Reconcile(context context.Context, req ctrl.
 	}
 	workflowCtx.Connection = connection
 
-	atlasClient, err := r.AtlasProvider.CreateClient(&connection, log)
+	atlasClient, err := r.AtlasProvider.CreateClient(&connection, log, plan.ClientOpts()...)
 	if err != nil {
 		result := workflow.Terminate(workflow.Internal, err.Error())
 		workflowCtx.SetConditionFromResult(status.DeploymentReadyType, result)
@@ -155,6 +162,30 @@ func (r *AtlasDeploymentReconciler) Reconcile(context context.Context, req ctrl.
 	}
 	workflowCtx.Client = atlasClient
 
+	if err := validate.DeploymentSpec(&deployment.Spec, r.AtlasProvider.IsCloudGov(&connection), project.Spec.RegionUsageRestrictions); err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result.ReconcileResult(), nil
+	}
+	workflowCtx.SetConditionTrue(status.ValidationSucceeded)
+
+	// the deployments violating the policies can still be deleted
+	if !r.EnablePolicies {
+		workflowCtx.UnsetCondition(status.PolicyViolationType)
+	} else if deployment.GetDeletionTimestamp().IsZero() {
+		if result := policy.CheckDeployment(workflowCtx, r.Client, r.EventRecorder, deployment); !result.IsOk() {
+			workflowCtx.SetConditionFromResult(status.DeploymentReadyType, result)
+			return result.ReconcileResult(), nil
+		}
+	}
+
+	if !r.AtlasProvider.IsResourceSupported(deployment, &connection) {
+		result := workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasDeployment is not supported by Atlas for government").
+			WithoutRetry()
+		workflowCtx.SetConditionFromResult(status.DeploymentReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
 	// Allow users to specify M0/M2/M5 deployments without providing TENANT for Normal and Serverless deployments
 	r.verifyNonTenantCase(deployment)
 
@@ -162,15 +193,34 @@ func (r *AtlasDeploymentReconciler) Reconcile(context context.Context, req ctrl.
 	// convertedDeployment is always a separate copy, to avoid changes on it to go back to k8s
 	convertedDeployment := deployment.DeepCopy()
 
+	// the pause schedule is turned into the paused flag it requires now
+	now := time.Now()
+	nextPauseTransition, err := applyPauseSchedule(convertedDeployment, now)
+	if err != nil {
+		result := workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.DeploymentReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+	nextPause := ""
+	if !nextPauseTransition.IsZero() {
+		nextPause = nextPauseTransition.UTC().Format(time.RFC3339)
+	}
+	workflowCtx.EnsureStatusOption(status.AtlasDeploymentNextPauseTransitionOption(nextPause))
+
 	if result := r.checkDeploymentIsManaged(workflowCtx, log, project, convertedDeployment); !result.IsOk() {
 		return result.ReconcileResult(), nil
 	}
 
-	deletionRequest, result := r.handleDeletion(workflowCtx, log, prevResult, project, deployment)
+	deletionRequest, result := r.handleDeletion(workflowCtx, log, prevResult, project, deployment, plan)
 	if deletionRequest {
 		return result.ReconcileResult(), nil
 	}
 
+	if result := r.checkDrift(workflowCtx, project, convertedDeployment, checkDrift); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.DeploymentReadyType, result)
+		return drift.ResyncResult(result, r.ResyncInterval).ReconcileResult(), nil
+	}
+
 	if err := uniqueKey(&convertedDeployment.Spec); err != nil {
 		log.Errorw("failed to validate tags", "error", err)
 		result := workflow.Terminate(workflow.Internal, err.Error())
@@ -180,6 +230,12 @@ func (r *AtlasDeploymentReconciler) Reconcile(context context.Context, req ctrl.
 
 	handleDeployment := r.selectDeploymentHandler(convertedDeployment)
 	if result, _ := handleDeployment(workflowCtx, project, convertedDeployment, req); !result.IsOk() {
+		operationsStarted(workflowCtx, convertedDeployment, result)
+		workflowCtx.SetConditionFromResult(status.DeploymentReadyType, result)
+		return r.registerConfigAndReturn(workflowCtx, log, deployment, result), nil
+	}
+
+	if result := r.handleOperations(workflowCtx, project, convertedDeployment); !result.IsOk() {
 		workflowCtx.SetConditionFromResult(status.DeploymentReadyType, result)
 		return r.registerConfigAndReturn(workflowCtx, log, deployment, result), nil
 	}
@@ -189,9 +245,18 @@ func (r *AtlasDeploymentReconciler) Reconcile(context context.Context, req ctrl.
 			workflowCtx.SetConditionFromResult(status.DeploymentReadyType, result)
 			return r.registerConfigAndReturn(workflowCtx, log, deployment, result), nil
 		}
+
+		// Online Archives can only be created once the deployment is running, so they're applied after the deployment
+		// is updated. Their failures are reported in the OnlineArchivesReady condition, the deployment itself stays ready.
+		archivesProtected := r.SubObjectDeletionProtection || customresource.ResourceShouldBeLeftInAtlas(deployment)
+		if result := EnsureOnlineArchives(workflowCtx, project.ID(), convertedDeployment.Spec.DeploymentSpec.OnlineArchives, convertedDeployment.GetDeploymentName(), archivesProtected); !result.IsOk() {
+			workflowCtx.SetConditionFromResult(status.ReadyType, result)
+			return r.registerConfigAndReturn(workflowCtx, log, deployment, result), nil
+		}
 	}
 
-	return r.registerConfigAndReturn(workflowCtx, log, deployment, workflow.OK()), nil
+	result = pauseScheduleResult(drift.ResyncResult(workflow.OK(), r.ResyncInterval), r.ResyncInterval, nextPauseTransition, now)
+	return r.registerConfigAndReturn(workflowCtx, log, deployment, result), nil
 }
 
 func (r *AtlasDeploymentReconciler) registerConfigAndReturn(
@@ -268,6 +333,7 @@ func (r *AtlasDeploymentReconciler) handleDeletion(
 	prevResult workflow.Result,
 	project *mdbv1.AtlasProject,
 	deployment *mdbv1.AtlasDeployment, // this must be the original non converted deployment
+	plan *dryrun.Plan,
 ) (bool, workflow.Result) {
 	if deployment.GetDeletionTimestamp().IsZero() {
 		if !customresource.HaveFinalizer(deployment, customresource.FinalizerLabel) {
@@ -284,10 +350,13 @@ func (r *AtlasDeploymentReconciler) handleDeletion(
 
 	if !deployment.GetDeletionTimestamp().IsZero() {
 		if customresource.HaveFinalizer(deployment, customresource.FinalizerLabel) {
-			if err := r.cleanupBindings(workflowCtx.Context, deployment); err != nil {
-				result := workflow.Terminate(workflow.Internal, err.Error())
-				log.Errorw("failed to cleanup deployment bindings (backups)", "error", err)
-				return true, result
+			// the backup bindings are kept with the deployment while its deletion is only planned
+			if plan == nil {
+				if err := r.cleanupBindings(workflowCtx.Context, deployment); err != nil {
+					result := workflow.Terminate(workflow.Internal, err.Error())
+					log.Errorw("failed to cleanup deployment bindings (backups)", "error", err)
+					return true, result
+				}
 			}
 			isProtected := customresource.IsResourceProtected(deployment, r.ObjectDeletionProtection)
 			if isProtected {
@@ -296,7 +365,7 @@ func (r *AtlasDeploymentReconciler) handleDeletion(
 				if customresource.ResourceShouldBeLeftInAtlas(deployment) {
 					log.Infof("Not removing Atlas Deployment from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
 				} else {
-					if err := r.deleteDeploymentFromAtlas(workflowCtx, log, project, deployment); err != nil {
+					if err := r.deleteDeploymentFromAtlas(workflowCtx, log, project, deployment, plan); err != nil {
 						log.Errorf("failed to remove deployment from Atlas: %s", err)
 						result := workflow.Terminate(workflow.Internal, err.Error())
 						workflowCtx.SetConditionFromResult(status.DeploymentReadyType, result)
@@ -304,6 +373,11 @@ func (r *AtlasDeploymentReconciler) handleDeletion(
 					}
 				}
 			}
+			if plan.KeepFinalizer(deployment) {
+				result := dryrun.DeletionPlanned()
+				workflowCtx.SetConditionFromResult(status.DeploymentReadyType, result)
+				return true, result
+			}
 			err := customresource.ManageFinalizer(workflowCtx.Context, r.Client, deployment, customresource.UnsetFinalizer)
 			if err != nil {
 				result := workflow.Terminate(workflow.Internal, err.Error())
@@ -381,7 +455,7 @@ func (r *AtlasDeploymentReconciler) handleAdvancedDeployment(
 	}
 
 	if err := r.ensureBackupScheduleAndPolicy(
-		workflowCtx, project.ID(),
+		workflowCtx,
 		deployment,
 		backupEnabled,
 	); err != nil {
@@ -485,7 +559,7 @@ func (r *AtlasDeploymentReconciler) readProjectResource(ctx context.Context, dep
 }
 
 func (r *AtlasDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	c, err := controller.New("AtlasDeployment", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("AtlasDeployment", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: r.MaxConcurrentReconciles})
 	if err != nil {
 		return err
 	}
@@ -496,7 +570,15 @@ func (r *AtlasDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	// Watch for Backup schedules
+	// The operations and the upgrade approvals are requested by changing annotations only, which the global predicates
+	// filter out. The cache only holds the watched namespaces so no other predicate is needed.
+	annotations := append(operationAnnotationKeys(), UpgradeApprovalAnnotation)
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasDeployment{}}, &handler.EnqueueRequestForObject{}, watch.AnnotationsChanged(annotations...))
+	if err != nil {
+		return err
+	}
+
+	// Watch for Backup schedules, they are checked again when they change
 	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasBackupSchedule{}}, watch.NewBackupScheduleHandler(r.WatchedResources))
 	if err != nil {
 		return err
@@ -508,9 +590,69 @@ func (r *AtlasDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, r.globalSecretHandler())
+	if err != nil {
+		return err
+	}
+
+	if r.CredentialsEvents != nil {
+		err = c.Watch(&source.Channel{Source: r.CredentialsEvents}, r.globalSecretHandler())
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.EnablePolicies {
+		err = c.Watch(&source.Kind{Type: &mdbv1.AtlasPolicy{}}, r.policyHandler())
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// policyHandler enqueues all the deployments when an AtlasPolicy changes: a change of its namespace selector affects
+// the namespaces it selected before as well
+func (r *AtlasDeploymentReconciler) policyHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		deployments := &mdbv1.AtlasDeploymentList{}
+		if err := r.Client.List(context.Background(), deployments); err != nil {
+			r.Log.Errorf("failed to list the AtlasDeployments of the AtlasPolicy %s: %s", obj.GetName(), err)
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(deployments.Items))
+		for i := range deployments.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(&deployments.Items[i])})
+		}
+		return requests
+	})
+}
+
+// globalSecretHandler enqueues the deployments of the projects using the global connection Secret once it changes
+func (r *AtlasDeploymentReconciler) globalSecretHandler() *watch.GlobalSecretHandler {
+	return watch.NewGlobalSecretHandler(r.GlobalAPISecret, func(ctx context.Context) ([]client.ObjectKey, error) {
+		projects, err := atlas.ProjectsUsingGlobalAPISecret(ctx, r.Client, r.GlobalAPISecret)
+		if err != nil {
+			return nil, err
+		}
+
+		deployments := &mdbv1.AtlasDeploymentList{}
+		if err = r.Client.List(ctx, deployments); err != nil {
+			return nil, fmt.Errorf("failed to list the AtlasDeployments: %w", err)
+		}
+
+		var dependants []client.ObjectKey
+		for i := range deployments.Items {
+			if projects[deployments.Items[i].AtlasProjectObjectKey()] {
+				dependants = append(dependants, kube.ObjectKeyFromObject(&deployments.Items[i]))
+			}
+		}
+		return dependants, nil
+	})
+}
+
 // Delete implements a handler for the Delete event.
 func (r *AtlasDeploymentReconciler) deleteConnectionStrings(
 	context context.Context,
@@ -541,14 +683,18 @@ func (r *AtlasDeploymentReconciler) deleteDeploymentFromAtlas(
 	log *zap.SugaredLogger,
 	project *mdbv1.AtlasProject,
 	deployment *mdbv1.AtlasDeployment,
+	plan *dryrun.Plan,
 ) error {
 	log.Infow("-> Starting AtlasDeployment deletion", "spec", deployment.Spec)
 
-	err := r.deleteConnectionStrings(workflowCtx.Context, log, project, deployment)
-	if err != nil {
-		return err
+	// the connection secrets are kept with the deployment while its deletion is only planned
+	if plan == nil {
+		if err := r.deleteConnectionStrings(workflowCtx.Context, log, project, deployment); err != nil {
+			return err
+		}
 	}
 
+	var err error
 	atlasClient := workflowCtx.Client
 	if deployment.IsServerless() {
 		_, err = atlasClient.ServerlessInstances.Delete(workflowCtx.Context, project.Status.ID, deployment.GetDeploymentName())
@@ -643,52 +789,67 @@ func findTypedAtlasCluster(workflowCtx *workflow.Context, projectID, deploymentN
 }
 
 func deploymentMatchesSpec(log *zap.SugaredLogger, atlasSpec *atlasTypedCluster, deployment *mdbv1.AtlasDeployment) (bool, error) {
+	fields, err := deploymentDiff(atlasSpec, deployment)
+	if err != nil {
+		return false, err
+	}
+	if len(fields) > 0 {
+		log.Debugf("Deployment differs from spec: %s", strings.Join(fields, ", "))
+	}
+
+	return len(fields) == 0, nil
+}
+
+// deploymentDiff returns the fields of the deployment spec that differ from the deployment in Atlas
+func deploymentDiff(atlasSpec *atlasTypedCluster, deployment *mdbv1.AtlasDeployment) ([]string, error) {
 	if deployment.IsServerless() {
 		if atlasSpec.clusterType != Serverless {
-			return false, nil
+			return []string{"serverlessSpec"}, nil
 		}
-		return serverlessDeploymentMatchesSpec(log, atlasSpec.serverless, deployment.Spec.ServerlessSpec)
+		return serverlessDeploymentDiff(atlasSpec.serverless, deployment.Spec.ServerlessSpec)
 	}
 	if atlasSpec.clusterType != Advanced {
-		return false, nil
+		return []string{"deploymentSpec"}, nil
 	}
-	return advancedDeploymentMatchesSpec(log, atlasSpec.advanced, deployment.Spec.DeploymentSpec)
+	return advancedDeploymentDiff(atlasSpec.advanced, deployment.Spec.DeploymentSpec)
 }
 
-func serverlessDeploymentMatchesSpec(log *zap.SugaredLogger, atlasSpec *mongodbatlas.Cluster, operatorSpec *mdbv1.ServerlessSpec) (bool, error) {
+func serverlessDeploymentDiff(atlasSpec *mongodbatlas.Cluster, operatorSpec *mdbv1.ServerlessSpec) ([]string, error) {
 	clusterMerged := mongodbatlas.Cluster{}
 	if err := compat.JSONCopy(&clusterMerged, atlasSpec); err != nil {
-		return false, err
+		return nil, err
 	}
 
 	if err := compat.JSONCopy(&clusterMerged, operatorSpec); err != nil {
-		return false, err
+		return nil, err
 	}
 
-	d := cmp.Diff(atlasSpec, &clusterMerged, cmpopts.EquateEmpty())
-	if d != "" {
-		log.Debugf("Serverless deployment differs from spec: %s", d)
+	return drift.Fields(atlasSpec, &clusterMerged, cmpopts.EquateEmpty()), nil
+}
+
+func advancedDeploymentMatchesSpec(log *zap.SugaredLogger, atlasSpec *mongodbatlas.AdvancedCluster, operatorSpec *mdbv1.AdvancedDeploymentSpec) (bool, error) {
+	fields, err := advancedDeploymentDiff(atlasSpec, operatorSpec)
+	if err != nil {
+		return false, err
+	}
+	if len(fields) > 0 {
+		log.Debugf("Advanced deployment differs from spec: %s", strings.Join(fields, ", "))
 	}
 
-	return d == "", nil
+	return len(fields) == 0, nil
 }
 
-func advancedDeploymentMatchesSpec(log *zap.SugaredLogger, atlasSpec *mongodbatlas.AdvancedCluster, operatorSpec *mdbv1.AdvancedDeploymentSpec) (bool, error) {
+func advancedDeploymentDiff(atlasSpec *mongodbatlas.AdvancedCluster, operatorSpec *mdbv1.AdvancedDeploymentSpec) ([]string, error) {
 	clusterMerged := mongodbatlas.AdvancedCluster{}
 	if err := compat.JSONCopy(&clusterMerged, atlasSpec); err != nil {
-		return false, err
+		return nil, err
 	}
 
 	if err := compat.JSONCopy(&clusterMerged, operatorSpec); err != nil {
-		return false, err
-	}
-
-	d := cmp.Diff(atlasSpec, &clusterMerged, cmpopts.EquateEmpty())
-	if d != "" {
-		log.Debugf("Advanced deployment differs from spec: %s", d)
+		return nil, err
 	}
 
-	return d == "", nil
+	return drift.Fields(atlasSpec, &clusterMerged, cmpopts.EquateEmpty()), nil
 }
 
 // Parse through tags and verify that all keys are unique. Return error otherwise.
@@ -38,12 +38,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/controllertest"
 	atlas_mock "github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/mocks/atlas"
 	v1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
@@ -211,6 +213,7 @@ func TestFinalizerNotFound(t *testing.T) {
 		te.prevResult,
 		te.project,
 		te.deployment,
+		nil,
 	)
 
 	require.True(t, deletionRequest)
@@ -250,6 +253,7 @@ func TestFinalizerGetsSet(t *testing.T) {
 				te.prevResult,
 				te.project,
 				te.deployment,
+				nil,
 			)
 
 			require.False(t, deletionRequest)
@@ -301,6 +305,7 @@ func TestDeploymentDeletionProtection(t *testing.T) {
 				te.prevResult,
 				te.project,
 				te.deployment,
+				nil,
 			)
 
 			require.True(t, deletionRequest)
@@ -310,6 +315,47 @@ func TestDeploymentDeletionProtection(t *testing.T) {
 	}
 }
 
+func TestDryRunDeploymentDeletionKeepsFinalizer(t *testing.T) {
+	advancedClusterClient := &atlas_mock.AdvancedClustersClientMock{
+		DeleteFunc: func(groupID string, clusterName string) (*mongodbatlas.Response, error) {
+			return nil, nil
+		},
+	}
+	project := testProject(fakeNamespace)
+	atlasClient := mongodbatlas.Client{
+		AdvancedClusters: advancedClusterClient,
+	}
+	deployment := v1.NewDeployment(project.Namespace, fakeDeployment, fakeDeployment)
+	deployment.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	k8sclient := testK8sClient()
+	customresource.SetFinalizer(deployment, customresource.FinalizerLabel)
+	require.NoError(t, k8sclient.Create(context.Background(), deployment))
+	te := newTestDeploymentEnv(t, false, atlasClient, k8sclient, project, deployment)
+	plan := dryrun.NewPlan()
+
+	deletionRequest, result := te.reconciler.handleDeletion(
+		te.workflowCtx,
+		te.log,
+		te.prevResult,
+		te.project,
+		te.deployment,
+		plan,
+	)
+
+	require.True(t, deletionRequest)
+	assert.True(t, result.IsInProgress())
+	assert.Equal(t, workflow.AtlasDryRunDeletionPlanned, result.GetReason())
+	assert.Len(t, advancedClusterClient.DeleteRequests, 1)
+	assert.Equal(t,
+		[]status.PlannedChange{{Action: dryrun.ActionDelete, Path: client.ObjectKeyFromObject(deployment).String()}},
+		plan.Changes(),
+	)
+
+	finalDeployment := &v1.AtlasDeployment{}
+	require.NoError(t, k8sclient.Get(context.Background(), client.ObjectKeyFromObject(deployment), finalDeployment))
+	assert.True(t, customresource.HaveFinalizer(finalDeployment, customresource.FinalizerLabel))
+}
+
 func TestKeepAnnotatedDeploymentAlwaysRemain(t *testing.T) {
 	testCases := []struct {
 		title     string
@@ -352,6 +398,7 @@ func TestKeepAnnotatedDeploymentAlwaysRemain(t *testing.T) {
 				te.prevResult,
 				te.project,
 				te.deployment,
+				nil,
 			)
 
 			require.True(t, deletionRequest)
@@ -403,6 +450,7 @@ func TestDeleteAnnotatedDeploymentGetRemoved(t *testing.T) {
 				te.prevResult,
 				te.project,
 				te.deployment,
+				nil,
 			)
 
 			require.True(t, deletionRequest)
@@ -632,6 +680,7 @@ func newTestDeploymentEnv(t *testing.T,
 func testK8sClient() client.Client {
 	sch := runtime.NewScheme()
 	sch.AddKnownTypes(v1.GroupVersion, &v1.AtlasDeployment{})
+	sch.AddKnownTypes(v1.GroupVersion, &v1.AtlasDeploymentList{})
 	sch.AddKnownTypes(v1.GroupVersion, &v1.AtlasBackupSchedule{})
 	sch.AddKnownTypes(v1.GroupVersion, &v1.AtlasBackupScheduleList{})
 	sch.AddKnownTypes(v1.GroupVersion, &v1.AtlasBackupPolicy{})
@@ -971,3 +1020,135 @@ func TestReconciliation(t *testing.T) {
 		assert.Equal(t, ctrl.Result{Requeue: false, RequeueAfter: 0}, result)
 	})
 }
+
+func TestOnlineArchivesReconciliation(t *testing.T) {
+	atlasCluster := func(instanceSize string) *mongodbatlas.AdvancedCluster {
+		return &mongodbatlas.AdvancedCluster{
+			ID:          "123789",
+			Name:        "test-deployment-advanced",
+			GroupID:     "abc123",
+			ClusterType: "REPLICASET",
+			ReplicationSpecs: []*mongodbatlas.AdvancedReplicationSpec{
+				{
+					ID:       "789123",
+					ZoneName: "Zone 1",
+					RegionConfigs: []*mongodbatlas.AdvancedRegionConfig{
+						{
+							ProviderName: "AWS",
+							RegionName:   "US_EAST_1",
+							ElectableSpecs: &mongodbatlas.Specs{
+								InstanceSize: instanceSize,
+								NodeCount:    toptr.MakePtr(3),
+							},
+							Priority: toptr.MakePtr(7),
+						},
+					},
+				},
+			},
+			StateName: "IDLE",
+		}
+	}
+
+	reconcile := func(t *testing.T, cluster *mongodbatlas.AdvancedCluster, clusters *atlas_mock.AdvancedClustersClientMock, archives *atlas_mock.OnlineArchiveClientMock, annotations map[string]string) *v1.AtlasDeployment {
+		project := &v1.AtlasProject{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-project", Namespace: "default"},
+			Spec:       v1.AtlasProjectSpec{Name: "MyProject"},
+			Status:     status.AtlasProjectStatus{ID: "abc123"},
+		}
+		deployment := v1.DefaultAwsAdvancedDeployment(project.Namespace, project.Name)
+		deployment.Spec.DeploymentSpec.OnlineArchives = []v1.OnlineArchive{testOnlineArchive()}
+		deployment.Annotations = annotations
+
+		sch := runtime.NewScheme()
+		sch.AddKnownTypes(corev1.SchemeGroupVersion, &corev1.Secret{}, &corev1.SecretList{})
+		sch.AddKnownTypes(v1.GroupVersion, &v1.AtlasProject{}, &v1.AtlasDeployment{}, &v1.AtlasDatabaseUserList{}, &v1.AtlasBackupScheduleList{})
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(sch).
+			WithObjects(project, deployment).
+			Build()
+
+		clusters.GetFunc = func(projectID string, clusterName string) (*mongodbatlas.AdvancedCluster, *mongodbatlas.Response, error) {
+			return cluster, nil, nil
+		}
+		reconciler := &AtlasDeploymentReconciler{
+			ResourceWatcher: watch.NewResourceWatcher(),
+			Client:          k8sClient,
+			Log:             zaptest.NewLogger(t).Sugar(),
+			EventRecorder:   record.NewFakeRecorder(10),
+			AtlasProvider: &atlas_mock.TestProvider{
+				CreateConnectionFunc: func(secretRef *client.ObjectKey) (atlas.Connection, error) {
+					return atlas.Connection{OrgID: "0987654321", PublicKey: "a1b2c3", PrivateKey: "abcdef123456"}, nil
+				},
+				CreateClientFunc: func() (mongodbatlas.Client, error) {
+					return mongodbatlas.Client{
+						AdvancedClusters: clusters,
+						GlobalClusters: &atlas_mock.GlobalClustersClientMock{
+							GetFunc: func(projectID string, clusterName string) (*mongodbatlas.GlobalCluster, *mongodbatlas.Response, error) {
+								return &mongodbatlas.GlobalCluster{}, nil, nil
+							},
+						},
+						OnlineArchives: archives,
+					}, nil
+				},
+				IsCloudGovFunc:  func() bool { return false },
+				IsSupportedFunc: func() bool { return true },
+			},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(deployment)})
+		require.NoError(t, err)
+
+		reconciled := &v1.AtlasDeployment{}
+		require.NoError(t, k8sClient.Get(context.Background(), kube.ObjectKeyFromObject(deployment), reconciled))
+		return reconciled
+	}
+
+	t.Run("the deployment is updated before the online archives are applied", func(t *testing.T) {
+		clusters := &atlas_mock.AdvancedClustersClientMock{
+			UpdateFunc: func(projectID string, clusterName string, cluster *mongodbatlas.AdvancedCluster) (*mongodbatlas.AdvancedCluster, *mongodbatlas.Response, error) {
+				return cluster, nil, nil
+			},
+		}
+		archives := &atlas_mock.OnlineArchiveClientMock{}
+
+		reconciled := reconcile(t, atlasCluster("M20"), clusters, archives, nil)
+
+		assert.Len(t, clusters.UpdateRequests, 1)
+		assert.Empty(t, archives.ListRequests)
+		controllertest.AssertCondition(t, reconciled, status.DeploymentReadyType, corev1.ConditionFalse, string(workflow.DeploymentUpdating))
+	})
+
+	t.Run("the online archive failures are reported in their own condition", func(t *testing.T) {
+		clusters := &atlas_mock.AdvancedClustersClientMock{}
+		archives := &atlas_mock.OnlineArchiveClientMock{
+			ListFunc: func(projectID, clusterName string, options *mongodbatlas.ListOptions) (*mongodbatlas.OnlineArchives, *mongodbatlas.Response, error) {
+				return nil, nil, fmt.Errorf("failed to list the archives")
+			},
+		}
+
+		reconciled := reconcile(t, atlasCluster("M10"), clusters, archives, nil)
+
+		assert.Empty(t, clusters.UpdateRequests)
+		assert.Len(t, archives.ListRequests, 1)
+		controllertest.AssertCondition(t, reconciled, status.DeploymentReadyType, corev1.ConditionTrue, "")
+		controllertest.AssertCondition(t, reconciled, status.OnlineArchivesReadyType, corev1.ConditionFalse, string(workflow.OnlineArchivesReady))
+		controllertest.AssertCondition(t, reconciled, status.ReadyType, corev1.ConditionFalse, string(workflow.OnlineArchivesReady))
+	})
+
+	t.Run("the removed archives are kept when the deployment has the keep annotation", func(t *testing.T) {
+		clusters := &atlas_mock.AdvancedClustersClientMock{}
+		removed := testAtlasOnlineArchive()
+		removed.ID = "removed-archive-id"
+		removed.CollName = "invoices"
+		archives := &atlas_mock.OnlineArchiveClientMock{
+			ListFunc: func(projectID, clusterName string, options *mongodbatlas.ListOptions) (*mongodbatlas.OnlineArchives, *mongodbatlas.Response, error) {
+				return &mongodbatlas.OnlineArchives{Results: []*mongodbatlas.OnlineArchive{testAtlasOnlineArchive(), removed}}, &mongodbatlas.Response{}, nil
+			},
+		}
+
+		reconciled := reconcile(t, atlasCluster("M10"), clusters, archives, map[string]string{customresource.ResourcePolicyAnnotation: customresource.ResourcePolicyKeep})
+
+		assert.Empty(t, archives.DeleteRequests)
+		controllertest.AssertCondition(t, reconciled, status.OnlineArchivesReadyType, corev1.ConditionTrue, "")
+	})
+}
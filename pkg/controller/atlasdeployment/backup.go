@@ -5,29 +5,23 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
+	"golang.org/x/sync/errgroup"
 
-	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
-	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/compat"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
 
-	"go.mongodb.org/atlas/mongodbatlas"
-	"golang.org/x/sync/errgroup"
-
-	"sigs.k8s.io/controller-runtime/pkg/client"
-
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
 )
 
+// ensureBackupScheduleAndPolicy checks the backup schedule and policy referenced by the deployment and binds them to
+// it, so that they can't be deleted while in use. They are applied to Atlas by the AtlasBackupSchedule controller.
 func (r *AtlasDeploymentReconciler) ensureBackupScheduleAndPolicy(
 	service *workflow.Context,
-	projectID string,
 	deployment *mdbv1.AtlasDeployment,
 	isEnabled bool,
 ) error {
@@ -56,14 +50,12 @@ func (r *AtlasDeploymentReconciler) ensureBackupScheduleAndPolicy(
 		return err
 	}
 
-	bPolicy, err := r.ensureBackupPolicy(service, bSchedule, &resourcesToWatch)
-	if err != nil {
-		return err
-	}
-
-	return r.updateBackupScheduleAndPolicy(service.Context, service, projectID, deployment, bSchedule, bPolicy)
+	_, err = r.ensureBackupPolicy(service, bSchedule, &resourcesToWatch)
+	return err
 }
 
+// ensureBackupSchedule reads the backup schedule of the deployment and records the deployment in its status. The
+// finalizer of the schedule is set while it's bound to deployments.
 func (r *AtlasDeploymentReconciler) ensureBackupSchedule(
 	service *workflow.Context,
 	deployment *mdbv1.AtlasDeployment,
@@ -87,7 +79,7 @@ func (r *AtlasDeploymentReconciler) ensureBackupSchedule(
 		return nil, err
 	}
 
-	if !r.AtlasProvider.IsResourceSupported(bSchedule) {
+	if !r.AtlasProvider.IsResourceSupported(bSchedule, &service.Connection) {
 		return nil, errors.New("the AtlasBackupSchedule is not supported by Atlas for government")
 	}
 
@@ -122,6 +114,8 @@ func (r *AtlasDeploymentReconciler) ensureBackupSchedule(
 	return bSchedule, nil
 }
 
+// ensureBackupPolicy reads the backup policy of the backup schedule and records the schedule in its status. The
+// finalizer of the policy is set while it's bound to schedules.
 func (r *AtlasDeploymentReconciler) ensureBackupPolicy(
 	service *workflow.Context,
 	bSchedule *mdbv1.AtlasBackupSchedule,
@@ -141,7 +135,11 @@ func (r *AtlasDeploymentReconciler) ensureBackupPolicy(
 		return nil, errors.New(errText)
 	}
 
-	if !r.AtlasProvider.IsResourceSupported(bPolicy) {
+	if err = validate.BackupPolicy(bPolicy); err != nil {
+		return nil, err
+	}
+
+	if !r.AtlasProvider.IsResourceSupported(bPolicy, &service.Connection) {
 		return nil, errors.New("the AtlasBackupPolicy is not supported by Atlas for government")
 	}
 
@@ -177,92 +175,8 @@ func (r *AtlasDeploymentReconciler) ensureBackupPolicy(
 	return bPolicy, nil
 }
 
-func (r *AtlasDeploymentReconciler) updateBackupScheduleAndPolicy(
-	ctx context.Context,
-	service *workflow.Context,
-	projectID string,
-	deployment *mdbv1.AtlasDeployment,
-	bSchedule *mdbv1.AtlasBackupSchedule,
-	bPolicy *mdbv1.AtlasBackupPolicy,
-) error {
-	clusterName := deployment.GetDeploymentName()
-	currentSchedule, response, err := service.Client.CloudProviderSnapshotBackupPolicies.Get(ctx, projectID, clusterName)
-	if err != nil {
-		errMessage := "unable to get current backup configuration for project"
-		r.Log.Debugf("%s: %s:%s, %v", errMessage, projectID, clusterName, err)
-		return fmt.Errorf("%s: %s:%s, %w", errMessage, projectID, clusterName, err)
-	}
-
-	if currentSchedule == nil && response != nil {
-		return fmt.Errorf("can not get сurrent backup configuration. response status: %s", response.Status)
-	}
-
-	r.Log.Debugf("successfully received backup configuration: %v", currentSchedule)
-
-	r.Log.Debugf("updating backup configuration for the atlas deployment: %v", clusterName)
-
-	apiScheduleReq := bSchedule.ToAtlas(currentSchedule.ClusterID, clusterName, deployment.GetReplicationSetID(), bPolicy)
-
-	// There is only one policy, always
-	apiScheduleReq.Policies[0].ID = currentSchedule.Policies[0].ID
-
-	equal, err := backupSchedulesAreEqual(currentSchedule, apiScheduleReq)
-	if err != nil {
-		return fmt.Errorf("can not compare BackupSchedule resources: %w", err)
-	}
-
-	if equal {
-		r.Log.Debug("backup schedules are equal, nothing to change")
-		return nil
-	}
-
-	r.Log.Debugf("applying backup configuration: %v", *bSchedule)
-	if _, _, err := service.Client.CloudProviderSnapshotBackupPolicies.Update(ctx, projectID, clusterName, apiScheduleReq); err != nil {
-		return fmt.Errorf("unable to create backup schedule %s. e: %w", client.ObjectKeyFromObject(bSchedule).String(), err)
-	}
-	r.Log.Infof("successfully updated backup configuration for deployment %v", clusterName)
-	return nil
-}
-
-func backupSchedulesAreEqual(currentSchedule *mongodbatlas.CloudProviderSnapshotBackupPolicy, newSchedule *mongodbatlas.CloudProviderSnapshotBackupPolicy) (bool, error) {
-	currentCopy := mongodbatlas.CloudProviderSnapshotBackupPolicy{}
-	err := compat.JSONCopy(&currentCopy, currentSchedule)
-	if err != nil {
-		return false, err
-	}
-
-	newCopy := mongodbatlas.CloudProviderSnapshotBackupPolicy{}
-	err = compat.JSONCopy(&newCopy, newSchedule)
-	if err != nil {
-		return false, err
-	}
-
-	normalizeBackupSchedule(&currentCopy)
-	normalizeBackupSchedule(&newCopy)
-	d := cmp.Diff(&currentCopy, &newCopy, cmpopts.EquateEmpty())
-	if d != "" {
-		return false, nil
-	}
-	return true, nil
-}
-
-func normalizeBackupSchedule(s *mongodbatlas.CloudProviderSnapshotBackupPolicy) {
-	s.Links = nil
-	s.NextSnapshot = ""
-	if len(s.Policies) > 0 && len(s.Policies[0].PolicyItems) > 0 {
-		for i := range s.Policies[0].PolicyItems {
-			s.Policies[0].PolicyItems[i].ID = ""
-		}
-	}
-	s.UpdateSnapshots = nil
-
-	if len(s.CopySettings) > 0 {
-		for i := range s.CopySettings {
-			s.CopySettings[i].ReplicationSpecID = nil
-		}
-	}
-}
-
+// garbageCollectBackupResource unbinds the deployment from the backup schedules recording it. The schedules and the
+// policies which aren't bound anymore lose their finalizer.
 func (r *AtlasDeploymentReconciler) garbageCollectBackupResource(ctx context.Context, clusterName string) error {
 	schedules := &mdbv1.AtlasBackupScheduleList{}
 
@@ -282,7 +196,7 @@ func (r *AtlasDeploymentReconciler) garbageCollectBackupResource(ctx context.Con
 
 				backupSchedule.UpdateStatus([]status.Condition{}, status.AtlasBackupScheduleUnsetDeploymentID(clusterName))
 
-				if err = r.Client.Status().Update(ctx, &backupSchedule); err != nil {
+				if err := r.Client.Status().Update(ctx, &backupSchedule); err != nil {
 					r.Log.Errorw("failed to update BackupSchedule status", "error", err)
 					return err
 				}
@@ -294,7 +208,7 @@ func (r *AtlasDeploymentReconciler) garbageCollectBackupResource(ctx context.Con
 					lastScheduleRef = true
 				}
 
-				if err = r.Client.Update(ctx, &backupSchedule); err != nil {
+				if err := r.Client.Update(ctx, &backupSchedule); err != nil {
 					r.Log.Errorw("failed to update BackupSchedule object", "error", err)
 					return err
 				}
@@ -305,15 +219,14 @@ func (r *AtlasDeploymentReconciler) garbageCollectBackupResource(ctx context.Con
 
 				bPolicy := &mdbv1.AtlasBackupPolicy{}
 				bPolicyRef := *backupSchedule.Spec.PolicyRef.GetObject(backupSchedule.Namespace)
-				err = r.Client.Get(ctx, bPolicyRef, bPolicy)
-				if err != nil {
-					return fmt.Errorf("failed to retrieve list of backup schedules: %w", err)
+				if err := r.Client.Get(ctx, bPolicyRef, bPolicy); err != nil {
+					return fmt.Errorf("failed to retrieve the backup policy %s: %w", bPolicyRef, err)
 				}
 
 				scheduleRef := kube.ObjectKeyFromObject(&backupSchedule).String()
 				bPolicy.UpdateStatus([]status.Condition{}, status.AtlasBackupPolicyUnsetScheduleID(scheduleRef))
 
-				if err = r.Client.Status().Update(ctx, bPolicy); err != nil {
+				if err := r.Client.Status().Update(ctx, bPolicy); err != nil {
 					r.Log.Errorw("failed to update BackupPolicy status", "error", err)
 					return err
 				}
@@ -323,7 +236,7 @@ func (r *AtlasDeploymentReconciler) garbageCollectBackupResource(ctx context.Con
 					customresource.UnsetFinalizer(bPolicy, customresource.FinalizerLabel)
 				}
 
-				if err = r.Client.Update(ctx, bPolicy); err != nil {
+				if err := r.Client.Update(ctx, bPolicy); err != nil {
 					r.Log.Errorw("failed to update BackupPolicy object", "error", err)
 					return err
 				}
@@ -333,9 +246,5 @@ func (r *AtlasDeploymentReconciler) garbageCollectBackupResource(ctx context.Con
 		})
 	}
 
-	if err = g.Wait(); err != nil {
-		return err
-	}
-
-	return nil
+	return g.Wait()
 }
@@ -0,0 +1,31 @@
+package atlasdeployment
+
+import (
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+// checkDrift reports the differences between the deployment in Atlas and its spec. A deployment deleted in Atlas
+// is reported as well, the rest of the reconciliation creates it again.
+func (r *AtlasDeploymentReconciler) checkDrift(workflowCtx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment, enabled bool) workflow.Result {
+	if !enabled {
+		workflowCtx.UnsetCondition(status.DriftDetectedType)
+		return workflow.OK()
+	}
+
+	typedAtlasCluster, err := findTypedAtlasCluster(workflowCtx, project.ID(), deployment.GetDeploymentName())
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	fields := []string{"deployment was deleted"}
+	if typedAtlasCluster != nil {
+		if fields, err = deploymentDiff(typedAtlasCluster, deployment); err != nil {
+			return workflow.Terminate(workflow.Internal, err.Error())
+		}
+	}
+
+	return drift.Report(workflowCtx, r.EventRecorder, deployment, drift.PolicyFor(deployment, r.DriftPolicy), fields)
+}
@@ -0,0 +1,226 @@
+package atlasdeployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+const onlineArchiveStateDeleted = "DELETED"
+
+// EnsureOnlineArchives creates, updates, pauses and resumes the Online Archives of the deployment. The archives aren't
+// managed while the onlineArchives field isn't set. The archives removed from the field are deleted, with their
+// archived data, unless they're protected from deletion by the sub-resources protection or the keep annotation of the
+// deployment.
+func EnsureOnlineArchives(service *workflow.Context, groupID string, archives []mdbv1.OnlineArchive, deploymentName string, protected bool) workflow.Result {
+	if archives == nil {
+		service.UnsetCondition(status.OnlineArchivesReadyType)
+		service.EnsureStatusOption(status.AtlasDeploymentOnlineArchivesOption(nil))
+		return workflow.OK()
+	}
+
+	result := syncOnlineArchives(service, groupID, deploymentName, archives, protected)
+	if !result.IsOk() {
+		service.SetConditionFromResult(status.OnlineArchivesReadyType, result)
+		return result
+	}
+
+	service.SetConditionTrue(status.OnlineArchivesReadyType)
+	return result
+}
+
+func syncOnlineArchives(service *workflow.Context, groupID, deploymentName string, archives []mdbv1.OnlineArchive, protected bool) workflow.Result {
+	logger := service.Log
+	logger.Debugf("Syncing online archives %s", deploymentName)
+
+	existingByNamespace := map[string]*mongodbatlas.OnlineArchive{}
+	err := atlas.TraversePages(
+		func(pageNum int) (atlas.Paginated, error) {
+			existing, response, err := service.Client.OnlineArchives.List(service.Context, groupID, deploymentName, atlas.DefaultListOptions(pageNum))
+			if err != nil {
+				return nil, err
+			}
+			return atlas.NewAtlasPaginated(response, existing.Results), nil
+		},
+		func(entity interface{}) bool {
+			if archive, ok := entity.(*mongodbatlas.OnlineArchive); ok && archive != nil && archive.State != onlineArchiveStateDeleted {
+				existingByNamespace[archive.DBName+"."+archive.CollName] = archive
+			}
+			return false
+		},
+	)
+	if err != nil {
+		return workflow.Terminate(workflow.OnlineArchivesReady, fmt.Sprintf("Failed to get online archives: %v", err))
+	}
+
+	statuses := make([]status.OnlineArchive, 0, len(archives))
+	for i := range archives {
+		desired := &archives[i]
+		current, ok := existingByNamespace[desired.Namespace()]
+		delete(existingByNamespace, desired.Namespace())
+
+		var archiveErr error
+		if !ok {
+			logger.Infof("Creating the online archive of %s", desired.Namespace())
+			current, _, archiveErr = service.Client.OnlineArchives.Create(service.Context, groupID, deploymentName, desired.ToAtlas())
+		} else if field := immutableOnlineArchiveChange(desired, current); field != "" {
+			archiveErr = fmt.Errorf("the %s of the online archive can't be changed", field)
+		} else if onlineArchiveChanged(desired, current) {
+			logger.Infof("Updating the online archive of %s", desired.Namespace())
+			current, _, archiveErr = service.Client.OnlineArchives.Update(service.Context, groupID, deploymentName, current.ID, onlineArchiveUpdate(desired))
+		}
+
+		if archiveErr != nil {
+			statuses = append(statuses, status.NewFailedOnlineArchiveStatus(desired.ToAtlas(), archiveErr))
+			continue
+		}
+		statuses = append(statuses, status.NewOnlineArchiveStatus(current))
+	}
+
+	for namespace, archive := range existingByNamespace {
+		if protected {
+			logger.Infof("Not deleting the online archive of %s as per configuration", namespace)
+			continue
+		}
+
+		logger.Infof("Deleting the online archive of %s", namespace)
+		if _, err = service.Client.OnlineArchives.Delete(service.Context, groupID, deploymentName, archive.ID); err != nil {
+			return workflow.Terminate(workflow.OnlineArchivesReady, fmt.Sprintf("Failed to delete the online archive of %s: %v", namespace, err))
+		}
+	}
+
+	logger.Debugw("Online archives statuses", "statuses", statuses)
+	service.EnsureStatusOption(status.AtlasDeploymentOnlineArchivesOption(statuses))
+	return checkOnlineArchivesStatus(statuses)
+}
+
+func checkOnlineArchivesStatus(archives []status.OnlineArchive) workflow.Result {
+	var failed, pausing []string
+	for _, archive := range archives {
+		namespace := archive.DBName + "." + archive.CollName
+		switch archive.State {
+		case status.StatusFailed, status.OnlineArchiveStateOrphaned:
+			failed = append(failed, namespace)
+		case status.OnlineArchiveStatePausing:
+			pausing = append(pausing, namespace)
+		}
+	}
+
+	if len(failed) > 0 {
+		return workflow.Terminate(workflow.OnlineArchivesReady, fmt.Sprintf("Online archives of %s are not ready", strings.Join(failed, ", ")))
+	}
+
+	if len(pausing) > 0 {
+		return workflow.InProgress(workflow.OnlineArchivesReady, fmt.Sprintf("Online archives of %s are pausing", strings.Join(pausing, ", ")))
+	}
+
+	return workflow.OK()
+}
+
+// immutableOnlineArchiveChange returns the name of the field which can't be changed once the archive is created and
+// differs from Atlas, or an empty string. The fields defaulted by Atlas are only compared when they're set.
+func immutableOnlineArchiveChange(desired *mdbv1.OnlineArchive, current *mongodbatlas.OnlineArchive) string {
+	criteria := current.Criteria
+	if criteria == nil {
+		criteria = &mongodbatlas.OnlineArchiveCriteria{}
+	}
+
+	switch {
+	case desired.CollectionType != "" && current.CollectionType != "" && desired.CollectionType != current.CollectionType:
+		return "collectionType"
+	case desired.Criteria.Type != criteria.Type:
+		return "criteria type"
+	case desired.Criteria.DateField != criteria.DateField:
+		return "dateField"
+	case desired.Criteria.DateFormat != "" && desired.Criteria.DateFormat != criteria.DateFormat:
+		return "dateFormat"
+	}
+
+	// Atlas adds the date field to the partition fields
+	partitionFields := map[string]struct{}{}
+	for _, field := range current.PartitionFields {
+		if field != nil {
+			partitionFields[field.FieldName] = struct{}{}
+		}
+	}
+	for _, field := range desired.PartitionFields {
+		if _, ok := partitionFields[field.FieldName]; !ok {
+			return "partitionFields"
+		}
+	}
+
+	return ""
+}
+
+func onlineArchiveChanged(desired *mdbv1.OnlineArchive, current *mongodbatlas.OnlineArchive) bool {
+	if desired.Paused != toptr.PtrValOrDefault(current.Paused, false) {
+		return true
+	}
+
+	criteria := current.Criteria
+	if criteria == nil {
+		criteria = &mongodbatlas.OnlineArchiveCriteria{}
+	}
+	if float64(desired.Criteria.ExpireAfterDays) != toptr.PtrValOrDefault(criteria.ExpireAfterDays, 0) ||
+		!onlineArchiveQueriesEqual(desired.Criteria.Query, criteria.Query) {
+		return true
+	}
+
+	return !onlineArchiveSchedulesEqual(desired.ToAtlas().Schedule, current.Schedule)
+}
+
+// onlineArchiveQueriesEqual compares the JSON queries regardless of their formatting
+func onlineArchiveQueriesEqual(desired, current string) bool {
+	if desired == current {
+		return true
+	}
+
+	var desiredQuery, currentQuery interface{}
+	if json.Unmarshal([]byte(desired), &desiredQuery) != nil || json.Unmarshal([]byte(current), &currentQuery) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(desiredQuery, currentQuery)
+}
+
+// onlineArchiveSchedulesEqual compares the schedules, an unset schedule being the DEFAULT one
+func onlineArchiveSchedulesEqual(desired, current *mongodbatlas.OnlineArchiveSchedule) bool {
+	defaultSchedule := &mongodbatlas.OnlineArchiveSchedule{Type: "DEFAULT"}
+	if desired == nil {
+		desired = defaultSchedule
+	}
+	if current == nil {
+		current = defaultSchedule
+	}
+
+	return desired.Type == current.Type &&
+		desired.DayOfMonth == current.DayOfMonth &&
+		desired.DayOfWeek == current.DayOfWeek &&
+		toptr.PtrValOrDefault(desired.StartHour, 0) == toptr.PtrValOrDefault(current.StartHour, 0) &&
+		toptr.PtrValOrDefault(desired.StartMinute, 0) == toptr.PtrValOrDefault(current.StartMinute, 0) &&
+		toptr.PtrValOrDefault(desired.EndHour, 0) == toptr.PtrValOrDefault(current.EndHour, 0) &&
+		toptr.PtrValOrDefault(desired.EndMinute, 0) == toptr.PtrValOrDefault(current.EndMinute, 0)
+}
+
+// onlineArchiveUpdate returns the fields of the archive which can be updated
+func onlineArchiveUpdate(desired *mdbv1.OnlineArchive) *mongodbatlas.OnlineArchive {
+	archive := desired.ToAtlas()
+	if archive.Schedule == nil {
+		archive.Schedule = &mongodbatlas.OnlineArchiveSchedule{Type: "DEFAULT"}
+	}
+
+	return &mongodbatlas.OnlineArchive{
+		Criteria: archive.Criteria,
+		Schedule: archive.Schedule,
+		Paused:   archive.Paused,
+	}
+}
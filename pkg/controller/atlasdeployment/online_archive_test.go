@@ -0,0 +1,225 @@
+package atlasdeployment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+
+	atlas_mock "github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/mocks/atlas"
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+func TestEnsureOnlineArchives(t *testing.T) {
+	t.Run("the archives aren't managed while the field isn't set", func(t *testing.T) {
+		archiveAPI := &atlas_mock.OnlineArchiveClientMock{}
+		workflowCtx := testOnlineArchiveContext(t, archiveAPI)
+
+		result := EnsureOnlineArchives(workflowCtx, "project-id", nil, "cluster", false)
+
+		assert.True(t, result.IsOk())
+		assert.Empty(t, archiveAPI.ListRequests)
+		_, found := workflowCtx.GetCondition(status.OnlineArchivesReadyType)
+		assert.False(t, found)
+	})
+
+	t.Run("the missing archives are created", func(t *testing.T) {
+		archiveAPI := &atlas_mock.OnlineArchiveClientMock{
+			ListFunc: func(projectID, clusterName string, options *mongodbatlas.ListOptions) (*mongodbatlas.OnlineArchives, *mongodbatlas.Response, error) {
+				return &mongodbatlas.OnlineArchives{}, &mongodbatlas.Response{}, nil
+			},
+			CreateFunc: func(projectID, clusterName string, archive *mongodbatlas.OnlineArchive) (*mongodbatlas.OnlineArchive, *mongodbatlas.Response, error) {
+				created := *archive
+				created.ID = "archive-id"
+				created.State = status.OnlineArchiveStatePending
+				return &created, nil, nil
+			},
+		}
+		workflowCtx := testOnlineArchiveContext(t, archiveAPI)
+
+		result := EnsureOnlineArchives(workflowCtx, "project-id", []mdbv1.OnlineArchive{testOnlineArchive()}, "cluster", false)
+
+		assert.True(t, result.IsOk())
+		require.Contains(t, archiveAPI.CreateRequests, "project-id.cluster.shop.orders")
+		created := archiveAPI.CreateRequests["project-id.cluster.shop.orders"]
+		assert.Equal(t, toptr.MakePtr(90.0), created.Criteria.ExpireAfterDays)
+		assert.Equal(t, []*mongodbatlas.PartitionFields{{FieldName: "customerId", Order: toptr.MakePtr(0.0)}}, created.PartitionFields)
+		assertOnlineArchivesCondition(t, workflowCtx, corev1.ConditionTrue, "")
+	})
+
+	t.Run("the changed archives are updated and paused", func(t *testing.T) {
+		archiveAPI := &atlas_mock.OnlineArchiveClientMock{
+			ListFunc: func(projectID, clusterName string, options *mongodbatlas.ListOptions) (*mongodbatlas.OnlineArchives, *mongodbatlas.Response, error) {
+				return &mongodbatlas.OnlineArchives{Results: []*mongodbatlas.OnlineArchive{testAtlasOnlineArchive()}}, &mongodbatlas.Response{}, nil
+			},
+			UpdateFunc: func(projectID, clusterName, archiveID string, archive *mongodbatlas.OnlineArchive) (*mongodbatlas.OnlineArchive, *mongodbatlas.Response, error) {
+				updated := testAtlasOnlineArchive()
+				updated.Paused = archive.Paused
+				updated.State = status.OnlineArchiveStatePausing
+				return updated, nil, nil
+			},
+		}
+		workflowCtx := testOnlineArchiveContext(t, archiveAPI)
+		archive := testOnlineArchive()
+		archive.Paused = true
+
+		result := EnsureOnlineArchives(workflowCtx, "project-id", []mdbv1.OnlineArchive{archive}, "cluster", false)
+
+		assert.True(t, result.IsInProgress())
+		require.Contains(t, archiveAPI.UpdateRequests, "project-id.cluster.archive-id")
+		assert.Equal(t, toptr.MakePtr(true), archiveAPI.UpdateRequests["project-id.cluster.archive-id"].Paused)
+		assertOnlineArchivesCondition(t, workflowCtx, corev1.ConditionFalse, string(workflow.OnlineArchivesReady))
+	})
+
+	t.Run("the archives up to date are left untouched", func(t *testing.T) {
+		archiveAPI := &atlas_mock.OnlineArchiveClientMock{
+			ListFunc: func(projectID, clusterName string, options *mongodbatlas.ListOptions) (*mongodbatlas.OnlineArchives, *mongodbatlas.Response, error) {
+				return &mongodbatlas.OnlineArchives{Results: []*mongodbatlas.OnlineArchive{testAtlasOnlineArchive()}}, &mongodbatlas.Response{}, nil
+			},
+		}
+		workflowCtx := testOnlineArchiveContext(t, archiveAPI)
+
+		result := EnsureOnlineArchives(workflowCtx, "project-id", []mdbv1.OnlineArchive{testOnlineArchive()}, "cluster", false)
+
+		assert.True(t, result.IsOk())
+		assert.Empty(t, archiveAPI.CreateRequests)
+		assert.Empty(t, archiveAPI.UpdateRequests)
+	})
+
+	t.Run("the immutable fields can't be changed", func(t *testing.T) {
+		archiveAPI := &atlas_mock.OnlineArchiveClientMock{
+			ListFunc: func(projectID, clusterName string, options *mongodbatlas.ListOptions) (*mongodbatlas.OnlineArchives, *mongodbatlas.Response, error) {
+				return &mongodbatlas.OnlineArchives{Results: []*mongodbatlas.OnlineArchive{testAtlasOnlineArchive()}}, &mongodbatlas.Response{}, nil
+			},
+		}
+		workflowCtx := testOnlineArchiveContext(t, archiveAPI)
+		archive := testOnlineArchive()
+		archive.Criteria.DateField = "updatedAt"
+
+		result := EnsureOnlineArchives(workflowCtx, "project-id", []mdbv1.OnlineArchive{archive}, "cluster", false)
+
+		assert.False(t, result.IsOk())
+		assert.Empty(t, archiveAPI.UpdateRequests)
+		assertOnlineArchivesCondition(t, workflowCtx, corev1.ConditionFalse, string(workflow.OnlineArchivesReady))
+	})
+
+	t.Run("the creation failures are reported", func(t *testing.T) {
+		archiveAPI := &atlas_mock.OnlineArchiveClientMock{
+			ListFunc: func(projectID, clusterName string, options *mongodbatlas.ListOptions) (*mongodbatlas.OnlineArchives, *mongodbatlas.Response, error) {
+				return &mongodbatlas.OnlineArchives{}, &mongodbatlas.Response{}, nil
+			},
+			CreateFunc: func(projectID, clusterName string, archive *mongodbatlas.OnlineArchive) (*mongodbatlas.OnlineArchive, *mongodbatlas.Response, error) {
+				return nil, nil, errors.New("the collection doesn't exist")
+			},
+		}
+		workflowCtx := testOnlineArchiveContext(t, archiveAPI)
+
+		result := EnsureOnlineArchives(workflowCtx, "project-id", []mdbv1.OnlineArchive{testOnlineArchive()}, "cluster", false)
+
+		assert.False(t, result.IsOk())
+		assert.Equal(t, "Online archives of shop.orders are not ready", result.GetMessage())
+	})
+
+	t.Run("the archives are listed from all the pages", func(t *testing.T) {
+		archiveAPI := &atlas_mock.OnlineArchiveClientMock{
+			ListFunc: func(projectID, clusterName string, options *mongodbatlas.ListOptions) (*mongodbatlas.OnlineArchives, *mongodbatlas.Response, error) {
+				if options.PageNum == 1 {
+					return &mongodbatlas.OnlineArchives{}, &mongodbatlas.Response{Links: []*mongodbatlas.Link{{Rel: "next"}}}, nil
+				}
+				return &mongodbatlas.OnlineArchives{Results: []*mongodbatlas.OnlineArchive{testAtlasOnlineArchive()}}, &mongodbatlas.Response{}, nil
+			},
+		}
+		workflowCtx := testOnlineArchiveContext(t, archiveAPI)
+
+		result := EnsureOnlineArchives(workflowCtx, "project-id", []mdbv1.OnlineArchive{testOnlineArchive()}, "cluster", false)
+
+		assert.True(t, result.IsOk())
+		assert.Empty(t, archiveAPI.CreateRequests)
+	})
+
+	t.Run("the removed archives are deleted unless protected", func(t *testing.T) {
+		for _, protected := range []bool{false, true} {
+			archiveAPI := &atlas_mock.OnlineArchiveClientMock{
+				ListFunc: func(projectID, clusterName string, options *mongodbatlas.ListOptions) (*mongodbatlas.OnlineArchives, *mongodbatlas.Response, error) {
+					return &mongodbatlas.OnlineArchives{Results: []*mongodbatlas.OnlineArchive{testAtlasOnlineArchive()}}, &mongodbatlas.Response{}, nil
+				},
+				DeleteFunc: func(projectID, clusterName, archiveID string) (*mongodbatlas.Response, error) {
+					return nil, nil
+				},
+			}
+			workflowCtx := testOnlineArchiveContext(t, archiveAPI)
+
+			result := EnsureOnlineArchives(workflowCtx, "project-id", []mdbv1.OnlineArchive{}, "cluster", protected)
+
+			assert.True(t, result.IsOk())
+			if protected {
+				assert.Empty(t, archiveAPI.DeleteRequests)
+			} else {
+				assert.Equal(t, map[string]struct{}{"project-id.cluster.archive-id": {}}, archiveAPI.DeleteRequests)
+			}
+		}
+	})
+}
+
+func TestOnlineArchiveQueriesEqual(t *testing.T) {
+	assert.True(t, onlineArchiveQueriesEqual(`{"status": "done"}`, `{"status":"done"}`))
+	assert.False(t, onlineArchiveQueriesEqual(`{"status": "done"}`, `{"status":"open"}`))
+	assert.False(t, onlineArchiveQueriesEqual(`{"status": "done"}`, ""))
+}
+
+func testOnlineArchiveContext(t *testing.T, archiveAPI *atlas_mock.OnlineArchiveClientMock) *workflow.Context {
+	t.Helper()
+
+	workflowCtx := workflow.NewContext(zaptest.NewLogger(t).Sugar(), []status.Condition{}, context.Background())
+	workflowCtx.Client = mongodbatlas.Client{OnlineArchives: archiveAPI}
+	return workflowCtx
+}
+
+func testOnlineArchive() mdbv1.OnlineArchive {
+	return mdbv1.OnlineArchive{
+		DBName:          "shop",
+		CollName:        "orders",
+		Criteria:        mdbv1.OnlineArchiveCriteria{Type: "DATE", DateField: "createdAt", ExpireAfterDays: 90},
+		PartitionFields: []mdbv1.OnlineArchivePartitionField{{FieldName: "customerId"}},
+	}
+}
+
+// testAtlasOnlineArchive returns the archive of testOnlineArchive as Atlas reports it
+func testAtlasOnlineArchive() *mongodbatlas.OnlineArchive {
+	return &mongodbatlas.OnlineArchive{
+		ID:             "archive-id",
+		DBName:         "shop",
+		CollName:       "orders",
+		CollectionType: "STANDARD",
+		Criteria: &mongodbatlas.OnlineArchiveCriteria{
+			Type:            "DATE",
+			DateField:       "createdAt",
+			DateFormat:      "ISODATE",
+			ExpireAfterDays: toptr.MakePtr(90.0),
+		},
+		PartitionFields: []*mongodbatlas.PartitionFields{
+			{FieldName: "createdAt", FieldType: "date", Order: toptr.MakePtr(0.0)},
+			{FieldName: "customerId", FieldType: "string", Order: toptr.MakePtr(1.0)},
+		},
+		Paused:   toptr.MakePtr(false),
+		Schedule: &mongodbatlas.OnlineArchiveSchedule{Type: "DEFAULT"},
+		State:    status.OnlineArchiveStateActive,
+	}
+}
+
+func assertOnlineArchivesCondition(t *testing.T, workflowCtx *workflow.Context, conditionStatus corev1.ConditionStatus, reason string) {
+	t.Helper()
+
+	condition, found := workflowCtx.GetCondition(status.OnlineArchivesReadyType)
+	require.True(t, found)
+	assert.Equal(t, conditionStatus, condition.Status)
+	assert.Equal(t, reason, condition.Reason)
+}
@@ -0,0 +1,159 @@
+package atlasdeployment
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+const (
+	// TestFailoverAnnotation requests a test of the failover of the primaries of the deployment.
+	// The test runs once per value of the annotation.
+	TestFailoverAnnotation = "mongodb.com/atlas-test-failover"
+	// RollingRestartAnnotation requests a rolling restart of the nodes of the deployment.
+	// The restart runs once per value of the annotation.
+	RollingRestartAnnotation = "mongodb.com/atlas-rolling-restart"
+)
+
+var operationAnnotations = []struct {
+	name       string
+	annotation string
+}{
+	{name: status.DeploymentOperationTestFailover, annotation: TestFailoverAnnotation},
+	{name: status.DeploymentOperationRollingRestart, annotation: RollingRestartAnnotation},
+}
+
+func operationAnnotationKeys() []string {
+	keys := make([]string, 0, len(operationAnnotations))
+	for _, operation := range operationAnnotations {
+		keys = append(keys, operation.annotation)
+	}
+	return keys
+}
+
+// operationStartTimeout is how long a requested operation may wait for the deployment to leave the IDLE state
+const operationStartTimeout = 30 * time.Minute
+
+// handleOperations runs the one-shot operations requested with the annotations of the deployment.
+// It must only be called once the deployment is idle in Atlas: the operations seen running are then over.
+func (r *AtlasDeploymentReconciler) handleOperations(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment) workflow.Result {
+	result := workflow.OK()
+	for _, operation := range operationAnnotations {
+		request := deployment.GetAnnotations()[operation.annotation]
+		if request == "" {
+			continue
+		}
+
+		last := deployment.Status.Operation(operation.name)
+		if last != nil && last.Request == request {
+			if operationResult := checkOperation(ctx, last, time.Now()); !operationResult.IsOk() {
+				result = operationResult
+			}
+			continue
+		}
+
+		if operationResult := r.startOperation(ctx, project, deployment, operation.name, request); !operationResult.IsOk() {
+			result = operationResult
+		}
+	}
+
+	return result
+}
+
+// checkOperation moves the operation of the idle deployment forward. An operation is only completed once the
+// deployment was seen running it, and never started again for the same request.
+func checkOperation(ctx *workflow.Context, operation *status.DeploymentOperation, now time.Time) workflow.Result {
+	switch operation.State {
+	case status.DeploymentOperationStarting:
+		// the operator stopped before knowing if Atlas accepted the request
+		ctx.EnsureStatusOption(status.AtlasDeploymentOperationOption(newOperation(operation.Name, operation.Request, status.DeploymentOperationFailed,
+			"the operator stopped while the operation was requested from Atlas, it may not have run: change the value of the annotation to request it again")))
+
+	case status.DeploymentOperationRequested:
+		if now.Sub(operation.LastTransitionTime.Time) >= operationStartTimeout {
+			ctx.EnsureStatusOption(status.AtlasDeploymentOperationOption(newOperation(operation.Name, operation.Request, status.DeploymentOperationFailed,
+				fmt.Sprintf("the deployment stayed idle for %s after the operation was requested from Atlas", operationStartTimeout))))
+			return workflow.OK()
+		}
+		return workflow.InProgress(workflow.DeploymentOperationInProgress, fmt.Sprintf("waiting for Atlas to start the %s operation", operation.Name))
+
+	case status.DeploymentOperationInProgress:
+		ctx.EnsureStatusOption(status.AtlasDeploymentOperationOption(newOperation(operation.Name, operation.Request, status.DeploymentOperationCompleted, "")))
+	}
+
+	return workflow.OK()
+}
+
+// operationsStarted marks the requested operations as in progress once the deployment left the IDLE state to run
+// them. It's called with the result of the deployment handler while the deployment isn't idle.
+func operationsStarted(ctx *workflow.Context, deployment *mdbv1.AtlasDeployment, result workflow.Result) {
+	if result.GetReason() != workflow.DeploymentUpdating {
+		return
+	}
+
+	for _, operation := range deployment.Status.Operations {
+		if operation.State == status.DeploymentOperationRequested {
+			ctx.EnsureStatusOption(status.AtlasDeploymentOperationOption(newOperation(operation.Name, operation.Request, status.DeploymentOperationInProgress, "the deployment is running the operation")))
+		}
+	}
+}
+
+func (r *AtlasDeploymentReconciler) startOperation(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment, name, request string) workflow.Result {
+	fail := func(message string) workflow.Result {
+		ctx.Log.Warnw("Deployment operation failed", "operation", name, "request", request, "message", message)
+		ctx.EnsureStatusOption(status.AtlasDeploymentOperationOption(newOperation(name, request, status.DeploymentOperationFailed, message)))
+		return workflow.OK()
+	}
+
+	switch name {
+	case status.DeploymentOperationTestFailover:
+		if deployment.IsServerless() {
+			return fail("test failovers aren't supported by serverless instances")
+		}
+
+		dryRun := customresource.IsDryRun(deployment, r.DryRun)
+		if !dryRun {
+			// the operation is saved before being started in Atlas, so it never runs twice for the same request,
+			// even if the operator restarts in between
+			ctx.EnsureStatusOption(status.AtlasDeploymentOperationOption(newOperation(name, request, status.DeploymentOperationStarting, "the failover test is requested from Atlas")))
+			if err := statushandler.Persist(ctx, r.Client, deployment); err != nil {
+				return workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to save the %s operation before starting it: %s", name, err))
+			}
+		}
+
+		ctx.Log.Infow("Starting a failover test", "deployment", deployment.GetDeploymentName(), "request", request)
+		if _, err := ctx.Client.AdvancedClusters.TestFailover(ctx.Context, project.ID(), deployment.GetDeploymentName()); err != nil {
+			return fail(fmt.Sprintf("failed to start the failover test: %s", err))
+		}
+
+		if dryRun {
+			return workflow.OK()
+		}
+
+		// the test is only in progress once the deployment is seen leaving the IDLE state
+		ctx.EnsureStatusOption(status.AtlasDeploymentOperationOption(newOperation(name, request, status.DeploymentOperationRequested, "waiting for Atlas to start the failover test")))
+		return workflow.InProgress(workflow.DeploymentOperationInProgress, "a failover test was requested")
+
+	case status.DeploymentOperationRollingRestart:
+		return fail("rolling restarts can't be requested through the Atlas Admin API")
+	}
+
+	return fail(fmt.Sprintf("unknown operation %q", name))
+}
+
+func newOperation(name, request, state, message string) status.DeploymentOperation {
+	return status.DeploymentOperation{
+		Name:               name,
+		Request:            request,
+		State:              state,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+}
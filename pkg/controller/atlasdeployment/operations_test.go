@@ -0,0 +1,243 @@
+package atlasdeployment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	atlas_mock "github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/mocks/atlas"
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+func TestHandleOperations(t *testing.T) {
+	t.Run("nothing runs without annotations", func(t *testing.T) {
+		clusterAPI := &atlas_mock.AdvancedClustersClientMock{}
+		deployment := testOperationsDeployment(nil)
+		reconciler, workflowCtx := testOperationsReconciler(t, clusterAPI, deployment)
+
+		result := reconciler.handleOperations(workflowCtx, testOperationsProject(), deployment)
+
+		assert.True(t, result.IsOk())
+		assert.Empty(t, clusterAPI.TestFailoverRequests)
+		assert.Empty(t, workflowCtx.StatusOptions())
+	})
+
+	t.Run("a failover test is saved then started", func(t *testing.T) {
+		deployment := testOperationsDeployment(map[string]string{TestFailoverAnnotation: "1"})
+		var reconciler *AtlasDeploymentReconciler
+		clusterAPI := &atlas_mock.AdvancedClustersClientMock{
+			TestFailoverFunc: func(projectID string, clusterName string) (*mongodbatlas.Response, error) {
+				saved := &mdbv1.AtlasDeployment{}
+				require.NoError(t, reconciler.Client.Get(context.Background(), client.ObjectKeyFromObject(deployment), saved))
+				require.NotNil(t, saved.Status.Operation(status.DeploymentOperationTestFailover))
+				assert.Equal(t, "1", saved.Status.Operation(status.DeploymentOperationTestFailover).Request)
+				assert.Equal(t, status.DeploymentOperationStarting, saved.Status.Operation(status.DeploymentOperationTestFailover).State)
+				return nil, nil
+			},
+		}
+		reconciler, workflowCtx := testOperationsReconciler(t, clusterAPI, deployment)
+
+		result := reconciler.handleOperations(workflowCtx, testOperationsProject(), deployment)
+
+		assert.True(t, result.IsInProgress())
+		assert.Equal(t, workflow.DeploymentOperationInProgress, result.GetReason())
+		assert.Contains(t, clusterAPI.TestFailoverRequests, "project-id.cluster")
+		assertOperation(t, workflowCtx, status.DeploymentOperationTestFailover, "1", status.DeploymentOperationRequested)
+	})
+
+	t.Run("a requested failover test waits for the deployment to leave the IDLE state", func(t *testing.T) {
+		clusterAPI := &atlas_mock.AdvancedClustersClientMock{}
+		deployment := testOperationsDeployment(map[string]string{TestFailoverAnnotation: "1"})
+		deployment.Status.Operations = []status.DeploymentOperation{
+			newOperation(status.DeploymentOperationTestFailover, "1", status.DeploymentOperationRequested, ""),
+		}
+		reconciler, workflowCtx := testOperationsReconciler(t, clusterAPI, deployment)
+
+		result := reconciler.handleOperations(workflowCtx, testOperationsProject(), deployment)
+
+		assert.True(t, result.IsInProgress())
+		assert.Empty(t, clusterAPI.TestFailoverRequests)
+		assert.Empty(t, workflowCtx.StatusOptions())
+	})
+
+	t.Run("a requested failover test the deployment never runs fails", func(t *testing.T) {
+		clusterAPI := &atlas_mock.AdvancedClustersClientMock{}
+		deployment := testOperationsDeployment(map[string]string{TestFailoverAnnotation: "1"})
+		requested := newOperation(status.DeploymentOperationTestFailover, "1", status.DeploymentOperationRequested, "")
+		requested.LastTransitionTime = metav1.NewTime(time.Now().Add(-operationStartTimeout))
+		deployment.Status.Operations = []status.DeploymentOperation{requested}
+		reconciler, workflowCtx := testOperationsReconciler(t, clusterAPI, deployment)
+
+		result := reconciler.handleOperations(workflowCtx, testOperationsProject(), deployment)
+
+		assert.True(t, result.IsOk())
+		operation := assertOperation(t, workflowCtx, status.DeploymentOperationTestFailover, "1", status.DeploymentOperationFailed)
+		assert.Equal(t, "the deployment stayed idle for 30m0s after the operation was requested from Atlas", operation.Message)
+	})
+
+	t.Run("a failover test interrupted while requested from Atlas fails", func(t *testing.T) {
+		clusterAPI := &atlas_mock.AdvancedClustersClientMock{}
+		deployment := testOperationsDeployment(map[string]string{TestFailoverAnnotation: "1"})
+		deployment.Status.Operations = []status.DeploymentOperation{
+			newOperation(status.DeploymentOperationTestFailover, "1", status.DeploymentOperationStarting, ""),
+		}
+		reconciler, workflowCtx := testOperationsReconciler(t, clusterAPI, deployment)
+
+		result := reconciler.handleOperations(workflowCtx, testOperationsProject(), deployment)
+
+		assert.True(t, result.IsOk())
+		assert.Empty(t, clusterAPI.TestFailoverRequests)
+		operation := assertOperation(t, workflowCtx, status.DeploymentOperationTestFailover, "1", status.DeploymentOperationFailed)
+		assert.Contains(t, operation.Message, "it may not have run")
+	})
+
+	t.Run("a failover test in progress is completed once the deployment is idle", func(t *testing.T) {
+		clusterAPI := &atlas_mock.AdvancedClustersClientMock{}
+		deployment := testOperationsDeployment(map[string]string{TestFailoverAnnotation: "1"})
+		deployment.Status.Operations = []status.DeploymentOperation{
+			newOperation(status.DeploymentOperationTestFailover, "1", status.DeploymentOperationInProgress, ""),
+		}
+		reconciler, workflowCtx := testOperationsReconciler(t, clusterAPI, deployment)
+
+		result := reconciler.handleOperations(workflowCtx, testOperationsProject(), deployment)
+
+		assert.True(t, result.IsOk())
+		assert.Empty(t, clusterAPI.TestFailoverRequests)
+		assertOperation(t, workflowCtx, status.DeploymentOperationTestFailover, "1", status.DeploymentOperationCompleted)
+	})
+
+	t.Run("an operation runs once per value of the annotation", func(t *testing.T) {
+		clusterAPI := &atlas_mock.AdvancedClustersClientMock{}
+		deployment := testOperationsDeployment(map[string]string{TestFailoverAnnotation: "1"})
+		deployment.Status.Operations = []status.DeploymentOperation{
+			newOperation(status.DeploymentOperationTestFailover, "1", status.DeploymentOperationFailed, "failed"),
+		}
+		reconciler, workflowCtx := testOperationsReconciler(t, clusterAPI, deployment)
+
+		result := reconciler.handleOperations(workflowCtx, testOperationsProject(), deployment)
+
+		assert.True(t, result.IsOk())
+		assert.Empty(t, clusterAPI.TestFailoverRequests)
+		assert.Empty(t, workflowCtx.StatusOptions())
+	})
+
+	t.Run("a failover test failing to start is reported", func(t *testing.T) {
+		clusterAPI := &atlas_mock.AdvancedClustersClientMock{
+			TestFailoverFunc: func(projectID string, clusterName string) (*mongodbatlas.Response, error) {
+				return nil, errors.New("cluster is paused")
+			},
+		}
+		deployment := testOperationsDeployment(map[string]string{TestFailoverAnnotation: "2"})
+		reconciler, workflowCtx := testOperationsReconciler(t, clusterAPI, deployment)
+
+		result := reconciler.handleOperations(workflowCtx, testOperationsProject(), deployment)
+
+		assert.True(t, result.IsOk())
+		operation := assertOperation(t, workflowCtx, status.DeploymentOperationTestFailover, "2", status.DeploymentOperationFailed)
+		assert.Equal(t, "failed to start the failover test: cluster is paused", operation.Message)
+	})
+
+	t.Run("a failover test is only planned in dry-run", func(t *testing.T) {
+		clusterAPI := &atlas_mock.AdvancedClustersClientMock{
+			TestFailoverFunc: func(projectID string, clusterName string) (*mongodbatlas.Response, error) {
+				return nil, nil
+			},
+		}
+		deployment := testOperationsDeployment(map[string]string{TestFailoverAnnotation: "1", customresource.DryRunAnnotation: "true"})
+		reconciler, workflowCtx := testOperationsReconciler(t, clusterAPI, deployment)
+
+		result := reconciler.handleOperations(workflowCtx, testOperationsProject(), deployment)
+
+		assert.True(t, result.IsOk())
+		assert.Contains(t, clusterAPI.TestFailoverRequests, "project-id.cluster")
+		assert.Empty(t, workflowCtx.StatusOptions())
+	})
+
+	t.Run("rolling restarts aren't supported", func(t *testing.T) {
+		clusterAPI := &atlas_mock.AdvancedClustersClientMock{}
+		deployment := testOperationsDeployment(map[string]string{RollingRestartAnnotation: "now"})
+		reconciler, workflowCtx := testOperationsReconciler(t, clusterAPI, deployment)
+
+		result := reconciler.handleOperations(workflowCtx, testOperationsProject(), deployment)
+
+		assert.True(t, result.IsOk())
+		operation := assertOperation(t, workflowCtx, status.DeploymentOperationRollingRestart, "now", status.DeploymentOperationFailed)
+		assert.Equal(t, "rolling restarts can't be requested through the Atlas Admin API", operation.Message)
+	})
+}
+
+func TestOperationsStarted(t *testing.T) {
+	deployment := testOperationsDeployment(map[string]string{TestFailoverAnnotation: "1"})
+	deployment.Status.Operations = []status.DeploymentOperation{
+		newOperation(status.DeploymentOperationTestFailover, "1", status.DeploymentOperationRequested, ""),
+	}
+
+	t.Run("the deployment is updating", func(t *testing.T) {
+		workflowCtx := workflow.NewContext(zaptest.NewLogger(t).Sugar(), []status.Condition{}, context.Background())
+		operationsStarted(workflowCtx, deployment, workflow.InProgress(workflow.DeploymentUpdating, "deployment is updating"))
+
+		assertOperation(t, workflowCtx, status.DeploymentOperationTestFailover, "1", status.DeploymentOperationInProgress)
+	})
+
+	t.Run("the deployment is pending for another reason", func(t *testing.T) {
+		workflowCtx := workflow.NewContext(zaptest.NewLogger(t).Sugar(), []status.Condition{}, context.Background())
+		operationsStarted(workflowCtx, deployment, workflow.InProgress(workflow.DeploymentUpgradePending, "the upgrade is pending"))
+
+		assert.Empty(t, workflowCtx.StatusOptions())
+	})
+}
+
+func testOperationsReconciler(t *testing.T, clusterAPI *atlas_mock.AdvancedClustersClientMock, deployment *mdbv1.AtlasDeployment) (*AtlasDeploymentReconciler, *workflow.Context) {
+	t.Helper()
+
+	sch := runtime.NewScheme()
+	require.NoError(t, mdbv1.AddToScheme(sch))
+	reconciler := &AtlasDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(sch).WithObjects(deployment.DeepCopy()).Build(),
+		Log:    zaptest.NewLogger(t).Sugar(),
+	}
+
+	workflowCtx := workflow.NewContext(reconciler.Log, []status.Condition{}, context.Background())
+	workflowCtx.Client = mongodbatlas.Client{AdvancedClusters: clusterAPI}
+	return reconciler, workflowCtx
+}
+
+func testOperationsDeployment(annotations map[string]string) *mdbv1.AtlasDeployment {
+	deployment := mdbv1.NewDeployment("default", "my-deployment", "cluster")
+	deployment.Annotations = annotations
+	return deployment
+}
+
+func testOperationsProject() *mdbv1.AtlasProject {
+	project := mdbv1.NewProject("default", "my-project", "my-project")
+	project.Status.ID = "project-id"
+	return project
+}
+
+func assertOperation(t *testing.T, workflowCtx *workflow.Context, name, request, state string) status.DeploymentOperation {
+	t.Helper()
+
+	deploymentStatus := status.AtlasDeploymentStatus{}
+	for _, option := range workflowCtx.StatusOptions() {
+		option.(status.AtlasDeploymentStatusOption)(&deploymentStatus)
+	}
+
+	operation := deploymentStatus.Operation(name)
+	require.NotNil(t, operation)
+	assert.Equal(t, request, operation.Request)
+	assert.Equal(t, state, operation.State)
+	return *operation
+}
@@ -0,0 +1,171 @@
+package atlasdeployment
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	// the operator image doesn't ship the time zone database
+	_ "time/tzdata"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+// pauseScheduleLookahead is how far the next transition of a schedule is looked for.
+// A weekly schedule changing the state of the deployment does it at least once in a week and a day.
+const pauseScheduleLookahead = 8 * 24 * time.Hour
+
+var weekdays = map[mdbv1.Weekday]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+type pauseInterval struct {
+	start time.Time
+	end   time.Time
+}
+
+func (i pauseInterval) contains(t time.Time) bool {
+	return !t.Before(i.start) && t.Before(i.end)
+}
+
+// applyPauseSchedule replaces the pause schedule of the deployment with the paused flag the schedule requires at the
+// given time. It returns the time of the next pause or resume, zero if the schedule never changes the state again
+func applyPauseSchedule(deployment *mdbv1.AtlasDeployment, now time.Time) (time.Time, error) {
+	if deployment.Spec.DeploymentSpec == nil || deployment.Spec.DeploymentSpec.PauseSchedule == nil {
+		return time.Time{}, nil
+	}
+
+	paused, next, err := evaluatePauseSchedule(deployment.Spec.DeploymentSpec.PauseSchedule, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	deployment.Spec.DeploymentSpec.Paused = &paused
+	deployment.Spec.DeploymentSpec.PauseSchedule = nil
+
+	return next, nil
+}
+
+// evaluatePauseSchedule returns whether the deployment is paused at the given time and when this changes next
+func evaluatePauseSchedule(schedule *mdbv1.PauseSchedule, now time.Time) (bool, time.Time, error) {
+	// windows are at most one day long: the state at a given time only depends on the windows started the day before
+	// and on that day
+	horizon := now.Add(pauseScheduleLookahead)
+	intervals, err := pauseIntervals(schedule, now.Add(-24*time.Hour), horizon)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	paused := pausedAt(intervals, now)
+
+	boundaries := make([]time.Time, 0, 2*len(intervals))
+	for _, interval := range intervals {
+		for _, boundary := range []time.Time{interval.start, interval.end} {
+			if boundary.After(now) && !boundary.After(horizon) {
+				boundaries = append(boundaries, boundary)
+			}
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool {
+		return boundaries[i].Before(boundaries[j])
+	})
+
+	// adjacent windows don't change the state of the deployment where they meet
+	for _, boundary := range boundaries {
+		if pausedAt(intervals, boundary) != paused {
+			return paused, boundary, nil
+		}
+	}
+
+	return paused, time.Time{}, nil
+}
+
+func pausedAt(intervals []pauseInterval, t time.Time) bool {
+	for _, interval := range intervals {
+		if interval.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// pauseIntervals returns the intervals of the windows of the schedule starting between from and to
+func pauseIntervals(schedule *mdbv1.PauseSchedule, from, to time.Time) ([]pauseInterval, error) {
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone of the pause schedule: %w", err)
+	}
+
+	from = from.In(location)
+	var intervals []pauseInterval
+	for _, window := range schedule.Windows {
+		start, err := minutesOfDay(window.Start, false)
+		if err != nil {
+			return nil, err
+		}
+		end, err := minutesOfDay(window.End, true)
+		if err != nil {
+			return nil, err
+		}
+
+		days := map[time.Weekday]bool{}
+		for _, day := range window.Days {
+			weekday, ok := weekdays[day]
+			if !ok {
+				return nil, fmt.Errorf("invalid day of the pause window: %q", day)
+			}
+			days[weekday] = true
+		}
+
+		for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, location); !day.After(to); day = day.AddDate(0, 0, 1) {
+			if len(days) > 0 && !days[day.Weekday()] {
+				continue
+			}
+
+			endDay := day.Day()
+			if end <= start {
+				endDay++
+			}
+			intervals = append(intervals, pauseInterval{
+				start: time.Date(day.Year(), day.Month(), day.Day(), start/60, start%60, 0, 0, location),
+				end:   time.Date(day.Year(), day.Month(), endDay, end/60, end%60, 0, 0, location),
+			})
+		}
+	}
+
+	return intervals, nil
+}
+
+// minutesOfDay parses a HH:MM time of the day. 24:00 is only allowed as the end of a window
+func minutesOfDay(value string, isEnd bool) (int, error) {
+	hours, minutes, found := strings.Cut(value, ":")
+	h, hErr := strconv.Atoi(hours)
+	m, mErr := strconv.Atoi(minutes)
+	if !found || len(hours) != 2 || len(minutes) != 2 || hErr != nil || mErr != nil || m < 0 || m > 59 || h < 0 || h > 24 ||
+		(h == 24 && (m != 0 || !isEnd)) {
+		return 0, fmt.Errorf("invalid time of the pause window %q, expected HH:MM", value)
+	}
+	return h*60 + m, nil
+}
+
+// pauseScheduleResult requeues the reconciliation when the pause schedule changes the state of the deployment next,
+// unless the result already requeues it earlier
+func pauseScheduleResult(result workflow.Result, resyncInterval time.Duration, next time.Time, now time.Time) workflow.Result {
+	if next.IsZero() {
+		return result
+	}
+
+	untilNext := next.Sub(now)
+	if resyncInterval > 0 && resyncInterval <= untilNext {
+		return result
+	}
+	return result.WithRetry(untilNext)
+}
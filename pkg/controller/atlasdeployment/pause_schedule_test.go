@@ -0,0 +1,163 @@
+package atlasdeployment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+// testNightsAndWeekends pauses the deployment from 20:00 to 08:00 during the week and during the whole weekend
+func testNightsAndWeekends() *mdbv1.PauseSchedule {
+	return &mdbv1.PauseSchedule{
+		TimeZone: "Europe/Paris",
+		Windows: []mdbv1.PauseWindow{
+			{Days: []mdbv1.Weekday{"Monday", "Tuesday", "Wednesday", "Thursday"}, Start: "20:00", End: "08:00"},
+			{Days: []mdbv1.Weekday{"Friday"}, Start: "20:00", End: "24:00"},
+			{Days: []mdbv1.Weekday{"Saturday", "Sunday"}, Start: "00:00", End: "24:00"},
+			{Days: []mdbv1.Weekday{"Monday"}, Start: "00:00", End: "08:00"},
+		},
+	}
+}
+
+func TestEvaluatePauseSchedule(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	at := func(day, hour, minute int) time.Time {
+		// November 2023 starts on a Wednesday
+		return time.Date(2023, time.November, day, hour, minute, 0, 0, paris)
+	}
+
+	testCases := []struct {
+		title      string
+		schedule   *mdbv1.PauseSchedule
+		now        time.Time
+		paused     bool
+		transition time.Time
+	}{
+		{
+			title:      "running during the day",
+			schedule:   testNightsAndWeekends(),
+			now:        at(1, 12, 0),
+			paused:     false,
+			transition: at(1, 20, 0),
+		},
+		{
+			title:      "paused at night until the next morning",
+			schedule:   testNightsAndWeekends(),
+			now:        at(1, 23, 0),
+			paused:     true,
+			transition: at(2, 8, 0),
+		},
+		{
+			title:      "paused after midnight by the window started the day before",
+			schedule:   testNightsAndWeekends(),
+			now:        at(2, 7, 59),
+			paused:     true,
+			transition: at(2, 8, 0),
+		},
+		{
+			title:      "the window starts on time",
+			schedule:   testNightsAndWeekends(),
+			now:        at(2, 20, 0),
+			paused:     true,
+			transition: at(3, 8, 0),
+		},
+		{
+			title:      "the adjacent windows of the weekend are merged",
+			schedule:   testNightsAndWeekends(),
+			now:        at(3, 21, 0),
+			paused:     true,
+			transition: at(6, 8, 0),
+		},
+		{
+			title: "a schedule without days applies every day in UTC",
+			schedule: &mdbv1.PauseSchedule{
+				Windows: []mdbv1.PauseWindow{{Start: "01:00", End: "02:00"}},
+			},
+			now:        time.Date(2023, time.November, 1, 3, 0, 0, 0, time.UTC),
+			paused:     false,
+			transition: time.Date(2023, time.November, 2, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			title: "a schedule pausing all the time never changes",
+			schedule: &mdbv1.PauseSchedule{
+				Windows: []mdbv1.PauseWindow{{Start: "00:00", End: "24:00"}},
+			},
+			now:    time.Date(2023, time.November, 1, 3, 0, 0, 0, time.UTC),
+			paused: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			paused, transition, err := evaluatePauseSchedule(tc.schedule, tc.now)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.paused, paused)
+			assert.True(t, tc.transition.Equal(transition), "expected the transition at %s, got %s", tc.transition, transition)
+		})
+	}
+
+	t.Run("the windows follow the daylight saving time", func(t *testing.T) {
+		// the clocks go back one hour on the last Sunday of October in Paris
+		schedule := &mdbv1.PauseSchedule{
+			TimeZone: "Europe/Paris",
+			Windows:  []mdbv1.PauseWindow{{Start: "20:00", End: "08:00"}},
+		}
+
+		_, transition, err := evaluatePauseSchedule(schedule, time.Date(2023, time.October, 29, 3, 0, 0, 0, paris))
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2023, time.October, 29, 7, 0, 0, 0, time.UTC), transition.UTC())
+	})
+
+	t.Run("an invalid schedule is reported", func(t *testing.T) {
+		_, _, err := evaluatePauseSchedule(&mdbv1.PauseSchedule{TimeZone: "Mars/Olympus_Mons"}, at(1, 12, 0))
+		assert.ErrorContains(t, err, "invalid time zone of the pause schedule")
+
+		_, _, err = evaluatePauseSchedule(&mdbv1.PauseSchedule{Windows: []mdbv1.PauseWindow{{Start: "24:00", End: "08:00"}}}, at(1, 12, 0))
+		assert.EqualError(t, err, "invalid time of the pause window \"24:00\", expected HH:MM")
+	})
+}
+
+func TestApplyPauseSchedule(t *testing.T) {
+	t.Run("the deployments without schedule are left untouched", func(t *testing.T) {
+		deployment := mdbv1.DefaultAWSDeployment("default", "my-project")
+		deployment.Spec.DeploymentSpec.Paused = toptr.MakePtr(true)
+
+		next, err := applyPauseSchedule(deployment, time.Now())
+
+		require.NoError(t, err)
+		assert.True(t, next.IsZero())
+		assert.Equal(t, toptr.MakePtr(true), deployment.Spec.DeploymentSpec.Paused)
+	})
+
+	t.Run("the schedule is replaced with the paused flag", func(t *testing.T) {
+		deployment := mdbv1.DefaultAWSDeployment("default", "my-project")
+		deployment.Spec.DeploymentSpec.PauseSchedule = &mdbv1.PauseSchedule{
+			Windows: []mdbv1.PauseWindow{{Start: "01:00", End: "02:00"}},
+		}
+		now := time.Date(2023, time.November, 1, 1, 30, 0, 0, time.UTC)
+
+		next, err := applyPauseSchedule(deployment, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2023, time.November, 1, 2, 0, 0, 0, time.UTC), next)
+		assert.Equal(t, toptr.MakePtr(true), deployment.Spec.DeploymentSpec.Paused)
+		assert.Nil(t, deployment.Spec.DeploymentSpec.PauseSchedule)
+	})
+}
+
+func TestPauseScheduleResult(t *testing.T) {
+	now := time.Date(2023, time.November, 1, 1, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Hour, pauseScheduleResult(workflow.OK(), 0, now.Add(time.Hour), now).ReconcileResult().RequeueAfter)
+	assert.Equal(t, 10*time.Minute, pauseScheduleResult(workflow.OK().WithRetry(10*time.Minute), 10*time.Minute, now.Add(time.Hour), now).ReconcileResult().RequeueAfter)
+	assert.Equal(t, time.Hour, pauseScheduleResult(workflow.OK().WithRetry(2*time.Hour), 2*time.Hour, now.Add(time.Hour), now).ReconcileResult().RequeueAfter)
+	assert.Equal(t, workflow.OK().ReconcileResult(), pauseScheduleResult(workflow.OK(), 0, time.Time{}, now).ReconcileResult())
+}
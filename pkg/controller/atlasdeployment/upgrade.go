@@ -0,0 +1,214 @@
+package atlasdeployment
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+// UpgradeApprovalAnnotation approves the major version upgrade of a deployment requiring approvals.
+// Its value is the approved target major version.
+const UpgradeApprovalAnnotation = "mongodb.com/atlas-approve-upgrade"
+
+const defaultMaintenanceWindowHours = 4
+
+// upgradePreflightRetry is how often the pre-flight checks blocked by the state of the deployment or the project in
+// Atlas, such as the feature compatibility version or the maintenance window, are run again
+const upgradePreflightRetry = 5 * time.Minute
+
+// legacyMajorVersionUpgrades are the upgrades of the major versions older than 5.0, which don't follow the X.0 scheme
+var legacyMajorVersionUpgrades = map[string]string{
+	"3.6": "4.0",
+	"4.0": "4.2",
+	"4.2": "4.4",
+	"4.4": "5.0",
+}
+
+// ensureMajorVersionUpgrade runs the pre-flight checks of the major version upgrade requested by the spec of a
+// deployment with an upgrade policy, and records the progress of the upgrade. It returns the major version to apply:
+// the current one while the upgrade is pending. The deployment must be idle.
+func ensureMajorVersionUpgrade(ctx *workflow.Context, project *mdbv1.AtlasProject, deployment *mdbv1.AtlasDeployment, specDeployment, atlasDeployment mdbv1.AdvancedDeploymentSpec, featureCompatibilityVersion atlas.FeatureCompatibilityVersion, now time.Time) (string, workflow.Result) {
+	from := atlasDeployment.MongoDBMajorVersion
+	to := specDeployment.MongoDBMajorVersion
+	last := deployment.Status.Upgrade
+
+	if to == "" || to == from {
+		switch {
+		case last == nil:
+		case last.State == status.DeploymentUpgradeInProgress && last.ToVersion == from:
+			ctx.EnsureStatusOption(status.AtlasDeploymentUpgradeOption(newUpgrade(last.FromVersion, last.ToVersion, status.DeploymentUpgradeCompleted, "")))
+		case last.State == status.DeploymentUpgradePending:
+			// the upgrade was reverted before it started
+			ctx.EnsureStatusOption(status.AtlasDeploymentUpgradeOption(nil))
+		}
+		return to, workflow.OK()
+	}
+
+	pending := func(message string) {
+		if last == nil || last.State != status.DeploymentUpgradePending || last.ToVersion != to || last.Message != message {
+			ctx.EnsureStatusOption(status.AtlasDeploymentUpgradeOption(newUpgrade(from, to, status.DeploymentUpgradePending, message)))
+		}
+	}
+
+	// the upgrade is applied alone, once the deployment is up-to-date with the rest of the spec
+	specDeployment.MongoDBMajorVersion = from
+	if areEqual, _ := AdvancedDeploymentsEqual(ctx.Log, specDeployment, atlasDeployment); !areEqual {
+		pending("waiting for the other changes of the deployment to be applied")
+		return from, workflow.OK()
+	}
+
+	// the feature compatibility version may lag behind the major version after an upgrade or a downgrade, it's only
+	// read from Atlas once an upgrade is requested
+	fcv, err := featureCompatibilityVersion(ctx.Context, project.ID(), deployment.GetDeploymentName())
+	if err != nil {
+		message := fmt.Sprintf("the feature compatibility version of the deployment can't be read from Atlas: %s", err)
+		pending(message)
+		return from, workflow.InProgress(workflow.DeploymentUpgradePending, fmt.Sprintf("the upgrade to MongoDB %s is pending: %s", to, message))
+	}
+
+	// the maintenance window can be changed outside the Operator, or by another AtlasProject resource, it's read from
+	// Atlas once it's required
+	window, err := upgradeMaintenanceWindow(ctx, project.ID(), deployment.Spec.DeploymentSpec.UpgradePolicy)
+	if err != nil {
+		message := fmt.Sprintf("the maintenance window of the project can't be read from Atlas: %s", err)
+		pending(message)
+		return from, workflow.InProgress(workflow.DeploymentUpgradePending, fmt.Sprintf("the upgrade to MongoDB %s is pending: %s", to, message))
+	}
+
+	if message, retry := upgradePreflightCheck(deployment, window, from, to, fcv, now); message != "" {
+		pending(message)
+		result := workflow.InProgress(workflow.DeploymentUpgradePending, fmt.Sprintf("the upgrade to MongoDB %s is pending: %s", to, message))
+		if retry > 0 {
+			return from, result.WithRetry(retry)
+		}
+		// the deployment is reconciled again once the approval annotation changes
+		return from, result.WithoutRetry()
+	}
+
+	if last == nil || last.State != status.DeploymentUpgradeInProgress || last.ToVersion != to {
+		ctx.Log.Infow("Upgrading the major version of the deployment", "from", from, "to", to)
+		ctx.EnsureStatusOption(status.AtlasDeploymentUpgradeOption(newUpgrade(from, to, status.DeploymentUpgradeInProgress, "")))
+	}
+	return to, workflow.OK()
+}
+
+// upgradePreflightCheck returns why the upgrade can't start, and when to check again if it depends on the time
+func upgradePreflightCheck(deployment *mdbv1.AtlasDeployment, window project.MaintenanceWindow, from, to, fcv string, now time.Time) (string, time.Duration) {
+	policy := deployment.Spec.DeploymentSpec.UpgradePolicy
+
+	if message := checkMajorVersions(from, to, fcv); message != "" {
+		return message, upgradePreflightRetry
+	}
+
+	if policy.RequireApproval && deployment.GetAnnotations()[UpgradeApprovalAnnotation] != to {
+		return fmt.Sprintf("the upgrade must be approved by setting the %s annotation to %s", UpgradeApprovalAnnotation, to), 0
+	}
+
+	if policy.MaintenanceWindowOnly {
+		if window.DayOfWeek == 0 {
+			return "the project has no maintenance window", upgradePreflightRetry
+		}
+
+		hours := policy.MaintenanceWindowHours
+		if hours == 0 {
+			hours = defaultMaintenanceWindowHours
+		}
+		start := maintenanceWindowStart(window, now)
+		if !now.Before(start.Add(time.Duration(hours) * time.Hour)) {
+			next := start.AddDate(0, 0, 7)
+			return fmt.Sprintf("waiting for the maintenance window of the project starting at %s", next.Format(time.RFC3339)), next.Sub(now)
+		}
+	}
+
+	return "", 0
+}
+
+// upgradeMaintenanceWindow reads the maintenance window of the project from Atlas if the upgrade policy requires it
+func upgradeMaintenanceWindow(ctx *workflow.Context, projectID string, policy *mdbv1.UpgradePolicy) (project.MaintenanceWindow, error) {
+	window := project.MaintenanceWindow{}
+	if policy == nil || !policy.MaintenanceWindowOnly {
+		return window, nil
+	}
+
+	atlasWindow, _, err := ctx.Client.MaintenanceWindows.Get(ctx.Context, projectID)
+	if err != nil {
+		return window, err
+	}
+	if atlasWindow != nil {
+		window.DayOfWeek = atlasWindow.DayOfWeek
+		if atlasWindow.HourOfDay != nil {
+			window.HourOfDay = *atlasWindow.HourOfDay
+		}
+	}
+	return window, nil
+}
+
+// checkMajorVersions checks that the deployment can be upgraded from one major version to the other. MongoDB only
+// allows upgrading to the next major version, once the feature compatibility version matches the current one
+func checkMajorVersions(from, to, fcv string) string {
+	fromVersion, fromErr := semver.NewVersion(from)
+	toVersion, toErr := semver.NewVersion(to)
+	if fromErr != nil || toErr != nil {
+		return fmt.Sprintf("the upgrade from %q to %q can't be checked: the versions must be in <major>.<minor> format", from, to)
+	}
+
+	if toVersion.LessThan(fromVersion) {
+		return fmt.Sprintf("the deployment can't be downgraded from MongoDB %s to %s", from, to)
+	}
+
+	if fcv != from {
+		return fmt.Sprintf("the feature compatibility version of the deployment is %s: it must be set to %s before upgrading to MongoDB %s", fcv, from, to)
+	}
+
+	if next := nextMajorVersion(from); next != to {
+		return fmt.Sprintf("the deployment can only be upgraded from MongoDB %s to %s", from, next)
+	}
+
+	return ""
+}
+
+func nextMajorVersion(version string) string {
+	if next, ok := legacyMajorVersionUpgrades[version]; ok {
+		return next
+	}
+
+	major, minor, found := strings.Cut(version, ".")
+	number, err := strconv.Atoi(major)
+	if !found || err != nil || minor != "0" || number < 5 {
+		return ""
+	}
+	return fmt.Sprintf("%d.0", number+1)
+}
+
+// maintenanceWindowStart returns the start of the last maintenance window before the given time.
+// The maintenance windows start every week on their day and hour in UTC
+func maintenanceWindowStart(window project.MaintenanceWindow, now time.Time) time.Time {
+	now = now.UTC()
+	// the days of the maintenance window start from 1 on Sunday
+	daysSince := (int(now.Weekday()) - (window.DayOfWeek - 1) + 7) % 7
+	start := time.Date(now.Year(), now.Month(), now.Day()-daysSince, window.HourOfDay, 0, 0, 0, time.UTC)
+	if start.After(now) {
+		start = start.AddDate(0, 0, -7)
+	}
+	return start
+}
+
+func newUpgrade(from, to, state, message string) *status.DeploymentUpgrade {
+	return &status.DeploymentUpgrade{
+		FromVersion:        from,
+		ToVersion:          to,
+		State:              state,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+}
@@ -0,0 +1,214 @@
+package atlasdeployment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap/zaptest"
+
+	atlas_mock "github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/mocks/atlas"
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+func TestEnsureMajorVersionUpgrade(t *testing.T) {
+	// Wednesday
+	now := time.Date(2023, time.November, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("an approved upgrade starts in the maintenance window", func(t *testing.T) {
+		workflowCtx, atlasProject, deployment := testUpgrade(t, &mdbv1.UpgradePolicy{RequireApproval: true, MaintenanceWindowOnly: true})
+		testMaintenanceWindow(workflowCtx, &mongodbatlas.MaintenanceWindow{DayOfWeek: 4, HourOfDay: toptr.MakePtr(10)}, nil)
+		deployment.Annotations = map[string]string{UpgradeApprovalAnnotation: "7.0"}
+
+		version, result := ensureMajorVersionUpgrade(workflowCtx, atlasProject, deployment, testUpgradeSpec("7.0"), testUpgradeSpec("6.0"), testFeatureCompatibilityVersion("6.0", nil), now)
+
+		assert.True(t, result.IsOk())
+		assert.Equal(t, "7.0", version)
+		upgrade := assertUpgrade(t, workflowCtx, status.DeploymentUpgradeInProgress)
+		assert.Equal(t, "6.0", upgrade.FromVersion)
+		assert.Equal(t, "7.0", upgrade.ToVersion)
+	})
+
+	t.Run("an upgrade waits for the approval", func(t *testing.T) {
+		workflowCtx, atlasProject, deployment := testUpgrade(t, &mdbv1.UpgradePolicy{RequireApproval: true})
+		deployment.Annotations = map[string]string{UpgradeApprovalAnnotation: "6.0"}
+
+		version, result := ensureMajorVersionUpgrade(workflowCtx, atlasProject, deployment, testUpgradeSpec("7.0"), testUpgradeSpec("6.0"), testFeatureCompatibilityVersion("6.0", nil), now)
+
+		assert.True(t, result.IsInProgress())
+		assert.Equal(t, workflow.DeploymentUpgradePending, result.GetReason())
+		assert.Equal(t, time.Duration(0), result.ReconcileResult().RequeueAfter)
+		assert.Equal(t, "6.0", version)
+		upgrade := assertUpgrade(t, workflowCtx, status.DeploymentUpgradePending)
+		assert.Equal(t, "the upgrade must be approved by setting the mongodb.com/atlas-approve-upgrade annotation to 7.0", upgrade.Message)
+	})
+
+	t.Run("an upgrade waits for the maintenance window", func(t *testing.T) {
+		workflowCtx, atlasProject, deployment := testUpgrade(t, &mdbv1.UpgradePolicy{MaintenanceWindowOnly: true, MaintenanceWindowHours: 2})
+		// Wednesday 9:00 to 11:00, the window of the spec is ignored as it may not be applied to Atlas yet
+		atlasProject.Spec.MaintenanceWindow = project.NewMaintenanceWindow().WithDay(4).WithHour(12)
+		testMaintenanceWindow(workflowCtx, &mongodbatlas.MaintenanceWindow{DayOfWeek: 4, HourOfDay: toptr.MakePtr(9)}, nil)
+
+		version, result := ensureMajorVersionUpgrade(workflowCtx, atlasProject, deployment, testUpgradeSpec("7.0"), testUpgradeSpec("6.0"), testFeatureCompatibilityVersion("6.0", nil), now)
+
+		assert.True(t, result.IsInProgress())
+		assert.Equal(t, 7*24*time.Hour-3*time.Hour, result.ReconcileResult().RequeueAfter)
+		assert.Equal(t, "6.0", version)
+		upgrade := assertUpgrade(t, workflowCtx, status.DeploymentUpgradePending)
+		assert.Equal(t, "waiting for the maintenance window of the project starting at 2023-11-08T09:00:00Z", upgrade.Message)
+	})
+
+	t.Run("an upgrade is retried until the project has a maintenance window", func(t *testing.T) {
+		workflowCtx, atlasProject, deployment := testUpgrade(t, &mdbv1.UpgradePolicy{MaintenanceWindowOnly: true})
+		testMaintenanceWindow(workflowCtx, &mongodbatlas.MaintenanceWindow{}, nil)
+
+		version, result := ensureMajorVersionUpgrade(workflowCtx, atlasProject, deployment, testUpgradeSpec("7.0"), testUpgradeSpec("6.0"), testFeatureCompatibilityVersion("6.0", nil), now)
+
+		assert.True(t, result.IsInProgress())
+		assert.Equal(t, upgradePreflightRetry, result.ReconcileResult().RequeueAfter)
+		assert.Equal(t, "6.0", version)
+		upgrade := assertUpgrade(t, workflowCtx, status.DeploymentUpgradePending)
+		assert.Equal(t, "the project has no maintenance window", upgrade.Message)
+	})
+
+	t.Run("an upgrade is retried when the maintenance window can't be read", func(t *testing.T) {
+		workflowCtx, atlasProject, deployment := testUpgrade(t, &mdbv1.UpgradePolicy{MaintenanceWindowOnly: true})
+		testMaintenanceWindow(workflowCtx, nil, errors.New("service unavailable"))
+
+		version, result := ensureMajorVersionUpgrade(workflowCtx, atlasProject, deployment, testUpgradeSpec("7.0"), testUpgradeSpec("6.0"), testFeatureCompatibilityVersion("6.0", nil), now)
+
+		assert.True(t, result.IsInProgress())
+		assert.NotZero(t, result.ReconcileResult().RequeueAfter)
+		assert.Equal(t, "6.0", version)
+		upgrade := assertUpgrade(t, workflowCtx, status.DeploymentUpgradePending)
+		assert.Equal(t, "the maintenance window of the project can't be read from Atlas: service unavailable", upgrade.Message)
+	})
+
+	t.Run("an upgrade skipping a major version is blocked", func(t *testing.T) {
+		workflowCtx, atlasProject, deployment := testUpgrade(t, &mdbv1.UpgradePolicy{})
+
+		version, result := ensureMajorVersionUpgrade(workflowCtx, atlasProject, deployment, testUpgradeSpec("7.0"), testUpgradeSpec("5.0"), testFeatureCompatibilityVersion("5.0", nil), now)
+
+		assert.True(t, result.IsInProgress())
+		assert.Equal(t, upgradePreflightRetry, result.ReconcileResult().RequeueAfter)
+		assert.Equal(t, "5.0", version)
+		upgrade := assertUpgrade(t, workflowCtx, status.DeploymentUpgradePending)
+		assert.Equal(t, "the deployment can only be upgraded from MongoDB 5.0 to 6.0", upgrade.Message)
+	})
+
+	t.Run("an upgrade is blocked until the feature compatibility version matches the major version", func(t *testing.T) {
+		workflowCtx, atlasProject, deployment := testUpgrade(t, &mdbv1.UpgradePolicy{})
+
+		version, result := ensureMajorVersionUpgrade(workflowCtx, atlasProject, deployment, testUpgradeSpec("7.0"), testUpgradeSpec("6.0"), testFeatureCompatibilityVersion("5.0", nil), now)
+
+		assert.True(t, result.IsInProgress())
+		assert.Equal(t, upgradePreflightRetry, result.ReconcileResult().RequeueAfter)
+		assert.Equal(t, "6.0", version)
+		upgrade := assertUpgrade(t, workflowCtx, status.DeploymentUpgradePending)
+		assert.Equal(t, "the feature compatibility version of the deployment is 5.0: it must be set to 6.0 before upgrading to MongoDB 7.0", upgrade.Message)
+	})
+
+	t.Run("an upgrade is retried when the feature compatibility version can't be read", func(t *testing.T) {
+		workflowCtx, atlasProject, deployment := testUpgrade(t, &mdbv1.UpgradePolicy{})
+
+		version, result := ensureMajorVersionUpgrade(workflowCtx, atlasProject, deployment, testUpgradeSpec("7.0"), testUpgradeSpec("6.0"), testFeatureCompatibilityVersion("", errors.New("service unavailable")), now)
+
+		assert.True(t, result.IsInProgress())
+		assert.NotZero(t, result.ReconcileResult().RequeueAfter)
+		assert.Equal(t, "6.0", version)
+		upgrade := assertUpgrade(t, workflowCtx, status.DeploymentUpgradePending)
+		assert.Equal(t, "the feature compatibility version of the deployment can't be read from Atlas: service unavailable", upgrade.Message)
+	})
+
+	t.Run("an upgrade waits for the other changes to be applied", func(t *testing.T) {
+		workflowCtx, atlasProject, deployment := testUpgrade(t, &mdbv1.UpgradePolicy{})
+		spec := testUpgradeSpec("7.0")
+		spec.ReplicationSpecs[0].RegionConfigs[0].ElectableSpecs.InstanceSize = "M30"
+
+		version, result := ensureMajorVersionUpgrade(workflowCtx, atlasProject, deployment, spec, testUpgradeSpec("6.0"), testFeatureCompatibilityVersion("6.0", nil), now)
+
+		assert.True(t, result.IsOk())
+		assert.Equal(t, "6.0", version)
+		assertUpgrade(t, workflowCtx, status.DeploymentUpgradePending)
+	})
+
+	t.Run("an upgrade in progress is completed once Atlas runs the new version", func(t *testing.T) {
+		workflowCtx, atlasProject, deployment := testUpgrade(t, &mdbv1.UpgradePolicy{})
+		deployment.Status.Upgrade = newUpgrade("6.0", "7.0", status.DeploymentUpgradeInProgress, "")
+
+		version, result := ensureMajorVersionUpgrade(workflowCtx, atlasProject, deployment, testUpgradeSpec("7.0"), testUpgradeSpec("7.0"), testFeatureCompatibilityVersion("7.0", nil), now)
+
+		assert.True(t, result.IsOk())
+		assert.Equal(t, "7.0", version)
+		assertUpgrade(t, workflowCtx, status.DeploymentUpgradeCompleted)
+	})
+}
+
+func TestCheckMajorVersions(t *testing.T) {
+	assert.Empty(t, checkMajorVersions("4.4", "5.0", "4.4"))
+	assert.Empty(t, checkMajorVersions("7.0", "8.0", "7.0"))
+	assert.Equal(t, "the deployment can't be downgraded from MongoDB 7.0 to 6.0", checkMajorVersions("7.0", "6.0", "7.0"))
+	assert.Equal(t, "the deployment can only be upgraded from MongoDB 4.2 to 4.4", checkMajorVersions("4.2", "5.0", "4.2"))
+	assert.Equal(t, "the feature compatibility version of the deployment is 6.0: it must be set to 7.0 before upgrading to MongoDB 8.0", checkMajorVersions("7.0", "8.0", "6.0"))
+	assert.Equal(t, "the upgrade from \"\" to \"7.0\" can't be checked: the versions must be in <major>.<minor> format", checkMajorVersions("", "7.0", ""))
+}
+
+func TestMaintenanceWindowStart(t *testing.T) {
+	window := project.NewMaintenanceWindow().WithDay(1).WithHour(3)
+
+	// Sunday 3:00
+	assert.Equal(t, time.Date(2023, time.November, 5, 3, 0, 0, 0, time.UTC), maintenanceWindowStart(window, time.Date(2023, time.November, 5, 3, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2023, time.November, 5, 3, 0, 0, 0, time.UTC), maintenanceWindowStart(window, time.Date(2023, time.November, 11, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2023, time.October, 29, 3, 0, 0, 0, time.UTC), maintenanceWindowStart(window, time.Date(2023, time.November, 5, 2, 59, 0, 0, time.UTC)))
+}
+
+func testUpgrade(t *testing.T, policy *mdbv1.UpgradePolicy) (*workflow.Context, *mdbv1.AtlasProject, *mdbv1.AtlasDeployment) {
+	t.Helper()
+
+	workflowCtx := workflow.NewContext(zaptest.NewLogger(t).Sugar(), []status.Condition{}, context.Background())
+	deployment := mdbv1.NewDeployment("default", "my-deployment", "cluster")
+	deployment.Spec.DeploymentSpec.UpgradePolicy = policy
+	return workflowCtx, mdbv1.NewProject("default", "my-project", "my-project"), deployment
+}
+
+func testMaintenanceWindow(workflowCtx *workflow.Context, window *mongodbatlas.MaintenanceWindow, err error) {
+	workflowCtx.Client.MaintenanceWindows = &atlas_mock.MaintenanceWindowClientMock{
+		GetFunc: func(projectID string) (*mongodbatlas.MaintenanceWindow, *mongodbatlas.Response, error) {
+			return window, nil, err
+		},
+	}
+}
+
+func testFeatureCompatibilityVersion(fcv string, err error) atlas.FeatureCompatibilityVersion {
+	return func(ctx context.Context, projectID, clusterName string) (string, error) {
+		return fcv, err
+	}
+}
+
+func testUpgradeSpec(version string) mdbv1.AdvancedDeploymentSpec {
+	spec := *mdbv1.NewDeployment("default", "my-deployment", "cluster").Spec.DeploymentSpec.DeepCopy()
+	spec.MongoDBMajorVersion = version
+	return spec
+}
+
+func assertUpgrade(t *testing.T, workflowCtx *workflow.Context, state string) *status.DeploymentUpgrade {
+	t.Helper()
+
+	deploymentStatus := status.AtlasDeploymentStatus{}
+	for _, option := range workflowCtx.StatusOptions() {
+		option.(status.AtlasDeploymentStatusOption)(&deploymentStatus)
+	}
+
+	require.NotNil(t, deploymentStatus.Upgrade)
+	assert.Equal(t, state, deploymentStatus.Upgrade.State)
+	return deploymentStatus.Upgrade
+}
@@ -10,19 +10,25 @@ import (
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -37,10 +43,16 @@ type AtlasFederatedAuthReconciler struct {
 	Log                         *zap.SugaredLogger
 	Scheme                      *runtime.Scheme
 	AtlasDomain                 string
+	AtlasProvider               atlas.Provider
 	GlobalPredicates            []predicate.Predicate
 	EventRecorder               record.EventRecorder
 	ObjectDeletionProtection    bool
 	SubObjectDeletionProtection bool
+	MaxConcurrentReconciles     int
+	GlobalAPISecret             client.ObjectKey
+	DryRun                      bool
+	// CredentialsEvents receives the Secret references whose credentials changed in the credentials directory
+	CredentialsEvents <-chan event.GenericEvent
 }
 
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasfederatedauths,verbs=get;list;watch;create;update;patch;delete
@@ -49,6 +61,7 @@ type AtlasFederatedAuthReconciler struct {
 // +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasfederatedauths/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasconnections,verbs=get;list;watch
 
 func (r *AtlasFederatedAuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.With("atlasfederatedauth", req.NamespacedName)
@@ -74,7 +87,12 @@ func (r *AtlasFederatedAuthReconciler) Reconcile(ctx context.Context, req ctrl.R
 	workflowCtx := customresource.MarkReconciliationStarted(r.Client, fedauth, log, ctx)
 	log.Infow("-> Starting AtlasFederatedAuth reconciliation")
 
-	defer statushandler.Update(workflowCtx, r.Client, r.EventRecorder, fedauth)
+	plan := dryrun.PlanFor(fedauth, r.DryRun, log)
+	defer func() {
+		workflowCtx.EnsureStatusOption(status.AtlasFederatedAuthPlannedChangesOption(plan.Changes()))
+		plan.Emit(r.EventRecorder, fedauth)
+		statushandler.Update(workflowCtx, r.Client, r.EventRecorder, fedauth)
+	}()
 
 	resourceVersionIsValid := customresource.ValidateResourceVersion(workflowCtx, fedauth, r.Log)
 	if !resourceVersionIsValid.IsOk() {
@@ -82,15 +100,14 @@ func (r *AtlasFederatedAuthReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return resourceVersionIsValid.ReconcileResult(), nil
 	}
 
-	if !customresource.IsResourceSupportedInDomain(fedauth, r.AtlasDomain) {
-		result := workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasFederatedAuth is not supported by Atlas for government").
-			WithoutRetry()
-		setCondition(workflowCtx, status.FederatedAuthReadyType, result)
+	if err := validate.FederatedAuth(fedauth); err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.ValidationSucceeded, result)
 		return result.ReconcileResult(), nil
 	}
+	workflowCtx.SetConditionTrue(status.ValidationSucceeded)
 
-	connection, err := atlas.ReadConnection(log, r.Client, types.NamespacedName{},
-		fedauth.ConnectionSecretObjectKey())
+	connection, err := r.AtlasProvider.CreateConnection(fedauth, log)
 	if err != nil {
 		result = workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
 		setCondition(workflowCtx, status.FederatedAuthReadyType, result)
@@ -103,7 +120,14 @@ func (r *AtlasFederatedAuthReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	workflowCtx.Connection = connection
 
-	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
+	if !customresource.IsResourceSupportedInDomain(fedauth, connection.DomainOr(r.AtlasDomain)) {
+		result := workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasFederatedAuth is not supported by Atlas for government").
+			WithoutRetry()
+		setCondition(workflowCtx, status.FederatedAuthReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	atlasClient, err := r.AtlasProvider.CreateClient(&connection, log, plan.ClientOpts()...)
 	if err != nil {
 		result := workflow.Terminate(workflow.Internal, err.Error())
 		setCondition(workflowCtx, status.FederatedAuthReadyType, result)
@@ -141,11 +165,56 @@ func (r *AtlasFederatedAuthReconciler) Reconcile(ctx context.Context, req ctrl.R
 }
 
 func (r *AtlasFederatedAuthReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named("AtlasFederatedAuth").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		For(&mdbv1.AtlasFederatedAuth{}, builder.WithPredicates(r.GlobalPredicates...)).
-		Watches(&source.Kind{Type: &corev1.Secret{}}, watch.NewSecretHandler(r.WatchedResources)).
-		Complete(r)
+		Watches(&source.Kind{Type: &corev1.Secret{}}, r.SecretHandler()).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, r.globalSecretHandler())
+	if r.CredentialsEvents != nil {
+		b = b.Watches(&source.Channel{Source: r.CredentialsEvents}, r.globalSecretHandler())
+	}
+	if r.AtlasProvider.AtlasConnectionsEnabled() {
+		b = b.Watches(&source.Kind{Type: &mdbv1.AtlasConnection{}}, r.atlasConnectionHandler())
+	}
+	return b.Complete(r)
+}
+
+// atlasConnectionHandler enqueues the federated auths referencing the AtlasConnection
+func (r *AtlasFederatedAuthReconciler) atlasConnectionHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		fedauths := &mdbv1.AtlasFederatedAuthList{}
+		if err := r.Client.List(context.Background(), fedauths); err != nil {
+			r.Log.Errorf("failed to list the AtlasFederatedAuths of the AtlasConnection %s: %s", obj.GetName(), err)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range fedauths.Items {
+			if fedauths.Items[i].AtlasConnectionName() == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(&fedauths.Items[i])})
+			}
+		}
+		return requests
+	})
+}
+
+// globalSecretHandler enqueues the federated auths referencing the global connection Secret once it changes
+func (r *AtlasFederatedAuthReconciler) globalSecretHandler() *watch.GlobalSecretHandler {
+	return watch.NewGlobalSecretHandler(r.GlobalAPISecret, func(ctx context.Context) ([]client.ObjectKey, error) {
+		fedauths := &mdbv1.AtlasFederatedAuthList{}
+		if err := r.Client.List(ctx, fedauths); err != nil {
+			return nil, fmt.Errorf("failed to list the AtlasFederatedAuths: %w", err)
+		}
+
+		var dependants []client.ObjectKey
+		for i := range fedauths.Items {
+			if atlas.UsesGlobalAPISecret(&fedauths.Items[i], r.GlobalAPISecret) {
+				dependants = append(dependants, kube.ObjectKeyFromObject(&fedauths.Items[i]))
+			}
+		}
+		return dependants, nil
+	})
 }
 
 func setCondition(ctx *workflow.Context, condition status.ConditionType, result workflow.Result) {
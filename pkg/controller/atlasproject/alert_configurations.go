@@ -53,7 +53,7 @@ func (r *AtlasProjectReconciler) readAlertConfigurationsSecretsData(project *mdb
 	projectNs := project.Namespace
 	defer func() {
 		service.AddResourcesToWatch(resourcesToWatch...)
-		r.Log.Debugf("watching alert configuration secrets: %v\r\n", r.WatchedResources)
+		r.Log.Debugf("watching alert configuration secrets: %v\r\n", r.ResourceWatcher)
 	}()
 
 	for i := 0; i < len(alertConfigs); i++ {
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 
@@ -31,16 +32,24 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/authmode"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/concurrency"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/statushandler"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
@@ -54,11 +63,19 @@ type AtlasProjectReconciler struct {
 	Log                         *zap.SugaredLogger
 	Scheme                      *runtime.Scheme
 	AtlasDomain                 string
+	AtlasProvider               atlas.Provider
 	GlobalAPISecret             client.ObjectKey
 	GlobalPredicates            []predicate.Predicate
 	EventRecorder               record.EventRecorder
 	ObjectDeletionProtection    bool
 	SubObjectDeletionProtection bool
+	DryRun                      bool
+	ResyncInterval              time.Duration
+	DriftPolicy                 drift.Policy
+	MaxConcurrentReconciles     int
+	ProjectLocks                *concurrency.ProjectLocks
+	// CredentialsEvents receives the Secret references whose credentials changed in the credentials directory
+	CredentialsEvents <-chan event.GenericEvent
 }
 
 // Dev note: duplicate the permissions in both sections below to generate both Role and ClusterRoles
@@ -73,6 +90,8 @@ type AtlasProjectReconciler struct {
 // +kubebuilder:rbac:groups="",namespace=default,resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
 
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasconnections,verbs=get;list;watch
+
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasteams,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasteams/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasteams,verbs=get;list;watch;create;update;patch;delete
@@ -100,17 +119,34 @@ func (r *AtlasProjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return workflow.OK().ReconcileResult(), nil
 	}
 
+	unlock, err := r.ProjectLocks.Lock(ctx, req.NamespacedName)
+	if err != nil {
+		return workflow.TerminateSilently().ReconcileResult(), nil
+	}
+	defer unlock()
+
+	checkDrift := drift.ShouldCheck(project, r.ResyncInterval)
 	workflowCtx := customresource.MarkReconciliationStarted(r.Client, project, log, ctx)
 	log.Infow("-> Starting AtlasProject reconciliation", "spec", project.Spec)
 
 	if project.ConnectionSecretObjectKey() != nil {
-		// Note, that we are not watching the global connection secret - seems there is no point in reconciling all
-		// the projects once that secret is changed
+		// the global connection secret isn't tracked per project, its changes are handled by globalSecretHandler
 		workflowCtx.AddResourcesToWatch(watch.WatchedObject{ResourceKind: "Secret", Resource: *project.ConnectionSecretObjectKey()})
 	}
+	if name := project.AtlasConnectionName(); name != "" && r.AtlasProvider.AtlasConnectionsEnabled() {
+		// the changes of the AtlasConnection itself are handled by atlasConnectionHandler
+		atlasConnection := &mdbv1.AtlasConnection{}
+		if err := r.Client.Get(ctx, kube.ObjectKey("", name), atlasConnection); err == nil {
+			workflowCtx.AddResourcesToWatch(watch.WatchedObject{ResourceKind: "Secret", Resource: atlasConnection.CredentialsSecretObjectKey()})
+		}
+	}
+
+	plan := dryrun.PlanFor(project, r.DryRun, log)
 
 	// This update will make sure the status is always updated in case of any errors or successful result
 	defer func() {
+		workflowCtx.EnsureStatusOption(status.AtlasProjectPlannedChangesOption(plan.Changes()))
+		plan.Emit(r.EventRecorder, project)
 		statushandler.Update(workflowCtx, r.Client, r.EventRecorder, project)
 		r.EnsureMultiplesResourcesAreWatched(req.NamespacedName, log, workflowCtx.ListResourcesToWatch()...)
 	}()
@@ -121,33 +157,34 @@ func (r *AtlasProjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return resourceVersionIsValid.ReconcileResult(), nil
 	}
 
-	if err := validate.Project(project, customresource.IsGov(r.AtlasDomain)); err != nil {
+	connection, err := r.AtlasProvider.CreateConnection(project, log)
+	if err != nil {
+		result = workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+		setCondition(workflowCtx, status.ProjectReadyType, result)
+		if errRm := customresource.ManageFinalizer(ctx, r.Client, project, customresource.UnsetFinalizer); errRm != nil {
+			result = workflow.Terminate(workflow.Internal, errRm.Error())
+			return result.ReconcileResult(), nil
+		}
+		return result.ReconcileResult(), nil
+	}
+	workflowCtx.Connection = connection
+
+	// the validation for Atlas for Government depends on the domain of the connection
+	if err := validate.Project(project, customresource.IsGov(connection.DomainOr(r.AtlasDomain))); err != nil {
 		result := workflow.Terminate(workflow.Internal, err.Error())
 		setCondition(workflowCtx, status.ValidationSucceeded, result)
 		return result.ReconcileResult(), nil
 	}
 	workflowCtx.SetConditionTrue(status.ValidationSucceeded)
 
-	if !customresource.IsResourceSupportedInDomain(project, r.AtlasDomain) {
+	if !customresource.IsResourceSupportedInDomain(project, connection.DomainOr(r.AtlasDomain)) {
 		result := workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasProject is not supported by Atlas for government").
 			WithoutRetry()
 		setCondition(workflowCtx, status.ProjectReadyType, result)
 		return result.ReconcileResult(), nil
 	}
 
-	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
-	if err != nil {
-		result = workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
-		setCondition(workflowCtx, status.ProjectReadyType, result)
-		if errRm := customresource.ManageFinalizer(ctx, r.Client, project, customresource.UnsetFinalizer); errRm != nil {
-			result = workflow.Terminate(workflow.Internal, errRm.Error())
-			return result.ReconcileResult(), nil
-		}
-		return result.ReconcileResult(), nil
-	}
-	workflowCtx.Connection = connection
-
-	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
+	atlasClient, err := r.AtlasProvider.CreateClient(&connection, log, plan.ClientOpts()...)
 	if err != nil {
 		result := workflow.Terminate(workflow.Internal, err.Error())
 		setCondition(workflowCtx, status.DeploymentReadyType, result)
@@ -175,15 +212,26 @@ func (r *AtlasProjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return result.ReconcileResult(), nil
 	}
 
-	projectID, result := r.ensureProjectExists(workflowCtx, project)
+	projectID, result := r.ensureProjectExists(workflowCtx, project, plan)
 	if !result.IsOk() {
 		setCondition(workflowCtx, status.ProjectReadyType, result)
 		return result.ReconcileResult(), nil
 	}
 
+	if projectID == "" {
+		// the creation of the project is only planned in dry-run mode: the changes to its resources can't be planned
+		// without its ID
+		result = workflow.InProgress(workflow.ProjectCreationPlanned, "the creation of the project is only planned in dry-run mode, the changes to its resources are planned once it exists")
+		if !project.GetDeletionTimestamp().IsZero() && plan.KeepFinalizer(project) {
+			result = dryrun.DeletionPlanned()
+		}
+		setCondition(workflowCtx, status.ProjectReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
 	workflowCtx.EnsureStatusOption(status.AtlasProjectIDOption(projectID))
 
-	if result = r.ensureDeletionFinalizer(workflowCtx, atlasClient, project); !result.IsOk() {
+	if result = r.ensureDeletionFinalizer(workflowCtx, atlasClient, project, plan); !result.IsOk() {
 		setCondition(workflowCtx, status.ProjectReadyType, result)
 		return result.ReconcileResult(), nil
 	}
@@ -201,6 +249,11 @@ func (r *AtlasProjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return result.WithRetry(workflow.DefaultRetry).ReconcileResult(), nil
 	}
 
+	if result = r.checkDrift(workflowCtx, project, checkDrift); !result.IsOk() {
+		setCondition(workflowCtx, status.ProjectReadyType, result)
+		return drift.ResyncResult(result, r.ResyncInterval).ReconcileResult(), nil
+	}
+
 	var authModes authmode.AuthModes
 	if authModes, result = r.ensureX509(workflowCtx, projectID, project); !result.IsOk() {
 		setCondition(workflowCtx, status.ProjectReadyType, result)
@@ -231,10 +284,10 @@ func (r *AtlasProjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 
 	workflowCtx.SetConditionTrue(status.ReadyType)
-	return workflow.OK().ReconcileResult(), nil
+	return drift.ResyncResult(workflow.OK(), r.ResyncInterval).ReconcileResult(), nil
 }
 
-func (r *AtlasProjectReconciler) ensureDeletionFinalizer(workflowCtx *workflow.Context, atlasClient mongodbatlas.Client, project *mdbv1.AtlasProject) (result workflow.Result) {
+func (r *AtlasProjectReconciler) ensureDeletionFinalizer(workflowCtx *workflow.Context, atlasClient mongodbatlas.Client, project *mdbv1.AtlasProject, plan *dryrun.Plan) (result workflow.Result) {
 	log := workflowCtx.Log
 
 	if project.GetDeletionTimestamp().IsZero() {
@@ -268,6 +321,10 @@ func (r *AtlasProjectReconciler) ensureDeletionFinalizer(workflowCtx *workflow.C
 				}
 			}
 
+			if plan.KeepFinalizer(project) {
+				return dryrun.DeletionPlanned()
+			}
+
 			if err := customresource.ManageFinalizer(workflowCtx.Context, r.Client, project, customresource.UnsetFinalizer); err != nil {
 				return workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
 			}
@@ -286,13 +343,26 @@ func (r *AtlasProjectReconciler) ensureProjectResources(workflowCtx *workflow.Co
 	}
 
 	var result workflow.Result
-	if result = ensureIPAccessList(workflowCtx, atlas.CustomIPAccessListStatus(&workflowCtx.Client), project, r.SubObjectDeletionProtection); result.IsOk() {
-		r.EventRecorder.Event(project, "Normal", string(status.IPAccessListReadyType), "")
+	self := ownerName("AtlasProject", project)
+	if ipAccessListOwners, err := ipAccessListOwners(workflowCtx.Context, r.Client, project); err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.IPAccessListReadyType, result)
+	} else {
+		claimed, _ := claimedEntries(ipAccessListOwners, self, ipAccessListKey)
+		if result = ensureIPAccessList(workflowCtx, atlas.CustomIPAccessListStatus(&workflowCtx.Client), project, r.SubObjectDeletionProtection, claimed); result.IsOk() {
+			r.EventRecorder.Event(project, "Normal", string(status.IPAccessListReadyType), "")
+		}
 	}
 	results = append(results, result)
 
-	if result = ensurePrivateEndpoint(workflowCtx, project, r.SubObjectDeletionProtection); result.IsOk() {
-		r.EventRecorder.Event(project, "Normal", string(status.PrivateEndpointReadyType), "")
+	if privateEndpointOwners, err := privateEndpointOwners(workflowCtx.Context, r.Client, project); err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.PrivateEndpointReadyType, result)
+	} else {
+		claimed, _ := claimedEntries(privateEndpointOwners, self, privateEndpointKey)
+		if result = ensurePrivateEndpoint(workflowCtx, project, r.SubObjectDeletionProtection, claimed); result.IsOk() {
+			r.EventRecorder.Event(project, "Normal", string(status.PrivateEndpointReadyType), "")
+		}
 	}
 	results = append(results, result)
 
@@ -301,8 +371,14 @@ func (r *AtlasProjectReconciler) ensureProjectResources(workflowCtx *workflow.Co
 	}
 	results = append(results, result)
 
-	if result = ensureNetworkPeers(workflowCtx, project, r.SubObjectDeletionProtection); result.IsOk() {
-		r.EventRecorder.Event(project, "Normal", string(status.NetworkPeerReadyType), "")
+	if networkPeeringOwners, err := networkPeeringOwners(workflowCtx.Context, r.Client, project); err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.NetworkPeerReadyType, result)
+	} else {
+		claimed, _ := claimedEntries(networkPeeringOwners, self, networkPeerKey)
+		if result = ensureNetworkPeers(workflowCtx, project, r.SubObjectDeletionProtection, claimed); result.IsOk() {
+			r.EventRecorder.Event(project, "Normal", string(status.NetworkPeerReadyType), "")
+		}
 	}
 	results = append(results, result)
 
@@ -364,12 +440,110 @@ func (r *AtlasProjectReconciler) deleteAtlasProject(ctx context.Context, atlasCl
 }
 
 func (r *AtlasProjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named("AtlasProject").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		For(&mdbv1.AtlasProject{}, builder.WithPredicates(r.GlobalPredicates...)).
-		Watches(&source.Kind{Type: &corev1.Secret{}}, watch.NewSecretHandler(r.WatchedResources)).
-		Watches(&source.Kind{Type: &mdbv1.AtlasTeam{}}, watch.NewAtlasTeamHandler(r.WatchedResources)).
-		Complete(r)
+		Watches(&source.Kind{Type: &corev1.Secret{}}, r.SecretHandler()).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, r.globalSecretHandler()).
+		Watches(&source.Kind{Type: &mdbv1.AtlasTeam{}}, r.AtlasTeamHandler()).
+		Watches(&source.Kind{Type: &mdbv1.AtlasIPAccessList{}}, standaloneSubResourceHandler(), builder.WithPredicates(watch.CommonPredicates())).
+		Watches(&source.Kind{Type: &mdbv1.AtlasNetworkPeering{}}, standaloneSubResourceHandler(), builder.WithPredicates(watch.CommonPredicates())).
+		Watches(&source.Kind{Type: &mdbv1.AtlasPrivateEndpoint{}}, standaloneSubResourceHandler(), builder.WithPredicates(watch.CommonPredicates()))
+	if r.CredentialsEvents != nil {
+		b = b.Watches(&source.Channel{Source: r.CredentialsEvents}, r.credentialsHandler())
+	}
+	if r.AtlasProvider.AtlasConnectionsEnabled() {
+		b = b.Watches(&source.Kind{Type: &mdbv1.AtlasConnection{}}, r.atlasConnectionHandler())
+	}
+	return b.Complete(r)
+}
+
+// atlasConnectionHandler enqueues the projects referencing the AtlasConnection
+func (r *AtlasProjectReconciler) atlasConnectionHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		projects := &mdbv1.AtlasProjectList{}
+		if err := r.Client.List(context.Background(), projects); err != nil {
+			r.Log.Errorf("failed to list the AtlasProjects of the AtlasConnection %s: %s", obj.GetName(), err)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range projects.Items {
+			if projects.Items[i].AtlasConnectionName() == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(&projects.Items[i])})
+			}
+		}
+		return requests
+	})
+}
+
+// globalSecretHandler enqueues the projects using the global connection Secret once it changes
+func (r *AtlasProjectReconciler) globalSecretHandler() *watch.GlobalSecretHandler {
+	return watch.NewGlobalSecretHandler(r.GlobalAPISecret, func(ctx context.Context) ([]client.ObjectKey, error) {
+		projects, err := atlas.ProjectsUsingGlobalAPISecret(ctx, r.Client, r.GlobalAPISecret)
+		if err != nil {
+			return nil, err
+		}
+
+		dependants := make([]client.ObjectKey, 0, len(projects))
+		for project := range projects {
+			dependants = append(dependants, project)
+		}
+		return dependants, nil
+	})
+}
+
+// credentialsHandler enqueues the projects using the credentials of the Secret reference of the event, either directly
+// or through their AtlasConnection. The changes of the global credentials are handled by globalSecretHandler
+func (r *AtlasProjectReconciler) credentialsHandler() handler.EventHandler {
+	globalSecretHandler := r.globalSecretHandler()
+	secretHandler := r.secretCredentialsHandler()
+	return handler.Funcs{
+		GenericFunc: func(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+			if kube.ObjectKeyFromObject(e.Object) == r.GlobalAPISecret {
+				globalSecretHandler.Generic(e, q)
+				return
+			}
+			secretHandler.Generic(e, q)
+		},
+	}
+}
+
+// secretCredentialsHandler enqueues the projects referencing the Secret, directly or through their AtlasConnection
+func (r *AtlasProjectReconciler) secretCredentialsHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		secretRef := kube.ObjectKeyFromObject(obj)
+		projects := &mdbv1.AtlasProjectList{}
+		if err := r.Client.List(context.Background(), projects); err != nil {
+			r.Log.Errorf("failed to list the AtlasProjects using the credentials of %s: %s", secretRef, err)
+			return nil
+		}
+
+		atlasConnections := map[string]bool{}
+		if r.AtlasProvider.AtlasConnectionsEnabled() {
+			connections := &mdbv1.AtlasConnectionList{}
+			if err := r.Client.List(context.Background(), connections); err != nil {
+				r.Log.Errorf("failed to list the AtlasConnections using the credentials of %s: %s", secretRef, err)
+				return nil
+			}
+			for i := range connections.Items {
+				if connections.Items[i].CredentialsSecretObjectKey() == secretRef {
+					atlasConnections[connections.Items[i].Name] = true
+				}
+			}
+		}
+
+		var requests []reconcile.Request
+		for i := range projects.Items {
+			project := &projects.Items[i]
+			projectSecretRef := project.ConnectionSecretObjectKey()
+			if (projectSecretRef != nil && *projectSecretRef == secretRef) || atlasConnections[project.AtlasConnectionName()] {
+				requests = append(requests, reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(project)})
+			}
+		}
+		return requests
+	})
 }
 
 // setCondition sets the condition from the result and logs the warnings
@@ -0,0 +1,144 @@
+package atlasproject
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	atlas_mock "github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/mocks/atlas"
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+func TestCredentialsHandler(t *testing.T) {
+	globalSecretRef := kube.ObjectKey("operator", "global")
+	withGlobalCredentials := &mdbv1.AtlasProject{ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: "ns"}}
+	withOwnCredentials := &mdbv1.AtlasProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "own", Namespace: "ns"},
+		Spec:       mdbv1.AtlasProjectSpec{ConnectionSecret: &common.ResourceRefNamespaced{Name: "credentials"}},
+	}
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, mdbv1.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(withGlobalCredentials, withOwnCredentials).Build()
+	r := &AtlasProjectReconciler{
+		Client:          kubeClient,
+		Log:             zaptest.NewLogger(t).Sugar(),
+		GlobalAPISecret: globalSecretRef,
+		AtlasProvider:   atlas.NewProductionProvider("", globalSecretRef, kubeClient),
+	}
+
+	for secretRef, expected := range map[client.ObjectKey]*mdbv1.AtlasProject{
+		globalSecretRef:                     withGlobalCredentials,
+		kube.ObjectKey("ns", "credentials"): withOwnCredentials,
+		kube.ObjectKey("ns", "other"):       nil,
+	} {
+		t.Run(secretRef.String(), func(t *testing.T) {
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: secretRef.Namespace, Name: secretRef.Name}}
+			r.credentialsHandler().Generic(event.GenericEvent{Object: secret}, queue)
+
+			if expected == nil {
+				assert.Equal(t, 0, queue.Len())
+				return
+			}
+			assert.Equal(t, 1, queue.Len())
+			item, _ := queue.Get()
+			assert.Equal(t, reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(expected)}, item)
+		})
+	}
+}
+
+func TestAtlasConnectionHandlers(t *testing.T) {
+	withConnection := &mdbv1.AtlasProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "gov", Namespace: "ns"},
+		Spec:       mdbv1.AtlasProjectSpec{AtlasConnectionRef: &common.ResourceRef{Name: "gov"}},
+	}
+	withGlobalCredentials := &mdbv1.AtlasProject{ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: "ns"}}
+	gov := &mdbv1.AtlasConnection{
+		ObjectMeta: metav1.ObjectMeta{Name: "gov"},
+		Spec: mdbv1.AtlasConnectionSpec{
+			OrgID:                "org",
+			CredentialsSecretRef: common.ResourceRefNamespaced{Name: "gov-credentials", Namespace: "operator"},
+			AllowedNamespaces:    []string{"ns"},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, mdbv1.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(withConnection, withGlobalCredentials, gov).Build()
+	r := &AtlasProjectReconciler{
+		Client:          kubeClient,
+		Log:             zaptest.NewLogger(t).Sugar(),
+		GlobalAPISecret: kube.ObjectKey("operator", "global"),
+		AtlasProvider:   atlas.NewProductionProvider("", kube.ObjectKey("operator", "global"), kubeClient, atlas.WithAtlasConnections(true)),
+	}
+
+	enqueued := func(h handler.EventHandler, obj client.Object) []interface{} {
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+
+		h.Generic(event.GenericEvent{Object: obj}, queue)
+		var items []interface{}
+		for queue.Len() > 0 {
+			item, _ := queue.Get()
+			items = append(items, item)
+		}
+		return items
+	}
+	expected := []interface{}{reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(withConnection)}}
+
+	assert.Equal(t, expected, enqueued(r.atlasConnectionHandler(), gov))
+
+	govCredentials := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "operator", Name: "gov-credentials"}}
+	assert.Equal(t, expected, enqueued(r.credentialsHandler(), govCredentials))
+
+	globalCredentials := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "operator", Name: "global"}}
+	assert.Equal(t, []interface{}{reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(withGlobalCredentials)}}, enqueued(r.credentialsHandler(), globalCredentials))
+}
+
+func TestEnsureProjectExistsPlanned(t *testing.T) {
+	projects := &atlas_mock.ProjectsClientMock{
+		GetOneProjectFunc: func(projectID string) (*mongodbatlas.Project, *mongodbatlas.Response, error) {
+			request := &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/api/atlas/v1.0/groups/byName/test-project"}}
+			return nil, nil, &mongodbatlas.ErrorResponse{Response: &http.Response{Request: request, StatusCode: http.StatusNotFound}, ErrorCode: atlas.NotInGroup}
+		},
+		CreateFunc: func(project *mongodbatlas.Project) (*mongodbatlas.Project, *mongodbatlas.Response, error) {
+			// the dry-run client echoes the requested project, which doesn't have an ID
+			return project, nil, nil
+		},
+	}
+	workflowCtx := &workflow.Context{
+		Client:  mongodbatlas.Client{Projects: projects},
+		Log:     zaptest.NewLogger(t).Sugar(),
+		Context: context.Background(),
+	}
+	r := &AtlasProjectReconciler{}
+
+	projectID, result := r.ensureProjectExists(workflowCtx, mdbv1.DefaultProject("ns", "credentials"), dryrun.NewPlan())
+	assert.True(t, result.IsOk())
+	assert.Empty(t, projectID)
+
+	_, result = r.ensureProjectExists(workflowCtx, mdbv1.DefaultProject("ns", "credentials"), nil)
+	assert.False(t, result.IsOk())
+}
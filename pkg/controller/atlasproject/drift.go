@@ -0,0 +1,90 @@
+package atlasproject
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/compat"
+)
+
+// checkDrift reports the differences between the project in Atlas and its spec: the IP Access List entries which
+// aren't claimed by a standalone resource, the maintenance window, the auditing and the project settings. The settings
+// left empty in the spec keep the values set by Atlas and are not reported.
+func (r *AtlasProjectReconciler) checkDrift(workflowCtx *workflow.Context, project *mdbv1.AtlasProject, enabled bool) workflow.Result {
+	if !enabled {
+		workflowCtx.UnsetCondition(status.DriftDetectedType)
+		return workflow.OK()
+	}
+
+	fields, err := r.projectDiff(workflowCtx, project)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	return drift.Report(workflowCtx, r.EventRecorder, project, drift.PolicyFor(project, r.DriftPolicy), fields)
+}
+
+// projectDiff returns the fields of the project spec that differ from Atlas
+func (r *AtlasProjectReconciler) projectDiff(workflowCtx *workflow.Context, project *mdbv1.AtlasProject) ([]string, error) {
+	var fields []string
+
+	owners, err := ipAccessListOwners(workflowCtx.Context, r.Client, project)
+	if err != nil {
+		return nil, err
+	}
+	claimed, _ := claimedEntries(owners, ownerName("AtlasProject", project), ipAccessListKey)
+	list, _, err := workflowCtx.Client.ProjectIPAccessList.List(workflowCtx.Context, project.ID(), &mongodbatlas.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve IP Access list: %w", err)
+	}
+	desiredList, _ := filterActiveIPAccessLists(project.Spec.ProjectIPAccessList)
+	if cmp.Diff(unclaimedIPAccessList(mapToOperatorSpec(list.Results), claimed), desiredList, cmpopts.EquateEmpty()) != "" {
+		fields = append(fields, "projectIpAccessList")
+	}
+
+	if !isEmptyWindow(project.Spec.MaintenanceWindow) {
+		window, _, err := workflowCtx.Client.MaintenanceWindows.Get(workflowCtx.Context, project.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve the maintenance window: %w", err)
+		}
+		if isAtlasMaintenanceWindowEmpty(window) || !isMaintenanceWindowConfigEqual(project.Spec.MaintenanceWindow, *window) {
+			fields = append(fields, "maintenanceWindow")
+		}
+	}
+
+	if project.Spec.Auditing != nil {
+		auditing, err := fetchAuditing(workflowCtx, project.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve the auditing: %w", err)
+		}
+		if !auditingInSync(auditing, project.Spec.Auditing) {
+			fields = append(fields, "auditing")
+		}
+	}
+
+	if project.Spec.Settings != nil {
+		settings, err := fetchSettings(workflowCtx, project.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve the project settings: %w", err)
+		}
+		mergedSettings := mdbv1.ProjectSettings{}
+		if err = compat.JSONCopy(&mergedSettings, settings); err != nil {
+			return nil, err
+		}
+		if err = compat.JSONCopy(&mergedSettings, project.Spec.Settings); err != nil {
+			return nil, err
+		}
+		for _, field := range drift.Fields(*settings, mergedSettings) {
+			fields = append(fields, "settings."+field)
+		}
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,75 @@
+package atlasproject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap/zaptest"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	atlas_mock "github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/mocks/atlas"
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/drift"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+func TestCheckDrift(t *testing.T) {
+	akoProject := mdbv1.DefaultProject("ns", "credentials")
+	akoProject.Status.ID = "project-id"
+	akoProject.Spec.ProjectIPAccessList = []project.IPAccessList{{CIDRBlock: "10.0.0.0/24"}}
+	akoProject.Spec.MaintenanceWindow = project.MaintenanceWindow{DayOfWeek: 1, HourOfDay: 2}
+	akoProject.Spec.Settings = &mdbv1.ProjectSettings{IsDataExplorerEnabled: toptr.MakePtr(true)}
+
+	atlasClient := mongodbatlas.Client{
+		ProjectIPAccessList: &atlas_mock.ProjectIPAccessListClientMock{
+			ListFunc: func(projectID string) (*mongodbatlas.ProjectIPAccessLists, *mongodbatlas.Response, error) {
+				return &mongodbatlas.ProjectIPAccessLists{Results: []mongodbatlas.ProjectIPAccessList{{CIDRBlock: "10.0.0.0/24"}, {CIDRBlock: "10.1.0.0/24"}}}, nil, nil
+			},
+		},
+		MaintenanceWindows: &atlas_mock.MaintenanceWindowClientMock{
+			GetFunc: func(projectID string) (*mongodbatlas.MaintenanceWindow, *mongodbatlas.Response, error) {
+				return &mongodbatlas.MaintenanceWindow{DayOfWeek: 1, HourOfDay: toptr.MakePtr(2)}, nil, nil
+			},
+		},
+		Projects: &atlas_mock.ProjectsClientMock{
+			GetProjectSettingsFunc: func(projectID string) (*mongodbatlas.ProjectSettings, *mongodbatlas.Response, error) {
+				return &mongodbatlas.ProjectSettings{IsDataExplorerEnabled: toptr.MakePtr(false), IsSchemaAdvisorEnabled: toptr.MakePtr(false)}, nil, nil
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, mdbv1.AddToScheme(scheme))
+	r := &AtlasProjectReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(scheme).Build(),
+		EventRecorder: record.NewFakeRecorder(10),
+		DriftPolicy:   drift.PolicyReport,
+	}
+
+	t.Run("the drift isn't checked when it's disabled", func(t *testing.T) {
+		workflowCtx := &workflow.Context{Client: atlasClient, Log: zaptest.NewLogger(t).Sugar(), Context: context.Background()}
+
+		assert.True(t, r.checkDrift(workflowCtx, akoProject, false).IsOk())
+		assert.Empty(t, workflowCtx.Conditions())
+	})
+
+	t.Run("the fields differing from the spec are reported", func(t *testing.T) {
+		workflowCtx := &workflow.Context{Client: atlasClient, Log: zaptest.NewLogger(t).Sugar(), Context: context.Background()}
+
+		result := r.checkDrift(workflowCtx, akoProject, true)
+		assert.False(t, result.IsOk())
+		assert.Equal(t, workflow.AtlasDriftDetected, result.GetReason())
+
+		condition, found := workflowCtx.GetCondition(status.DriftDetectedType)
+		require.True(t, found)
+		assert.Equal(t, "Atlas differs from the spec: projectIpAccessList, settings.isDataExplorerEnabled", condition.Message)
+	})
+}
@@ -29,9 +29,9 @@ const ipAccessStatusFailed = "FAILED"
 
 // ensureIPAccessList ensures that the state of the Atlas IP Access List matches the
 // state of the IP Access list specified in the project CR. Any Access Lists which exist
-// in Atlas but are not specified in the CR are deleted.
-func ensureIPAccessList(service *workflow.Context, statusFunc atlas.IPAccessListStatus, akoProject *mdbv1.AtlasProject, subobjectProtect bool) workflow.Result {
-	canReconcile, err := canIPAccessListReconcile(service.Context, service.Client, subobjectProtect, akoProject)
+// in Atlas but are not specified in the CR nor claimed by an AtlasIPAccessList are deleted.
+func ensureIPAccessList(service *workflow.Context, statusFunc atlas.IPAccessListStatus, akoProject *mdbv1.AtlasProject, subobjectProtect bool, claimed []project.IPAccessList) workflow.Result {
+	canReconcile, err := canIPAccessListReconcile(service.Context, service.Client, subobjectProtect, akoProject, claimed)
 	if err != nil {
 		result := workflow.Terminate(workflow.Internal, fmt.Sprintf("unable to resolve ownership for deletion protection: %s", err))
 		service.SetConditionFromResult(status.IPAccessListReadyType, result)
@@ -52,55 +52,52 @@ func ensureIPAccessList(service *workflow.Context, statusFunc atlas.IPAccessList
 	desiredList, expiredList := filterActiveIPAccessLists(akoProject.Spec.ProjectIPAccessList)
 	service.EnsureStatusOption(status.AtlasProjectExpiredIPAccessOption(expiredList))
 
-	list, _, err := service.Client.ProjectIPAccessList.List(service.Context, akoProject.ID(), &mongodbatlas.ListOptions{})
-	if err != nil {
-		result := workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to retrieve IP Access list: %s", err))
+	if result := syncIPAccessListWithAtlas(service, statusFunc, akoProject.ID(), desiredList, claimed); !result.IsOk() {
 		service.SetConditionFromResult(status.IPAccessListReadyType, result)
 
 		return result
 	}
 
-	currentList := mapToOperatorSpec(list.Results)
-	if cmp.Diff(currentList, akoProject.Spec.ProjectIPAccessList, cmpopts.EquateEmpty()) != "" {
-		err = syncIPAccessList(service, akoProject.ID(), currentList, desiredList)
-		if err != nil {
-			result := workflow.Terminate(workflow.ProjectIPNotCreatedInAtlas, fmt.Sprintf("failed to sync desired state with Atlas: %s", err))
-			service.SetConditionFromResult(status.IPAccessListReadyType, result)
+	service.SetConditionTrue(status.IPAccessListReadyType)
+
+	if len(akoProject.Spec.ProjectIPAccessList) == 0 {
+		service.UnsetCondition(status.IPAccessListReadyType)
+	}
+
+	return workflow.OK()
+}
 
-			return result
+// syncIPAccessListWithAtlas makes the Atlas IP Access List entries which aren't claimed by another resource match the
+// desired ones and waits for the desired entries to be active.
+func syncIPAccessListWithAtlas(service *workflow.Context, statusFunc atlas.IPAccessListStatus, projectID string, desiredList, claimed []project.IPAccessList) workflow.Result {
+	list, _, err := service.Client.ProjectIPAccessList.List(service.Context, projectID, &mongodbatlas.ListOptions{})
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, fmt.Sprintf("failed to retrieve IP Access list: %s", err))
+	}
+
+	currentList := unclaimedIPAccessList(mapToOperatorSpec(list.Results), claimed)
+	if cmp.Diff(currentList, desiredList, cmpopts.EquateEmpty()) != "" {
+		err = syncIPAccessList(service, projectID, currentList, desiredList)
+		if err != nil {
+			return workflow.Terminate(workflow.ProjectIPNotCreatedInAtlas, fmt.Sprintf("failed to sync desired state with Atlas: %s", err))
 		}
 	}
 
 	for _, ipAccessList := range desiredList {
-		ipAccessStatus, err := statusFunc(service.Context, akoProject.ID(), mapToEntryValue(ipAccessList, true))
+		ipAccessStatus, err := statusFunc(service.Context, projectID, mapToEntryValue(ipAccessList, true))
 		if err != nil {
-			result := workflow.Terminate(workflow.ProjectIPNotCreatedInAtlas, fmt.Sprintf("failed to check status in Atlas: %s", err))
-			service.SetConditionFromResult(status.IPAccessListReadyType, result)
-
-			return result
+			return workflow.Terminate(workflow.ProjectIPNotCreatedInAtlas, fmt.Sprintf("failed to check status in Atlas: %s", err))
 		}
 
 		if ipAccessStatus == ipAccessStatusFailed {
-			result := workflow.Terminate(workflow.ProjectIPNotCreatedInAtlas, fmt.Sprintf("configuration of %s failed in Atlas", mapToEntryValue(ipAccessList, false)))
-			service.SetConditionFromResult(status.IPAccessListReadyType, result)
-
-			return result
+			return workflow.Terminate(workflow.ProjectIPNotCreatedInAtlas, fmt.Sprintf("configuration of %s failed in Atlas", mapToEntryValue(ipAccessList, false)))
 		}
 
 		if ipAccessStatus == ipAccessStatusPending {
-			result := workflow.InProgress(workflow.ProjectIPAccessListNotActive, fmt.Sprintf("waiting Atlas to configure entry %s", mapToEntryValue(ipAccessList, false)))
-			service.SetConditionFromResult(status.IPAccessListReadyType, result)
-
-			return result
+			return workflow.InProgress(workflow.ProjectIPAccessListNotActive, fmt.Sprintf("waiting Atlas to configure entry %s", mapToEntryValue(ipAccessList, false)))
 		}
 	}
 
-	service.SetConditionTrue(status.IPAccessListReadyType)
-
-	if len(akoProject.Spec.ProjectIPAccessList) == 0 {
-		service.UnsetCondition(status.IPAccessListReadyType)
-	}
-
 	return workflow.OK()
 }
 
@@ -224,7 +221,7 @@ func filterActiveIPAccessLists(accessLists []project.IPAccessList) ([]project.IP
 	return active, expired
 }
 
-func canIPAccessListReconcile(ctx context.Context, atlasClient mongodbatlas.Client, protected bool, akoProject *mdbv1.AtlasProject) (bool, error) {
+func canIPAccessListReconcile(ctx context.Context, atlasClient mongodbatlas.Client, protected bool, akoProject *mdbv1.AtlasProject, claimed []project.IPAccessList) (bool, error) {
 	if !protected {
 		return true, nil
 	}
@@ -242,11 +239,11 @@ func canIPAccessListReconcile(ctx context.Context, atlasClient mongodbatlas.Clie
 		return false, err
 	}
 
-	if list.TotalCount == 0 {
+	atlasAccessLists := unclaimedIPAccessList(mapToOperatorSpec(list.Results), claimed)
+	if len(atlasAccessLists) == 0 {
 		return true, nil
 	}
 
-	atlasAccessLists := mapToOperatorSpec(list.Results)
 	if cmp.Equal(atlasAccessLists, latestConfig.ProjectIPAccessList, cmpopts.EquateEmpty()) {
 		return true, nil
 	}
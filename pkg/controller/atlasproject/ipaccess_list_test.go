@@ -41,7 +41,7 @@ func TestFilterActiveIPAccessLists(t *testing.T) {
 
 func TestCanIPAccessListReconcile(t *testing.T) {
 	t.Run("should return true when subResourceDeletionProtection is disabled", func(t *testing.T) {
-		result, err := canIPAccessListReconcile(context.TODO(), mongodbatlas.Client{}, false, &mdbv1.AtlasProject{})
+		result, err := canIPAccessListReconcile(context.TODO(), mongodbatlas.Client{}, false, &mdbv1.AtlasProject{}, nil)
 		require.NoError(t, err)
 		require.True(t, result)
 	})
@@ -49,7 +49,7 @@ func TestCanIPAccessListReconcile(t *testing.T) {
 	t.Run("should return error when unable to deserialize last applied configuration", func(t *testing.T) {
 		akoProject := &mdbv1.AtlasProject{}
 		akoProject.WithAnnotations(map[string]string{customresource.AnnotationLastAppliedConfiguration: "{wrong}"})
-		result, err := canIPAccessListReconcile(context.TODO(), mongodbatlas.Client{}, true, akoProject)
+		result, err := canIPAccessListReconcile(context.TODO(), mongodbatlas.Client{}, true, akoProject, nil)
 		require.EqualError(t, err, "invalid character 'w' looking for beginning of object key string")
 		require.False(t, result)
 	})
@@ -64,7 +64,7 @@ func TestCanIPAccessListReconcile(t *testing.T) {
 		}
 		akoProject := &mdbv1.AtlasProject{}
 		akoProject.WithAnnotations(map[string]string{customresource.AnnotationLastAppliedConfiguration: "{}"})
-		result, err := canIPAccessListReconcile(context.TODO(), atlasClient, true, akoProject)
+		result, err := canIPAccessListReconcile(context.TODO(), atlasClient, true, akoProject, nil)
 
 		require.EqualError(t, err, "failed to retrieve data")
 		require.False(t, result)
@@ -80,7 +80,7 @@ func TestCanIPAccessListReconcile(t *testing.T) {
 		}
 		akoProject := &mdbv1.AtlasProject{}
 		akoProject.WithAnnotations(map[string]string{customresource.AnnotationLastAppliedConfiguration: "{}"})
-		result, err := canIPAccessListReconcile(context.TODO(), atlasClient, true, akoProject)
+		result, err := canIPAccessListReconcile(context.TODO(), atlasClient, true, akoProject, nil)
 
 		require.NoError(t, err)
 		require.True(t, result)
@@ -115,7 +115,7 @@ func TestCanIPAccessListReconcile(t *testing.T) {
 			},
 		}
 		akoProject.WithAnnotations(map[string]string{customresource.AnnotationLastAppliedConfiguration: "{\"projectIpAccessList\":[{\"cidrBlock\":\"192.168.0.0/24\"}]}"})
-		result, err := canIPAccessListReconcile(context.TODO(), atlasClient, true, akoProject)
+		result, err := canIPAccessListReconcile(context.TODO(), atlasClient, true, akoProject, nil)
 
 		require.NoError(t, err)
 		require.True(t, result)
@@ -154,7 +154,7 @@ func TestCanIPAccessListReconcile(t *testing.T) {
 			},
 		}
 		akoProject.WithAnnotations(map[string]string{customresource.AnnotationLastAppliedConfiguration: "{\"projectIpAccessList\":[{\"cidrBlock\":\"192.168.0.0/24\"}]}"})
-		result, err := canIPAccessListReconcile(context.TODO(), atlasClient, true, akoProject)
+		result, err := canIPAccessListReconcile(context.TODO(), atlasClient, true, akoProject, nil)
 
 		require.NoError(t, err)
 		require.True(t, result)
@@ -193,7 +193,7 @@ func TestCanIPAccessListReconcile(t *testing.T) {
 			},
 		}
 		akoProject.WithAnnotations(map[string]string{customresource.AnnotationLastAppliedConfiguration: "{\"projectIpAccessList\":[{\"cidrBlock\":\"192.168.0.0/24\"}]}"})
-		result, err := canIPAccessListReconcile(context.TODO(), atlasClient, true, akoProject)
+		result, err := canIPAccessListReconcile(context.TODO(), atlasClient, true, akoProject, nil)
 
 		require.NoError(t, err)
 		require.False(t, result)
@@ -215,7 +215,7 @@ func TestEnsureIPAccessList(t *testing.T) {
 			Client:  atlasClient,
 			Context: context.TODO(),
 		}
-		result := ensureIPAccessList(workflowCtx, atlas.CustomIPAccessListStatus(&atlasClient), akoProject, true)
+		result := ensureIPAccessList(workflowCtx, atlas.CustomIPAccessListStatus(&atlasClient), akoProject, true, nil)
 
 		require.Equal(t, workflow.Terminate(workflow.Internal, "unable to resolve ownership for deletion protection: failed to retrieve data"), result)
 	})
@@ -257,7 +257,7 @@ func TestEnsureIPAccessList(t *testing.T) {
 			Client:  atlasClient,
 			Context: context.TODO(),
 		}
-		result := ensureIPAccessList(workflowCtx, atlas.CustomIPAccessListStatus(&atlasClient), akoProject, true)
+		result := ensureIPAccessList(workflowCtx, atlas.CustomIPAccessListStatus(&atlasClient), akoProject, true, nil)
 
 		require.Equal(
 			t,
@@ -322,6 +322,7 @@ func TestEnsureIPAccessList(t *testing.T) {
 			},
 			akoProject,
 			false,
+			nil,
 		)
 
 		assert.Equal(t, workflow.OK(), result)
@@ -0,0 +1,109 @@
+package atlasproject
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/concurrency"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+// AtlasIPAccessListReconciler reconciles an AtlasIPAccessList object. It manages the IP Access List entries of the
+// resource, leaving the ones of the AtlasProject and of the other AtlasIPAccessLists untouched.
+type AtlasIPAccessListReconciler struct {
+	Client                   client.Client
+	Log                      *zap.SugaredLogger
+	Scheme                   *runtime.Scheme
+	GlobalPredicates         []predicate.Predicate
+	EventRecorder            record.EventRecorder
+	AtlasProvider            atlas.Provider
+	ObjectDeletionProtection bool
+	DryRun                   bool
+	MaxConcurrentReconciles  int
+	ProjectLocks             *concurrency.ProjectLocks
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasipaccesslists,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasipaccesslists/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasipaccesslists,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasipaccesslists/status,verbs=get;update;patch
+
+func (r *AtlasIPAccessListReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasipaccesslist", req.NamespacedName)
+
+	ipAccessList := &mdbv1.AtlasIPAccessList{}
+	reconciliation := &subResourceReconciliation{
+		kind:                     "AtlasIPAccessList",
+		resource:                 ipAccessList,
+		conditionType:            status.IPAccessListReadyType,
+		k8sClient:                r.Client,
+		eventRecorder:            r.EventRecorder,
+		atlasProvider:            r.AtlasProvider,
+		objectDeletionProtection: r.ObjectDeletionProtection,
+		dryRun:                   r.DryRun,
+		projectLocks:             r.ProjectLocks,
+		plannedChanges: func(changes []status.PlannedChange) status.Option {
+			return status.AtlasIPAccessListPlannedChangesOption(changes)
+		},
+		validate: func(_ *mdbv1.AtlasProject, _ *atlas.Connection) error {
+			return validate.IPAccessList(ipAccessList)
+		},
+		conflicts: func(ctx context.Context, akoProject *mdbv1.AtlasProject) ([]string, error) {
+			_, conflicts, err := r.claimedEntries(ctx, ipAccessList, akoProject)
+			return conflicts, err
+		},
+		sync: func(workflowCtx *workflow.Context, akoProject *mdbv1.AtlasProject, deleting bool) workflow.Result {
+			claimed, _, err := r.claimedEntries(workflowCtx.Context, ipAccessList, akoProject)
+			if err != nil {
+				return workflow.Terminate(workflow.Internal, err.Error())
+			}
+
+			var desiredList, expiredList []project.IPAccessList
+			if !deleting {
+				desiredList, expiredList = filterActiveIPAccessLists(ipAccessList.Spec.Entries)
+			}
+			workflowCtx.EnsureStatusOption(status.AtlasIPAccessListExpiredOption(expiredList))
+
+			return syncIPAccessListWithAtlas(workflowCtx, atlas.CustomIPAccessListStatus(&workflowCtx.Client), akoProject.ID(), desiredList, claimed)
+		},
+	}
+
+	return reconciliation.reconcile(ctx, req, log).ReconcileResult(), nil
+}
+
+// claimedEntries returns the IP Access List entries managed by the other resources and the conflicts of the entries
+// of the AtlasIPAccessList
+func (r *AtlasIPAccessListReconciler) claimedEntries(ctx context.Context, ipAccessList *mdbv1.AtlasIPAccessList, akoProject *mdbv1.AtlasProject) ([]project.IPAccessList, []string, error) {
+	owners, err := ipAccessListOwners(ctx, r.Client, akoProject)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claimed, conflicts := claimedEntries(owners, ownerName("AtlasIPAccessList", ipAccessList), ipAccessListKey)
+	return claimed, conflicts, nil
+}
+
+func (r *AtlasIPAccessListReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("AtlasIPAccessList").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		For(&mdbv1.AtlasIPAccessList{}, builder.WithPredicates(r.GlobalPredicates...)).
+		Watches(&source.Kind{Type: &mdbv1.AtlasProject{}}, projectSubResourceHandler(func(ctx context.Context) ([]*mdbv1.AtlasIPAccessList, error) {
+			return listIPAccessLists(ctx, r.Client)
+		}), builder.WithPredicates(projectSubResourcePredicate())).
+		Complete(r)
+}
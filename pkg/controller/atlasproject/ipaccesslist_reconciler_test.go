@@ -0,0 +1,124 @@
+package atlasproject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/controllertest"
+	atlas_mock "github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/mocks/atlas"
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+func TestAtlasIPAccessListReconcile(t *testing.T) {
+	t.Run("the project must be created in Atlas", func(t *testing.T) {
+		akoProject := controllertest.Project()
+		akoProject.Status.ID = ""
+		ipAccessList := testIPAccessList("my-list", project.IPAccessList{IPAddress: "192.0.2.1"})
+		r := testIPAccessListReconciler(t, mongodbatlas.Client{}, akoProject, ipAccessList)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(ipAccessList)})
+		require.NoError(t, err)
+
+		endIPAccessList := controllertest.Read(t, r.Client, ipAccessList)
+		controllertest.AssertCondition(t, endIPAccessList, status.IPAccessListReadyType, corev1.ConditionFalse, string(workflow.ProjectNotCreatedInAtlas))
+		assert.Empty(t, endIPAccessList.Finalizers)
+	})
+
+	t.Run("the entries managed by the project are reported as conflicts", func(t *testing.T) {
+		akoProject := controllertest.Project()
+		akoProject.Spec.ProjectIPAccessList = []project.IPAccessList{{CIDRBlock: "10.0.0.0/24"}}
+		ipAccessList := testIPAccessList("my-list", project.IPAccessList{CIDRBlock: "10.0.0.0/24"})
+		r := testIPAccessListReconciler(t, mongodbatlas.Client{}, akoProject, ipAccessList)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(ipAccessList)})
+		require.NoError(t, err)
+
+		endIPAccessList := controllertest.Read(t, r.Client, ipAccessList)
+		controllertest.AssertCondition(t, endIPAccessList, status.IPAccessListReadyType, corev1.ConditionFalse, string(workflow.ProjectSubResourceConflict))
+		assert.Empty(t, endIPAccessList.Finalizers)
+	})
+
+	t.Run("the deletion only removes the entries of the resource", func(t *testing.T) {
+		akoProject := controllertest.Project()
+		akoProject.Spec.ProjectIPAccessList = []project.IPAccessList{{CIDRBlock: "10.0.0.0/24"}}
+		ipAccessList := testIPAccessList("my-list", project.IPAccessList{IPAddress: "192.0.2.1"})
+		ipAccessList.Finalizers = []string{customresource.FinalizerLabel}
+		ipAccessList.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		otherIPAccessList := testIPAccessList("other-list", project.IPAccessList{IPAddress: "192.0.2.2"})
+		ipAccessListAPI := &atlas_mock.ProjectIPAccessListClientMock{
+			ListFunc: func(projectID string) (*mongodbatlas.ProjectIPAccessLists, *mongodbatlas.Response, error) {
+				return &mongodbatlas.ProjectIPAccessLists{
+					Results: []mongodbatlas.ProjectIPAccessList{
+						{GroupID: projectID, CIDRBlock: "10.0.0.0/24"},
+						{GroupID: projectID, IPAddress: "192.0.2.1"},
+						{GroupID: projectID, IPAddress: "192.0.2.2"},
+					},
+					TotalCount: 3,
+				}, nil, nil
+			},
+			DeleteFunc: func(projectID, entry string) (*mongodbatlas.Response, error) {
+				return nil, nil
+			},
+		}
+		r := testIPAccessListReconciler(t, mongodbatlas.Client{ProjectIPAccessList: ipAccessListAPI}, akoProject, ipAccessList, otherIPAccessList)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(ipAccessList)})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]struct{}{"my-project-id.192.0.2.1": {}}, ipAccessListAPI.DeleteRequests)
+		err = r.Client.Get(context.Background(), kube.ObjectKeyFromObject(ipAccessList), &mdbv1.AtlasIPAccessList{})
+		assert.True(t, apiErrors.IsNotFound(err), "the resource should be deleted once the finalizer is removed")
+	})
+
+	t.Run("the entries are kept in Atlas when the resource is protected", func(t *testing.T) {
+		ipAccessList := testIPAccessList("my-list", project.IPAccessList{IPAddress: "192.0.2.1"})
+		ipAccessList.Finalizers = []string{customresource.FinalizerLabel}
+		ipAccessList.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		r := testIPAccessListReconciler(t, mongodbatlas.Client{}, controllertest.Project(), ipAccessList)
+		r.ObjectDeletionProtection = true
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(ipAccessList)})
+		require.NoError(t, err)
+
+		err = r.Client.Get(context.Background(), kube.ObjectKeyFromObject(ipAccessList), &mdbv1.AtlasIPAccessList{})
+		assert.True(t, apiErrors.IsNotFound(err), "the resource should be deleted once the finalizer is removed")
+	})
+}
+
+func testIPAccessListReconciler(t *testing.T, atlasClient mongodbatlas.Client, objects ...client.Object) *AtlasIPAccessListReconciler {
+	t.Helper()
+
+	return &AtlasIPAccessListReconciler{
+		Client:        controllertest.NewKubeClient(objects...),
+		Log:           zaptest.NewLogger(t).Sugar(),
+		EventRecorder: record.NewFakeRecorder(10),
+		AtlasProvider: controllertest.NewProvider(atlasClient),
+	}
+}
+
+func testIPAccessList(name string, entries ...project.IPAccessList) *mdbv1.AtlasIPAccessList {
+	return &mdbv1.AtlasIPAccessList{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: controllertest.Namespace},
+		Spec: mdbv1.AtlasIPAccessListSpec{
+			Project: common.ResourceRefNamespaced{Name: "my-project"},
+			Entries: entries,
+		},
+	}
+}
@@ -32,8 +32,15 @@ type networkPeerDiff struct {
 	PeersToUpdate []mongodbatlas.Peer
 }
 
-func ensureNetworkPeers(workflowCtx *workflow.Context, akoProject *mdbv1.AtlasProject, subobjectProtect bool) workflow.Result {
-	canReconcile, err := canNetworkPeeringReconcile(workflowCtx, subobjectProtect, akoProject)
+// networkPeersStatusOption builds the status option reporting the network peers of the resource being reconciled
+type networkPeersStatusOption func(peerStatuses *[]status.AtlasNetworkPeer) status.Option
+
+func projectNetworkPeersOption(peerStatuses *[]status.AtlasNetworkPeer) status.Option {
+	return status.AtlasProjectSetNetworkPeerOption(peerStatuses)
+}
+
+func ensureNetworkPeers(workflowCtx *workflow.Context, akoProject *mdbv1.AtlasProject, subobjectProtect bool, claimed []mdbv1.NetworkPeer) workflow.Result {
+	canReconcile, err := canNetworkPeeringReconcile(workflowCtx, subobjectProtect, akoProject, claimed)
 	if err != nil {
 		result := workflow.Terminate(workflow.Internal, fmt.Sprintf("unable to resolve ownership for deletion protection: %s", err))
 		workflowCtx.SetConditionFromResult(status.NetworkPeerReadyType, result)
@@ -54,7 +61,7 @@ func ensureNetworkPeers(workflowCtx *workflow.Context, akoProject *mdbv1.AtlasPr
 	networkPeerStatus := akoProject.Status.DeepCopy().NetworkPeers
 	networkPeerSpec := akoProject.Spec.DeepCopy().NetworkPeers
 
-	result, condition := SyncNetworkPeer(workflowCtx, akoProject.ID(), networkPeerStatus, networkPeerSpec)
+	result, condition := SyncNetworkPeer(workflowCtx, akoProject.ID(), networkPeerStatus, networkPeerSpec, claimed, projectNetworkPeersOption)
 	if !result.IsOk() {
 		workflowCtx.SetConditionFromResult(condition, result)
 		return result
@@ -85,8 +92,9 @@ func failedPeerStatus(errMessage string, peer mdbv1.NetworkPeer) status.AtlasNet
 	}
 }
 
-func SyncNetworkPeer(workflowCtx *workflow.Context, groupID string, peerStatuses []status.AtlasNetworkPeer, peerSpecs []mdbv1.NetworkPeer) (workflow.Result, status.ConditionType) {
-	defer workflowCtx.EnsureStatusOption(status.AtlasProjectSetNetworkPeerOption(&peerStatuses))
+// SyncNetworkPeer makes the Atlas network peers which aren't claimed by another resource match the peer specs
+func SyncNetworkPeer(workflowCtx *workflow.Context, groupID string, peerStatuses []status.AtlasNetworkPeer, peerSpecs, claimed []mdbv1.NetworkPeer, statusOption networkPeersStatusOption) (workflow.Result, status.ConditionType) {
+	defer workflowCtx.EnsureStatusOption(statusOption(&peerStatuses))
 	logger := workflowCtx.Log
 	mongoClient := workflowCtx.Client
 	logger.Debugf("syncing network peers for project %v", groupID)
@@ -96,6 +104,7 @@ func SyncNetworkPeer(workflowCtx *workflow.Context, groupID string, peerStatuses
 		return workflow.Terminate(workflow.ProjectNetworkPeerIsNotReadyInAtlas, "failed to get all network peers"),
 			status.NetworkPeerReadyType
 	}
+	list, claimedContainerIDs := unclaimedNetworkPeers(list, claimed)
 
 	diff, err := sortPeers(list, peerSpecs, logger, mongoClient.Containers, groupID)
 	if err != nil {
@@ -122,7 +131,7 @@ func SyncNetworkPeer(workflowCtx *workflow.Context, groupID string, peerStatuses
 		return workflow.Terminate(workflow.ProjectNetworkPeerIsNotReadyInAtlas,
 			"failed to update network peer statuses"), status.NetworkPeerReadyType
 	}
-	err = deleteUnusedContainers(workflowCtx.Context, mongoClient.Containers, groupID, getPeerIDs(peerStatuses))
+	err = deleteUnusedContainers(workflowCtx.Context, mongoClient.Containers, groupID, append(getPeerIDs(peerStatuses), claimedContainerIDs...))
 	if err != nil {
 		logger.Errorf("failed to delete unused containers: %v", err)
 		return workflow.Terminate(workflow.ProjectNetworkPeerIsNotReadyInAtlas,
@@ -604,7 +613,7 @@ func deleteAllNetworkPeers(ctx context.Context, groupID string, service mongodba
 	return nil
 }
 
-func canNetworkPeeringReconcile(workflowCtx *workflow.Context, protected bool, akoProject *mdbv1.AtlasProject) (bool, error) {
+func canNetworkPeeringReconcile(workflowCtx *workflow.Context, protected bool, akoProject *mdbv1.AtlasProject, claimed []mdbv1.NetworkPeer) (bool, error) {
 	if !protected {
 		return true, nil
 	}
@@ -621,6 +630,7 @@ func canNetworkPeeringReconcile(workflowCtx *workflow.Context, protected bool, a
 	if err != nil {
 		return false, err
 	}
+	containers = unclaimedContainers(containers, claimed)
 
 	if len(containers) > 0 && !areContainersEqual(latestConfig.NetworkPeers, containers) && !areContainersEqual(akoProject.Spec.NetworkPeers, containers) {
 		return false, nil
@@ -630,6 +640,7 @@ func canNetworkPeeringReconcile(workflowCtx *workflow.Context, protected bool, a
 	if err != nil {
 		return false, err
 	}
+	peers, _ = unclaimedNetworkPeers(peers, claimed)
 
 	if len(peers) == 0 {
 		return true, nil
@@ -649,30 +660,61 @@ func areContainersEqual(operatorContainers []mdbv1.NetworkPeer, atlasContainers
 
 	atlasContainersIDs := map[string]struct{}{}
 	for _, container := range atlasContainers {
-		switch container.ProviderName {
-		case string(provider.ProviderAWS):
-			atlasContainersIDs[fmt.Sprintf("%s.%s.%s", container.ProviderName, container.RegionName, container.AtlasCIDRBlock)] = struct{}{}
-		case string(provider.ProviderGCP):
-			atlasContainersIDs[fmt.Sprintf("%s.%s", container.ProviderName, container.AtlasCIDRBlock)] = struct{}{}
-		case string(provider.ProviderAzure):
-			atlasContainersIDs[fmt.Sprintf("%s.%s.%s", container.ProviderName, container.Region, container.AtlasCIDRBlock)] = struct{}{}
+		if key := atlasContainerKey(container); key != "" {
+			atlasContainersIDs[key] = struct{}{}
 		}
 	}
 
 	for _, container := range operatorContainers {
-		switch container.ProviderName {
-		case provider.ProviderAWS:
-			delete(atlasContainersIDs, fmt.Sprintf("%s.%s.%s", container.ProviderName, containerRegionNameMatcher(container.GetContainerRegion(), container.ProviderName), container.AtlasCIDRBlock))
-		case provider.ProviderGCP:
-			delete(atlasContainersIDs, fmt.Sprintf("%s.%s", container.ProviderName, container.AtlasCIDRBlock))
-		case provider.ProviderAzure:
-			delete(atlasContainersIDs, fmt.Sprintf("%s.%s.%s", container.ProviderName, containerRegionMatcher(container.GetContainerRegion(), container.ProviderName), container.AtlasCIDRBlock))
-		}
+		delete(atlasContainersIDs, networkPeerContainerKey(container))
 	}
 
 	return len(atlasContainersIDs) == 0
 }
 
+func atlasContainerKey(container mongodbatlas.Container) string {
+	switch container.ProviderName {
+	case string(provider.ProviderAWS):
+		return fmt.Sprintf("%s.%s.%s", container.ProviderName, container.RegionName, container.AtlasCIDRBlock)
+	case string(provider.ProviderGCP):
+		return fmt.Sprintf("%s.%s", container.ProviderName, container.AtlasCIDRBlock)
+	case string(provider.ProviderAzure):
+		return fmt.Sprintf("%s.%s.%s", container.ProviderName, container.Region, container.AtlasCIDRBlock)
+	}
+
+	return ""
+}
+
+func networkPeerContainerKey(peer mdbv1.NetworkPeer) string {
+	switch peer.ProviderName {
+	case provider.ProviderAWS:
+		return fmt.Sprintf("%s.%s.%s", peer.ProviderName, containerRegionNameMatcher(peer.GetContainerRegion(), peer.ProviderName), peer.AtlasCIDRBlock)
+	case provider.ProviderGCP:
+		return fmt.Sprintf("%s.%s", peer.ProviderName, peer.AtlasCIDRBlock)
+	case provider.ProviderAzure:
+		return fmt.Sprintf("%s.%s.%s", peer.ProviderName, containerRegionMatcher(peer.GetContainerRegion(), peer.ProviderName), peer.AtlasCIDRBlock)
+	}
+
+	return ""
+}
+
+// unclaimedContainers returns the Atlas containers which aren't used by the network peers claimed by another resource
+func unclaimedContainers(containers []mongodbatlas.Container, claimed []mdbv1.NetworkPeer) []mongodbatlas.Container {
+	claimedKeys := map[string]struct{}{}
+	for _, peer := range claimed {
+		claimedKeys[networkPeerContainerKey(peer)] = struct{}{}
+	}
+
+	unclaimed := make([]mongodbatlas.Container, 0, len(containers))
+	for _, container := range containers {
+		if _, ok := claimedKeys[atlasContainerKey(container)]; !ok {
+			unclaimed = append(unclaimed, container)
+		}
+	}
+
+	return unclaimed
+}
+
 func arePeersEqual(operatorPeers []mdbv1.NetworkPeer, atlasPeers []mongodbatlas.Peer) bool {
 	if len(operatorPeers) != len(atlasPeers) {
 		return false
@@ -21,7 +21,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 			Client:  mongodbatlas.Client{},
 			Context: context.TODO(),
 		}
-		result, err := canNetworkPeeringReconcile(workflowCtx, false, &mdbv1.AtlasProject{})
+		result, err := canNetworkPeeringReconcile(workflowCtx, false, &mdbv1.AtlasProject{}, nil)
 		require.NoError(t, err)
 		require.True(t, result)
 	})
@@ -33,7 +33,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 			Client:  mongodbatlas.Client{},
 			Context: context.TODO(),
 		}
-		result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+		result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 		require.EqualError(t, err, "invalid character 'w' looking for beginning of object key string")
 		require.False(t, result)
 	})
@@ -52,7 +52,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 			Client:  atlasClient,
 			Context: context.TODO(),
 		}
-		result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+		result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 
 		require.EqualError(t, err, "failed to retrieve data")
 		require.False(t, result)
@@ -77,7 +77,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 			Client:  atlasClient,
 			Context: context.TODO(),
 		}
-		result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+		result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 
 		require.EqualError(t, err, "failed to retrieve data")
 		require.False(t, result)
@@ -102,7 +102,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 			Client:  atlasClient,
 			Context: context.TODO(),
 		}
-		result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+		result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 
 		require.NoError(t, err)
 		require.True(t, result)
@@ -159,7 +159,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 				Client:  atlasClient,
 				Context: context.TODO(),
 			}
-			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 
 			require.NoError(t, err)
 			require.True(t, result)
@@ -212,7 +212,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 				Client:  atlasClient,
 				Context: context.TODO(),
 			}
-			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 
 			require.NoError(t, err)
 			require.True(t, result)
@@ -270,7 +270,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 				Client:  atlasClient,
 				Context: context.TODO(),
 			}
-			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 
 			require.NoError(t, err)
 			require.True(t, result)
@@ -328,7 +328,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 				Client:  atlasClient,
 				Context: context.TODO(),
 			}
-			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 
 			require.NoError(t, err)
 			require.False(t, result)
@@ -381,7 +381,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 				Client:  atlasClient,
 				Context: context.TODO(),
 			}
-			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 
 			require.NoError(t, err)
 			require.False(t, result)
@@ -439,7 +439,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 				Client:  atlasClient,
 				Context: context.TODO(),
 			}
-			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 
 			require.NoError(t, err)
 			require.False(t, result)
@@ -497,7 +497,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 				Client:  atlasClient,
 				Context: context.TODO(),
 			}
-			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 
 			require.NoError(t, err)
 			require.False(t, result)
@@ -549,7 +549,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 				Client:  atlasClient,
 				Context: context.TODO(),
 			}
-			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 
 			require.NoError(t, err)
 			require.False(t, result)
@@ -607,7 +607,7 @@ func TestCanNetworkPeeringReconcile(t *testing.T) {
 				Client:  atlasClient,
 				Context: context.TODO(),
 			}
-			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject)
+			result, err := canNetworkPeeringReconcile(workflowCtx, true, akoProject, nil)
 
 			require.NoError(t, err)
 			require.False(t, result)
@@ -630,7 +630,7 @@ func TestEnsureNetworkPeers(t *testing.T) {
 			Client:  atlasClient,
 			Context: context.TODO(),
 		}
-		result := ensureNetworkPeers(workflowCtx, akoProject, true)
+		result := ensureNetworkPeers(workflowCtx, akoProject, true, nil)
 
 		require.Equal(t, workflow.Terminate(workflow.Internal, "unable to resolve ownership for deletion protection: failed to retrieve data"), result)
 	})
@@ -685,7 +685,7 @@ func TestEnsureNetworkPeers(t *testing.T) {
 			Client:  atlasClient,
 			Context: context.TODO(),
 		}
-		result := ensureNetworkPeers(workflowCtx, akoProject, true)
+		result := ensureNetworkPeers(workflowCtx, akoProject, true, nil)
 
 		require.Equal(
 			t,
@@ -0,0 +1,112 @@
+package atlasproject
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/concurrency"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+// AtlasNetworkPeeringReconciler reconciles an AtlasNetworkPeering object. It manages the Network Peers of the
+// resource, leaving the ones of the AtlasProject and of the other AtlasNetworkPeerings untouched.
+type AtlasNetworkPeeringReconciler struct {
+	Client                   client.Client
+	Log                      *zap.SugaredLogger
+	Scheme                   *runtime.Scheme
+	GlobalPredicates         []predicate.Predicate
+	EventRecorder            record.EventRecorder
+	AtlasProvider            atlas.Provider
+	ObjectDeletionProtection bool
+	DryRun                   bool
+	MaxConcurrentReconciles  int
+	ProjectLocks             *concurrency.ProjectLocks
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasnetworkpeerings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasnetworkpeerings/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasnetworkpeerings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasnetworkpeerings/status,verbs=get;update;patch
+
+func (r *AtlasNetworkPeeringReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasnetworkpeering", req.NamespacedName)
+
+	networkPeering := &mdbv1.AtlasNetworkPeering{}
+	reconciliation := &subResourceReconciliation{
+		kind:                     "AtlasNetworkPeering",
+		resource:                 networkPeering,
+		conditionType:            status.NetworkPeerReadyType,
+		k8sClient:                r.Client,
+		eventRecorder:            r.EventRecorder,
+		atlasProvider:            r.AtlasProvider,
+		objectDeletionProtection: r.ObjectDeletionProtection,
+		dryRun:                   r.DryRun,
+		projectLocks:             r.ProjectLocks,
+		plannedChanges: func(changes []status.PlannedChange) status.Option {
+			return status.AtlasNetworkPeeringPlannedChangesOption(changes)
+		},
+		validate: func(akoProject *mdbv1.AtlasProject, connection *atlas.Connection) error {
+			return validate.NetworkPeering(networkPeering, r.AtlasProvider.IsCloudGov(connection), akoProject.Spec.RegionUsageRestrictions)
+		},
+		conflicts: func(ctx context.Context, akoProject *mdbv1.AtlasProject) ([]string, error) {
+			_, conflicts, err := r.claimedEntries(ctx, networkPeering, akoProject)
+			return conflicts, err
+		},
+		sync: func(workflowCtx *workflow.Context, akoProject *mdbv1.AtlasProject, deleting bool) workflow.Result {
+			claimed, _, err := r.claimedEntries(workflowCtx.Context, networkPeering, akoProject)
+			if err != nil {
+				return workflow.Terminate(workflow.Internal, err.Error())
+			}
+
+			var peerSpecs []mdbv1.NetworkPeer
+			if !deleting {
+				peerSpecs = networkPeering.Spec.DeepCopy().NetworkPeers
+			}
+
+			result, _ := SyncNetworkPeer(workflowCtx, akoProject.ID(), networkPeering.Status.DeepCopy().NetworkPeers, peerSpecs, claimed, networkPeeringPeersOption)
+			return result
+		},
+	}
+
+	return reconciliation.reconcile(ctx, req, log).ReconcileResult(), nil
+}
+
+func networkPeeringPeersOption(peerStatuses *[]status.AtlasNetworkPeer) status.Option {
+	return status.AtlasNetworkPeeringSetNetworkPeersOption(peerStatuses)
+}
+
+// claimedEntries returns the Network Peers managed by the other resources and the conflicts of the peers of the
+// AtlasNetworkPeering
+func (r *AtlasNetworkPeeringReconciler) claimedEntries(ctx context.Context, networkPeering *mdbv1.AtlasNetworkPeering, akoProject *mdbv1.AtlasProject) ([]mdbv1.NetworkPeer, []string, error) {
+	owners, err := networkPeeringOwners(ctx, r.Client, akoProject)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claimed, conflicts := claimedEntries(owners, ownerName("AtlasNetworkPeering", networkPeering), networkPeerKey)
+	return claimed, conflicts, nil
+}
+
+func (r *AtlasNetworkPeeringReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("AtlasNetworkPeering").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		For(&mdbv1.AtlasNetworkPeering{}, builder.WithPredicates(r.GlobalPredicates...)).
+		Watches(&source.Kind{Type: &mdbv1.AtlasProject{}}, projectSubResourceHandler(func(ctx context.Context) ([]*mdbv1.AtlasNetworkPeering, error) {
+			return listNetworkPeerings(ctx, r.Client)
+		}), builder.WithPredicates(projectSubResourcePredicate())).
+		Complete(r)
+}
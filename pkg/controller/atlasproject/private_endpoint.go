@@ -20,8 +20,19 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/set"
 )
 
-func ensurePrivateEndpoint(workflowCtx *workflow.Context, project *mdbv1.AtlasProject, protected bool) workflow.Result {
-	canReconcile, err := canPrivateEndpointReconcile(workflowCtx.Client, protected, project)
+// privateEndpointsStatusOptions builds the status options reporting the synced and the newly created Private Endpoints
+// of the resource being reconciled
+type privateEndpointsStatusOptions func(synced, created []status.ProjectPrivateEndpoint) []status.Option
+
+func projectPrivateEndpointsOptions(synced, created []status.ProjectPrivateEndpoint) []status.Option {
+	return []status.Option{
+		status.AtlasProjectSetPrivateEnpointsOption(synced),
+		status.AtlasProjectAddPrivateEnpointsOption(created),
+	}
+}
+
+func ensurePrivateEndpoint(workflowCtx *workflow.Context, project *mdbv1.AtlasProject, protected bool, claimed []mdbv1.PrivateEndpoint) workflow.Result {
+	canReconcile, err := canPrivateEndpointReconcile(workflowCtx.Client, protected, project, claimed)
 	if err != nil {
 		result := workflow.Terminate(workflow.Internal, fmt.Sprintf("unable to resolve ownership for deletion protection: %s", err))
 		workflowCtx.SetConditionFromResult(status.PrivateEndpointReadyType, result)
@@ -39,14 +50,19 @@ func ensurePrivateEndpoint(workflowCtx *workflow.Context, project *mdbv1.AtlasPr
 		return result
 	}
 
-	specPEs := project.Spec.DeepCopy().PrivateEndpoints
+	return syncPrivateEndpoints(workflowCtx, project.ID(), project.Spec.DeepCopy().PrivateEndpoints, claimed, projectPrivateEndpointsOptions)
+}
 
-	atlasPEs, err := getAllPrivateEndpoints(workflowCtx.Client, project.ID())
+// syncPrivateEndpoints makes the Atlas Private Endpoints which aren't claimed by another resource match the spec ones
+// and sets the Private Endpoint conditions accordingly
+func syncPrivateEndpoints(workflowCtx *workflow.Context, projectID string, specPEs, claimed []mdbv1.PrivateEndpoint, statusOptions privateEndpointsStatusOptions) workflow.Result {
+	atlasPEs, err := getAllPrivateEndpoints(workflowCtx.Client, projectID)
 	if err != nil {
 		return workflow.Terminate(workflow.Internal, err.Error())
 	}
+	atlasPEs = unclaimedPrivateEndpoints(atlasPEs, claimed)
 
-	result, conditionType := syncPrivateEndpointsWithAtlas(workflowCtx, project.ID(), specPEs, atlasPEs)
+	result, conditionType := syncPrivateEndpointsWithAtlas(workflowCtx, projectID, specPEs, atlasPEs, statusOptions)
 	if !result.IsOk() {
 		if conditionType == status.PrivateEndpointServiceReadyType {
 			workflowCtx.UnsetCondition(status.PrivateEndpointReadyType)
@@ -80,13 +96,13 @@ func ensurePrivateEndpoint(workflowCtx *workflow.Context, project *mdbv1.AtlasPr
 		}
 	}
 
-	interfaceStatus := getStatusForInterfaces(workflowCtx, project.ID(), specPEs, atlasPEs)
+	interfaceStatus := getStatusForInterfaces(workflowCtx, projectID, specPEs, atlasPEs)
 	workflowCtx.SetConditionFromResult(status.PrivateEndpointReadyType, interfaceStatus)
 
 	return interfaceStatus
 }
 
-func syncPrivateEndpointsWithAtlas(ctx *workflow.Context, projectID string, specPEs []mdbv1.PrivateEndpoint, atlasPEs []atlasPE) (workflow.Result, status.ConditionType) {
+func syncPrivateEndpointsWithAtlas(ctx *workflow.Context, projectID string, specPEs []mdbv1.PrivateEndpoint, atlasPEs []atlasPE, statusOptions privateEndpointsStatusOptions) (workflow.Result, status.ConditionType) {
 	log := ctx.Log
 
 	log.Debugw("PE Connections", "atlasPEs", atlasPEs, "specPEs", specPEs)
@@ -111,7 +127,7 @@ func syncPrivateEndpointsWithAtlas(ctx *workflow.Context, projectID string, spec
 	}
 
 	log.Debugw("PE Changes", "newConnections", newConnections, "syncedConnections", syncedConnections)
-	updatePEStatusOption(ctx, projectID, newConnections, syncedConnections)
+	updatePEStatusOption(ctx, projectID, newConnections, syncedConnections, statusOptions)
 
 	if len(newConnections) != 0 {
 		return notReadyServiceResult, status.PrivateEndpointServiceReadyType
@@ -183,19 +199,12 @@ func areServicesAvailableOrFailed(atlasPeConnections []atlasPE) (allAvailable bo
 	return
 }
 
-func updatePEStatusOption(ctx *workflow.Context, projectID string, newConnections, syncedConnections []atlasPE) {
-	setPEStatusOption(ctx, projectID, syncedConnections)
-	addPEStatusOption(ctx, projectID, newConnections)
-}
-
-func addPEStatusOption(ctx *workflow.Context, projectID string, newPEs []atlasPE) {
-	statusPEs := convertAllToStatus(ctx, projectID, newPEs)
-	ctx.EnsureStatusOption(status.AtlasProjectAddPrivateEnpointsOption(statusPEs))
-}
-
-func setPEStatusOption(ctx *workflow.Context, projectID string, atlasPeConnections []atlasPE) {
-	statusPEs := convertAllToStatus(ctx, projectID, atlasPeConnections)
-	ctx.EnsureStatusOption(status.AtlasProjectSetPrivateEnpointsOption(statusPEs))
+func updatePEStatusOption(ctx *workflow.Context, projectID string, newConnections, syncedConnections []atlasPE, statusOptions privateEndpointsStatusOptions) {
+	synced := convertAllToStatus(ctx, projectID, syncedConnections)
+	created := convertAllToStatus(ctx, projectID, newConnections)
+	for _, option := range statusOptions(synced, created) {
+		ctx.EnsureStatusOption(option)
+	}
 }
 
 type atlasPE mongodbatlas.PrivateEndpointConnection
@@ -550,7 +559,7 @@ type intersectionPair struct {
 	atlas atlasPE
 }
 
-func canPrivateEndpointReconcile(atlasClient mongodbatlas.Client, protected bool, akoProject *mdbv1.AtlasProject) (bool, error) {
+func canPrivateEndpointReconcile(atlasClient mongodbatlas.Client, protected bool, akoProject *mdbv1.AtlasProject, claimed []mdbv1.PrivateEndpoint) (bool, error) {
 	if !protected {
 		return true, nil
 	}
@@ -567,6 +576,7 @@ func canPrivateEndpointReconcile(atlasClient mongodbatlas.Client, protected bool
 	if err != nil {
 		return false, err
 	}
+	list = unclaimedPrivateEndpoints(list, claimed)
 
 	if len(list) == 0 {
 		return true, nil
@@ -85,7 +85,7 @@ func TestGetEndpointsNotInSpec(t *testing.T) {
 
 func TestCanPrivateEndpointReconcile(t *testing.T) {
 	t.Run("should return true when subResourceDeletionProtection is disabled", func(t *testing.T) {
-		result, err := canPrivateEndpointReconcile(mongodbatlas.Client{}, false, &mdbv1.AtlasProject{})
+		result, err := canPrivateEndpointReconcile(mongodbatlas.Client{}, false, &mdbv1.AtlasProject{}, nil)
 		require.NoError(t, err)
 		require.True(t, result)
 	})
@@ -93,7 +93,7 @@ func TestCanPrivateEndpointReconcile(t *testing.T) {
 	t.Run("should return error when unable to deserialize last applied configuration", func(t *testing.T) {
 		akoProject := &mdbv1.AtlasProject{}
 		akoProject.WithAnnotations(map[string]string{customresource.AnnotationLastAppliedConfiguration: "{wrong}"})
-		result, err := canPrivateEndpointReconcile(mongodbatlas.Client{}, true, akoProject)
+		result, err := canPrivateEndpointReconcile(mongodbatlas.Client{}, true, akoProject, nil)
 		require.EqualError(t, err, "invalid character 'w' looking for beginning of object key string")
 		require.False(t, result)
 	})
@@ -108,7 +108,7 @@ func TestCanPrivateEndpointReconcile(t *testing.T) {
 		}
 		akoProject := &mdbv1.AtlasProject{}
 		akoProject.WithAnnotations(map[string]string{customresource.AnnotationLastAppliedConfiguration: "{}"})
-		result, err := canPrivateEndpointReconcile(atlasClient, true, akoProject)
+		result, err := canPrivateEndpointReconcile(atlasClient, true, akoProject, nil)
 
 		require.EqualError(t, err, "failed to retrieve data")
 		require.False(t, result)
@@ -124,7 +124,7 @@ func TestCanPrivateEndpointReconcile(t *testing.T) {
 		}
 		akoProject := &mdbv1.AtlasProject{}
 		akoProject.WithAnnotations(map[string]string{customresource.AnnotationLastAppliedConfiguration: "{}"})
-		result, err := canPrivateEndpointReconcile(atlasClient, true, akoProject)
+		result, err := canPrivateEndpointReconcile(atlasClient, true, akoProject, nil)
 
 		require.NoError(t, err)
 		require.True(t, result)
@@ -164,7 +164,7 @@ func TestCanPrivateEndpointReconcile(t *testing.T) {
 			},
 		}
 		akoProject.WithAnnotations(map[string]string{customresource.AnnotationLastAppliedConfiguration: "{\"privateEndpoints\":[{\"provider\":\"AWS\",\"region\":\"eu-west-2\"}]}"})
-		result, err := canPrivateEndpointReconcile(atlasClient, true, akoProject)
+		result, err := canPrivateEndpointReconcile(atlasClient, true, akoProject, nil)
 
 		require.NoError(t, err)
 		require.True(t, result)
@@ -209,7 +209,7 @@ func TestCanPrivateEndpointReconcile(t *testing.T) {
 			},
 		}
 		akoProject.WithAnnotations(map[string]string{customresource.AnnotationLastAppliedConfiguration: "{\"privateEndpoints\":[{\"provider\":\"AWS\",\"region\":\"eu-west-2\"}]}"})
-		result, err := canPrivateEndpointReconcile(atlasClient, true, akoProject)
+		result, err := canPrivateEndpointReconcile(atlasClient, true, akoProject, nil)
 
 		require.NoError(t, err)
 		require.True(t, result)
@@ -254,7 +254,7 @@ func TestCanPrivateEndpointReconcile(t *testing.T) {
 			},
 		}
 		akoProject.WithAnnotations(map[string]string{customresource.AnnotationLastAppliedConfiguration: "{\"privateEndpoints\":[{\"provider\":\"AWS\",\"region\":\"eu-west-2\"}]}"})
-		result, err := canPrivateEndpointReconcile(atlasClient, true, akoProject)
+		result, err := canPrivateEndpointReconcile(atlasClient, true, akoProject, nil)
 
 		require.NoError(t, err)
 		require.False(t, result)
@@ -275,7 +275,7 @@ func TestEnsurePrivateEndpoint(t *testing.T) {
 		workflowCtx := &workflow.Context{
 			Client: atlasClient,
 		}
-		result := ensurePrivateEndpoint(workflowCtx, akoProject, true)
+		result := ensurePrivateEndpoint(workflowCtx, akoProject, true, nil)
 
 		require.Equal(t, workflow.Terminate(workflow.Internal, "unable to resolve ownership for deletion protection: failed to retrieve data"), result)
 	})
@@ -322,7 +322,7 @@ func TestEnsurePrivateEndpoint(t *testing.T) {
 		workflowCtx := &workflow.Context{
 			Client: atlasClient,
 		}
-		result := ensurePrivateEndpoint(workflowCtx, akoProject, true)
+		result := ensurePrivateEndpoint(workflowCtx, akoProject, true, nil)
 
 		require.Equal(
 			t,
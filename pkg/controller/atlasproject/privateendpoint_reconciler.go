@@ -0,0 +1,113 @@
+package atlasproject
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/concurrency"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+// AtlasPrivateEndpointReconciler reconciles an AtlasPrivateEndpoint object. It manages the Private Endpoints of the
+// resource, leaving the ones of the AtlasProject and of the other AtlasPrivateEndpoints untouched.
+type AtlasPrivateEndpointReconciler struct {
+	Client                   client.Client
+	Log                      *zap.SugaredLogger
+	Scheme                   *runtime.Scheme
+	GlobalPredicates         []predicate.Predicate
+	EventRecorder            record.EventRecorder
+	AtlasProvider            atlas.Provider
+	ObjectDeletionProtection bool
+	DryRun                   bool
+	MaxConcurrentReconciles  int
+	ProjectLocks             *concurrency.ProjectLocks
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasprivateendpoints,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasprivateendpoints/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasprivateendpoints,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasprivateendpoints/status,verbs=get;update;patch
+
+func (r *AtlasPrivateEndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlasprivateendpoint", req.NamespacedName)
+
+	privateEndpoint := &mdbv1.AtlasPrivateEndpoint{}
+	reconciliation := &subResourceReconciliation{
+		kind:                     "AtlasPrivateEndpoint",
+		resource:                 privateEndpoint,
+		conditionType:            status.PrivateEndpointReadyType,
+		k8sClient:                r.Client,
+		eventRecorder:            r.EventRecorder,
+		atlasProvider:            r.AtlasProvider,
+		objectDeletionProtection: r.ObjectDeletionProtection,
+		dryRun:                   r.DryRun,
+		projectLocks:             r.ProjectLocks,
+		plannedChanges: func(changes []status.PlannedChange) status.Option {
+			return status.AtlasPrivateEndpointPlannedChangesOption(changes)
+		},
+		validate: func(akoProject *mdbv1.AtlasProject, connection *atlas.Connection) error {
+			return validate.PrivateEndpoint(privateEndpoint, r.AtlasProvider.IsCloudGov(connection), akoProject.Spec.RegionUsageRestrictions)
+		},
+		conflicts: func(ctx context.Context, akoProject *mdbv1.AtlasProject) ([]string, error) {
+			_, conflicts, err := r.claimedEntries(ctx, privateEndpoint, akoProject)
+			return conflicts, err
+		},
+		sync: func(workflowCtx *workflow.Context, akoProject *mdbv1.AtlasProject, deleting bool) workflow.Result {
+			claimed, _, err := r.claimedEntries(workflowCtx.Context, privateEndpoint, akoProject)
+			if err != nil {
+				return workflow.Terminate(workflow.Internal, err.Error())
+			}
+
+			var specPEs []mdbv1.PrivateEndpoint
+			if !deleting {
+				specPEs = privateEndpoint.Spec.DeepCopy().PrivateEndpoints
+			}
+
+			return syncPrivateEndpoints(workflowCtx, akoProject.ID(), specPEs, claimed, privateEndpointsOptions)
+		},
+	}
+
+	return reconciliation.reconcile(ctx, req, log).ReconcileResult(), nil
+}
+
+func privateEndpointsOptions(synced, created []status.ProjectPrivateEndpoint) []status.Option {
+	return []status.Option{
+		status.AtlasPrivateEndpointSetPrivateEndpointsOption(append(synced, created...)),
+	}
+}
+
+// claimedEntries returns the Private Endpoints managed by the other resources and the conflicts of the Private
+// Endpoints of the AtlasPrivateEndpoint
+func (r *AtlasPrivateEndpointReconciler) claimedEntries(ctx context.Context, privateEndpoint *mdbv1.AtlasPrivateEndpoint, akoProject *mdbv1.AtlasProject) ([]mdbv1.PrivateEndpoint, []string, error) {
+	owners, err := privateEndpointOwners(ctx, r.Client, akoProject)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claimed, conflicts := claimedEntries(owners, ownerName("AtlasPrivateEndpoint", privateEndpoint), privateEndpointKey)
+	return claimed, conflicts, nil
+}
+
+func (r *AtlasPrivateEndpointReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("AtlasPrivateEndpoint").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		For(&mdbv1.AtlasPrivateEndpoint{}, builder.WithPredicates(r.GlobalPredicates...)).
+		Watches(&source.Kind{Type: &mdbv1.AtlasProject{}}, projectSubResourceHandler(func(ctx context.Context) ([]*mdbv1.AtlasPrivateEndpoint, error) {
+			return listPrivateEndpoints(ctx, r.Client)
+		}), builder.WithPredicates(projectSubResourcePredicate())).
+		Complete(r)
+}
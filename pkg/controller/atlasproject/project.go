@@ -8,11 +8,13 @@ import (
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
 )
 
-// ensureProjectExists creates the project if it doesn't exist yet. Returns the project ID
-func (r *AtlasProjectReconciler) ensureProjectExists(ctx *workflow.Context, project *mdbv1.AtlasProject) (string, workflow.Result) {
+// ensureProjectExists creates the project if it doesn't exist yet. Returns the project ID, which is empty when the
+// creation is only planned in dry-run mode
+func (r *AtlasProjectReconciler) ensureProjectExists(ctx *workflow.Context, project *mdbv1.AtlasProject, plan *dryrun.Plan) (string, workflow.Result) {
 	// Try to find the project
 	p, _, err := ctx.Client.Projects.GetOneProjectByName(context.Background(), project.Spec.Name)
 	if err != nil {
@@ -29,6 +31,10 @@ func (r *AtlasProjectReconciler) ensureProjectExists(ctx *workflow.Context, proj
 			if p, _, err = ctx.Client.Projects.Create(context.Background(), p, &mongodbatlas.CreateProjectOptions{}); err != nil {
 				return "", workflow.Terminate(workflow.ProjectNotCreatedInAtlas, err.Error())
 			}
+			if plan != nil {
+				ctx.Log.Infow("Planned the creation of the Atlas Project", "name", project.Spec.Name)
+				return "", workflow.OK()
+			}
 			ctx.Log.Infow("Created Atlas Project", "name", project.Spec.Name, "id", p.ID)
 		} else {
 			return "", workflow.Terminate(workflow.ProjectNotCreatedInAtlas, err.Error())
@@ -0,0 +1,261 @@
+package atlasproject
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// The IP Access List, the Network Peers and the Private Endpoints of a project can be managed both by the AtlasProject
+// and by standalone resources (AtlasIPAccessList, AtlasNetworkPeering and AtlasPrivateEndpoint) referencing the project.
+// Each resource only creates, updates and deletes the Atlas entries which aren't claimed by another resource, so that
+// they don't undo each other's changes. An entry declared by several resources belongs to the first one: the
+// AtlasProject, then the standalone resources from the oldest to the newest. The other resources report a conflict.
+
+// subResourceOwner is a resource declaring entries of a project sub-resource
+type subResourceOwner[T any] struct {
+	name    string
+	entries []T
+}
+
+// claimedEntries returns the entries declared by the owners other than the one named self, and the conflicts of the
+// entries of self already declared by a preceding owner.
+func claimedEntries[T any](owners []subResourceOwner[T], self string, key func(T) string) (claimed []T, conflicts []string) {
+	firstOwners := map[string]string{}
+	for _, owner := range owners {
+		for _, entry := range owner.entries {
+			entryKey := key(entry)
+			firstOwner, declared := firstOwners[entryKey]
+			if !declared {
+				firstOwners[entryKey] = owner.name
+			}
+
+			if owner.name != self {
+				claimed = append(claimed, entry)
+				continue
+			}
+
+			if declared && firstOwner != self {
+				conflicts = append(conflicts, fmt.Sprintf("%s is managed by %s", entryKey, firstOwner))
+			}
+		}
+	}
+
+	return claimed, conflicts
+}
+
+// projectSubResource is a standalone resource managing entries of a project sub-resource
+type projectSubResource interface {
+	client.Object
+	AtlasProjectObjectKey() client.ObjectKey
+}
+
+// referencingResources returns the resources referencing the project which aren't being deleted, from the oldest to
+// the newest.
+func referencingResources[T projectSubResource](resources []T, akoProject *mdbv1.AtlasProject) []T {
+	projectKey := kube.ObjectKeyFromObject(akoProject)
+	referencing := make([]T, 0, len(resources))
+	for _, resource := range resources {
+		if resource.GetDeletionTimestamp().IsZero() && resource.AtlasProjectObjectKey() == projectKey {
+			referencing = append(referencing, resource)
+		}
+	}
+
+	sort.SliceStable(referencing, func(i, j int) bool {
+		left, right := referencing[i].GetCreationTimestamp(), referencing[j].GetCreationTimestamp()
+		if !left.Equal(&right) {
+			return left.Before(&right)
+		}
+		return kube.ObjectKeyFromObject(referencing[i]).String() < kube.ObjectKeyFromObject(referencing[j]).String()
+	})
+
+	return referencing
+}
+
+func ownerName(kind string, obj client.Object) string {
+	return fmt.Sprintf("%s %s", kind, kube.ObjectKeyFromObject(obj))
+}
+
+// ipAccessListOwners returns the resources declaring IP Access List entries of the project
+func ipAccessListOwners(ctx context.Context, k8sClient client.Client, akoProject *mdbv1.AtlasProject) ([]subResourceOwner[project.IPAccessList], error) {
+	resources, err := listIPAccessLists(ctx, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := []subResourceOwner[project.IPAccessList]{
+		{name: ownerName("AtlasProject", akoProject), entries: akoProject.Spec.ProjectIPAccessList},
+	}
+	for _, resource := range referencingResources(resources, akoProject) {
+		owners = append(owners, subResourceOwner[project.IPAccessList]{name: ownerName("AtlasIPAccessList", resource), entries: resource.Spec.Entries})
+	}
+
+	return owners, nil
+}
+
+// networkPeeringOwners returns the resources declaring Network Peers of the project
+func networkPeeringOwners(ctx context.Context, k8sClient client.Client, akoProject *mdbv1.AtlasProject) ([]subResourceOwner[mdbv1.NetworkPeer], error) {
+	resources, err := listNetworkPeerings(ctx, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := []subResourceOwner[mdbv1.NetworkPeer]{
+		{name: ownerName("AtlasProject", akoProject), entries: akoProject.Spec.NetworkPeers},
+	}
+	for _, resource := range referencingResources(resources, akoProject) {
+		owners = append(owners, subResourceOwner[mdbv1.NetworkPeer]{name: ownerName("AtlasNetworkPeering", resource), entries: resource.Spec.NetworkPeers})
+	}
+
+	return owners, nil
+}
+
+// privateEndpointOwners returns the resources declaring Private Endpoints of the project
+func privateEndpointOwners(ctx context.Context, k8sClient client.Client, akoProject *mdbv1.AtlasProject) ([]subResourceOwner[mdbv1.PrivateEndpoint], error) {
+	resources, err := listPrivateEndpoints(ctx, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := []subResourceOwner[mdbv1.PrivateEndpoint]{
+		{name: ownerName("AtlasProject", akoProject), entries: akoProject.Spec.PrivateEndpoints},
+	}
+	for _, resource := range referencingResources(resources, akoProject) {
+		owners = append(owners, subResourceOwner[mdbv1.PrivateEndpoint]{name: ownerName("AtlasPrivateEndpoint", resource), entries: resource.Spec.PrivateEndpoints})
+	}
+
+	return owners, nil
+}
+
+// ipAccessListKey identifies an IP Access List entry in Atlas
+func ipAccessListKey(ipAccessList project.IPAccessList) string {
+	return mapToEntryValue(ipAccessList, false)
+}
+
+// unclaimedIPAccessList returns the entries which aren't claimed by another resource
+func unclaimedIPAccessList(entries, claimed []project.IPAccessList) []project.IPAccessList {
+	claimedKeys := map[string]struct{}{}
+	for _, entry := range claimed {
+		claimedKeys[ipAccessListKey(entry)] = struct{}{}
+	}
+
+	unclaimed := make([]project.IPAccessList, 0, len(entries))
+	for _, entry := range entries {
+		if _, ok := claimedKeys[ipAccessListKey(entry)]; !ok {
+			unclaimed = append(unclaimed, entry)
+		}
+	}
+
+	return unclaimed
+}
+
+// networkPeerKey identifies a Network Peer in Atlas
+func networkPeerKey(peer mdbv1.NetworkPeer) string {
+	switch peer.ProviderName {
+	case provider.ProviderGCP:
+		return fmt.Sprintf("%s.%s.%s", provider.ProviderGCP, peer.GCPProjectID, peer.NetworkName)
+	case provider.ProviderAzure:
+		return fmt.Sprintf("%s.%s.%s.%s.%s", provider.ProviderAzure, peer.AzureSubscriptionID, peer.AzureDirectoryID, peer.ResourceGroupName, peer.VNetName)
+	default:
+		return fmt.Sprintf("%s.%s.%s.%s", provider.ProviderAWS, peer.AWSAccountID, peer.VpcID, peer.RouteTableCIDRBlock)
+	}
+}
+
+func atlasNetworkPeerKey(peer mongodbatlas.Peer) string {
+	switch {
+	case peer.GCPProjectID != "" || peer.ProviderName == string(provider.ProviderGCP):
+		return fmt.Sprintf("%s.%s.%s", provider.ProviderGCP, peer.GCPProjectID, peer.NetworkName)
+	case peer.AzureSubscriptionID != "" || peer.ProviderName == string(provider.ProviderAzure):
+		return fmt.Sprintf("%s.%s.%s.%s.%s", provider.ProviderAzure, peer.AzureSubscriptionID, peer.AzureDirectoryID, peer.ResourceGroupName, peer.VNetName)
+	default:
+		return fmt.Sprintf("%s.%s.%s.%s", provider.ProviderAWS, peer.AWSAccountID, peer.VpcID, peer.RouteTableCIDRBlock)
+	}
+}
+
+// unclaimedNetworkPeers splits the Atlas Network Peers between the ones which aren't claimed by another resource and
+// the containers used by the claimed ones
+func unclaimedNetworkPeers(peers []mongodbatlas.Peer, claimed []mdbv1.NetworkPeer) (unclaimed []mongodbatlas.Peer, claimedContainerIDs []string) {
+	claimedKeys := map[string]struct{}{}
+	for _, peer := range claimed {
+		claimedKeys[networkPeerKey(peer)] = struct{}{}
+	}
+
+	for _, peer := range peers {
+		if _, ok := claimedKeys[atlasNetworkPeerKey(peer)]; ok {
+			claimedContainerIDs = append(claimedContainerIDs, peer.ContainerID)
+			continue
+		}
+		unclaimed = append(unclaimed, peer)
+	}
+
+	return unclaimed, claimedContainerIDs
+}
+
+// privateEndpointKey identifies a Private Endpoint Service in Atlas
+func privateEndpointKey(pe mdbv1.PrivateEndpoint) string {
+	return pe.Identifier().(string)
+}
+
+// unclaimedPrivateEndpoints returns the Atlas Private Endpoint Services which aren't claimed by another resource
+func unclaimedPrivateEndpoints(atlasPEs []atlasPE, claimed []mdbv1.PrivateEndpoint) []atlasPE {
+	claimedKeys := map[string]struct{}{}
+	for _, pe := range claimed {
+		claimedKeys[privateEndpointKey(pe)] = struct{}{}
+	}
+
+	unclaimed := make([]atlasPE, 0, len(atlasPEs))
+	for _, pe := range atlasPEs {
+		if _, ok := claimedKeys[pe.Identifier().(string)]; !ok {
+			unclaimed = append(unclaimed, pe)
+		}
+	}
+
+	return unclaimed
+}
+
+func listIPAccessLists(ctx context.Context, k8sClient client.Client) ([]*mdbv1.AtlasIPAccessList, error) {
+	list := &mdbv1.AtlasIPAccessListList{}
+	if err := k8sClient.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to retrieve list of IP Access Lists: %w", err)
+	}
+
+	resources := make([]*mdbv1.AtlasIPAccessList, 0, len(list.Items))
+	for i := range list.Items {
+		resources = append(resources, &list.Items[i])
+	}
+	return resources, nil
+}
+
+func listNetworkPeerings(ctx context.Context, k8sClient client.Client) ([]*mdbv1.AtlasNetworkPeering, error) {
+	list := &mdbv1.AtlasNetworkPeeringList{}
+	if err := k8sClient.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to retrieve list of Network Peerings: %w", err)
+	}
+
+	resources := make([]*mdbv1.AtlasNetworkPeering, 0, len(list.Items))
+	for i := range list.Items {
+		resources = append(resources, &list.Items[i])
+	}
+	return resources, nil
+}
+
+func listPrivateEndpoints(ctx context.Context, k8sClient client.Client) ([]*mdbv1.AtlasPrivateEndpoint, error) {
+	list := &mdbv1.AtlasPrivateEndpointList{}
+	if err := k8sClient.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to retrieve list of Private Endpoints: %w", err)
+	}
+
+	resources := make([]*mdbv1.AtlasPrivateEndpoint, 0, len(list.Items))
+	for i := range list.Items {
+		resources = append(resources, &list.Items[i])
+	}
+	return resources, nil
+}
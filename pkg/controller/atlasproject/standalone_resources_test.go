@@ -0,0 +1,97 @@
+package atlasproject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/provider"
+)
+
+func TestClaimedEntries(t *testing.T) {
+	owners := []subResourceOwner[project.IPAccessList]{
+		{name: "project", entries: []project.IPAccessList{{CIDRBlock: "10.0.0.0/24"}}},
+		{name: "first", entries: []project.IPAccessList{{IPAddress: "192.0.2.1"}, {CIDRBlock: "10.0.0.0/24"}}},
+		{name: "second", entries: []project.IPAccessList{{IPAddress: "192.0.2.2"}, {IPAddress: "192.0.2.1"}}},
+	}
+
+	t.Run("the first owner doesn't have conflicts", func(t *testing.T) {
+		claimed, conflicts := claimedEntries(owners, "project", ipAccessListKey)
+		assert.Equal(t, []project.IPAccessList{{IPAddress: "192.0.2.1"}, {CIDRBlock: "10.0.0.0/24"}, {IPAddress: "192.0.2.2"}, {IPAddress: "192.0.2.1"}}, claimed)
+		assert.Empty(t, conflicts)
+	})
+
+	t.Run("the entries declared by a preceding owner are conflicts", func(t *testing.T) {
+		claimed, conflicts := claimedEntries(owners, "first", ipAccessListKey)
+		assert.Equal(t, []project.IPAccessList{{CIDRBlock: "10.0.0.0/24"}, {IPAddress: "192.0.2.2"}, {IPAddress: "192.0.2.1"}}, claimed)
+		assert.Equal(t, []string{"10.0.0.0/24 is managed by project"}, conflicts)
+	})
+
+	t.Run("the entries declared by a following owner aren't conflicts", func(t *testing.T) {
+		_, conflicts := claimedEntries(owners, "second", ipAccessListKey)
+		assert.Equal(t, []string{"192.0.2.1 is managed by first"}, conflicts)
+	})
+}
+
+func TestReferencingResources(t *testing.T) {
+	akoProject := &mdbv1.AtlasProject{ObjectMeta: metav1.ObjectMeta{Name: "my-project", Namespace: "ns"}}
+	newIPAccessList := func(name, namespace, projectNamespace string, created time.Time) *mdbv1.AtlasIPAccessList {
+		return &mdbv1.AtlasIPAccessList{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.NewTime(created)},
+			Spec: mdbv1.AtlasIPAccessListSpec{
+				Project: common.ResourceRefNamespaced{Name: "my-project", Namespace: projectNamespace},
+			},
+		}
+	}
+
+	now := time.Now()
+	newest := newIPAccessList("newest", "ns", "", now)
+	oldest := newIPAccessList("oldest", "other", "ns", now.Add(-time.Hour))
+	sameTimeB := newIPAccessList("b", "ns", "", now.Add(-time.Minute))
+	sameTimeA := newIPAccessList("a", "ns", "", now.Add(-time.Minute))
+	otherProject := newIPAccessList("other-project", "other", "", now.Add(-2*time.Hour))
+	deleting := newIPAccessList("deleting", "ns", "", now.Add(-2*time.Hour))
+	deleting.DeletionTimestamp = &metav1.Time{Time: now}
+
+	referencing := referencingResources([]*mdbv1.AtlasIPAccessList{newest, otherProject, sameTimeB, deleting, oldest, sameTimeA}, akoProject)
+	assert.Equal(t, []*mdbv1.AtlasIPAccessList{oldest, sameTimeA, sameTimeB, newest}, referencing)
+}
+
+func TestUnclaimedNetworkPeers(t *testing.T) {
+	peers := []mongodbatlas.Peer{
+		{ID: "aws", ContainerID: "aws-container", AWSAccountID: "account", VpcID: "vpc", RouteTableCIDRBlock: "10.0.0.0/24"},
+		{ID: "gcp", ContainerID: "gcp-container", GCPProjectID: "gcp-project", NetworkName: "network"},
+		{ID: "azure", ContainerID: "azure-container", AzureSubscriptionID: "subscription", AzureDirectoryID: "directory", ResourceGroupName: "group", VNetName: "vnet"},
+	}
+	claimed := []mdbv1.NetworkPeer{
+		{ProviderName: provider.ProviderGCP, GCPProjectID: "gcp-project", NetworkName: "network"},
+		{ProviderName: provider.ProviderAWS, AWSAccountID: "account", VpcID: "other-vpc", RouteTableCIDRBlock: "10.0.0.0/24"},
+	}
+
+	unclaimed, claimedContainerIDs := unclaimedNetworkPeers(peers, claimed)
+	assert.Equal(t, []mongodbatlas.Peer{peers[0], peers[2]}, unclaimed)
+	assert.Equal(t, []string{"gcp-container"}, claimedContainerIDs)
+}
+
+func TestUnclaimedPrivateEndpoints(t *testing.T) {
+	atlasPEs := []atlasPE{
+		{ID: "aws", ProviderName: "AWS", RegionName: "us-east-1"},
+		{ID: "azure", ProviderName: "AZURE", RegionName: "westeurope"},
+	}
+	claimed := []mdbv1.PrivateEndpoint{{Provider: provider.ProviderAWS, Region: "US_EAST_1"}}
+
+	assert.Equal(t, []atlasPE{atlasPEs[1]}, unclaimedPrivateEndpoints(atlasPEs, claimed))
+}
+
+func TestUnclaimedIPAccessList(t *testing.T) {
+	entries := []project.IPAccessList{{IPAddress: "192.0.2.1"}, {CIDRBlock: "10.0.0.0/24"}, {AwsSecurityGroup: "sg-1"}}
+	claimed := []project.IPAccessList{{CIDRBlock: "10.0.0.0/24", Comment: "declared elsewhere"}}
+
+	assert.Equal(t, []project.IPAccessList{{IPAddress: "192.0.2.1"}, {AwsSecurityGroup: "sg-1"}}, unclaimedIPAccessList(entries, claimed))
+}
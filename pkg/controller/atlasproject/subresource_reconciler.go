@@ -0,0 +1,284 @@
+package atlasproject
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/concurrency"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// standaloneSubResource is a standalone resource managing entries of a project sub-resource
+type standaloneSubResource interface {
+	mdbv1.AtlasCustomResource
+	projectSubResource
+}
+
+// subResourceReconciliation describes how a standalone resource is reconciled. The common flow reads the referenced
+// project, manages the finalizer and the conflicts, while validate and sync handle the specifics of the resource.
+type subResourceReconciliation struct {
+	kind          string
+	resource      standaloneSubResource
+	conditionType status.ConditionType
+
+	k8sClient                client.Client
+	eventRecorder            record.EventRecorder
+	atlasProvider            atlas.Provider
+	objectDeletionProtection bool
+	dryRun                   bool
+	projectLocks             *concurrency.ProjectLocks
+
+	// plannedChanges returns the status option reporting the changes planned in dry-run mode
+	plannedChanges func(changes []status.PlannedChange) status.Option
+
+	// validate checks the spec of the resource against the referenced project and the domain of its connection
+	validate func(akoProject *mdbv1.AtlasProject, connection *atlas.Connection) error
+	// conflicts returns the entries of the resource already managed by another resource
+	conflicts func(ctx context.Context, akoProject *mdbv1.AtlasProject) ([]string, error)
+	// sync makes Atlas match the entries of the resource. It removes them when the resource is being deleted.
+	sync func(workflowCtx *workflow.Context, akoProject *mdbv1.AtlasProject, deleting bool) workflow.Result
+}
+
+func (s *subResourceReconciliation) reconcile(ctx context.Context, req ctrl.Request, log *zap.SugaredLogger) workflow.Result {
+	result := customresource.PrepareResource(s.k8sClient, req, s.resource, log)
+	if !result.IsOk() {
+		return result
+	}
+
+	if customresource.ReconciliationShouldBeSkipped(s.resource) {
+		log.Infow(fmt.Sprintf("-> Skipping %s reconciliation as annotation %s=%s", s.kind, customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip))
+		return workflow.OK()
+	}
+
+	unlock, err := s.projectLocks.Lock(ctx, s.resource.AtlasProjectObjectKey())
+	if err != nil {
+		return workflow.TerminateSilently()
+	}
+	defer unlock()
+
+	workflowCtx := customresource.MarkReconciliationStarted(s.k8sClient, s.resource, log, ctx)
+	log.Infow(fmt.Sprintf("-> Starting %s reconciliation", s.kind))
+	plan := dryrun.PlanFor(s.resource, s.dryRun, log)
+	defer func() {
+		workflowCtx.EnsureStatusOption(s.plannedChanges(plan.Changes()))
+		plan.Emit(s.eventRecorder, s.resource)
+		statushandler.Update(workflowCtx, s.k8sClient, s.eventRecorder, s.resource)
+	}()
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(workflowCtx, s.resource, log)
+	if !resourceVersionIsValid.IsOk() {
+		log.Debugf("%s validation result: %v", s.kind, resourceVersionIsValid)
+		return resourceVersionIsValid
+	}
+
+	deleting := !s.resource.GetDeletionTimestamp().IsZero()
+	akoProject, result := s.readProject(ctx)
+	if !result.IsOk() {
+		if deleting {
+			// The entries of the resource are removed from Atlas together with the project
+			return s.removeFinalizer(workflowCtx, plan)
+		}
+		workflowCtx.SetConditionFromResult(s.conditionType, result)
+		return result
+	}
+
+	if result = s.connect(workflowCtx, akoProject, plan); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(s.conditionType, result)
+		return result
+	}
+
+	if err := s.validate(akoProject, &workflowCtx.Connection); err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result
+	}
+	workflowCtx.SetConditionTrue(status.ValidationSucceeded)
+
+	if !s.atlasProvider.IsResourceSupported(s.resource, &workflowCtx.Connection) {
+		result = workflow.Terminate(workflow.AtlasGovUnsupported, fmt.Sprintf("the %s is not supported by Atlas for government", s.kind)).
+			WithoutRetry()
+		workflowCtx.SetConditionFromResult(s.conditionType, result)
+		return result
+	}
+
+	if deleting {
+		return s.handleDeletion(workflowCtx, akoProject, plan)
+	}
+
+	conflicts, err := s.conflicts(ctx, akoProject)
+	if err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(s.conditionType, result)
+		return result
+	}
+	if len(conflicts) > 0 {
+		result = workflow.Terminate(workflow.ProjectSubResourceConflict, strings.Join(conflicts, ", "))
+		workflowCtx.SetConditionFromResult(s.conditionType, result)
+		log.Warn(result.GetMessage())
+		return result
+	}
+
+	if !customresource.HaveFinalizer(s.resource, customresource.FinalizerLabel) {
+		if err = customresource.ManageFinalizer(ctx, s.k8sClient, s.resource, customresource.SetFinalizer); err != nil {
+			result = workflow.Terminate(workflow.AtlasFinalizerNotSet, err.Error())
+			workflowCtx.SetConditionFromResult(s.conditionType, result)
+			return result
+		}
+	}
+
+	if result = s.sync(workflowCtx, akoProject, false); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(s.conditionType, result)
+		return result
+	}
+
+	workflowCtx.SetConditionTrue(s.conditionType)
+	workflowCtx.SetConditionTrue(status.ReadyType)
+	return workflow.OK()
+}
+
+// readProject returns the referenced project once it's created in Atlas
+func (s *subResourceReconciliation) readProject(ctx context.Context) (*mdbv1.AtlasProject, workflow.Result) {
+	akoProject := &mdbv1.AtlasProject{}
+	if err := s.k8sClient.Get(ctx, s.resource.AtlasProjectObjectKey(), akoProject); err != nil {
+		if apiErrors.IsNotFound(err) {
+			return nil, workflow.Terminate(workflow.ProjectNotCreatedInAtlas, fmt.Sprintf("the AtlasProject %s doesn't exist", s.resource.AtlasProjectObjectKey()))
+		}
+		return nil, workflow.Terminate(workflow.Internal, fmt.Sprintf("unable to get AtlasProject resource %s: %s", s.resource.AtlasProjectObjectKey(), err))
+	}
+
+	if !akoProject.GetDeletionTimestamp().IsZero() {
+		return nil, workflow.Terminate(workflow.ProjectNotCreatedInAtlas, fmt.Sprintf("the AtlasProject %s is being deleted", s.resource.AtlasProjectObjectKey()))
+	}
+
+	if akoProject.ID() == "" {
+		return nil, workflow.Terminate(workflow.ProjectNotCreatedInAtlas, fmt.Sprintf("the AtlasProject %s is not created in Atlas yet", s.resource.AtlasProjectObjectKey()))
+	}
+
+	return akoProject, workflow.OK()
+}
+
+func (s *subResourceReconciliation) connect(workflowCtx *workflow.Context, akoProject *mdbv1.AtlasProject, plan *dryrun.Plan) workflow.Result {
+	connection, err := s.atlasProvider.CreateConnection(akoProject, workflowCtx.Log)
+	if err != nil {
+		return workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+	}
+	workflowCtx.Connection = connection
+
+	atlasClient, err := s.atlasProvider.CreateClient(&connection, workflowCtx.Log, plan.ClientOpts()...)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	workflowCtx.Client = atlasClient
+
+	return workflow.OK()
+}
+
+// handleDeletion removes the entries of the resource from Atlas, unless the resource is protected, then removes its
+// finalizer.
+func (s *subResourceReconciliation) handleDeletion(workflowCtx *workflow.Context, akoProject *mdbv1.AtlasProject, plan *dryrun.Plan) workflow.Result {
+	if !customresource.HaveFinalizer(s.resource, customresource.FinalizerLabel) {
+		return workflow.OK()
+	}
+
+	if customresource.IsResourceProtected(s.resource, s.objectDeletionProtection) {
+		workflowCtx.Log.Infof("Not removing %s entries from Atlas as per configuration", s.kind)
+	} else if result := s.sync(workflowCtx, akoProject, true); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(s.conditionType, result)
+		return result
+	}
+
+	return s.removeFinalizer(workflowCtx, plan)
+}
+
+// removeFinalizer removes the finalizer of the deleted resource, unless its deletion is only planned
+func (s *subResourceReconciliation) removeFinalizer(workflowCtx *workflow.Context, plan *dryrun.Plan) workflow.Result {
+	if !customresource.HaveFinalizer(s.resource, customresource.FinalizerLabel) {
+		return workflow.OK()
+	}
+
+	if plan.KeepFinalizer(s.resource) {
+		result := dryrun.DeletionPlanned()
+		workflowCtx.SetConditionFromResult(s.conditionType, result)
+		return result
+	}
+
+	if err := customresource.ManageFinalizer(workflowCtx.Context, s.k8sClient, s.resource, customresource.UnsetFinalizer); err != nil {
+		result := workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
+		workflowCtx.SetConditionFromResult(s.conditionType, result)
+		return result
+	}
+
+	return workflow.OK()
+}
+
+// projectSubResourceHandler enqueues the standalone resources referencing a project, so that they're reconciled once
+// the project is created in Atlas or when its own entries change.
+func projectSubResourceHandler[T projectSubResource](list func(ctx context.Context) ([]T, error)) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		akoProject, ok := obj.(*mdbv1.AtlasProject)
+		if !ok {
+			return nil
+		}
+
+		resources, err := list(context.Background())
+		if err != nil {
+			zap.S().Errorf("failed to list the resources referencing the project %s: %s", kube.ObjectKeyFromObject(obj), err)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, resource := range resources {
+			if resource.AtlasProjectObjectKey() == kube.ObjectKeyFromObject(akoProject) {
+				requests = append(requests, reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(resource)})
+			}
+		}
+		return requests
+	})
+}
+
+// projectSubResourcePredicate filters out the project changes which don't affect the standalone resources: the ones
+// which neither change its spec nor create it in Atlas.
+func projectSubResourcePredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldProject, okOld := e.ObjectOld.(*mdbv1.AtlasProject)
+			newProject, okNew := e.ObjectNew.(*mdbv1.AtlasProject)
+			if !okOld || !okNew {
+				return false
+			}
+
+			return oldProject.GetGeneration() != newProject.GetGeneration() || oldProject.ID() != newProject.ID()
+		},
+	}
+}
+
+// standaloneSubResourceHandler enqueues the project referenced by a standalone resource, so that the project stops or
+// starts managing the entries of the resource.
+func standaloneSubResourceHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		resource, ok := obj.(projectSubResource)
+		if !ok {
+			return nil
+		}
+
+		return []reconcile.Request{{NamespacedName: resource.AtlasProjectObjectKey()}}
+	})
+}
@@ -21,12 +21,14 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
 )
 
 func (r *AtlasProjectReconciler) teamReconcile(
 	team *v1.AtlasTeam,
 	connection atlas.Connection,
+	atlasClient mongodbatlas.Client,
 ) reconcile.Func {
 	return func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
 		log := r.Log.With("atlasteam", req.NamespacedName)
@@ -41,11 +43,7 @@ func (r *AtlasProjectReconciler) teamReconcile(
 			return workflow.OK().ReconcileResult(), nil
 		}
 
-		teamCtx, err := createTeamContextFromParent(ctx, team, r.Client, connection, r.AtlasDomain, log)
-		if err != nil {
-			teamCtx.SetConditionFalse(status.ReadyType)
-			return workflow.Terminate(workflow.Internal, err.Error()).ReconcileResult(), nil
-		}
+		teamCtx := createTeamContextFromParent(ctx, team, r.Client, connection, atlasClient, log)
 
 		defer statushandler.Update(teamCtx, r.Client, r.EventRecorder, team)
 
@@ -55,9 +53,16 @@ func (r *AtlasProjectReconciler) teamReconcile(
 			return resourceVersionIsValid.ReconcileResult(), nil
 		}
 
+		if err := validate.Team(team); err != nil {
+			result = workflow.Terminate(workflow.Internal, err.Error())
+			teamCtx.SetConditionFromResult(status.ValidationSucceeded, result)
+			return result.ReconcileResult(), nil
+		}
+		teamCtx.SetConditionTrue(status.ValidationSucceeded)
+
 		log.Infow("-> Starting AtlasTeam reconciliation", "spec", team.Spec)
 
-		if !customresource.IsResourceSupportedInDomain(team, r.AtlasDomain) {
+		if !customresource.IsResourceSupportedInDomain(team, connection.DomainOr(r.AtlasDomain)) {
 			result := workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasTeam is not supported by Atlas for government").
 				WithoutRetry()
 			setCondition(teamCtx, status.ReadyType, result)
@@ -149,23 +154,21 @@ func (r *AtlasProjectReconciler) teamReconcile(
 	}
 }
 
+// createTeamContextFromParent reuses the Atlas client of the project so that the changes to the team are planned
+// along with the ones of the project in dry-run mode
 func createTeamContextFromParent(
 	ctx context.Context,
 	team *v1.AtlasTeam,
 	kubeClient client.Client,
 	atlasConnection atlas.Connection,
-	atlasDomain string,
+	atlasClient mongodbatlas.Client,
 	logger *zap.SugaredLogger,
-) (*workflow.Context, error) {
+) *workflow.Context {
 	teamCtx := customresource.MarkReconciliationStarted(kubeClient, team, logger, ctx)
 	teamCtx.Connection = atlasConnection
-	atlasClient, err := atlas.Client(atlasDomain, atlasConnection, logger)
-	if err != nil {
-		return nil, err
-	}
 	teamCtx.Client = atlasClient
 
-	return teamCtx, nil
+	return teamCtx
 }
 
 func ensureTeamState(workflowCtx *workflow.Context, team *v1.AtlasTeam) (string, workflow.Result) {
@@ -35,7 +35,7 @@ func (r *AtlasProjectReconciler) ensureAssignedTeams(workflowCtx *workflow.Conte
 	resourcesToWatch := make([]watch.WatchedObject, 0, len(project.Spec.Teams))
 	defer func() {
 		workflowCtx.AddResourcesToWatch(resourcesToWatch...)
-		r.Log.Debugf("watching team resources: %v\r\n", r.WatchedResources)
+		r.Log.Debugf("watching team resources: %v\r\n", r.ResourceWatcher)
 	}()
 
 	teamsToAssign := map[string]*v1.Team{}
@@ -53,7 +53,7 @@ func (r *AtlasProjectReconciler) ensureAssignedTeams(workflowCtx *workflow.Conte
 		}
 
 		team := &v1.AtlasTeam{}
-		teamReconciler := r.teamReconcile(team, workflowCtx.Connection)
+		teamReconciler := r.teamReconcile(team, workflowCtx.Connection, workflowCtx.Client)
 		_, err := teamReconciler(
 			context.Background(),
 			controllerruntime.Request{NamespacedName: types.NamespacedName{Name: assignedTeam.TeamRef.Name, Namespace: assignedTeam.TeamRef.Namespace}},
@@ -225,10 +225,7 @@ func (r *AtlasProjectReconciler) updateTeamState(ctx *workflow.Context, project
 	}
 
 	log := r.Log.With("atlasteam", teamRef)
-	teamCtx, err := createTeamContextFromParent(ctx.Context, team, r.Client, ctx.Connection, r.AtlasDomain, log)
-	if err != nil {
-		return err
-	}
+	teamCtx := createTeamContextFromParent(ctx.Context, team, r.Client, ctx.Connection, ctx.Client, log)
 
 	if len(assignedProjects) == 0 {
 		log.Debugf("team %s has no project associated to it. removing from atlas.", team.Spec.Name)
@@ -0,0 +1,247 @@
+package atlassearchindex
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/dryrun"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+// AtlasSearchIndexReconciler reconciles an AtlasSearchIndex object. It manages the Atlas Search index in the
+// deployment referenced by the resource and reports the build status of the index.
+type AtlasSearchIndexReconciler struct {
+	Client                   client.Client
+	Log                      *zap.SugaredLogger
+	Scheme                   *runtime.Scheme
+	GlobalPredicates         []predicate.Predicate
+	EventRecorder            record.EventRecorder
+	AtlasProvider            atlas.Provider
+	ObjectDeletionProtection bool
+	DryRun                   bool
+	MaxConcurrentReconciles  int
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlassearchindexes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlassearchindexes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlassearchindexes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlassearchindexes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasdeployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasdeployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *AtlasSearchIndexReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.With("atlassearchindex", req.NamespacedName)
+
+	searchIndex := &mdbv1.AtlasSearchIndex{}
+	result := customresource.PrepareResource(r.Client, req, searchIndex, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if customresource.ReconciliationShouldBeSkipped(searchIndex) {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasSearchIndex reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", searchIndex.Spec)
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	workflowCtx := customresource.MarkReconciliationStarted(r.Client, searchIndex, log, ctx)
+	log.Infow("-> Starting AtlasSearchIndex reconciliation", "spec", searchIndex.Spec)
+	plan := dryrun.PlanFor(searchIndex, r.DryRun, log)
+	defer func() {
+		workflowCtx.EnsureStatusOption(status.AtlasSearchIndexPlannedChangesOption(plan.Changes()))
+		plan.Emit(r.EventRecorder, searchIndex)
+		statushandler.Update(workflowCtx, r.Client, r.EventRecorder, searchIndex)
+	}()
+
+	resourceVersionIsValid := customresource.ValidateResourceVersion(workflowCtx, searchIndex, log)
+	if !resourceVersionIsValid.IsOk() {
+		log.Debugf("search index validation result: %v", resourceVersionIsValid)
+		return resourceVersionIsValid.ReconcileResult(), nil
+	}
+
+	if err := validate.SearchIndex(searchIndex); err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result.ReconcileResult(), nil
+	}
+	workflowCtx.SetConditionTrue(status.ValidationSucceeded)
+
+	deleting := !searchIndex.GetDeletionTimestamp().IsZero()
+	deployment, project, result := r.readDeployment(ctx, searchIndex)
+	if !result.IsOk() {
+		if deleting {
+			// The index is removed from Atlas together with the deployment
+			return r.removeFinalizer(workflowCtx, searchIndex, plan).ReconcileResult(), nil
+		}
+		workflowCtx.SetConditionFromResult(status.SearchIndexReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if result = r.connect(workflowCtx, project, plan); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.SearchIndexReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if !r.AtlasProvider.IsResourceSupported(searchIndex, &workflowCtx.Connection) {
+		result = workflow.Terminate(workflow.AtlasGovUnsupported, "the AtlasSearchIndex is not supported by Atlas for government").
+			WithoutRetry()
+		workflowCtx.SetConditionFromResult(status.SearchIndexReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	index := newIndexInAtlas(workflowCtx, project.ID(), deployment.GetDeploymentName())
+	if deleting {
+		return r.handleDeletion(workflowCtx, searchIndex, index, plan).ReconcileResult(), nil
+	}
+
+	owner, err := customresource.IsOwner(searchIndex, r.ObjectDeletionProtection, customresource.IsResourceManagedByOperator, index.managedByAtlas())
+	if err != nil {
+		result = workflow.Terminate(workflow.Internal, fmt.Sprintf("unable to resolve ownership for deletion protection: %s", err))
+		workflowCtx.SetConditionFromResult(status.SearchIndexReadyType, result)
+		log.Error(result.GetMessage())
+		return result.ReconcileResult(), nil
+	}
+
+	if !owner {
+		result = workflow.Terminate(
+			workflow.AtlasDeletionProtection,
+			"unable to reconcile search index: it already exists in Atlas, it was not previously managed by the operator, and the deletion protection is enabled.",
+		)
+		workflowCtx.SetConditionFromResult(status.SearchIndexReadyType, result)
+		log.Error(result.GetMessage())
+		return result.ReconcileResult(), nil
+	}
+
+	if !customresource.HaveFinalizer(searchIndex, customresource.FinalizerLabel) {
+		if err = customresource.ManageFinalizer(ctx, r.Client, searchIndex, customresource.SetFinalizer); err != nil {
+			result = workflow.Terminate(workflow.AtlasFinalizerNotSet, err.Error())
+			workflowCtx.SetConditionFromResult(status.SearchIndexReadyType, result)
+			return result.ReconcileResult(), nil
+		}
+	}
+
+	if result = index.ensure(searchIndex, plan != nil); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.SearchIndexReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if err = customresource.ApplyLastConfigApplied(ctx, searchIndex, r.Client); err != nil {
+		result = workflow.Terminate(workflow.Internal, err.Error())
+		workflowCtx.SetConditionFromResult(status.SearchIndexReadyType, result)
+		log.Error(result.GetMessage())
+		return result.ReconcileResult(), nil
+	}
+
+	workflowCtx.SetConditionTrue(status.SearchIndexReadyType)
+	workflowCtx.SetConditionTrue(status.ReadyType)
+	return workflow.OK().ReconcileResult(), nil
+}
+
+// readDeployment returns the referenced deployment and its project once the deployment is created in Atlas
+func (r *AtlasSearchIndexReconciler) readDeployment(ctx context.Context, searchIndex *mdbv1.AtlasSearchIndex) (*mdbv1.AtlasDeployment, *mdbv1.AtlasProject, workflow.Result) {
+	deploymentKey := searchIndex.AtlasDeploymentObjectKey()
+	deployment := &mdbv1.AtlasDeployment{}
+	if err := r.Client.Get(ctx, deploymentKey, deployment); err != nil {
+		if apiErrors.IsNotFound(err) {
+			return nil, nil, workflow.Terminate(workflow.SearchIndexDeploymentNotReady, fmt.Sprintf("the AtlasDeployment %s doesn't exist", deploymentKey))
+		}
+		return nil, nil, workflow.Terminate(workflow.Internal, fmt.Sprintf("unable to get AtlasDeployment resource %s: %s", deploymentKey, err))
+	}
+
+	if !deployment.GetDeletionTimestamp().IsZero() {
+		return nil, nil, workflow.Terminate(workflow.SearchIndexDeploymentNotReady, fmt.Sprintf("the AtlasDeployment %s is being deleted", deploymentKey))
+	}
+
+	if deployment.Status.StateName == "" || deployment.Status.StateName == status.StateCREATING {
+		return nil, nil, workflow.Terminate(workflow.SearchIndexDeploymentNotReady, fmt.Sprintf("the AtlasDeployment %s is not created in Atlas yet", deploymentKey))
+	}
+
+	project := &mdbv1.AtlasProject{}
+	if err := r.Client.Get(ctx, deployment.AtlasProjectObjectKey(), project); err != nil {
+		return nil, nil, workflow.Terminate(workflow.Internal, fmt.Sprintf("unable to get AtlasProject resource %s: %s", deployment.AtlasProjectObjectKey(), err))
+	}
+
+	if project.ID() == "" {
+		return nil, nil, workflow.Terminate(workflow.ProjectNotCreatedInAtlas, fmt.Sprintf("the AtlasProject %s is not created in Atlas yet", deployment.AtlasProjectObjectKey()))
+	}
+
+	return deployment, project, workflow.OK()
+}
+
+func (r *AtlasSearchIndexReconciler) connect(workflowCtx *workflow.Context, project *mdbv1.AtlasProject, plan *dryrun.Plan) workflow.Result {
+	connection, err := r.AtlasProvider.CreateConnection(project, workflowCtx.Log)
+	if err != nil {
+		return workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+	}
+	workflowCtx.Connection = connection
+
+	atlasClient, err := r.AtlasProvider.CreateClient(&connection, workflowCtx.Log, plan.ClientOpts()...)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	workflowCtx.Client = atlasClient
+
+	return workflow.OK()
+}
+
+// handleDeletion removes the index from Atlas, unless the resource is protected, then removes its finalizer
+func (r *AtlasSearchIndexReconciler) handleDeletion(workflowCtx *workflow.Context, searchIndex *mdbv1.AtlasSearchIndex, index *indexInAtlas, plan *dryrun.Plan) workflow.Result {
+	if !customresource.HaveFinalizer(searchIndex, customresource.FinalizerLabel) {
+		return workflow.OK()
+	}
+
+	if customresource.IsResourceProtected(searchIndex, r.ObjectDeletionProtection) {
+		workflowCtx.Log.Info("Not removing the search index from Atlas as per configuration")
+	} else if result := index.delete(searchIndex); !result.IsOk() {
+		workflowCtx.SetConditionFromResult(status.SearchIndexReadyType, result)
+		return result
+	}
+
+	return r.removeFinalizer(workflowCtx, searchIndex, plan)
+}
+
+func (r *AtlasSearchIndexReconciler) removeFinalizer(workflowCtx *workflow.Context, searchIndex *mdbv1.AtlasSearchIndex, plan *dryrun.Plan) workflow.Result {
+	if !customresource.HaveFinalizer(searchIndex, customresource.FinalizerLabel) {
+		return workflow.OK()
+	}
+
+	if plan.KeepFinalizer(searchIndex) {
+		result := dryrun.DeletionPlanned()
+		workflowCtx.SetConditionFromResult(status.SearchIndexReadyType, result)
+		return result
+	}
+
+	if err := customresource.ManageFinalizer(workflowCtx.Context, r.Client, searchIndex, customresource.UnsetFinalizer); err != nil {
+		result := workflow.Terminate(workflow.AtlasFinalizerNotRemoved, err.Error())
+		workflowCtx.SetConditionFromResult(status.SearchIndexReadyType, result)
+		return result
+	}
+
+	return workflow.OK()
+}
+
+func (r *AtlasSearchIndexReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("AtlasSearchIndex").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		For(&mdbv1.AtlasSearchIndex{}, builder.WithPredicates(r.GlobalPredicates...)).
+		Watches(&source.Kind{Type: &mdbv1.AtlasDeployment{}}, r.deploymentHandler(), builder.WithPredicates(deploymentPredicate())).
+		Complete(r)
+}
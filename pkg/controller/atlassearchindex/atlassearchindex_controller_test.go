@@ -0,0 +1,271 @@
+package atlassearchindex
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/controllertest"
+	atlas_mock "github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/mocks/atlas"
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+func TestAtlasSearchIndexReconcile(t *testing.T) {
+	t.Run("the deployment must be created in Atlas", func(t *testing.T) {
+		deployment := controllertest.Deployment("my-deployment", "my-cluster")
+		deployment.Status.StateName = status.StateCREATING
+		searchIndex := testSearchIndex()
+		r := testReconciler(t, mongodbatlas.Client{}, controllertest.Project(), deployment, searchIndex)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(searchIndex)})
+		require.NoError(t, err)
+
+		endSearchIndex := controllertest.Read(t, r.Client, searchIndex)
+		controllertest.AssertCondition(t, endSearchIndex, status.SearchIndexReadyType, corev1.ConditionFalse, string(workflow.SearchIndexDeploymentNotReady))
+		assert.Empty(t, endSearchIndex.Finalizers)
+	})
+
+	t.Run("the index is created and reported as building", func(t *testing.T) {
+		searchIndex := testSearchIndex()
+		searchAPI := &atlas_mock.SearchClientMock{
+			ListIndexesFunc: func(projectID, clusterName, databaseName, collectionName string) ([]*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+				return nil, nil, nil
+			},
+			CreateIndexFunc: func(projectID, clusterName string, index *mongodbatlas.SearchIndex) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+				created := *index
+				created.IndexID = "my-index-id"
+				created.Status = "IN_PROGRESS"
+				return &created, nil, nil
+			},
+		}
+		r := testReconciler(t, mongodbatlas.Client{Search: searchAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), searchIndex)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(searchIndex)})
+		require.NoError(t, err)
+
+		require.Contains(t, searchAPI.CreateIndexRequests, "my-project-id.my-cluster")
+		created := searchAPI.CreateIndexRequests["my-project-id.my-cluster"]
+		assert.Equal(t, "title", created.Name)
+		assert.Equal(t, map[string]interface{}{"title": map[string]interface{}{"type": "string"}}, *created.Mappings.Fields)
+
+		endSearchIndex := controllertest.Read(t, r.Client, searchIndex)
+		controllertest.AssertCondition(t, endSearchIndex, status.SearchIndexReadyType, corev1.ConditionFalse, string(workflow.SearchIndexBuilding))
+		assert.Equal(t, "my-index-id", endSearchIndex.Status.IndexID)
+		assert.Equal(t, "IN_PROGRESS", endSearchIndex.Status.IndexStatus)
+		assert.Equal(t, []string{customresource.FinalizerLabel}, endSearchIndex.Finalizers)
+	})
+
+	t.Run("a steady index up to date is ready", func(t *testing.T) {
+		searchIndex := testSearchIndex()
+		searchIndex.Status.IndexID = "my-index-id"
+		searchAPI := &atlas_mock.SearchClientMock{
+			GetIndexFunc: func(projectID, clusterName, indexID string) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+				return atlasIndex(t, searchIndex, "STEADY"), nil, nil
+			},
+		}
+		r := testReconciler(t, mongodbatlas.Client{Search: searchAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), searchIndex)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(searchIndex)})
+		require.NoError(t, err)
+
+		assert.Empty(t, searchAPI.UpdateIndexRequests)
+		endSearchIndex := controllertest.Read(t, r.Client, searchIndex)
+		controllertest.AssertCondition(t, endSearchIndex, status.SearchIndexReadyType, corev1.ConditionTrue, "")
+		controllertest.AssertCondition(t, endSearchIndex, status.ReadyType, corev1.ConditionTrue, "")
+		assert.Equal(t, "STEADY", endSearchIndex.Status.IndexStatus)
+	})
+
+	t.Run("a changed index is updated", func(t *testing.T) {
+		searchIndex := testSearchIndex()
+		searchIndex.Status.IndexID = "my-index-id"
+		current := atlasIndex(t, searchIndex, "STEADY")
+		searchIndex.Spec.Analyzer = "lucene.english"
+		searchAPI := &atlas_mock.SearchClientMock{
+			GetIndexFunc: func(projectID, clusterName, indexID string) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+				return current, nil, nil
+			},
+			UpdateIndexFunc: func(projectID, clusterName, indexID string, index *mongodbatlas.SearchIndex) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+				updated := *index
+				updated.Status = "IN_PROGRESS"
+				return &updated, nil, nil
+			},
+		}
+		r := testReconciler(t, mongodbatlas.Client{Search: searchAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), searchIndex)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(searchIndex)})
+		require.NoError(t, err)
+
+		require.Contains(t, searchAPI.UpdateIndexRequests, "my-project-id.my-cluster.my-index-id")
+		assert.Equal(t, "lucene.english", searchAPI.UpdateIndexRequests["my-project-id.my-cluster.my-index-id"].Analyzer)
+		endSearchIndex := controllertest.Read(t, r.Client, searchIndex)
+		controllertest.AssertCondition(t, endSearchIndex, status.SearchIndexReadyType, corev1.ConditionFalse, string(workflow.SearchIndexBuilding))
+	})
+
+	t.Run("a failed index is reported", func(t *testing.T) {
+		searchIndex := testSearchIndex()
+		searchIndex.Status.IndexID = "my-index-id"
+		searchAPI := &atlas_mock.SearchClientMock{
+			GetIndexFunc: func(projectID, clusterName, indexID string) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+				return atlasIndex(t, searchIndex, "FAILED"), nil, nil
+			},
+		}
+		r := testReconciler(t, mongodbatlas.Client{Search: searchAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), searchIndex)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(searchIndex)})
+		require.NoError(t, err)
+
+		endSearchIndex := controllertest.Read(t, r.Client, searchIndex)
+		controllertest.AssertCondition(t, endSearchIndex, status.SearchIndexReadyType, corev1.ConditionFalse, string(workflow.SearchIndexFailed))
+	})
+
+	t.Run("an index managed outside the operator isn't taken over with the deletion protection", func(t *testing.T) {
+		searchIndex := testSearchIndex()
+		current := atlasIndex(t, searchIndex, "STEADY")
+		current.Mappings = &mongodbatlas.IndexMapping{Dynamic: true}
+		searchAPI := &atlas_mock.SearchClientMock{
+			ListIndexesFunc: func(projectID, clusterName, databaseName, collectionName string) ([]*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+				return []*mongodbatlas.SearchIndex{current}, nil, nil
+			},
+		}
+		r := testReconciler(t, mongodbatlas.Client{Search: searchAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), searchIndex)
+		r.ObjectDeletionProtection = true
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(searchIndex)})
+		require.NoError(t, err)
+
+		assert.Empty(t, searchAPI.UpdateIndexRequests)
+		endSearchIndex := controllertest.Read(t, r.Client, searchIndex)
+		controllertest.AssertCondition(t, endSearchIndex, status.SearchIndexReadyType, corev1.ConditionFalse, string(workflow.AtlasDeletionProtection))
+	})
+
+	t.Run("the deletion removes the index from Atlas", func(t *testing.T) {
+		searchIndex := testSearchIndex()
+		searchIndex.Status.IndexID = "my-index-id"
+		searchIndex.Finalizers = []string{customresource.FinalizerLabel}
+		searchIndex.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		searchAPI := &atlas_mock.SearchClientMock{
+			GetIndexFunc: func(projectID, clusterName, indexID string) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+				return atlasIndex(t, searchIndex, "STEADY"), nil, nil
+			},
+			DeleteIndexFunc: func(projectID, clusterName, indexID string) (*mongodbatlas.Response, error) {
+				return nil, nil
+			},
+		}
+		r := testReconciler(t, mongodbatlas.Client{Search: searchAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), searchIndex)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(searchIndex)})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]struct{}{"my-project-id.my-cluster.my-index-id": {}}, searchAPI.DeleteIndexRequests)
+		err = r.Client.Get(context.Background(), kube.ObjectKeyFromObject(searchIndex), &mdbv1.AtlasSearchIndex{})
+		assert.True(t, apiErrors.IsNotFound(err), "the resource should be deleted once the finalizer is removed")
+	})
+
+	t.Run("the deletion of an index already removed from Atlas succeeds", func(t *testing.T) {
+		searchIndex := testSearchIndex()
+		searchIndex.Status.IndexID = "my-index-id"
+		searchIndex.Finalizers = []string{customresource.FinalizerLabel}
+		searchIndex.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		searchAPI := &atlas_mock.SearchClientMock{
+			GetIndexFunc: func(projectID, clusterName, indexID string) (*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+				return nil, nil, &mongodbatlas.ErrorResponse{HTTPCode: http.StatusNotFound}
+			},
+			ListIndexesFunc: func(projectID, clusterName, databaseName, collectionName string) ([]*mongodbatlas.SearchIndex, *mongodbatlas.Response, error) {
+				return nil, nil, nil
+			},
+		}
+		r := testReconciler(t, mongodbatlas.Client{Search: searchAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), searchIndex)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(searchIndex)})
+		require.NoError(t, err)
+
+		assert.Empty(t, searchAPI.DeleteIndexRequests)
+		err = r.Client.Get(context.Background(), kube.ObjectKeyFromObject(searchIndex), &mdbv1.AtlasSearchIndex{})
+		assert.True(t, apiErrors.IsNotFound(err), "the resource should be deleted once the finalizer is removed")
+	})
+
+	t.Run("the index is kept in Atlas when the resource is protected", func(t *testing.T) {
+		searchIndex := testSearchIndex()
+		searchIndex.Finalizers = []string{customresource.FinalizerLabel}
+		searchIndex.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		searchIndex.Annotations = map[string]string{customresource.ResourcePolicyAnnotation: customresource.ResourcePolicyKeep}
+		searchAPI := &atlas_mock.SearchClientMock{}
+		r := testReconciler(t, mongodbatlas.Client{Search: searchAPI}, controllertest.Project(), controllertest.Deployment("my-deployment", "my-cluster"), searchIndex)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(searchIndex)})
+		require.NoError(t, err)
+
+		assert.Empty(t, searchAPI.DeleteIndexRequests)
+		err = r.Client.Get(context.Background(), kube.ObjectKeyFromObject(searchIndex), &mdbv1.AtlasSearchIndex{})
+		assert.True(t, apiErrors.IsNotFound(err), "the resource should be deleted once the finalizer is removed")
+	})
+
+	t.Run("the finalizer is removed when the deployment is gone", func(t *testing.T) {
+		searchIndex := testSearchIndex()
+		searchIndex.Finalizers = []string{customresource.FinalizerLabel}
+		searchIndex.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		r := testReconciler(t, mongodbatlas.Client{}, controllertest.Project(), searchIndex)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: kube.ObjectKeyFromObject(searchIndex)})
+		require.NoError(t, err)
+
+		err = r.Client.Get(context.Background(), kube.ObjectKeyFromObject(searchIndex), &mdbv1.AtlasSearchIndex{})
+		assert.True(t, apiErrors.IsNotFound(err), "the resource should be deleted once the finalizer is removed")
+	})
+}
+
+func testReconciler(t *testing.T, atlasClient mongodbatlas.Client, objects ...client.Object) *AtlasSearchIndexReconciler {
+	t.Helper()
+
+	return &AtlasSearchIndexReconciler{
+		Client:        controllertest.NewKubeClient(objects...),
+		Log:           zaptest.NewLogger(t).Sugar(),
+		EventRecorder: record.NewFakeRecorder(10),
+		AtlasProvider: controllertest.NewProvider(atlasClient),
+	}
+}
+
+func testSearchIndex() *mdbv1.AtlasSearchIndex {
+	return &mdbv1.AtlasSearchIndex{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-index", Namespace: controllertest.Namespace},
+		Spec: mdbv1.AtlasSearchIndexSpec{
+			DeploymentRef:  common.ResourceRefNamespaced{Name: "my-deployment"},
+			Name:           "title",
+			Database:       "sample_mflix",
+			CollectionName: "movies",
+			Mappings: mdbv1.SearchIndexMappings{
+				Fields: &apiextensionsv1.JSON{Raw: []byte(`{"title": {"type": "string"}}`)},
+			},
+		},
+	}
+}
+
+// atlasIndex returns the index of the resource as Atlas reports it
+func atlasIndex(t *testing.T, searchIndex *mdbv1.AtlasSearchIndex, indexStatus string) *mongodbatlas.SearchIndex {
+	t.Helper()
+
+	index, err := searchIndex.Spec.ToAtlas()
+	require.NoError(t, err)
+	index.IndexID = "my-index-id"
+	index.Analyzer = "lucene.standard"
+	index.SearchAnalyzer = "lucene.standard"
+	index.Status = indexStatus
+	return index
+}
@@ -0,0 +1,277 @@
+package atlassearchindex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/compat"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+const (
+	IndexStatusSteady = "STEADY"
+	IndexStatusFailed = "FAILED"
+)
+
+// indexInAtlas manages the search indexes of a deployment in Atlas
+type indexInAtlas struct {
+	workflowCtx *workflow.Context
+	projectID   string
+	clusterName string
+}
+
+func newIndexInAtlas(workflowCtx *workflow.Context, projectID, clusterName string) *indexInAtlas {
+	return &indexInAtlas{
+		workflowCtx: workflowCtx,
+		projectID:   projectID,
+		clusterName: clusterName,
+	}
+}
+
+// find returns the index of the resource in Atlas or nil if it doesn't exist. The index is looked up by the ID it was
+// created with and then by its name in the collection.
+func (i *indexInAtlas) find(searchIndex *mdbv1.AtlasSearchIndex) (*mongodbatlas.SearchIndex, error) {
+	ctx := i.workflowCtx.Context
+
+	if searchIndex.Status.IndexID != "" {
+		index, _, err := i.workflowCtx.Client.Search.GetIndex(ctx, i.projectID, i.clusterName, searchIndex.Status.IndexID)
+		if err == nil {
+			return index, nil
+		}
+		if !isNotFound(err) {
+			return nil, err
+		}
+	}
+
+	indexes, _, err := i.workflowCtx.Client.Search.ListIndexes(ctx, i.projectID, i.clusterName, searchIndex.Spec.Database, searchIndex.Spec.CollectionName, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, index := range indexes {
+		if index != nil && index.Name == searchIndex.Spec.Name {
+			return index, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// managedByAtlas returns true when the index exists in Atlas and differs from the resource, meaning it is managed
+// outside the operator
+func (i *indexInAtlas) managedByAtlas() customresource.AtlasChecker {
+	return func(resource mdbv1.AtlasCustomResource) (bool, error) {
+		searchIndex, ok := resource.(*mdbv1.AtlasSearchIndex)
+		if !ok {
+			return false, errors.New("failed to match resource type as AtlasSearchIndex")
+		}
+
+		current, err := i.find(searchIndex)
+		if err != nil || current == nil {
+			return false, err
+		}
+
+		changed, err := indexChanged(searchIndex, current)
+		if err != nil {
+			return false, err
+		}
+
+		return changed, nil
+	}
+}
+
+// ensure creates or updates the index in Atlas and reports its build status
+func (i *indexInAtlas) ensure(searchIndex *mdbv1.AtlasSearchIndex, dryRun bool) workflow.Result {
+	ctx := i.workflowCtx.Context
+
+	desired, err := searchIndex.Spec.ToAtlas()
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	current, err := i.find(searchIndex)
+	if err != nil {
+		return workflow.Terminate(workflow.SearchIndexNotCreatedInAtlas, fmt.Sprintf("failed to get the search index from Atlas: %s", err))
+	}
+
+	// The name and the collection of an index can't be changed, so the index is recreated when they are
+	if current != nil && !sameTarget(desired, current) {
+		i.workflowCtx.Log.Infow("Recreating the search index as its name or collection changed", "indexID", current.IndexID)
+		if _, err = i.workflowCtx.Client.Search.DeleteIndex(ctx, i.projectID, i.clusterName, current.IndexID); err != nil && !isNotFound(err) {
+			return workflow.Terminate(workflow.SearchIndexNotDeletedInAtlas, fmt.Sprintf("failed to delete the search index from Atlas: %s", err))
+		}
+		current = nil
+	}
+
+	switch {
+	case current == nil:
+		i.workflowCtx.Log.Infow("Creating the search index in Atlas", "name", desired.Name)
+		current, _, err = i.workflowCtx.Client.Search.CreateIndex(ctx, i.projectID, i.clusterName, desired)
+		if err != nil {
+			return workflow.Terminate(workflow.SearchIndexNotCreatedInAtlas, fmt.Sprintf("failed to create the search index in Atlas: %s", err))
+		}
+	default:
+		changed, err := indexChanged(searchIndex, current)
+		if err != nil {
+			return workflow.Terminate(workflow.Internal, err.Error())
+		}
+		if changed {
+			i.workflowCtx.Log.Infow("Updating the search index in Atlas", "indexID", current.IndexID)
+			desired.IndexID = current.IndexID
+			current, _, err = i.workflowCtx.Client.Search.UpdateIndex(ctx, i.projectID, i.clusterName, desired.IndexID, desired)
+			if err != nil {
+				return workflow.Terminate(workflow.SearchIndexNotUpdatedInAtlas, fmt.Sprintf("failed to update the search index in Atlas: %s", err))
+			}
+		}
+	}
+
+	// Nothing is applied to Atlas in dry-run mode, so there is no build status to report
+	if dryRun {
+		return workflow.OK()
+	}
+
+	i.workflowCtx.EnsureStatusOption(status.AtlasSearchIndexIDOption(current.IndexID))
+	i.workflowCtx.EnsureStatusOption(status.AtlasSearchIndexBuildStatusOption(current.Status))
+
+	switch current.Status {
+	case IndexStatusSteady:
+		return workflow.OK()
+	case IndexStatusFailed:
+		return workflow.Terminate(workflow.SearchIndexFailed, "the index failed to build in Atlas")
+	default:
+		return workflow.InProgress(workflow.SearchIndexBuilding, fmt.Sprintf("the index is %s in Atlas", current.Status))
+	}
+}
+
+// delete removes the index from Atlas, if it exists
+func (i *indexInAtlas) delete(searchIndex *mdbv1.AtlasSearchIndex) workflow.Result {
+	current, err := i.find(searchIndex)
+	if err != nil {
+		return workflow.Terminate(workflow.SearchIndexNotDeletedInAtlas, fmt.Sprintf("failed to get the search index from Atlas: %s", err))
+	}
+
+	if current == nil {
+		return workflow.OK()
+	}
+
+	i.workflowCtx.Log.Infow("Deleting the search index from Atlas", "indexID", current.IndexID)
+	if _, err = i.workflowCtx.Client.Search.DeleteIndex(i.workflowCtx.Context, i.projectID, i.clusterName, current.IndexID); err != nil && !isNotFound(err) {
+		return workflow.Terminate(workflow.SearchIndexNotDeletedInAtlas, fmt.Sprintf("failed to delete the search index from Atlas: %s", err))
+	}
+
+	return workflow.OK()
+}
+
+func sameTarget(desired, current *mongodbatlas.SearchIndex) bool {
+	return desired.Name == current.Name &&
+		desired.Database == current.Database &&
+		desired.CollectionName == current.CollectionName
+}
+
+// indexChanged compares the definition of the index in the resource with the one in Atlas. The analyzers left unset in
+// the resource take the value Atlas defaults them to.
+func indexChanged(searchIndex *mdbv1.AtlasSearchIndex, current *mongodbatlas.SearchIndex) (bool, error) {
+	desired, err := searchIndex.Spec.ToAtlas()
+	if err != nil {
+		return false, err
+	}
+
+	if desired.Analyzer == "" {
+		desired.Analyzer = current.Analyzer
+	}
+	if desired.SearchAnalyzer == "" {
+		desired.SearchAnalyzer = current.SearchAnalyzer
+	}
+
+	desiredDefinition, err := definition(desired)
+	if err != nil {
+		return false, err
+	}
+
+	currentDefinition, err := definition(current)
+	if err != nil {
+		return false, err
+	}
+
+	return !reflect.DeepEqual(desiredDefinition, currentDefinition), nil
+}
+
+// definition returns the updatable part of the index in a normalized form
+func definition(index *mongodbatlas.SearchIndex) (map[string]interface{}, error) {
+	def := map[string]interface{}{}
+	err := compat.JSONCopy(&def, &mongodbatlas.SearchIndex{
+		Analyzer:       index.Analyzer,
+		SearchAnalyzer: index.SearchAnalyzer,
+		Mappings:       index.Mappings,
+		Synonyms:       index.Synonyms,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize the search index definition: %w", err)
+	}
+
+	return def, nil
+}
+
+func isNotFound(err error) bool {
+	var apiError *mongodbatlas.ErrorResponse
+	return errors.As(err, &apiError) && apiError.HTTPCode == http.StatusNotFound
+}
+
+// deploymentHandler enqueues the search indexes referencing an AtlasDeployment when it changes
+func (r *AtlasSearchIndexReconciler) deploymentHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		deployment, ok := obj.(*mdbv1.AtlasDeployment)
+		if !ok {
+			return nil
+		}
+
+		searchIndexes := &mdbv1.AtlasSearchIndexList{}
+		if err := r.Client.List(context.Background(), searchIndexes); err != nil {
+			r.Log.Errorf("failed to list the AtlasSearchIndexes of the deployment %s: %s", kube.ObjectKeyFromObject(deployment), err)
+			return nil
+		}
+
+		deploymentKey := kube.ObjectKeyFromObject(deployment)
+		var requests []reconcile.Request
+		for i := range searchIndexes.Items {
+			if searchIndexes.Items[i].AtlasDeploymentObjectKey() == deploymentKey {
+				requests = append(requests, reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(&searchIndexes.Items[i])})
+			}
+		}
+		return requests
+	})
+}
+
+// deploymentPredicate filters out the deployment changes which don't affect the search indexes: the ones which neither
+// change the state of the deployment in Atlas nor start its deletion.
+func deploymentPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldDeployment, okOld := e.ObjectOld.(*mdbv1.AtlasDeployment)
+			newDeployment, okNew := e.ObjectNew.(*mdbv1.AtlasDeployment)
+			if !okOld || !okNew {
+				return false
+			}
+
+			return oldDeployment.Status.StateName != newDeployment.Status.StateName ||
+				oldDeployment.GetDeletionTimestamp().IsZero() != newDeployment.GetDeletionTimestamp().IsZero()
+		},
+	}
+}
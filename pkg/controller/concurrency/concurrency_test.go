@@ -0,0 +1,97 @@
+package concurrency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestParseWorkers(t *testing.T) {
+	kinds := []string{"AtlasProject", "AtlasDatabaseUser", "AtlasDeployment"}
+
+	t.Run("the default number and the overrides per kind are parsed", func(t *testing.T) {
+		workers, err := ParseWorkers("4, AtlasDatabaseUser=16,AtlasDeployment=2", kinds)
+		require.NoError(t, err)
+		assert.Equal(t, 4, workers.For("AtlasProject"))
+		assert.Equal(t, 16, workers.For("AtlasDatabaseUser"))
+		assert.Equal(t, 2, workers.For("AtlasDeployment"))
+	})
+
+	t.Run("an empty value keeps a single worker", func(t *testing.T) {
+		workers, err := ParseWorkers("", kinds)
+		require.NoError(t, err)
+		assert.Equal(t, 1, workers.For("AtlasProject"))
+	})
+
+	t.Run("invalid numbers are rejected", func(t *testing.T) {
+		for _, value := range []string{"0", "AtlasDeployment=-1", "AtlasDeployment=many"} {
+			_, err := ParseWorkers(value, kinds)
+			assert.Error(t, err, value)
+		}
+	})
+
+	t.Run("unknown kinds are rejected", func(t *testing.T) {
+		_, err := ParseWorkers("4,AtlasDatabaseUsers=16", kinds)
+		assert.EqualError(t, err, `invalid number of workers "AtlasDatabaseUsers=16": unknown kind "AtlasDatabaseUsers", must be one of AtlasProject, AtlasDatabaseUser, AtlasDeployment`)
+	})
+}
+
+func TestProjectLocks(t *testing.T) {
+	project := client.ObjectKey{Name: "my-project", Namespace: "ns"}
+	otherProject := client.ObjectKey{Name: "other-project", Namespace: "ns"}
+
+	t.Run("the reconciliations of the same project are serialized", func(t *testing.T) {
+		locks := NewProjectLocks()
+		unlock, err := locks.Lock(context.Background(), project)
+		require.NoError(t, err)
+
+		unlockOther, err := locks.Lock(context.Background(), otherProject)
+		require.NoError(t, err)
+		unlockOther()
+
+		acquired := make(chan struct{})
+		go func() {
+			unlockSecond, err := locks.Lock(context.Background(), project)
+			assert.NoError(t, err)
+			close(acquired)
+			unlockSecond()
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("the lock of the project should be held")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		unlock()
+		<-acquired
+		assert.Eventually(t, func() bool {
+			locks.mu.Lock()
+			defer locks.mu.Unlock()
+			return len(locks.locks) == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("waiting for the lock stops with the context", func(t *testing.T) {
+		locks := NewProjectLocks()
+		unlock, err := locks.Lock(context.Background(), project)
+		require.NoError(t, err)
+		defer unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = locks.Lock(ctx, project)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("nil locks don't serialize", func(t *testing.T) {
+		var locks *ProjectLocks
+		unlock, err := locks.Lock(context.Background(), project)
+		require.NoError(t, err)
+		unlock()
+	})
+}
@@ -0,0 +1,71 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ProjectLocks serializes the reconciliations touching the same Atlas project across the controllers sharing its
+// settings, so that concurrent reconciliations don't race on the project sub-resources (IP Access List, teams, etc.).
+// A nil *ProjectLocks doesn't serialize anything.
+type ProjectLocks struct {
+	mu    sync.Mutex
+	locks map[client.ObjectKey]*projectLock
+}
+
+type projectLock struct {
+	held chan struct{}
+	// refs is the number of reconciliations holding or waiting for the lock
+	refs int
+}
+
+func NewProjectLocks() *ProjectLocks {
+	return &ProjectLocks{locks: map[client.ObjectKey]*projectLock{}}
+}
+
+// Lock blocks until no other reconciliation holds the lock of the AtlasProject, or until the context is done.
+// The returned function releases the lock.
+func (l *ProjectLocks) Lock(ctx context.Context, project client.ObjectKey) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	lock := l.acquire(project)
+	select {
+	case lock.held <- struct{}{}:
+		return func() {
+			<-lock.held
+			l.release(project)
+		}, nil
+	case <-ctx.Done():
+		l.release(project)
+		return nil, ctx.Err()
+	}
+}
+
+func (l *ProjectLocks) acquire(project client.ObjectKey) *projectLock {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock, ok := l.locks[project]
+	if !ok {
+		lock = &projectLock{held: make(chan struct{}, 1)}
+		l.locks[project] = lock
+	}
+	lock.refs++
+	return lock
+}
+
+// release forgets the lock once no reconciliation needs it, so that the locks of deleted projects don't pile up
+func (l *ProjectLocks) release(project client.ObjectKey) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock := l.locks[project]
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, project)
+	}
+}
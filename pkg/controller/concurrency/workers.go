@@ -0,0 +1,64 @@
+package concurrency
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+const DefaultWorkers = 1
+
+// Workers is the number of reconciliations each controller runs concurrently
+type Workers struct {
+	Default int
+	// PerKind overrides the default number for the controllers of the given kinds, e.g. "AtlasDatabaseUser"
+	PerKind map[string]int
+}
+
+// ParseWorkers parses a comma separated list of numbers of workers. An entry without a kind sets the default
+// number, e.g. "4,AtlasDatabaseUser=16,AtlasDeployment=2". The kinds must be among the kinds of the controllers.
+func ParseWorkers(value string, kinds []string) (Workers, error) {
+	workers := Workers{Default: DefaultWorkers, PerKind: map[string]int{}}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		kind, number, hasKind := strings.Cut(entry, "=")
+		if !hasKind {
+			kind, number = "", entry
+		}
+
+		count, err := strconv.Atoi(strings.TrimSpace(number))
+		if err != nil || count < 1 {
+			return Workers{}, fmt.Errorf("invalid number of workers %q: must be a positive integer", entry)
+		}
+
+		kind = strings.TrimSpace(kind)
+		switch {
+		case kind == "":
+			workers.Default = count
+		case !slices.Contains(kinds, kind):
+			return Workers{}, fmt.Errorf("invalid number of workers %q: unknown kind %q, must be one of %s", entry, kind, strings.Join(kinds, ", "))
+		default:
+			workers.PerKind[kind] = count
+		}
+	}
+
+	return workers, nil
+}
+
+// For returns the number of workers of the controller of the kind
+func (w Workers) For(kind string) int {
+	if count, ok := w.PerKind[kind]; ok {
+		return count
+	}
+	if w.Default < 1 {
+		return DefaultWorkers
+	}
+	return w.Default
+}
@@ -69,6 +69,15 @@ type deploymentSecret struct {
 }
 
 func createOrUpdateConnectionSecretsFromDeploymentSecrets(ctx *workflow.Context, k8sClient client.Client, recorder record.EventRecorder, project mdbv1.AtlasProject, dbUser mdbv1.AtlasDatabaseUser, deploymentSecrets []deploymentSecret) workflow.Result {
+	// Nobody connects as an LDAP or OIDC group, so the Secrets the user had before becoming a group are removed
+	if dbUser.Spec.IsGroup() {
+		ctx.Log.Debugw("The database user is a group - not creating connection Secrets", "username", dbUser.Spec.Username)
+		if err := RemoveStaleSecretsByUserName(k8sClient, project.ID(), dbUser.Spec.Username, dbUser, ctx.Log); err != nil {
+			return workflow.Terminate(workflow.DatabaseUserStaleConnectionSecrets, err.Error())
+		}
+		return workflow.OK()
+	}
+
 	requeue := false
 	secrets := make([]string, 0)
 
@@ -89,15 +98,16 @@ func createOrUpdateConnectionSecretsFromDeploymentSecrets(ctx *workflow.Context,
 			return workflow.Terminate(workflow.DatabaseUserConnectionSecretsNotCreated, err.Error())
 		}
 		data := ConnectionData{
-			DBUserName: dbUser.Spec.Username,
-			Password:   password,
-			ConnURL:    ds.connectionStrings.Standard,
-			SrvConnURL: ds.connectionStrings.StandardSrv,
+			DBUserName:    dbUser.Spec.Username,
+			Password:      password,
+			AuthMechanism: AuthMechanism(dbUser),
+			ConnURL:       ds.connectionStrings.Standard,
+			SrvConnURL:    ds.connectionStrings.StandardSrv,
 		}
 		FillPrivateConnStrings(ds.connectionStrings, &data)
 
 		var secretName string
-		if secretName, err = Ensure(k8sClient, dbUser.Namespace, project.Spec.Name, project.ID(), ds.name, data); err != nil {
+		if secretName, err = Ensure(k8sClient, dbUser.Namespace, project.Spec.Name, project.ID(), ds.name, data, dbUser.Spec.ConnectionSecretTemplate); err != nil {
 			return workflow.Terminate(workflow.DatabaseUserConnectionSecretsNotCreated, err.Error())
 		}
 		secrets = append(secrets, secretName)
@@ -10,6 +10,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
 )
 
@@ -26,11 +27,21 @@ const (
 	privateShardKey string = "connectionStringPrivateShard"
 	userNameKey     string = "username"
 	passwordKey     string = "password"
+	authMechanism   string = "authMechanism"
+)
+
+const (
+	AWSIAMAuthMechanism = "MONGODB-AWS"
+	LDAPAuthMechanism   = "PLAIN"
+	OIDCAuthMechanism   = "MONGODB-OIDC"
 )
 
 type ConnectionData struct {
-	DBUserName      string
-	Password        string
+	DBUserName string
+	Password   string
+	// AuthMechanism is set for the users authenticated outside of Atlas. The connection strings of such users carry
+	// the mechanism instead of the credentials.
+	AuthMechanism   string
 	ConnURL         string
 	SrvConnURL      string
 	PrivateConnURLs []PrivateLinkConnURLs
@@ -42,48 +53,85 @@ type PrivateLinkConnURLs struct {
 	PvtShardConnURL string
 }
 
-// Ensure creates or updates the connection Secret for the specific cluster and db user. Returns the name of the Secret
-// created.
-func Ensure(client client.Client, namespace, projectName, projectID, clusterName string, data ConnectionData) (string, error) {
+// Ensure creates or updates the connection Secret for the specific cluster and db user. The Secret is formatted with
+// the template if one is provided. Returns the name of the Secret created.
+func Ensure(client client.Client, namespace, projectName, projectID, clusterName string, data ConnectionData, template *mdbv1.ConnectionSecretTemplate) (string, error) {
+	var err error
+	name := formatSecretName(projectName, clusterName, data.DBUserName)
+	templatedName := template != nil && template.Name != ""
+	if templatedName {
+		if name, err = renderSecretName(template, newTemplateData(projectName, projectID, clusterName, data)); err != nil {
+			return "", err
+		}
+	}
+
 	var getError error
 	s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
-		Name:      formatSecretName(projectName, clusterName, data.DBUserName),
+		Name:      name,
 		Namespace: namespace,
 	}}
 	if getError = client.Get(context.Background(), kube.ObjectKeyFromObject(s), s); getError != nil && !apiErrors.IsNotFound(getError) {
 		return "", getError
 	}
-	if err := fillSecret(s, projectID, clusterName, data); err != nil {
+	// A templated name may clash with a Secret the operator doesn't own, which must be left untouched
+	if getError == nil && templatedName && s.Labels[TypeLabelKey] != CredLabelVal {
+		return "", fmt.Errorf("secret %s already exists and is not a connection secret", name)
+	}
+	if err = fillSecret(s, projectName, projectID, clusterName, data, template); err != nil {
 		return "", err
 	}
 	if getError != nil {
 		// Creating
-		return s.Name, client.Create(context.Background(), s)
+		err = client.Create(context.Background(), s)
+	} else {
+		err = client.Update(context.Background(), s)
+	}
+	if err != nil {
+		return "", err
 	}
 
-	return s.Name, client.Update(context.Background(), s)
+	return s.Name, removeRenamedSecrets(client, s, projectID, clusterName, data.DBUserName)
 }
 
-func fillSecret(secret *corev1.Secret, projectID string, clusterName string, data ConnectionData) error {
+// removeRenamedSecrets removes the other connection Secrets of the user for the deployment. They're left behind when
+// the Secret name changes, for example when the name template is changed or removed.
+func removeRenamedSecrets(k8sClient client.Client, secret *corev1.Secret, projectID, clusterName, userName string) error {
+	secrets, err := ListByDeploymentName(k8sClient, secret.Namespace, projectID, clusterName)
+	if err != nil {
+		return err
+	}
+	for i, s := range secrets {
+		if s.Name == secret.Name || string(s.Data[userNameKey]) != userName {
+			continue
+		}
+		if err = k8sClient.Delete(context.Background(), &secrets[i]); err != nil && !apiErrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func fillSecret(secret *corev1.Secret, projectName, projectID, clusterName string, data ConnectionData, template *mdbv1.ConnectionSecretTemplate) error {
 	var err error
-	if data.ConnURL, err = AddCredentialsToConnectionURL(data.ConnURL, data.DBUserName, data.Password); err != nil {
+	if data.ConnURL, err = authenticateConnectionURL(data.ConnURL, data); err != nil {
 		return err
 	}
-	if data.SrvConnURL, err = AddCredentialsToConnectionURL(data.SrvConnURL, data.DBUserName, data.Password); err != nil {
+	if data.SrvConnURL, err = authenticateConnectionURL(data.SrvConnURL, data); err != nil {
 		return err
 	}
 	for idx, privateConn := range data.PrivateConnURLs {
-		if data.PrivateConnURLs[idx].PvtConnURL, err = AddCredentialsToConnectionURL(privateConn.PvtConnURL, data.DBUserName, data.Password); err != nil {
+		if data.PrivateConnURLs[idx].PvtConnURL, err = authenticateConnectionURL(privateConn.PvtConnURL, data); err != nil {
 			return err
 		}
-		if data.PrivateConnURLs[idx].PvtSrvConnURL, err = AddCredentialsToConnectionURL(privateConn.PvtSrvConnURL, data.DBUserName, data.Password); err != nil {
+		if data.PrivateConnURLs[idx].PvtSrvConnURL, err = authenticateConnectionURL(privateConn.PvtSrvConnURL, data); err != nil {
 			return err
 		}
-		if data.PrivateConnURLs[idx].PvtShardConnURL, err = AddCredentialsToConnectionURL(privateConn.PvtShardConnURL, data.DBUserName, data.Password); err != nil {
+		if data.PrivateConnURLs[idx].PvtShardConnURL, err = authenticateConnectionURL(privateConn.PvtShardConnURL, data); err != nil {
 			return err
 		}
 	}
 
+	removeTemplateAnnotations(secret)
 	secret.Labels = map[string]string{
 		TypeLabelKey:    CredLabelVal,
 		ProjectLabelKey: projectID,
@@ -99,6 +147,14 @@ func fillSecret(secret *corev1.Secret, projectID string, clusterName string, dat
 		privateKeySrv:  []byte(""),
 	}
 
+	if data.AuthMechanism != "" {
+		// LDAP users authenticate with their LDAP password, if one is provided
+		if data.AuthMechanism != LDAPAuthMechanism || data.Password == "" {
+			delete(secret.Data, passwordKey)
+		}
+		secret.Data[authMechanism] = []byte(data.AuthMechanism)
+	}
+
 	for idx, privateConn := range data.PrivateConnURLs {
 		suffix := getSuffix(idx)
 		secret.Data[privateKey+suffix] = []byte(privateConn.PvtConnURL)
@@ -106,6 +162,10 @@ func fillSecret(secret *corev1.Secret, projectID string, clusterName string, dat
 		secret.Data[privateShardKey+suffix] = []byte(privateConn.PvtShardConnURL)
 	}
 
+	if template != nil {
+		return applyTemplate(secret, template, newTemplateData(projectName, projectID, clusterName, data))
+	}
+
 	return nil
 }
 
@@ -125,6 +185,70 @@ func formatSecretName(projectName, clusterName, dbUserName string) string {
 	return kube.NormalizeIdentifier(name)
 }
 
+func authenticateConnectionURL(connURL string, data ConnectionData) (string, error) {
+	switch data.AuthMechanism {
+	case "":
+		return AddCredentialsToConnectionURL(connURL, data.DBUserName, data.Password)
+	case LDAPAuthMechanism:
+		// PLAIN sends the LDAP credentials to the server, so they must be part of the connection string
+		authURL, err := AddAuthMechanismToConnectionURL(connURL, data.AuthMechanism)
+		if err != nil || authURL == "" {
+			return authURL, err
+		}
+		if data.Password == "" {
+			return addUserToConnectionURL(authURL, data.DBUserName)
+		}
+		return AddCredentialsToConnectionURL(authURL, data.DBUserName, data.Password)
+	default:
+		return AddAuthMechanismToConnectionURL(connURL, data.AuthMechanism)
+	}
+}
+
+// addUserToConnectionURL sets the username of the connection URL, leaving the password to the client
+func addUserToConnectionURL(connURL, userName string) (string, error) {
+	cs, err := url.Parse(connURL)
+	if err != nil {
+		return "", err
+	}
+	cs.User = url.User(userName)
+	return cs.String(), nil
+}
+
+// AuthMechanism returns the authentication mechanism the clients of the database user must use. It is empty for
+// SCRAM users, which authenticate with the username and password kept in the connection string, and for X.509 users
+// whose connection Secrets keep the same keys as the ones of the SCRAM users.
+func AuthMechanism(dbUser mdbv1.AtlasDatabaseUser) string {
+	authType, _ := dbUser.Spec.AuthType()
+	switch authType {
+	case "awsIAMType":
+		return AWSIAMAuthMechanism
+	case "ldapAuthType":
+		return LDAPAuthMechanism
+	case "oidcAuthType":
+		return OIDCAuthMechanism
+	default:
+		return ""
+	}
+}
+
+// AddAuthMechanismToConnectionURL sets the authentication source and mechanism for the users authenticated outside
+// of Atlas. The credentials are removed from the connection URL as these are provided by the client environment.
+func AddAuthMechanismToConnectionURL(connURL, mechanism string) (string, error) {
+	if connURL == "" {
+		return "", nil
+	}
+	cs, err := url.Parse(connURL)
+	if err != nil {
+		return "", err
+	}
+	cs.User = nil
+	query := cs.Query()
+	query.Set("authSource", mdbv1.ExternalAuthDatabase)
+	query.Set("authMechanism", mechanism)
+	cs.RawQuery = query.Encode()
+	return cs.String(), nil
+}
+
 func AddCredentialsToConnectionURL(connURL, userName, password string) (string, error) {
 	cs, err := url.Parse(connURL)
 	if err != nil {
@@ -23,31 +23,31 @@ func TestListConnectionSecrets(t *testing.T) {
 		// c1, user1
 		data := dataForSecret()
 		data.DBUserName = "user1"
-		_, err := Ensure(fakeClient, "testNs", "p1", "603e7bf38a94956835659ae5", "c1", data)
+		_, err := Ensure(fakeClient, "testNs", "p1", "603e7bf38a94956835659ae5", "c1", data, nil)
 		assert.NoError(t, err)
 
 		// c1, user2
 		data = dataForSecret()
 		data.DBUserName = "user2"
-		_, err = Ensure(fakeClient, "testNs", "p1", "603e7bf38a94956835659ae5", "c1", data)
+		_, err = Ensure(fakeClient, "testNs", "p1", "603e7bf38a94956835659ae5", "c1", data, nil)
 		assert.NoError(t, err)
 
 		// c2, user1
 		data = dataForSecret()
 		data.DBUserName = "user1"
-		_, err = Ensure(fakeClient, "testNs", "p1", "603e7bf38a94956835659ae5", "c2", data)
+		_, err = Ensure(fakeClient, "testNs", "p1", "603e7bf38a94956835659ae5", "c2", data, nil)
 		assert.NoError(t, err)
 
 		// c1, user1 but different project (p2)
 		data = dataForSecret()
 		data.DBUserName = "user1"
-		_, err = Ensure(fakeClient, "testNs", "p2", "some-other-project-id", "c1", data)
+		_, err = Ensure(fakeClient, "testNs", "p2", "some-other-project-id", "c1", data, nil)
 		assert.NoError(t, err)
 
 		// c1, user1 but different namespace
 		data = dataForSecret()
 		data.DBUserName = "user1"
-		_, err = Ensure(fakeClient, "otherNs", "p1", "603e7bf38a94956835659ae5", "c1", data)
+		_, err = Ensure(fakeClient, "otherNs", "p1", "603e7bf38a94956835659ae5", "c1", data, nil)
 		assert.NoError(t, err)
 
 		secrets, err := ListByDeploymentName(fakeClient, "testNs", "603e7bf38a94956835659ae5", "c1")
@@ -83,7 +83,7 @@ func TestListConnectionSecrets(t *testing.T) {
 
 		data := dataForSecret()
 		data.DBUserName = "user1"
-		_, err := Ensure(fakeClient, "testNs", "#nice project!", "603e7bf38a94956835659ae5", "the cluster@thecompany.com/", data)
+		_, err := Ensure(fakeClient, "testNs", "#nice project!", "603e7bf38a94956835659ae5", "the cluster@thecompany.com/", data, nil)
 		assert.NoError(t, err)
 
 		secrets, err := ListByDeploymentName(fakeClient, "testNs", "603e7bf38a94956835659ae5", "the cluster@thecompany.com/")
@@ -0,0 +1,115 @@
+package connectionsecret
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+)
+
+// TemplateData is the data the connection Secret templates are rendered over. The connection strings already
+// contain the credentials of the user.
+type TemplateData struct {
+	ConnectionData
+	ProjectName string
+	ProjectID   string
+	ClusterName string
+}
+
+func newTemplateData(projectName, projectID, clusterName string, data ConnectionData) TemplateData {
+	return TemplateData{
+		ConnectionData: data,
+		ProjectName:    projectName,
+		ProjectID:      projectID,
+		ClusterName:    clusterName,
+	}
+}
+
+// TemplateAnnotationsKey lists the annotations of the Secret added by the template, so that they're removed once
+// they're removed from the template.
+const TemplateAnnotationsKey = "atlas.mongodb.com/template-annotations"
+
+func renderTemplate(t *template.Template, data TemplateData) (string, error) {
+	var rendered strings.Builder
+	if err := t.Execute(&rendered, data); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+func renderSecretName(tmpl *mdbv1.ConnectionSecretTemplate, data TemplateData) (string, error) {
+	t, err := tmpl.ParseName()
+	if err != nil {
+		return "", err
+	}
+	name, err := renderTemplate(t, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to render the connection secret name: %w", err)
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("rendered connection secret name %q is invalid: %s", name, strings.Join(errs, ", "))
+	}
+	return name, nil
+}
+
+// applyTemplate adds the labels and annotations of the template to the Secret and replaces the default data with
+// the rendered one. The labels the operator tracks the connection Secrets by can't be overridden.
+func applyTemplate(secret *corev1.Secret, tmpl *mdbv1.ConnectionSecretTemplate, data TemplateData) error {
+	for key, value := range tmpl.Labels {
+		if _, reserved := secret.Labels[key]; !reserved {
+			secret.Labels[key] = value
+		}
+	}
+
+	if len(tmpl.Annotations) > 0 {
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		keys := make([]string, 0, len(tmpl.Annotations))
+		for key, value := range tmpl.Annotations {
+			secret.Annotations[key] = value
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		secret.Annotations[TemplateAnnotationsKey] = strings.Join(keys, ",")
+	}
+
+	if len(tmpl.Data) == 0 {
+		return nil
+	}
+
+	templates, err := tmpl.ParseData()
+	if err != nil {
+		return err
+	}
+	rendered := make(map[string][]byte, len(templates)+1)
+	for key, t := range templates {
+		value, err := renderTemplate(t, data)
+		if err != nil {
+			return fmt.Errorf("failed to render the connection secret key %s: %w", key, err)
+		}
+		rendered[key] = []byte(value)
+	}
+	rendered[userNameKey] = []byte(data.DBUserName)
+	secret.Data = rendered
+
+	return nil
+}
+
+// removeTemplateAnnotations removes the annotations previously added to the Secret by the template. The annotations
+// added by others are kept.
+func removeTemplateAnnotations(secret *corev1.Secret) {
+	keys, ok := secret.Annotations[TemplateAnnotationsKey]
+	if !ok {
+		return
+	}
+	for _, key := range strings.Split(keys, ",") {
+		delete(secret.Annotations, key)
+	}
+	delete(secret.Annotations, TemplateAnnotationsKey)
+}
@@ -0,0 +1,159 @@
+package connectionsecret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+func TestEnsureWithTemplate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	t.Run("Secret formatted by the template", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		template := &mdbv1.ConnectionSecretTemplate{
+			Name:        "{{ .ClusterName }}-{{ .DBUserName }}",
+			Labels:      map[string]string{"app": "my-app", ProjectLabelKey: "overridden"},
+			Annotations: map[string]string{"reloader.stakater.com/match": "true"},
+			Data: map[string]string{
+				"MONGODB_URI":            "{{ .SrvConnURL }}",
+				"application.properties": "spring.data.mongodb.uri={{ .SrvConnURL }}\nspring.data.mongodb.database={{ .ProjectName }}",
+			},
+		}
+
+		name, err := Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", dataForSecret(), template)
+		require.NoError(t, err)
+		assert.Equal(t, "cluster1-admin", name)
+
+		secret := corev1.Secret{}
+		require.NoError(t, fakeClient.Get(context.Background(), kube.ObjectKey("testNs", name), &secret))
+		srvURL := buildConnectionURL(dataForSecret().SrvConnURL, "admin", "m@gick%")
+		assert.Equal(t, map[string][]byte{
+			"MONGODB_URI":            []byte(srvURL),
+			"application.properties": []byte("spring.data.mongodb.uri=" + srvURL + "\nspring.data.mongodb.database=project1"),
+			"username":               []byte("admin"),
+		}, secret.Data)
+		assert.Equal(t, map[string]string{
+			TypeLabelKey:    CredLabelVal,
+			ProjectLabelKey: "603e7bf38a94956835659ae5",
+			ClusterLabelKey: "cluster1",
+			"app":           "my-app",
+		}, secret.Labels)
+		assert.Equal(t, map[string]string{"reloader.stakater.com/match": "true", TemplateAnnotationsKey: "reloader.stakater.com/match"}, secret.Annotations)
+
+		// The secret is still found by the user name
+		secrets, err := ListByUserName(fakeClient, "testNs", "603e7bf38a94956835659ae5", "admin")
+		require.NoError(t, err)
+		assert.Len(t, secrets, 1)
+	})
+
+	t.Run("Default data with labels only", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		template := &mdbv1.ConnectionSecretTemplate{Labels: map[string]string{"app": "my-app"}}
+		data := dataForSecret()
+
+		_, err := Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", data, template)
+		require.NoError(t, err)
+
+		secret := corev1.Secret{}
+		require.NoError(t, fakeClient.Get(context.Background(), kube.ObjectKey("testNs", "project1-cluster1-admin"), &secret))
+		assert.Equal(t, "my-app", secret.Labels["app"])
+		assert.Equal(t, []byte(data.Password), secret.Data["password"])
+	})
+
+	t.Run("Invalid rendered name", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		template := &mdbv1.ConnectionSecretTemplate{Name: "{{ .ClusterName }}_{{ .DBUserName }}"}
+
+		_, err := Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", dataForSecret(), template)
+		assert.ErrorContains(t, err, `rendered connection secret name "cluster1_admin" is invalid`)
+	})
+
+	t.Run("Unknown template field", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		template := &mdbv1.ConnectionSecretTemplate{Data: map[string]string{"uri": "{{ .Unknown }}"}}
+
+		_, err := Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", dataForSecret(), template)
+		assert.ErrorContains(t, err, "failed to render the connection secret key uri")
+	})
+
+	t.Run("Secret not owned by the operator is left untouched", func(t *testing.T) {
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster1-admin", Namespace: "testNs"},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+		template := &mdbv1.ConnectionSecretTemplate{Name: "{{ .ClusterName }}-{{ .DBUserName }}"}
+
+		_, err := Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", dataForSecret(), template)
+		assert.EqualError(t, err, "secret cluster1-admin already exists and is not a connection secret")
+
+		secret := corev1.Secret{}
+		require.NoError(t, fakeClient.Get(context.Background(), kube.ObjectKey("testNs", "cluster1-admin"), &secret))
+		assert.Equal(t, existing.Data, secret.Data)
+	})
+
+	t.Run("Name template not referencing the user", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		template := &mdbv1.ConnectionSecretTemplate{Name: "{{ .ClusterName }}-app"}
+
+		_, err := Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", dataForSecret(), template)
+		assert.ErrorContains(t, err, "must reference both {{ .DBUserName }} and {{ .ClusterName }}")
+	})
+
+	t.Run("Secret renamed by the template is removed once the template is removed", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		template := &mdbv1.ConnectionSecretTemplate{Name: "{{ .ClusterName }}-{{ .DBUserName }}"}
+
+		templated, err := Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", dataForSecret(), template)
+		require.NoError(t, err)
+		other, err := Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster2", dataForSecret(), template)
+		require.NoError(t, err)
+
+		name, err := Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", dataForSecret(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "project1-cluster1-admin", name)
+
+		secrets := corev1.SecretList{}
+		require.NoError(t, fakeClient.List(context.Background(), &secrets))
+		names := make([]string, 0, len(secrets.Items))
+		for _, secret := range secrets.Items {
+			names = append(names, secret.Name)
+		}
+		assert.ElementsMatch(t, []string{name, other}, names, "the Secret %s should be removed", templated)
+	})
+
+	t.Run("Annotations removed from the template are removed from the Secret", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		template := &mdbv1.ConnectionSecretTemplate{Annotations: map[string]string{"a": "1", "b": "2"}}
+
+		name, err := Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", dataForSecret(), template)
+		require.NoError(t, err)
+		secret := corev1.Secret{}
+		require.NoError(t, fakeClient.Get(context.Background(), kube.ObjectKey("testNs", name), &secret))
+		secret.Annotations["other"] = "kept"
+		require.NoError(t, fakeClient.Update(context.Background(), &secret))
+
+		template.Annotations = map[string]string{"b": "2"}
+		_, err = Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", dataForSecret(), template)
+		require.NoError(t, err)
+		require.NoError(t, fakeClient.Get(context.Background(), kube.ObjectKey("testNs", name), &secret))
+		assert.Equal(t, map[string]string{"b": "2", "other": "kept", TemplateAnnotationsKey: "b"}, secret.Annotations)
+
+		_, err = Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", dataForSecret(), nil)
+		require.NoError(t, err)
+		require.NoError(t, fakeClient.Get(context.Background(), kube.ObjectKey("testNs", name), &secret))
+		assert.Equal(t, map[string]string{"other": "kept"}, secret.Annotations)
+	})
+}
@@ -26,6 +26,7 @@ const (
 	ReconciliationPolicyAnnotation = "mongodb.com/atlas-reconciliation-policy"
 	ResourceVersion                = "mongodb.com/atlas-resource-version"
 	ResourceVersionOverride        = "mongodb.com/atlas-resource-version-policy"
+	DryRunAnnotation               = "mongodb.com/atlas-dry-run"
 	ResourcePolicyKeep             = "keep"
 	ResourcePolicyDelete           = "delete"
 	ReconciliationPolicySkip       = "skip"
@@ -138,6 +139,15 @@ func ReconciliationShouldBeSkipped(resource mdbv1.AtlasCustomResource) bool {
 	return false
 }
 
+// IsDryRun returns 'true' if the changes to Atlas must only be planned for this resource, not applied.
+// The annotation takes precedence over the Operator wide setting, so a single resource can also opt out of dry-run.
+func IsDryRun(resource mdbv1.AtlasCustomResource, dryRunByDefault bool) bool {
+	if v, ok := resource.GetAnnotations()[DryRunAnnotation]; ok {
+		return strings.EqualFold(v, "true")
+	}
+	return dryRunByDefault
+}
+
 // SetAnnotation sets an annotation in resource while respecting the rest of annotations.
 func SetAnnotation(resource mdbv1.AtlasCustomResource, key, value string) {
 	annot := resource.GetAnnotations()
@@ -173,8 +183,14 @@ func IsResourceSupportedInDomain(resource mdbv1.AtlasCustomResource, domain stri
 		*mdbv1.AtlasTeam,
 		*mdbv1.AtlasBackupSchedule,
 		*mdbv1.AtlasBackupPolicy,
+		*mdbv1.AtlasBackupSnapshot,
+		*mdbv1.AtlasBackupRestoreJob,
 		*mdbv1.AtlasDatabaseUser,
-		*mdbv1.AtlasFederatedAuth:
+		*mdbv1.AtlasFederatedAuth,
+		*mdbv1.AtlasIPAccessList,
+		*mdbv1.AtlasNetworkPeering,
+		*mdbv1.AtlasPrivateEndpoint,
+		*mdbv1.AtlasSearchIndex:
 		return true
 	case *mdbv1.AtlasDataFederation:
 		return false
@@ -81,6 +81,37 @@ func TestReconciliationShouldBeSkipped(t *testing.T) {
 	})
 }
 
+func TestIsDryRun(t *testing.T) {
+	newResourceTypes := func() []v1.AtlasCustomResource {
+		return []v1.AtlasCustomResource{
+			&v1.AtlasDeployment{},
+			&v1.AtlasDatabaseUser{},
+			&v1.AtlasProject{},
+		}
+	}
+
+	t.Run("No annotation, the Operator setting is used", func(t *testing.T) {
+		for _, resourceType := range newResourceTypes() {
+			assert.False(t, IsDryRun(resourceType, false))
+			assert.True(t, IsDryRun(resourceType, true))
+		}
+	})
+
+	t.Run("Annotation enables dry-run", func(t *testing.T) {
+		for _, resourceType := range newResourceTypes() {
+			resourceType.SetAnnotations(map[string]string{DryRunAnnotation: "true"})
+			assert.True(t, IsDryRun(resourceType, false))
+		}
+	})
+
+	t.Run("Annotation disables dry-run", func(t *testing.T) {
+		for _, resourceType := range newResourceTypes() {
+			resourceType.SetAnnotations(map[string]string{DryRunAnnotation: "false"})
+			assert.False(t, IsDryRun(resourceType, true))
+		}
+	})
+}
+
 func TestResourceVersionIsValid(t *testing.T) {
 	tests := []struct {
 		name            string
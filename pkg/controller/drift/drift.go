@@ -0,0 +1,105 @@
+package drift
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+// Policy defines what the Operator does when the Atlas resource was changed outside the Operator
+type Policy string
+
+const (
+	// PolicyCorrect makes the Operator apply the spec to Atlas once again
+	PolicyCorrect Policy = "correct"
+	// PolicyReport makes the Operator only report the drift, the Atlas resource is left as is
+	PolicyReport Policy = "report"
+
+	// PolicyAnnotation overrides the Operator wide drift policy for a single resource
+	PolicyAnnotation = "mongodb.com/atlas-drift-policy"
+)
+
+func ParsePolicy(value string) (Policy, error) {
+	switch policy := Policy(strings.ToLower(value)); policy {
+	case PolicyCorrect, PolicyReport:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown drift policy %q, expected %q or %q", value, PolicyCorrect, PolicyReport)
+}
+
+// PolicyFor returns the drift policy of the resource. An invalid annotation falls back to the default policy.
+func PolicyFor(resource mdbv1.AtlasCustomResource, defaultPolicy Policy) Policy {
+	if v, ok := resource.GetAnnotations()[PolicyAnnotation]; ok {
+		if policy, err := ParsePolicy(v); err == nil {
+			return policy
+		}
+	}
+	return defaultPolicy
+}
+
+// ShouldCheck returns 'true' if the current spec of the resource has already been applied to Atlas, so any
+// difference between Atlas and the spec is a change made outside the Operator. This must be called before the
+// reconciliation has started, as it resets the Ready condition.
+// Resources that have only reported the drift keep being checked until the spec changes. The drift isn't checked
+// at all if the periodic resync of the kind is disabled.
+func ShouldCheck(resource mdbv1.AtlasCustomResource, resyncInterval time.Duration) bool {
+	if resyncInterval <= 0 {
+		return false
+	}
+	if !resource.GetDeletionTimestamp().IsZero() || resource.GetStatus().GetObservedGeneration() != resource.GetGeneration() {
+		return false
+	}
+	for _, condition := range resource.GetStatus().GetConditions() {
+		if condition.Type == status.ReadyType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+		if condition.Type == status.DriftDetectedType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// Report sets the DriftDetected condition for the fields that differ between Atlas and the spec. The result is not
+// OK only if the drift must not be corrected according to the policy, the reconciliation must stop and set the
+// readiness condition of the resource from it in that case.
+func Report(ctx *workflow.Context, eventRecorder record.EventRecorder, resource mdbv1.AtlasCustomResource, policy Policy, fields []string) workflow.Result {
+	if len(fields) == 0 {
+		ctx.SetConditionFalse(status.DriftDetectedType)
+		return workflow.OK()
+	}
+
+	summary := Summary(fields)
+	ctx.Log.Infow("Atlas resource was changed outside the Operator", "fields", fields, "policy", policy)
+	eventRecorder.Eventf(resource, "Warning", string(workflow.AtlasDriftDetected), "Atlas differs from the spec: %s", summary)
+
+	condition := status.TrueCondition(status.DriftDetectedType).WithReason(string(workflow.AtlasDriftDetected))
+	if policy == PolicyReport {
+		condition.Message = fmt.Sprintf("Atlas differs from the spec: %s", summary)
+		ctx.EnsureCondition(condition)
+		return workflow.Terminate(
+			workflow.AtlasDriftDetected,
+			fmt.Sprintf("Atlas differs from the spec and is not corrected as per the %q drift policy: %s", policy, summary),
+		).WithoutRetry()
+	}
+
+	condition.Message = fmt.Sprintf("Atlas differs from the spec and is being corrected: %s", summary)
+	ctx.EnsureCondition(condition)
+	return workflow.OK()
+}
+
+// ResyncResult returns the final result of a successful reconciliation, that is requeued after the resync interval
+// to check for the drift periodically
+func ResyncResult(result workflow.Result, resyncInterval time.Duration) workflow.Result {
+	if resyncInterval <= 0 {
+		return result
+	}
+	return result.WithRetry(resyncInterval)
+}
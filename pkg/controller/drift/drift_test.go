@@ -0,0 +1,99 @@
+package drift
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy("Report")
+	require.NoError(t, err)
+	assert.Equal(t, PolicyReport, policy)
+
+	_, err = ParsePolicy("ignore")
+	assert.ErrorContains(t, err, `unknown drift policy "ignore"`)
+}
+
+func TestPolicyFor(t *testing.T) {
+	user := &mdbv1.AtlasDatabaseUser{}
+	assert.Equal(t, PolicyCorrect, PolicyFor(user, PolicyCorrect))
+
+	user.SetAnnotations(map[string]string{PolicyAnnotation: "report"})
+	assert.Equal(t, PolicyReport, PolicyFor(user, PolicyCorrect))
+
+	user.SetAnnotations(map[string]string{PolicyAnnotation: "foobar"})
+	assert.Equal(t, PolicyCorrect, PolicyFor(user, PolicyCorrect))
+}
+
+func TestShouldCheck(t *testing.T) {
+	newUser := func(generation, observedGeneration int64, conditions ...status.Condition) *mdbv1.AtlasDatabaseUser {
+		user := &mdbv1.AtlasDatabaseUser{ObjectMeta: metav1.ObjectMeta{Generation: generation}}
+		user.Status.ObservedGeneration = observedGeneration
+		user.Status.Conditions = conditions
+		return user
+	}
+
+	assert.True(t, ShouldCheck(newUser(2, 2, status.TrueCondition(status.ReadyType)), time.Hour))
+	assert.True(t, ShouldCheck(newUser(2, 2, status.FalseCondition(status.ReadyType), status.TrueCondition(status.DriftDetectedType)), time.Hour))
+	assert.False(t, ShouldCheck(newUser(3, 2, status.TrueCondition(status.ReadyType)), time.Hour), "spec has changed")
+	assert.False(t, ShouldCheck(newUser(2, 2, status.FalseCondition(status.ReadyType)), time.Hour), "spec wasn't applied yet")
+	assert.False(t, ShouldCheck(newUser(2, 2, status.TrueCondition(status.ReadyType)), 0), "resync is disabled")
+
+	deleted := newUser(2, 2, status.TrueCondition(status.ReadyType))
+	deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	assert.False(t, ShouldCheck(deleted, time.Hour))
+}
+
+func TestReport(t *testing.T) {
+	newContext := func() *workflow.Context {
+		return workflow.NewContext(zap.S(), []status.Condition{}, context.Background())
+	}
+
+	t.Run("no drift", func(t *testing.T) {
+		ctx := newContext()
+		recorder := record.NewFakeRecorder(1)
+
+		assert.True(t, Report(ctx, recorder, &mdbv1.AtlasDatabaseUser{}, PolicyReport, nil).IsOk())
+		condition, _ := ctx.GetCondition(status.DriftDetectedType)
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("drift is corrected", func(t *testing.T) {
+		ctx := newContext()
+		recorder := record.NewFakeRecorder(1)
+
+		assert.True(t, Report(ctx, recorder, &mdbv1.AtlasDatabaseUser{}, PolicyCorrect, []string{"roles"}).IsOk())
+		condition, _ := ctx.GetCondition(status.DriftDetectedType)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t, "Atlas differs from the spec and is being corrected: roles", condition.Message)
+		assert.Equal(t, "Warning AtlasDriftDetected Atlas differs from the spec: roles", <-recorder.Events)
+	})
+
+	t.Run("drift is reported only", func(t *testing.T) {
+		ctx := newContext()
+		recorder := record.NewFakeRecorder(1)
+
+		result := Report(ctx, recorder, &mdbv1.AtlasDatabaseUser{}, PolicyReport, []string{"roles", "scopes"})
+		assert.False(t, result.IsOk())
+		assert.Equal(t, `Atlas differs from the spec and is not corrected as per the "report" drift policy: roles, scopes`, result.GetMessage())
+		assert.Zero(t, ResyncResult(result, 0).ReconcileResult().RequeueAfter)
+		assert.Equal(t, time.Hour, ResyncResult(result, time.Hour).ReconcileResult().RequeueAfter)
+
+		condition, _ := ctx.GetCondition(status.DriftDetectedType)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t, "Atlas differs from the spec: roles, scopes", condition.Message)
+	})
+}
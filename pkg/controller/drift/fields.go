@@ -0,0 +1,103 @@
+package drift
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const maxSummaryFields = 10
+
+// Fields returns the paths of the fields that differ between 'x' and 'y', for example
+// "replicationSpecs[0].regionConfigs[0].priority". The JSON names of the fields are used if present.
+func Fields(x, y interface{}, opts ...cmp.Option) []string {
+	reporter := &fieldsReporter{seen: map[string]bool{}}
+	cmp.Equal(x, y, append(opts, cmp.Reporter(reporter))...)
+	return reporter.fields
+}
+
+// Summary formats the differing fields to a message short enough for a status condition
+func Summary(fields []string) string {
+	if len(fields) <= maxSummaryFields {
+		return strings.Join(fields, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(fields[:maxSummaryFields], ", "), len(fields)-maxSummaryFields)
+}
+
+type fieldsReporter struct {
+	path   cmp.Path
+	fields []string
+	seen   map[string]bool
+}
+
+func (r *fieldsReporter) PushStep(step cmp.PathStep) {
+	r.path = append(r.path, step)
+}
+
+func (r *fieldsReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+func (r *fieldsReporter) Report(result cmp.Result) {
+	if result.Equal() {
+		return
+	}
+	field := fieldPath(r.path)
+	if !r.seen[field] {
+		r.seen[field] = true
+		r.fields = append(r.fields, field)
+	}
+}
+
+func fieldPath(path cmp.Path) string {
+	b := strings.Builder{}
+	// the first step is the root of the compared values
+	for i := 1; i < len(path); i++ {
+		switch step := path[i].(type) {
+		case cmp.StructField:
+			name := jsonName(path[i-1].Type(), step)
+			if name == "" {
+				continue
+			}
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(name)
+		case cmp.SliceIndex:
+			index, otherIndex := step.SplitKeys()
+			if index < 0 {
+				index = otherIndex
+			}
+			fmt.Fprintf(&b, "[%d]", index)
+		case cmp.MapIndex:
+			fmt.Fprintf(&b, "[%v]", step.Key())
+		}
+	}
+	if b.Len() == 0 {
+		return "."
+	}
+	return b.String()
+}
+
+// jsonName returns the name of the struct field in JSON, or an empty string for the inlined structs
+func jsonName(parent reflect.Type, field cmp.StructField) string {
+	if parent == nil || parent.Kind() != reflect.Struct {
+		return field.Name()
+	}
+	structField, ok := parent.FieldByName(field.Name())
+	if !ok {
+		return field.Name()
+	}
+	tag, options, _ := strings.Cut(structField.Tag.Get("json"), ",")
+	switch {
+	case tag == "" && strings.Contains(options, "inline"):
+		return ""
+	case tag == "" && structField.Anonymous:
+		return ""
+	case tag == "" || tag == "-":
+		return field.Name()
+	}
+	return tag
+}
@@ -0,0 +1,54 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+func TestFields(t *testing.T) {
+	atlas := &mongodbatlas.AdvancedCluster{
+		Name:          "cluster",
+		BackupEnabled: toptr.MakePtr(true),
+		ReplicationSpecs: []*mongodbatlas.AdvancedReplicationSpec{
+			{
+				NumShards: 1,
+				RegionConfigs: []*mongodbatlas.AdvancedRegionConfig{
+					{RegionName: "US_EAST_1", Priority: toptr.MakePtr(7)},
+				},
+			},
+		},
+		Tags: []*mongodbatlas.Tag{{Key: "env", Value: "prod"}},
+	}
+	spec := &mongodbatlas.AdvancedCluster{
+		Name:          "cluster",
+		BackupEnabled: toptr.MakePtr(false),
+		ReplicationSpecs: []*mongodbatlas.AdvancedReplicationSpec{
+			{
+				NumShards: 1,
+				RegionConfigs: []*mongodbatlas.AdvancedRegionConfig{
+					{RegionName: "US_EAST_1", Priority: toptr.MakePtr(6)},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t,
+		[]string{"backupEnabled", "replicationSpecs[0].regionConfigs[0].priority", "tags"},
+		Fields(atlas, spec),
+	)
+	assert.Empty(t, Fields(atlas, atlas))
+	assert.Empty(t, Fields(mongodbatlas.AdvancedCluster{Tags: []*mongodbatlas.Tag{}}, mongodbatlas.AdvancedCluster{}, cmpopts.EquateEmpty()))
+}
+
+func TestSummary(t *testing.T) {
+	assert.Equal(t, "a, b", Summary([]string{"a", "b"}))
+	assert.Equal(t,
+		"f0, f1, f2, f3, f4, f5, f6, f7, f8, f9 and 2 more",
+		Summary([]string{"f0", "f1", "f2", "f3", "f4", "f5", "f6", "f7", "f8", "f9", "f10", "f11"}),
+	)
+}
@@ -0,0 +1,120 @@
+package dryrun
+
+import (
+	"net/http"
+	"sync"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/httputil"
+)
+
+const (
+	ActionCreate = "Create"
+	ActionUpdate = "Update"
+	ActionDelete = "Delete"
+
+	// PlannedChangeReason is the reason of the events emitted for the planned changes
+	PlannedChangeReason = "DryRunPlannedChange"
+)
+
+// Plan collects the changes to Atlas that a reconciliation would have made if it wasn't in dry-run mode.
+// A nil Plan is valid and means that dry-run is disabled: no changes are collected and nothing is emitted.
+type Plan struct {
+	mu      sync.Mutex
+	changes []status.PlannedChange
+}
+
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+// PlanFor returns a new Plan if the changes to Atlas must only be planned for the resource, nil otherwise
+func PlanFor(resource mdbv1.AtlasCustomResource, dryRunByDefault bool, log *zap.SugaredLogger) *Plan {
+	if !customresource.IsDryRun(resource, dryRunByDefault) {
+		return nil
+	}
+
+	log.Infow("-> Dry-run is enabled, the changes to Atlas are only planned", "annotation", customresource.DryRunAnnotation)
+	return NewPlan()
+}
+
+// ClientOpts returns the options routing all the mutations of an Atlas client to the plan instead of Atlas
+func (p *Plan) ClientOpts() []httputil.ClientOpt {
+	if p == nil {
+		return nil
+	}
+	return []httputil.ClientOpt{httputil.DryRun(p)}
+}
+
+func (p *Plan) Record(request *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.changes = append(p.changes, status.PlannedChange{
+		Action: action(request.Method),
+		Path:   request.URL.Path,
+	})
+}
+
+// KeepFinalizer tells if the finalizer of a deleted Custom Resource must be kept, which is the case in dry-run mode.
+// The deletion of the Custom Resource is recorded in the plan: it's only removed once it's reconciled without dry-run,
+// so that the changes planned for its deletion stay visible.
+func (p *Plan) KeepFinalizer(resource client.Object) bool {
+	if p == nil {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.changes = append(p.changes, status.PlannedChange{
+		Action: ActionDelete,
+		Path:   client.ObjectKeyFromObject(resource).String(),
+	})
+	return true
+}
+
+// DeletionPlanned is the result of the reconciliation of a Custom Resource whose deletion is only planned
+func DeletionPlanned() workflow.Result {
+	return workflow.InProgress(workflow.AtlasDryRunDeletionPlanned, "the deletion is only planned in dry-run mode, the resource is removed once dry-run is disabled")
+}
+
+// Changes returns the planned changes in the order they were recorded
+func (p *Plan) Changes() []status.PlannedChange {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.changes) == 0 {
+		return nil
+	}
+	return append([]status.PlannedChange{}, p.changes...)
+}
+
+// Emit reports each planned change as a Kubernetes event of the resource
+func (p *Plan) Emit(recorder record.EventRecorder, resource runtime.Object) {
+	for _, change := range p.Changes() {
+		recorder.Eventf(resource, "Normal", PlannedChangeReason, "%s %s", change.Action, change.Path)
+	}
+}
+
+func action(method string) string {
+	switch method {
+	case http.MethodPost:
+		return ActionCreate
+	case http.MethodDelete:
+		return ActionDelete
+	}
+	return ActionUpdate
+}
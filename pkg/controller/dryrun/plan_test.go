@@ -0,0 +1,78 @@
+package dryrun
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/record"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/httputil"
+)
+
+func TestPlanFor(t *testing.T) {
+	project := mdbv1.DefaultProject("test-ns", "connection")
+	assert.Nil(t, PlanFor(project, false, zap.S()))
+	assert.NotNil(t, PlanFor(project, true, zap.S()))
+
+	project.SetAnnotations(map[string]string{customresource.DryRunAnnotation: "true"})
+	assert.NotNil(t, PlanFor(project, false, zap.S()))
+}
+
+func TestNilPlan(t *testing.T) {
+	var plan *Plan
+	assert.Empty(t, plan.ClientOpts())
+	assert.Nil(t, plan.Changes())
+
+	recorder := record.NewFakeRecorder(10)
+	plan.Emit(recorder, &mdbv1.AtlasProject{})
+	assert.Empty(t, recorder.Events)
+
+	assert.False(t, plan.KeepFinalizer(mdbv1.DefaultProject("test-ns", "connection")))
+}
+
+func TestKeepFinalizer(t *testing.T) {
+	plan := NewPlan()
+	assert.True(t, plan.KeepFinalizer(mdbv1.DefaultProject("test-ns", "connection")))
+	assert.Equal(t,
+		[]status.PlannedChange{{Action: ActionDelete, Path: "test-ns/test-project"}},
+		plan.Changes(),
+	)
+}
+
+func TestPlan(t *testing.T) {
+	plan := NewPlan()
+	httpClient, err := httputil.DecorateClient(&http.Client{}, plan.ClientOpts()...)
+	require.NoError(t, err)
+
+	for _, method := range []string{http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete} {
+		request, err := http.NewRequestWithContext(context.Background(), method, "https://cloud.mongodb.com/api/atlas/v1.0/groups/123/accessList", strings.NewReader("{}"))
+		require.NoError(t, err)
+		response, err := httpClient.Do(request)
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+	}
+
+	path := "/api/atlas/v1.0/groups/123/accessList"
+	assert.Equal(t,
+		[]status.PlannedChange{
+			{Action: ActionCreate, Path: path},
+			{Action: ActionUpdate, Path: path},
+			{Action: ActionUpdate, Path: path},
+			{Action: ActionDelete, Path: path},
+		},
+		plan.Changes(),
+	)
+
+	recorder := record.NewFakeRecorder(10)
+	plan.Emit(recorder, &mdbv1.AtlasProject{})
+	require.Len(t, recorder.Events, 4)
+	assert.Equal(t, "Normal DryRunPlannedChange Create "+path, <-recorder.Events)
+}
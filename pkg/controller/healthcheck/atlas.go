@@ -0,0 +1,128 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/metrics"
+)
+
+const (
+	DefaultAtlasCheckInterval = time.Minute
+
+	atlasCheckTimeout = 10 * time.Second
+)
+
+// AtlasPing makes a cheap authenticated call to Atlas with the connection
+type AtlasPing func(ctx context.Context, connection atlas.Connection) error
+
+// OrganizationPing reads the organization of the API keys, which requires a valid authentication
+func OrganizationPing(provider atlas.Provider, log *zap.SugaredLogger) AtlasPing {
+	return func(ctx context.Context, connection atlas.Connection) error {
+		atlasClient, err := provider.CreateClient(&connection, log)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = atlasClient.Organizations.Get(ctx, connection.OrgID)
+		return err
+	}
+}
+
+// AtlasCheck periodically checks that the Operator can reach Atlas with the global API keys and reports the outcome
+// with the atlas_operator_atlas_reachable metric and in the readiness report. It doesn't affect the readiness probe:
+// restarting the replica or routing the traffic away from it doesn't fix Atlas being unreachable.
+type AtlasCheck struct {
+	provider *atlas.ProductionProvider
+	ping     AtlasPing
+	interval time.Duration
+	log      *zap.SugaredLogger
+
+	mu          sync.Mutex
+	lastErr     error
+	lastChecked time.Time
+}
+
+// NewAtlasCheck returns the Atlas check calling Atlas once per interval. It's meant to be added to the manager with
+// manager.Manager.Add().
+func NewAtlasCheck(provider *atlas.ProductionProvider, ping AtlasPing, interval time.Duration, log *zap.SugaredLogger) *AtlasCheck {
+	return &AtlasCheck{
+		provider: provider,
+		ping:     ping,
+		interval: interval,
+		log:      log,
+	}
+}
+
+// Start implements manager.Runnable. It checks Atlas until the context is done.
+func (c *AtlasCheck) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		_ = c.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica reports whether it can reach Atlas.
+func (c *AtlasCheck) NeedLeaderElection() bool {
+	return false
+}
+
+// Check reads the global API keys and pings Atlas with them, then updates the metric
+func (c *AtlasCheck) Check(ctx context.Context) error {
+	err := c.check(ctx)
+	metrics.SetAtlasReachable(err == nil)
+	if err != nil {
+		c.log.Warnf("Atlas is not reachable with the global API keys: %s", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastErr = err
+	c.lastChecked = time.Now()
+	return err
+}
+
+// Result returns the outcome of the last check, the readiness report serves it until the next check
+func (c *AtlasCheck) Result() CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := CheckResult{Name: CheckAtlas, Informational: true}
+	switch {
+	case c.lastChecked.IsZero():
+		result.Message = "Atlas wasn't checked yet"
+	case c.lastErr != nil:
+		result.Message = c.lastErr.Error()
+	default:
+		result.OK = true
+		result.Message = "Atlas is reachable with the global API keys"
+	}
+	if !c.lastChecked.IsZero() {
+		result.CheckedAt = c.lastChecked.UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+func (c *AtlasCheck) check(ctx context.Context) error {
+	connection, err := c.provider.ReadConnection(c.log, nil)
+	if err != nil {
+		return fmt.Errorf("unable to read the global API keys: %w", err)
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, atlasCheckTimeout)
+	defer cancel()
+	return c.ping(pingCtx, connection)
+}
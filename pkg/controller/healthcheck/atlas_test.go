@@ -0,0 +1,111 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+)
+
+var globalAPISecret = client.ObjectKey{Name: "global-secret", Namespace: "operator"}
+
+const atlasReachableMetric = `
+# HELP atlas_operator_atlas_reachable Whether the Atlas Admin API was reachable with the global API keys at the last check (1) or not (0)
+# TYPE atlas_operator_atlas_reachable gauge
+atlas_operator_atlas_reachable %s
+`
+
+func TestAtlasCheck(t *testing.T) {
+	t.Run("Atlas isn't reachable without the global API keys", func(t *testing.T) {
+		pings := 0
+		atlasCheck := testAtlasCheck(t, testPing(&pings, nil))
+
+		assert.ErrorContains(t, atlasCheck.Check(context.Background()), "unable to read the global API keys")
+		assert.Zero(t, pings)
+		assertAtlasReachable(t, "0")
+	})
+
+	t.Run("Atlas isn't reachable when it rejects the API keys", func(t *testing.T) {
+		pings := 0
+		atlasCheck := testAtlasCheck(t, testPing(&pings, errors.New("401 unauthorized")), testSecret("public"))
+
+		assert.EqualError(t, atlasCheck.Check(context.Background()), "401 unauthorized")
+		assert.Equal(t, 1, pings)
+		assertAtlasReachable(t, "0")
+	})
+
+	t.Run("Atlas is reachable with the API keys", func(t *testing.T) {
+		pings := 0
+		atlasCheck := testAtlasCheck(t, testPing(&pings, nil), testSecret("public"))
+
+		assert.NoError(t, atlasCheck.Check(context.Background()))
+		assert.Equal(t, 1, pings)
+		assertAtlasReachable(t, "1")
+		assert.True(t, atlasCheck.Result().OK)
+		assert.Equal(t, "Atlas is reachable with the global API keys", atlasCheck.Result().Message)
+	})
+
+	t.Run("Atlas is checked until the context is done", func(t *testing.T) {
+		pings := 0
+		ctx, cancel := context.WithCancel(context.Background())
+		atlasCheck := testAtlasCheck(t, func(context.Context, atlas.Connection) error {
+			pings++
+			cancel()
+			return nil
+		}, testSecret("public"))
+
+		assert.NoError(t, atlasCheck.Start(ctx))
+		assert.Equal(t, 1, pings)
+		assert.False(t, atlasCheck.NeedLeaderElection())
+	})
+}
+
+func testAtlasCheck(t *testing.T, ping AtlasPing, objects ...client.Object) *AtlasCheck {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	provider := atlas.NewProductionProvider("https://cloud.mongodb.com/", globalAPISecret, kubeClient)
+	return NewAtlasCheck(provider, ping, DefaultAtlasCheckInterval, zaptest.NewLogger(t).Sugar())
+}
+
+func testPing(calls *int, err error) AtlasPing {
+	return func(ctx context.Context, connection atlas.Connection) error {
+		*calls++
+		return err
+	}
+}
+
+func testSecret(publicKey string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: globalAPISecret.Name, Namespace: globalAPISecret.Namespace},
+		Data: map[string][]byte{
+			"orgId":         []byte("my-org"),
+			"publicApiKey":  []byte(publicKey),
+			"privateApiKey": []byte("private"),
+		},
+	}
+}
+
+func assertAtlasReachable(t *testing.T, value string) {
+	t.Helper()
+
+	expected := strings.NewReader(fmt.Sprintf(atlasReachableMetric, value))
+	assert.NoError(t, testutil.GatherAndCompare(ctrlmetrics.Registry, expected, "atlas_operator_atlas_reachable"))
+}
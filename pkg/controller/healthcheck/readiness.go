@@ -0,0 +1,128 @@
+package healthcheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+const (
+	CheckWebhook = "webhook"
+	CheckLeader  = "leader"
+	CheckAtlas   = "atlas"
+)
+
+// CheckResult is the outcome of a single readiness check
+type CheckResult struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message,omitempty"`
+	// Informational checks are reported without affecting the readiness
+	Informational bool `json:"informational,omitempty"`
+	// CheckedAt is the time of the last run of the checks running in the background
+	CheckedAt string `json:"checkedAt,omitempty"`
+}
+
+// Report is the detailed readiness of the Operator replica
+type Report struct {
+	Ready  bool          `json:"ready"`
+	Leader bool          `json:"leader"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// Readiness checks the local state of the Operator replica only: the webhook server and the leader election. The
+// last result of the AtlasCheck is reported as well, but doesn't affect the readiness, as the probes must not depend
+// on external services.
+type Readiness struct {
+	elected <-chan struct{}
+	webhook healthz.Checker
+	atlas   *AtlasCheck
+	log     *zap.SugaredLogger
+}
+
+// NewReadiness returns the readiness check of the Operator. The elected channel is closed once the replica holds the
+// leadership, see manager.Manager.Elected(). The webhook checker is nil if the webhooks are disabled, see
+// webhook.Server.StartedChecker(). The Atlas check is nil if it's disabled.
+func NewReadiness(elected <-chan struct{}, webhook healthz.Checker, atlasCheck *AtlasCheck, log *zap.SugaredLogger) *Readiness {
+	return &Readiness{
+		elected: elected,
+		webhook: webhook,
+		atlas:   atlasCheck,
+		log:     log,
+	}
+}
+
+// Check implements healthz.Checker. It fails if the webhook server doesn't serve the admission requests.
+func (r *Readiness) Check(req *http.Request) error {
+	report := r.Report()
+	if report.Ready {
+		return nil
+	}
+
+	var failures []string
+	for _, check := range report.Checks {
+		if !check.OK && !check.Informational {
+			failures = append(failures, fmt.Sprintf("%s: %s", check.Name, check.Message))
+		}
+	}
+	return errors.New(strings.Join(failures, "; "))
+}
+
+// Report runs the readiness checks. The leadership is reported but doesn't affect the readiness, as the replicas on
+// standby are expected to take over and serve the admission requests in the meantime. Neither does the last result of
+// the Atlas check.
+func (r *Readiness) Report() Report {
+	report := Report{Ready: true, Leader: r.isLeader()}
+
+	if r.webhook != nil {
+		webhookCheck := CheckResult{Name: CheckWebhook, OK: true}
+		if err := r.webhook(nil); err != nil {
+			report.Ready = false
+			webhookCheck.OK = false
+			webhookCheck.Message = err.Error()
+		}
+		report.Checks = append(report.Checks, webhookCheck)
+	}
+
+	leaderCheck := CheckResult{Name: CheckLeader, OK: true, Message: "this replica holds the leadership", Informational: true}
+	if !report.Leader {
+		leaderCheck.Message = "this replica is on standby"
+	}
+	report.Checks = append(report.Checks, leaderCheck)
+
+	if r.atlas != nil {
+		report.Checks = append(report.Checks, r.atlas.Result())
+	}
+
+	return report
+}
+
+// VerboseHandler serves the readiness report as JSON. It responds with 503 if the replica isn't ready.
+func (r *Readiness) VerboseHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report := r.Report()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if !report.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			r.log.Errorf("failed to write the readiness report: %s", err)
+		}
+	})
+}
+
+func (r *Readiness) isLeader() bool {
+	select {
+	case <-r.elected:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,114 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+func TestReadiness(t *testing.T) {
+	t.Run("the replica is ready without webhooks", func(t *testing.T) {
+		readiness := testReadiness(t, nil, nil)
+
+		report := readiness.Report()
+		assert.True(t, report.Ready)
+		assert.Equal(t, []CheckResult{{Name: CheckLeader, OK: true, Message: "this replica is on standby", Informational: true}}, report.Checks)
+		assert.NoError(t, readiness.Check(nil))
+	})
+
+	t.Run("the replica isn't ready until the webhook server is started", func(t *testing.T) {
+		readiness := testReadiness(t, nil, testWebhook(errors.New("webhook server has not been started yet")))
+
+		report := readiness.Report()
+		assert.False(t, report.Ready)
+		assert.Equal(t, CheckResult{Name: CheckWebhook, Message: "webhook server has not been started yet"}, report.Checks[0])
+		assert.EqualError(t, readiness.Check(nil), "webhook: webhook server has not been started yet")
+	})
+
+	t.Run("the replica is ready once the webhook server is started", func(t *testing.T) {
+		readiness := testReadiness(t, nil, testWebhook(nil))
+
+		report := readiness.Report()
+		assert.True(t, report.Ready)
+		assert.Equal(t, CheckResult{Name: CheckWebhook, OK: true}, report.Checks[0])
+	})
+
+	t.Run("the leadership is reported without affecting the readiness", func(t *testing.T) {
+		elected := make(chan struct{})
+		readiness := testReadiness(t, elected, testWebhook(nil))
+
+		report := readiness.Report()
+		assert.True(t, report.Ready)
+		assert.False(t, report.Leader)
+
+		close(elected)
+		assert.True(t, readiness.Report().Leader)
+	})
+
+	t.Run("the last Atlas check is reported without affecting the readiness", func(t *testing.T) {
+		pings := 0
+		atlasCheck := testAtlasCheck(t, testPing(&pings, errors.New("401 unauthorized")), testSecret("public"))
+		readiness := NewReadiness(make(chan struct{}), testWebhook(errors.New("webhook server has not been started yet")), atlasCheck, zaptest.NewLogger(t).Sugar())
+
+		assert.Equal(t, CheckResult{Name: CheckAtlas, Message: "Atlas wasn't checked yet", Informational: true}, readiness.Report().Checks[2])
+
+		assert.Error(t, atlasCheck.Check(context.Background()))
+		report := readiness.Report()
+		atlasResult := report.Checks[2]
+		assert.False(t, atlasResult.OK)
+		assert.Equal(t, "401 unauthorized", atlasResult.Message)
+		assert.NotEmpty(t, atlasResult.CheckedAt)
+		assert.EqualError(t, readiness.Check(nil), "webhook: webhook server has not been started yet")
+	})
+}
+
+func TestVerboseHandler(t *testing.T) {
+	readiness := testReadiness(t, nil, testWebhook(errors.New("webhook server is not reachable")))
+	handler := NewProbeServer(":0", readiness, zaptest.NewLogger(t).Sugar()).Handler()
+
+	t.Run("the readiness report is served as JSON", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz/verbose", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		report := Report{}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+		assert.False(t, report.Ready)
+		assert.Len(t, report.Checks, 2)
+		assert.Equal(t, "webhook server is not reachable", report.Checks[0].Message)
+	})
+
+	t.Run("the probes are served", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
+
+func testReadiness(t *testing.T, elected chan struct{}, webhook healthz.Checker) *Readiness {
+	t.Helper()
+
+	if elected == nil {
+		elected = make(chan struct{})
+	}
+	return NewReadiness(elected, webhook, nil, zaptest.NewLogger(t).Sugar())
+}
+
+func testWebhook(err error) healthz.Checker {
+	return func(req *http.Request) error {
+		return err
+	}
+}
@@ -0,0 +1,91 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+const (
+	livenessPath         = "/healthz"
+	readinessPath        = "/readyz"
+	verboseReadinessPath = "/readyz/verbose"
+
+	shutdownTimeout = 30 * time.Second
+)
+
+// ProbeServer serves the liveness and readiness probes of the Operator. It replaces the probe server of the manager,
+// which can't serve the detailed readiness report next to the checks.
+type ProbeServer struct {
+	addr      string
+	readiness *Readiness
+	log       *zap.SugaredLogger
+}
+
+// NewProbeServer returns the probe server listening on addr. It's meant to be added to the manager with
+// manager.Manager.Add().
+func NewProbeServer(addr string, readiness *Readiness, log *zap.SugaredLogger) *ProbeServer {
+	return &ProbeServer{
+		addr:      addr,
+		readiness: readiness,
+		log:       log,
+	}
+}
+
+// Handler returns the handler of the probes:
+//   - /healthz is the liveness probe
+//   - /readyz is the readiness probe
+//   - /readyz/verbose is the readiness report as JSON
+func (s *ProbeServer) Handler() http.Handler {
+	liveness := &healthz.Handler{Checks: map[string]healthz.Checker{"ping": healthz.Ping}}
+	readiness := &healthz.Handler{Checks: map[string]healthz.Checker{"readiness": s.readiness.Check}}
+
+	mux := http.NewServeMux()
+	mux.Handle(livenessPath, http.StripPrefix(livenessPath, liveness))
+	mux.Handle(livenessPath+"/", http.StripPrefix(livenessPath, liveness))
+	mux.Handle(readinessPath, http.StripPrefix(readinessPath, readiness))
+	mux.Handle(readinessPath+"/", http.StripPrefix(readinessPath, readiness))
+	mux.Handle(verboseReadinessPath, s.readiness.VerboseHandler())
+	return mux
+}
+
+// Start implements manager.Runnable. It serves the probes until the context is done.
+func (s *ProbeServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	shutdown := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.log.Errorf("failed to shut down the probe server: %s", err)
+		}
+		close(shutdown)
+	}()
+
+	s.log.Infof("serving the health probes on %s", listener.Addr())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	<-shutdown
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The probes are served by all the replicas.
+func (s *ProbeServer) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+// Selecting returns the AtlasPolicies selecting the namespace. The policies with an invalid namespace selector select
+// all the namespaces, so that they are reported rather than ignored
+func Selecting(ctx context.Context, kubeClient client.Client, namespace string) ([]mdbv1.AtlasPolicy, error) {
+	policies := &mdbv1.AtlasPolicyList{}
+	if err := kubeClient.List(ctx, policies); err != nil {
+		return nil, fmt.Errorf("failed to list the AtlasPolicies: %w", err)
+	}
+
+	if len(policies.Items) == 0 {
+		return nil, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := kubeClient.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return nil, fmt.Errorf("failed to read the namespace %s: %w", namespace, err)
+	}
+
+	var selecting []mdbv1.AtlasPolicy
+	for _, policy := range policies.Items {
+		if policy.Spec.NamespaceSelector == nil {
+			selecting = append(selecting, policy)
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+		if err != nil || selector.Matches(labels.Set(ns.Labels)) {
+			selecting = append(selecting, policy)
+		}
+	}
+
+	return selecting, nil
+}
+
+// DeploymentViolations returns how the deployment violates the AtlasPolicies selecting its namespace. An invalid
+// policy is a violation in itself
+func DeploymentViolations(ctx context.Context, kubeClient client.Client, deployment *mdbv1.AtlasDeployment) ([]string, error) {
+	policies, err := Selecting(ctx, kubeClient, deployment.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []string
+	for i := range policies {
+		policy := &policies[i]
+		if err := validate.AtlasPolicy(policy); err != nil {
+			violations = append(violations, fmt.Sprintf("AtlasPolicy %s is invalid: %s", policy.Name, strings.ReplaceAll(err.Error(), "\n", "; ")))
+			continue
+		}
+
+		for _, violation := range validate.DeploymentPolicyViolations(&policy.Spec.Deployments, &deployment.Spec) {
+			violations = append(violations, fmt.Sprintf("AtlasPolicy %s: %s", policy.Name, violation))
+		}
+	}
+
+	return violations, nil
+}
+
+// CheckDeployment sets the PolicyViolation condition of the deployment from the AtlasPolicies selecting its namespace.
+// The result is not OK if the deployment violates any of them, the reconciliation must stop and set the readiness
+// condition of the deployment from it in that case.
+func CheckDeployment(ctx *workflow.Context, kubeClient client.Client, eventRecorder record.EventRecorder, deployment *mdbv1.AtlasDeployment) workflow.Result {
+	violations, err := DeploymentViolations(ctx.Context, kubeClient, deployment)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	if len(violations) == 0 {
+		ctx.SetConditionFalse(status.PolicyViolationType)
+		return workflow.OK()
+	}
+
+	message := strings.Join(violations, "; ")
+	ctx.Log.Infow("Deployment violates the AtlasPolicies", "violations", violations)
+	eventRecorder.Eventf(deployment, "Warning", string(workflow.AtlasPolicyViolated), "The deployment violates the AtlasPolicies: %s", message)
+
+	condition := status.TrueCondition(status.PolicyViolationType).WithReason(string(workflow.AtlasPolicyViolated))
+	condition.Message = message
+	ctx.EnsureCondition(condition)
+
+	return workflow.Terminate(workflow.AtlasPolicyViolated, fmt.Sprintf("the deployment isn't changed in Atlas while it violates the AtlasPolicies: %s", message))
+}
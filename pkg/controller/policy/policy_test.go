@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+)
+
+func TestSelecting(t *testing.T) {
+	kubeClient := testClient(t,
+		testNamespace("dev", "dev"),
+		testPolicy("all", nil),
+		testPolicy("dev", &metav1.LabelSelector{MatchLabels: map[string]string{"environment": "dev"}}),
+		testPolicy("prod", &metav1.LabelSelector{MatchLabels: map[string]string{"environment": "prod"}}),
+		testPolicy("invalid", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "environment", Operator: "Like"}}}),
+	)
+
+	policies, err := Selecting(context.Background(), kubeClient, "dev")
+	require.NoError(t, err)
+
+	var names []string
+	for _, policy := range policies {
+		names = append(names, policy.Name)
+	}
+	assert.ElementsMatch(t, []string{"all", "dev", "invalid"}, names)
+}
+
+func TestCheckDeployment(t *testing.T) {
+	devLimits := testPolicy("dev-limits", &metav1.LabelSelector{MatchLabels: map[string]string{"environment": "dev"}})
+	devLimits.Spec.Deployments.MaxInstanceSize = "M40"
+
+	t.Run("a deployment complying with the policies", func(t *testing.T) {
+		deployment := mdbv1.NewDeployment("dev", "deployment", "deployment")
+		workflowCtx, recorder := testContext(t)
+
+		result := CheckDeployment(workflowCtx, testClient(t, testNamespace("dev", "dev"), devLimits), recorder, deployment)
+
+		assert.True(t, result.IsOk())
+		condition, ok := workflowCtx.GetCondition(status.PolicyViolationType)
+		require.True(t, ok)
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	})
+
+	t.Run("a deployment violating a policy", func(t *testing.T) {
+		deployment := mdbv1.NewDeployment("dev", "deployment", "deployment")
+		deployment.Spec.DeploymentSpec.ReplicationSpecs[0].RegionConfigs[0].ElectableSpecs.InstanceSize = "M50"
+		workflowCtx, recorder := testContext(t)
+
+		result := CheckDeployment(workflowCtx, testClient(t, testNamespace("dev", "dev"), devLimits), recorder, deployment)
+
+		assert.False(t, result.IsOk())
+		assert.Equal(t, workflow.AtlasPolicyViolated, result.GetReason())
+		condition, ok := workflowCtx.GetCondition(status.PolicyViolationType)
+		require.True(t, ok)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t, "AtlasPolicy dev-limits: the instance size M50 of the electable nodes in US_EAST_1 is above the maximum M40", condition.Message)
+		assert.Len(t, recorder.Events, 1)
+	})
+
+	t.Run("a deployment of an unselected namespace", func(t *testing.T) {
+		deployment := mdbv1.NewDeployment("prod", "deployment", "deployment")
+		deployment.Spec.DeploymentSpec.ReplicationSpecs[0].RegionConfigs[0].ElectableSpecs.InstanceSize = "M50"
+		workflowCtx, recorder := testContext(t)
+
+		result := CheckDeployment(workflowCtx, testClient(t, testNamespace("prod", "prod"), devLimits), recorder, deployment)
+
+		assert.True(t, result.IsOk())
+	})
+
+	t.Run("an invalid policy is a violation", func(t *testing.T) {
+		invalid := testPolicy("invalid", nil)
+		invalid.Spec.Deployments.MaxInstanceSize = "large"
+		workflowCtx, recorder := testContext(t)
+
+		result := CheckDeployment(workflowCtx, testClient(t, testNamespace("dev", "dev"), invalid), recorder, mdbv1.NewDeployment("dev", "deployment", "deployment"))
+
+		assert.False(t, result.IsOk())
+		assert.Contains(t, result.GetMessage(), "AtlasPolicy invalid is invalid: the maximum instance size \"large\" is invalid")
+	})
+}
+
+func testClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+
+	sch := runtime.NewScheme()
+	require.NoError(t, mdbv1.AddToScheme(sch))
+	require.NoError(t, corev1.AddToScheme(sch))
+	return fake.NewClientBuilder().WithScheme(sch).WithObjects(objects...).Build()
+}
+
+func testContext(t *testing.T) (*workflow.Context, *record.FakeRecorder) {
+	t.Helper()
+
+	return workflow.NewContext(zaptest.NewLogger(t).Sugar(), []status.Condition{}, context.Background()), record.NewFakeRecorder(10)
+}
+
+func testNamespace(name, environment string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"environment": environment}}}
+}
+
+func testPolicy(name string, selector *metav1.LabelSelector) *mdbv1.AtlasPolicy {
+	return &mdbv1.AtlasPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       mdbv1.AtlasPolicySpec{NamespaceSelector: selector},
+	}
+}
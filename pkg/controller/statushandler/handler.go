@@ -18,9 +18,11 @@ func Update(ctx *workflow.Context, kubeClient client.Client, eventRecorder recor
 	}
 
 	resource.UpdateStatus(ctx.Conditions(), ctx.StatusOptions()...)
+	recordMetrics(ctx, resource)
 
 	if err := patchUpdateStatus(kubeClient, resource); err != nil {
 		if apiErrors.IsNotFound(err) {
+			forgetMetrics(resource)
 			ctx.Log.Infof("The resource %s no longer exists, not updating the status", kube.ObjectKey(resource.GetNamespace(), resource.GetName()))
 			return
 		}
@@ -30,6 +32,13 @@ func Update(ctx *workflow.Context, kubeClient client.Client, eventRecorder recor
 	}
 }
 
+// Persist patches the status of the Atlas Custom Resource with the conditions and options collected so far, before the
+// end of the reconciliation. It is meant for the changes that must be saved before calling Atlas.
+func Persist(ctx *workflow.Context, kubeClient client.Client, resource mdbv1.AtlasCustomResource) error {
+	resource.UpdateStatus(ctx.Conditions(), ctx.StatusOptions()...)
+	return patchUpdateStatus(kubeClient, resource)
+}
+
 // logEvent logs the last condition to the output and also creates the Event for it in Kubernetes.
 // Some tradeoffs about event submission: the Event always requires the 'reason' and 'message' though our Status
 // conditions may lack that in case the condition is successful ("true"). In this case we leave the message empty
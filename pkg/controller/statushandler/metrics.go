@@ -0,0 +1,50 @@
+package statushandler
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/metrics"
+)
+
+// recordMetrics reports the outcome of the reconciliation based on the last condition set in the workflow context.
+// The status update performed at the beginning of the reconciliation doesn't have a last condition and is skipped.
+func recordMetrics(ctx *workflow.Context, resource mdbv1.AtlasCustomResource) {
+	lastCondition := ctx.LastCondition()
+	if lastCondition == nil {
+		return
+	}
+
+	kind := resourceKind(resource)
+	result := metrics.ResultInProgress
+	switch {
+	case lastCondition.Status == corev1.ConditionTrue:
+		result = metrics.ResultSuccess
+	case ctx.LastConditionWarn():
+		result = metrics.ResultFailure
+	}
+	metrics.ObserveReconcile(kind, resource.GetNamespace(), string(lastCondition.Type), lastCondition.Reason, result, ctx.Elapsed())
+
+	ready := false
+	if condition, found := ctx.GetCondition(status.ReadyType); found {
+		ready = condition.Status == corev1.ConditionTrue
+	}
+	metrics.SetResourceReady(kind, resource.GetNamespace(), resource.GetName(), ready)
+}
+
+func forgetMetrics(resource mdbv1.AtlasCustomResource) {
+	metrics.ForgetResource(resourceKind(resource), resource.GetNamespace(), resource.GetName())
+}
+
+// resourceKind returns the Kind of the resource. The TypeMeta is not always populated for the objects read from
+// the cache, so the name of the Go type is used instead.
+func resourceKind(resource mdbv1.AtlasCustomResource) string {
+	if kind := resource.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	return reflect.Indirect(reflect.ValueOf(resource)).Type().Name()
+}
@@ -0,0 +1,194 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/provider"
+)
+
+func AtlasPolicy(policy *mdbv1.AtlasPolicy) error {
+	var err error
+
+	if policy.Spec.NamespaceSelector != nil {
+		if _, selectorErr := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector); selectorErr != nil {
+			err = errors.Join(err, fmt.Errorf("the namespace selector is invalid: %w", selectorErr))
+		}
+	}
+
+	limits := policy.Spec.Deployments
+	for _, instanceSize := range limits.AllowedInstanceSizes {
+		if _, sizeErr := NewFromInstanceSizeName(instanceSize); sizeErr != nil {
+			err = errors.Join(err, fmt.Errorf("the allowed instance size %q is invalid: %w", instanceSize, sizeErr))
+		}
+	}
+
+	if limits.MaxInstanceSize != "" {
+		if _, sizeErr := NewFromInstanceSizeName(limits.MaxInstanceSize); sizeErr != nil {
+			err = errors.Join(err, fmt.Errorf("the maximum instance size %q is invalid: %w", limits.MaxInstanceSize, sizeErr))
+		}
+	}
+
+	for _, providerName := range limits.AllowedProviders {
+		if providerName != provider.ProviderAWS && providerName != provider.ProviderGCP && providerName != provider.ProviderAzure {
+			err = errors.Join(err, fmt.Errorf("the allowed provider %q is invalid. allowed providers must be AWS, GCP or AZURE", providerName))
+		}
+	}
+
+	return err
+}
+
+// DeploymentPolicyViolations returns the limits of the policy the deployment exceeds. The instance sizes which aren't
+// valid are reported by DeploymentSpec and ignored here
+func DeploymentPolicyViolations(limits *mdbv1.DeploymentPolicy, deploymentSpec *mdbv1.AtlasDeploymentSpec) []string {
+	var violations []string
+	add := func(newViolations ...string) {
+		for _, violation := range newViolations {
+			if !contains(violations, violation) {
+				violations = append(violations, violation)
+			}
+		}
+	}
+
+	if deploymentSpec.ServerlessSpec != nil && deploymentSpec.ServerlessSpec.ProviderSettings != nil {
+		settings := deploymentSpec.ServerlessSpec.ProviderSettings
+		add(locationPolicyViolations(limits, backingProvider(settings.ProviderName, settings.BackingProviderName), settings.RegionName)...)
+	}
+
+	if deploymentSpec.DeploymentSpec == nil {
+		return violations
+	}
+
+	nodeCount := 0
+	regions := map[string]struct{}{}
+	for _, replicationSpec := range deploymentSpec.DeploymentSpec.ReplicationSpecs {
+		shards := replicationSpec.NumShards
+		if shards < 1 {
+			shards = 1
+		}
+
+		for _, region := range replicationSpec.RegionConfigs {
+			regions[region.RegionName] = struct{}{}
+			add(locationPolicyViolations(limits, backingProvider(provider.ProviderName(region.ProviderName), region.BackingProviderName), region.RegionName)...)
+
+			nodes := []struct {
+				name  string
+				specs *mdbv1.Specs
+			}{
+				{name: "electable", specs: region.ElectableSpecs},
+				{name: "read-only", specs: region.ReadOnlySpecs},
+				{name: "analytics", specs: region.AnalyticsSpecs},
+			}
+			for _, node := range nodes {
+				if node.specs == nil {
+					continue
+				}
+
+				if node.specs.NodeCount != nil {
+					nodeCount += shards * *node.specs.NodeCount
+				}
+				add(instanceSizePolicyViolations(limits, node.specs.InstanceSize, fmt.Sprintf("the %s nodes in %s", node.name, region.RegionName))...)
+			}
+
+			if region.ProviderName != string(provider.ProviderTenant) {
+				add(autoScalingPolicyViolations(limits, region)...)
+			}
+		}
+	}
+
+	if limits.MaxNodeCount != nil && nodeCount > *limits.MaxNodeCount {
+		add(fmt.Sprintf("the deployment has %d nodes, the maximum is %d", nodeCount, *limits.MaxNodeCount))
+	}
+
+	if limits.MaxRegions != nil && len(regions) > *limits.MaxRegions {
+		add(fmt.Sprintf("the deployment spans %d regions, the maximum is %d", len(regions), *limits.MaxRegions))
+	}
+
+	return violations
+}
+
+func locationPolicyViolations(limits *mdbv1.DeploymentPolicy, providerName provider.ProviderName, regionName string) []string {
+	var violations []string
+
+	if len(limits.AllowedProviders) > 0 && !contains(limits.AllowedProviders, providerName) {
+		violations = append(violations, fmt.Sprintf("the provider %s of the region %s isn't allowed", providerName, regionName))
+	}
+
+	if len(limits.AllowedRegions) > 0 && !contains(limits.AllowedRegions, regionName) {
+		violations = append(violations, fmt.Sprintf("the region %s isn't allowed", regionName))
+	}
+
+	return violations
+}
+
+func instanceSizePolicyViolations(limits *mdbv1.DeploymentPolicy, instanceSize, of string) []string {
+	if instanceSize == "" {
+		return nil
+	}
+
+	var violations []string
+
+	if len(limits.AllowedInstanceSizes) > 0 && !contains(limits.AllowedInstanceSizes, instanceSize) {
+		violations = append(violations, fmt.Sprintf("the instance size %s of %s isn't allowed. allowed instance sizes are %s",
+			instanceSize, of, strings.Join(limits.AllowedInstanceSizes, ", ")))
+	}
+
+	if limits.MaxInstanceSize != "" {
+		size, sizeErr := NewFromInstanceSizeName(instanceSize)
+		maxSize, maxErr := NewFromInstanceSizeName(limits.MaxInstanceSize)
+		if sizeErr == nil && maxErr == nil && CompareInstanceSizes(size, maxSize) == 1 {
+			violations = append(violations, fmt.Sprintf("the instance size %s of %s is above the maximum %s", instanceSize, of, limits.MaxInstanceSize))
+		}
+	}
+
+	return violations
+}
+
+func autoScalingPolicyViolations(limits *mdbv1.DeploymentPolicy, region *mdbv1.AdvancedRegionConfig) []string {
+	var violations []string
+
+	var compute *mdbv1.ComputeSpec
+	diskGBEnabled := false
+	if region.AutoScaling != nil {
+		compute = region.AutoScaling.Compute
+		diskGBEnabled = region.AutoScaling.DiskGB != nil && region.AutoScaling.DiskGB.Enabled != nil && *region.AutoScaling.DiskGB.Enabled
+	}
+	computeEnabled := compute != nil && compute.Enabled != nil && *compute.Enabled
+
+	if limits.AutoScaling != nil {
+		if limits.AutoScaling.ComputeRequired && (!computeEnabled || compute.MinInstanceSize == "" || compute.MaxInstanceSize == "") {
+			violations = append(violations, fmt.Sprintf("the compute autoscaling in %s must be enabled with its minimum and maximum instance sizes", region.RegionName))
+		}
+
+		if limits.AutoScaling.DiskGBRequired && !diskGBEnabled {
+			violations = append(violations, fmt.Sprintf("the disk autoscaling in %s must be enabled", region.RegionName))
+		}
+	}
+
+	if computeEnabled {
+		violations = append(violations, instanceSizePolicyViolations(limits, compute.MaxInstanceSize, fmt.Sprintf("the compute autoscaling in %s", region.RegionName))...)
+	}
+
+	return violations
+}
+
+// backingProvider returns the cloud provider hosting tenant and serverless deployments
+func backingProvider(providerName provider.ProviderName, backingProviderName string) provider.ProviderName {
+	if providerName == provider.ProviderTenant || providerName == provider.ProviderServerless {
+		return provider.ProviderName(backingProviderName)
+	}
+	return providerName
+}
+
+func contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/toptr"
+)
+
+func TestAtlasPolicyValidation(t *testing.T) {
+	policy := &mdbv1.AtlasPolicy{
+		Spec: mdbv1.AtlasPolicySpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"environment": "dev"}},
+			Deployments: mdbv1.DeploymentPolicy{
+				AllowedInstanceSizes: []string{"M10", "M20"},
+				MaxInstanceSize:      "M40",
+				AllowedProviders:     []provider.ProviderName{provider.ProviderAWS},
+			},
+		},
+	}
+	assert.NoError(t, AtlasPolicy(policy))
+
+	invalid := policy.DeepCopy()
+	invalid.Spec.NamespaceSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "environment", Operator: "Like"}}
+	invalid.Spec.Deployments.AllowedInstanceSizes = []string{"X10"}
+	invalid.Spec.Deployments.MaxInstanceSize = "M"
+	invalid.Spec.Deployments.AllowedProviders = []provider.ProviderName{provider.ProviderTenant}
+	err := AtlasPolicy(invalid)
+	assert.ErrorContains(t, err, "the namespace selector is invalid")
+	assert.ErrorContains(t, err, "the allowed instance size \"X10\" is invalid")
+	assert.ErrorContains(t, err, "the maximum instance size \"M\" is invalid")
+	assert.ErrorContains(t, err, "the allowed provider \"TENANT\" is invalid")
+}
+
+func TestDeploymentPolicyViolations(t *testing.T) {
+	t.Run("a deployment within the limits", func(t *testing.T) {
+		limits := &mdbv1.DeploymentPolicy{
+			AllowedInstanceSizes: []string{"M10"},
+			MaxInstanceSize:      "M40",
+			MaxNodeCount:         toptr.MakePtr(3),
+			MaxRegions:           toptr.MakePtr(1),
+			AllowedProviders:     []provider.ProviderName{provider.ProviderAWS},
+			AllowedRegions:       []string{"US_EAST_1"},
+		}
+		assert.Empty(t, DeploymentPolicyViolations(limits, &mdbv1.NewDeployment("dev", "deployment", "deployment").Spec))
+	})
+
+	t.Run("instance sizes above the maximum", func(t *testing.T) {
+		deployment := mdbv1.NewDeployment("dev", "deployment", "deployment")
+		region := deployment.Spec.DeploymentSpec.ReplicationSpecs[0].RegionConfigs[0]
+		region.ElectableSpecs.InstanceSize = "M50"
+		region.AutoScaling = &mdbv1.AdvancedAutoScalingSpec{
+			Compute: &mdbv1.ComputeSpec{Enabled: toptr.MakePtr(true), MinInstanceSize: "M30", MaxInstanceSize: "R40"},
+		}
+
+		assert.Equal(t, []string{
+			"the instance size M50 of the electable nodes in US_EAST_1 is above the maximum M40",
+			"the instance size R40 of the compute autoscaling in US_EAST_1 is above the maximum M40",
+		}, DeploymentPolicyViolations(&mdbv1.DeploymentPolicy{MaxInstanceSize: "M40"}, &deployment.Spec))
+	})
+
+	t.Run("instance sizes which aren't allowed", func(t *testing.T) {
+		deployment := mdbv1.NewDeployment("dev", "deployment", "deployment")
+		deployment.Spec.DeploymentSpec.ReplicationSpecs[0].RegionConfigs[0].AnalyticsSpecs = &mdbv1.Specs{InstanceSize: "M30", NodeCount: toptr.MakePtr(1)}
+
+		assert.Equal(t, []string{
+			"the instance size M30 of the analytics nodes in US_EAST_1 isn't allowed. allowed instance sizes are M10, M20",
+		}, DeploymentPolicyViolations(&mdbv1.DeploymentPolicy{AllowedInstanceSizes: []string{"M10", "M20"}}, &deployment.Spec))
+	})
+
+	t.Run("the nodes of all the shards are counted", func(t *testing.T) {
+		deployment := mdbv1.NewDeployment("dev", "deployment", "deployment")
+		deployment.Spec.DeploymentSpec.ClusterType = "SHARDED"
+		deployment.Spec.DeploymentSpec.ReplicationSpecs[0].NumShards = 2
+
+		assert.Equal(t, []string{
+			"the deployment has 6 nodes, the maximum is 5",
+		}, DeploymentPolicyViolations(&mdbv1.DeploymentPolicy{MaxNodeCount: toptr.MakePtr(5)}, &deployment.Spec))
+	})
+
+	t.Run("multi-region deployments", func(t *testing.T) {
+		deployment := mdbv1.NewDeployment("dev", "deployment", "deployment")
+		regions := &deployment.Spec.DeploymentSpec.ReplicationSpecs[0].RegionConfigs
+		*regions = append(*regions, &mdbv1.AdvancedRegionConfig{
+			ElectableSpecs: &mdbv1.Specs{InstanceSize: "M10", NodeCount: toptr.MakePtr(2)},
+			ProviderName:   "GCP",
+			RegionName:     "WESTERN_EUROPE",
+		})
+		limits := &mdbv1.DeploymentPolicy{
+			MaxRegions:       toptr.MakePtr(1),
+			AllowedProviders: []provider.ProviderName{provider.ProviderAWS},
+			AllowedRegions:   []string{"US_EAST_1"},
+		}
+
+		assert.Equal(t, []string{
+			"the provider GCP of the region WESTERN_EUROPE isn't allowed",
+			"the region WESTERN_EUROPE isn't allowed",
+			"the deployment spans 2 regions, the maximum is 1",
+		}, DeploymentPolicyViolations(limits, &deployment.Spec))
+	})
+
+	t.Run("mandatory autoscaling", func(t *testing.T) {
+		deployment := mdbv1.NewDeployment("dev", "deployment", "deployment")
+		deployment.Spec.DeploymentSpec.ReplicationSpecs[0].RegionConfigs[0].AutoScaling = &mdbv1.AdvancedAutoScalingSpec{
+			Compute: &mdbv1.ComputeSpec{Enabled: toptr.MakePtr(true), MaxInstanceSize: "M30"},
+		}
+		limits := &mdbv1.DeploymentPolicy{AutoScaling: &mdbv1.AutoScalingPolicy{ComputeRequired: true, DiskGBRequired: true}}
+
+		assert.Equal(t, []string{
+			"the compute autoscaling in US_EAST_1 must be enabled with its minimum and maximum instance sizes",
+			"the disk autoscaling in US_EAST_1 must be enabled",
+		}, DeploymentPolicyViolations(limits, &deployment.Spec))
+	})
+
+	t.Run("the backing provider of tenant and serverless deployments", func(t *testing.T) {
+		tenant := mdbv1.NewDeployment("dev", "deployment", "deployment")
+		region := tenant.Spec.DeploymentSpec.ReplicationSpecs[0].RegionConfigs[0]
+		region.ProviderName = string(provider.ProviderTenant)
+		region.ElectableSpecs.InstanceSize = "M0"
+		limits := &mdbv1.DeploymentPolicy{
+			AllowedProviders: []provider.ProviderName{provider.ProviderGCP},
+			AutoScaling:      &mdbv1.AutoScalingPolicy{ComputeRequired: true},
+		}
+
+		assert.Equal(t, []string{"the provider AWS of the region US_EAST_1 isn't allowed"}, DeploymentPolicyViolations(limits, &tenant.Spec))
+		serverless := mdbv1.NewDefaultAWSServerlessInstance("dev", "project")
+		assert.Equal(t, []string{"the provider AWS of the region US_EAST_1 isn't allowed"}, DeploymentPolicyViolations(limits, &serverless.Spec))
+	})
+}
@@ -1,11 +1,16 @@
 package validate
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
 	"reflect"
 	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/timeutil"
 
@@ -13,6 +18,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
 )
@@ -49,6 +55,20 @@ func DeploymentSpec(deploymentSpec *mdbv1.AtlasDeploymentSpec, isGov bool, regio
 		if instanceSizeRangeErr != nil {
 			err = errors.Join(err, instanceSizeRangeErr)
 		}
+
+		onlineArchivesErr := onlineArchives(deploymentSpec.DeploymentSpec.OnlineArchives)
+		if onlineArchivesErr != nil {
+			err = errors.Join(err, onlineArchivesErr)
+		}
+
+		pauseScheduleErr := pauseSchedule(deploymentSpec.DeploymentSpec)
+		if pauseScheduleErr != nil {
+			err = errors.Join(err, pauseScheduleErr)
+		}
+
+		if deploymentSpec.DeploymentSpec.UpgradePolicy != nil && deploymentSpec.DeploymentSpec.VersionReleaseSystem == "CONTINUOUS" {
+			err = errors.Join(err, errors.New("upgradePolicy can't be set with the CONTINUOUS version release system, which has no major versions"))
+		}
 	}
 
 	return err
@@ -72,6 +92,10 @@ func deploymentForGov(deployment *mdbv1.AtlasDeploymentSpec, regionUsageRestrict
 }
 
 func Project(project *mdbv1.AtlasProject, isGov bool) error {
+	if project.Spec.ConnectionSecret != nil && project.Spec.AtlasConnectionRef != nil {
+		return errors.New("connectionSecretRef and atlasConnectionRef can't be used together")
+	}
+
 	if !isGov && project.Spec.RegionUsageRestrictions != "" && project.Spec.RegionUsageRestrictions != "NONE" {
 		return errors.New("regionUsageRestriction can be used only with Atlas for government")
 	}
@@ -102,18 +126,7 @@ func Project(project *mdbv1.AtlasProject, isGov bool) error {
 func projectForGov(project *mdbv1.AtlasProject) error {
 	var err error
 
-	if len(project.Spec.NetworkPeers) > 0 {
-		for _, peer := range project.Spec.NetworkPeers {
-			if peer.ProviderName != "AWS" {
-				err = errors.Join(err, errors.New("atlas for government only supports AWS provider. one or more network peers are not set to AWS"))
-			}
-
-			regionErr := validCloudGovRegion(project.Spec.RegionUsageRestrictions, peer.AccepterRegionName)
-			if regionErr != nil {
-				err = errors.Join(err, fmt.Errorf("network peering in atlas for government support a restricted set of regions: %w", regionErr))
-			}
-		}
-	}
+	err = errors.Join(err, networkPeersForGov(project.Spec.NetworkPeers, project.Spec.RegionUsageRestrictions))
 
 	if project.Spec.EncryptionAtRest != nil {
 		if project.Spec.EncryptionAtRest.AzureKeyVault.Enabled != nil && *project.Spec.EncryptionAtRest.AzureKeyVault.Enabled {
@@ -132,16 +145,39 @@ func projectForGov(project *mdbv1.AtlasProject) error {
 		}
 	}
 
-	if len(project.Spec.PrivateEndpoints) > 0 {
-		for _, pe := range project.Spec.PrivateEndpoints {
-			if pe.Provider != "AWS" {
-				err = errors.Join(err, errors.New("atlas for government only supports AWS provider. one or more private endpoints are not set to AWS"))
-			}
+	err = errors.Join(err, privateEndpointsForGov(project.Spec.PrivateEndpoints, project.Spec.RegionUsageRestrictions))
 
-			regionErr := validCloudGovRegion(project.Spec.RegionUsageRestrictions, pe.Region)
-			if regionErr != nil {
-				err = errors.Join(err, fmt.Errorf("private endpoint in atlas for government support a restricted set of regions: %w", regionErr))
-			}
+	return err
+}
+
+func networkPeersForGov(peers []mdbv1.NetworkPeer, regionUsageRestrictions string) error {
+	var err error
+
+	for _, peer := range peers {
+		if peer.ProviderName != "AWS" {
+			err = errors.Join(err, errors.New("atlas for government only supports AWS provider. one or more network peers are not set to AWS"))
+		}
+
+		regionErr := validCloudGovRegion(regionUsageRestrictions, peer.AccepterRegionName)
+		if regionErr != nil {
+			err = errors.Join(err, fmt.Errorf("network peering in atlas for government support a restricted set of regions: %w", regionErr))
+		}
+	}
+
+	return err
+}
+
+func privateEndpointsForGov(privateEndpoints []mdbv1.PrivateEndpoint, regionUsageRestrictions string) error {
+	var err error
+
+	for _, pe := range privateEndpoints {
+		if pe.Provider != "AWS" {
+			err = errors.Join(err, errors.New("atlas for government only supports AWS provider. one or more private endpoints are not set to AWS"))
+		}
+
+		regionErr := validCloudGovRegion(regionUsageRestrictions, pe.Region)
+		if regionErr != nil {
+			err = errors.Join(err, fmt.Errorf("private endpoint in atlas for government support a restricted set of regions: %w", regionErr))
 		}
 	}
 
@@ -180,10 +216,84 @@ func validCloudGovRegion(restriction, region string) error {
 	return nil
 }
 
-func DatabaseUser(_ *mdbv1.AtlasDatabaseUser) error {
-	return nil
+func DatabaseUser(dbUser *mdbv1.AtlasDatabaseUser) error {
+	var err error
+	spec := dbUser.Spec
+
+	switch {
+	case spec.ProjectSelector != nil && spec.Project.Name != "":
+		err = errors.Join(err, errors.New("only one of projectRef and projectSelector can be set"))
+	case spec.ProjectSelector == nil && spec.Project.Name == "":
+		err = errors.Join(err, errors.New("either projectRef or projectSelector must be set"))
+	case spec.ProjectSelector != nil:
+		// An empty selector would create the user in all the projects of the cluster, including the ones of other
+		// namespaces
+		if selector, selectorErr := metav1.LabelSelectorAsSelector(spec.ProjectSelector); selectorErr != nil {
+			err = errors.Join(err, fmt.Errorf("invalid projectSelector: %w", selectorErr))
+		} else if selector.Empty() {
+			err = errors.Join(err, errors.New("projectSelector must select the projects by matchLabels or matchExpressions, an empty selector matching all the projects is not allowed"))
+		}
+	}
+
+	var authTypes []string
+	for name, value := range map[string]string{
+		"x509Type":     spec.X509Type,
+		"awsIAMType":   spec.AWSIAMType,
+		"ldapAuthType": spec.LDAPAuthType,
+		"oidcAuthType": spec.OIDCAuthType,
+	} {
+		if value != "" && value != mdbv1.NoneAuthType {
+			authTypes = append(authTypes, name)
+		}
+	}
+	sort.Strings(authTypes)
+
+	if len(authTypes) > 1 {
+		err = errors.Join(err, fmt.Errorf("only one authentication type can be enabled for a database user, but %s were set", strings.Join(authTypes, ", ")))
+	}
+
+	if len(authTypes) > 0 {
+		// X.509 users ignore the password, and LDAP users may keep their LDAP password for the connection Secrets
+		authType, value := spec.AuthType()
+		passwordAllowed := authType == "x509Type" || (authType == "ldapAuthType" && value == "USER")
+		if spec.PasswordSecret != nil && spec.PasswordSecret.Name != "" && !passwordAllowed {
+			err = errors.Join(err, fmt.Errorf("passwordSecretRef must not be set for a database user with %s authentication", authTypes[0]))
+		}
+
+		switch spec.DatabaseName {
+		case "", mdbv1.AdminAuthDatabase, mdbv1.ExternalAuthDatabase:
+		default:
+			err = errors.Join(err, fmt.Errorf("databaseName must be %q or %q for a database user with %s authentication, but was %q", mdbv1.AdminAuthDatabase, mdbv1.ExternalAuthDatabase, authTypes[0], spec.DatabaseName))
+		}
+	}
+
+	if tmpl := spec.ConnectionSecretTemplate; tmpl != nil {
+		if tmpl.Name != "" {
+			if _, parseErr := tmpl.ParseName(); parseErr != nil {
+				err = errors.Join(err, parseErr)
+			}
+		}
+
+		if _, parseErr := tmpl.ParseData(); parseErr != nil {
+			err = errors.Join(err, parseErr)
+		}
+	}
+
+	if spec.PasswordRotation != nil {
+		if spec.PasswordSecret == nil || spec.PasswordSecret.Name == "" {
+			err = errors.Join(err, errors.New("passwordRotation requires passwordSecretRef to be set"))
+		}
+
+		if spec.PasswordRotation.Interval.Duration < time.Hour {
+			err = errors.Join(err, fmt.Errorf("passwordRotation interval must be at least 1h, but was %s", spec.PasswordRotation.Interval.Duration))
+		}
+	}
+
+	return err
 }
 
+// BackupSchedule validates the backup schedule against the deployment it's applied to.
+// If the deployment is nil only the checks not depending on the deployment are performed.
 func BackupSchedule(bSchedule *mdbv1.AtlasBackupSchedule, deployment *mdbv1.AtlasDeployment) error {
 	var err error
 
@@ -191,6 +301,16 @@ func BackupSchedule(bSchedule *mdbv1.AtlasBackupSchedule, deployment *mdbv1.Atla
 		err = errors.Join(err, errors.New("you must specify export policy when auto export is enabled"))
 	}
 
+	if deployment == nil {
+		for position, copySetting := range bSchedule.Spec.CopySettings {
+			if copySetting.RegionName == nil {
+				err = errors.Join(err, fmt.Errorf("copy setting at position %d: you must set a region name", position))
+			}
+		}
+
+		return err
+	}
+
 	replicaSets := map[string]struct{}{}
 	if deployment.Status.ReplicaSets != nil {
 		for _, replicaSet := range deployment.Status.ReplicaSets {
@@ -219,6 +339,206 @@ func BackupSchedule(bSchedule *mdbv1.AtlasBackupSchedule, deployment *mdbv1.Atla
 	return err
 }
 
+func BackupPolicy(bPolicy *mdbv1.AtlasBackupPolicy) error {
+	var err error
+	frequencies := map[string]int{}
+
+	for position, item := range bPolicy.Spec.Items {
+		if item.RetentionValue <= 0 {
+			err = errors.Join(err, fmt.Errorf("policy item at position %d: retention value must be positive", position))
+		}
+
+		frequencies[item.FrequencyType]++
+	}
+
+	for _, frequencyType := range []string{"hourly", "daily"} {
+		if frequencies[frequencyType] > 1 {
+			err = errors.Join(err, fmt.Errorf("you cannot specify multiple %s backup policy items", frequencyType))
+		}
+	}
+
+	return err
+}
+
+func IPAccessList(ipAccessList *mdbv1.AtlasIPAccessList) error {
+	return projectIPAccessList(ipAccessList.Spec.Entries)
+}
+
+func NetworkPeering(networkPeering *mdbv1.AtlasNetworkPeering, isGov bool, regionUsageRestrictions string) error {
+	if !isGov {
+		return nil
+	}
+
+	return networkPeersForGov(networkPeering.Spec.NetworkPeers, regionUsageRestrictions)
+}
+
+func PrivateEndpoint(privateEndpoint *mdbv1.AtlasPrivateEndpoint, isGov bool, regionUsageRestrictions string) error {
+	if !isGov {
+		return nil
+	}
+
+	return privateEndpointsForGov(privateEndpoint.Spec.PrivateEndpoints, regionUsageRestrictions)
+}
+
+func Team(team *mdbv1.AtlasTeam) error {
+	var err error
+	usernames := map[mdbv1.TeamUser]struct{}{}
+
+	for _, username := range team.Spec.Usernames {
+		if _, ok := usernames[username]; ok {
+			err = errors.Join(err, fmt.Errorf("the username \"%s\" is duplicate. usernames in a team must be unique", username))
+		}
+
+		usernames[username] = struct{}{}
+	}
+
+	return err
+}
+
+func DataFederation(dataFederation *mdbv1.AtlasDataFederation) error {
+	var err error
+	endpoints := map[string]struct{}{}
+
+	for _, pe := range dataFederation.Spec.PrivateEndpoints {
+		if _, ok := endpoints[pe.EndpointID]; ok {
+			err = errors.Join(err, fmt.Errorf("the private endpoint \"%s\" is duplicate. private endpoint ID must be unique", pe.EndpointID))
+		}
+
+		endpoints[pe.EndpointID] = struct{}{}
+	}
+
+	return err
+}
+
+func SearchIndex(searchIndex *mdbv1.AtlasSearchIndex) error {
+	var err error
+
+	mappings := searchIndex.Spec.Mappings
+	if !mappings.Dynamic && mappings.Fields == nil {
+		err = errors.Join(err, errors.New("mappings.fields must be set when the dynamic mapping is disabled"))
+	}
+
+	if _, convErr := searchIndex.Spec.ToAtlas(); convErr != nil {
+		err = errors.Join(err, convErr)
+	}
+
+	synonyms := map[string]struct{}{}
+	for _, synonym := range searchIndex.Spec.Synonyms {
+		if _, ok := synonyms[synonym.Name]; ok {
+			err = errors.Join(err, fmt.Errorf("the synonym mapping \"%s\" is duplicate. synonym mapping names must be unique", synonym.Name))
+		}
+
+		synonyms[synonym.Name] = struct{}{}
+	}
+
+	return err
+}
+
+func BackupRestoreJob(restoreJob *mdbv1.AtlasBackupRestoreJob) error {
+	var err error
+	spec := restoreJob.Spec
+
+	snapshotSet := spec.SnapshotRef != nil || spec.SnapshotID != ""
+	pointInTimeSet := spec.PointInTimeUTCSeconds != 0 || spec.OplogTs != 0 || spec.OplogInc != 0
+
+	switch spec.DeliveryType {
+	case mdbv1.RestoreDeliveryAutomated, mdbv1.RestoreDeliveryDownload:
+		if spec.SnapshotRef != nil && spec.SnapshotID != "" {
+			err = errors.Join(err, errors.New("only one of snapshotRef and snapshotID can be set"))
+		}
+		if !snapshotSet {
+			err = errors.Join(err, fmt.Errorf("the %s restore requires either snapshotRef or snapshotID", spec.DeliveryType))
+		}
+		if pointInTimeSet {
+			err = errors.Join(err, fmt.Errorf("the point in time can't be set for the %s restore", spec.DeliveryType))
+		}
+	case mdbv1.RestoreDeliveryPointInTime:
+		if snapshotSet {
+			err = errors.Join(err, errors.New("the snapshot can't be set for the pointInTime restore"))
+		}
+		oplogSet := spec.OplogTs != 0 || spec.OplogInc != 0
+		switch {
+		case spec.PointInTimeUTCSeconds != 0 && oplogSet:
+			err = errors.Join(err, errors.New("only one of pointInTimeUTCSeconds and the oplog timestamp can be set"))
+		case oplogSet && (spec.OplogTs == 0 || spec.OplogInc == 0):
+			err = errors.Join(err, errors.New("oplogTs and oplogInc must be set together"))
+		case !pointInTimeSet:
+			err = errors.Join(err, errors.New("the pointInTime restore requires either pointInTimeUTCSeconds or oplogTs and oplogInc"))
+		}
+	}
+
+	if spec.DeliveryType == mdbv1.RestoreDeliveryDownload {
+		if spec.TargetDeploymentRef != nil {
+			err = errors.Join(err, errors.New("targetDeploymentRef can't be set for the download restore"))
+		}
+	} else if spec.TargetDeploymentRef == nil {
+		err = errors.Join(err, fmt.Errorf("the %s restore requires targetDeploymentRef", spec.DeliveryType))
+	}
+
+	return err
+}
+
+func FederatedAuth(fedAuth *mdbv1.AtlasFederatedAuth) error {
+	var err error
+	if fedAuth.Spec.ConnectionSecretRef.Name != "" && fedAuth.Spec.AtlasConnectionRef != nil {
+		err = errors.New("connectionSecretRef and atlasConnectionRef can't be used together")
+	}
+
+	groups := map[string]struct{}{}
+
+	for _, roleMapping := range fedAuth.Spec.RoleMappings {
+		if _, ok := groups[roleMapping.ExternalGroupName]; ok {
+			err = errors.Join(err, fmt.Errorf("the role mapping for the group \"%s\" is duplicate. external group name must be unique", roleMapping.ExternalGroupName))
+		}
+		groups[roleMapping.ExternalGroupName] = struct{}{}
+
+		for _, assignment := range roleMapping.RoleAssignments {
+			isProjectRole := strings.HasPrefix(assignment.Role, "GROUP_")
+			if isProjectRole && assignment.ProjectName == "" {
+				err = errors.Join(err, fmt.Errorf("role mapping for the group \"%s\": project role %s requires a project name", roleMapping.ExternalGroupName, assignment.Role))
+			}
+
+			if !isProjectRole && assignment.ProjectName != "" {
+				err = errors.Join(err, fmt.Errorf("role mapping for the group \"%s\": organization role %s can't be assigned to a project", roleMapping.ExternalGroupName, assignment.Role))
+			}
+		}
+	}
+
+	return err
+}
+
+// AtlasConnection checks the connection has an organization, an absolute Atlas domain if any, a credentials Secret
+// with a namespace and the namespaces allowed to use it, as the AtlasConnections are cluster scoped
+func AtlasConnection(atlasConnection *mdbv1.AtlasConnection) error {
+	var err error
+	if atlasConnection.Spec.OrgID == "" {
+		err = errors.Join(err, errors.New("orgId must be set"))
+	}
+
+	if domain := atlasConnection.Spec.AtlasDomain; domain != "" {
+		domainURL, parseErr := url.Parse(domain)
+		if parseErr != nil || domainURL.Scheme == "" || domainURL.Host == "" {
+			err = errors.Join(err, fmt.Errorf("atlasDomain %q must be an absolute URL, e.g. https://cloud.mongodb.com/", domain))
+		}
+	}
+
+	secretRef := atlasConnection.Spec.CredentialsSecretRef
+	if secretRef.Name == "" || secretRef.Namespace == "" {
+		err = errors.Join(err, errors.New("credentialsSecretRef must have both the name and the namespace of the Secret"))
+	}
+
+	if len(atlasConnection.Spec.AllowedNamespaces) == 0 {
+		err = errors.Join(err, errors.New(`allowedNamespaces must list at least one namespace, or "*" for all the namespaces`))
+	}
+	for _, namespace := range atlasConnection.Spec.AllowedNamespaces {
+		if namespace == "" {
+			err = errors.Join(err, errors.New("allowedNamespaces can't have an empty namespace"))
+		}
+	}
+
+	return err
+}
+
 func getNonNilCount(values ...interface{}) int {
 	nonNilCount := 0
 	for _, v := range values {
@@ -448,6 +768,75 @@ func projectCustomRoles(customRoles []mdbv1.CustomRole) error {
 	return err
 }
 
+func onlineArchives(archives []mdbv1.OnlineArchive) error {
+	var err error
+	namespaces := map[string]struct{}{}
+
+	for _, archive := range archives {
+		if _, ok := namespaces[archive.Namespace()]; ok {
+			err = errors.Join(err, fmt.Errorf("the online archive of \"%s\" is duplicate. a collection can only have one online archive", archive.Namespace()))
+		}
+		namespaces[archive.Namespace()] = struct{}{}
+
+		switch archive.Criteria.Type {
+		case mdbv1.OnlineArchiveCriteriaDate:
+			if archive.Criteria.DateField == "" || archive.Criteria.ExpireAfterDays == 0 {
+				err = errors.Join(err, fmt.Errorf("the online archive of \"%s\" must set dateField and expireAfterDays with the DATE criteria", archive.Namespace()))
+			}
+		case mdbv1.OnlineArchiveCriteriaCustom:
+			if !json.Valid([]byte(archive.Criteria.Query)) {
+				err = errors.Join(err, fmt.Errorf("the online archive of \"%s\" must set a JSON query with the CUSTOM criteria", archive.Namespace()))
+			}
+		}
+
+		fields := map[string]struct{}{}
+		for _, field := range archive.PartitionFields {
+			if _, ok := fields[field.FieldName]; ok {
+				err = errors.Join(err, fmt.Errorf("the partition field \"%s\" of the online archive of \"%s\" is duplicate", field.FieldName, archive.Namespace()))
+			}
+			fields[field.FieldName] = struct{}{}
+		}
+	}
+
+	return err
+}
+
+var (
+	pauseWindowStart = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+	pauseWindowEnd   = regexp.MustCompile(`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`)
+)
+
+func pauseSchedule(deployment *mdbv1.AdvancedDeploymentSpec) error {
+	schedule := deployment.PauseSchedule
+	if schedule == nil {
+		return nil
+	}
+
+	var err error
+	if deployment.Paused != nil {
+		err = errors.Join(err, errors.New("paused and pauseSchedule can't be set together"))
+	}
+
+	if _, tzErr := time.LoadLocation(schedule.TimeZone); tzErr != nil {
+		err = errors.Join(err, fmt.Errorf("the time zone \"%s\" of the pause schedule is invalid: %w", schedule.TimeZone, tzErr))
+	}
+
+	if len(schedule.Windows) == 0 {
+		err = errors.Join(err, errors.New("the pause schedule must have at least one window"))
+	}
+
+	for i, window := range schedule.Windows {
+		if !pauseWindowStart.MatchString(window.Start) {
+			err = errors.Join(err, fmt.Errorf("the start \"%s\" of the pause window %d must be a HH:MM time", window.Start, i))
+		}
+		if !pauseWindowEnd.MatchString(window.End) {
+			err = errors.Join(err, fmt.Errorf("the end \"%s\" of the pause window %d must be a HH:MM time or 24:00", window.End, i))
+		}
+	}
+
+	return err
+}
+
 func alertConfigs(alertConfigs []mdbv1.AlertConfiguration) error {
 	seenConfigs := []mdbv1.AlertConfiguration{}
 	for j, cfg := range alertConfigs {
@@ -2,7 +2,12 @@ package validate
 
 import (
 	"testing"
+	"time"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/status"
@@ -162,6 +167,15 @@ func TestClusterValidation(t *testing.T) {
 				assert.Error(t, DeploymentSpec(&spec, false, "NONE"))
 			})
 		})
+		t.Run("Upgrade policy with continuous releases", func(t *testing.T) {
+			spec := mdbv1.AtlasDeploymentSpec{
+				DeploymentSpec: &mdbv1.AdvancedDeploymentSpec{
+					UpgradePolicy:        &mdbv1.UpgradePolicy{RequireApproval: true},
+					VersionReleaseSystem: "CONTINUOUS",
+				},
+			}
+			assert.EqualError(t, DeploymentSpec(&spec, false, "NONE"), "upgradePolicy can't be set with the CONTINUOUS version release system, which has no major versions")
+		})
 	})
 	t.Run("Valid cluster specs", func(t *testing.T) {
 		t.Run("Advanced cluster spec specified", func(t *testing.T) {
@@ -295,6 +309,17 @@ func TestProjectValidation(t *testing.T) {
 		assert.NoError(t, Project(akoProject, false))
 	})
 
+	t.Run("should fail when both the connection secret and the atlas connection are set", func(t *testing.T) {
+		akoProject := &mdbv1.AtlasProject{
+			Spec: mdbv1.AtlasProjectSpec{
+				ConnectionSecret:   &common.ResourceRefNamespaced{Name: "credentials"},
+				AtlasConnectionRef: &common.ResourceRef{Name: "gov"},
+			},
+		}
+
+		assert.EqualError(t, Project(akoProject, false), "connectionSecretRef and atlasConnectionRef can't be used together")
+	})
+
 	t.Run("custom roles spec", func(t *testing.T) {
 		t.Run("empty custom roles spec", func(t *testing.T) {
 			spec := &mdbv1.AtlasProject{
@@ -658,6 +683,292 @@ func TestBackupScheduleValidation(t *testing.T) {
 	})
 }
 
+func TestBackupScheduleWithoutDeployment(t *testing.T) {
+	t.Run("copy settings are checked without a deployment", func(t *testing.T) {
+		bSchedule := &mdbv1.AtlasBackupSchedule{
+			Spec: mdbv1.AtlasBackupScheduleSpec{
+				CopySettings: []mdbv1.CopySetting{
+					{
+						CloudProvider: toptr.MakePtr("AWS"),
+					},
+				},
+			},
+		}
+		assert.ErrorContains(t, BackupSchedule(bSchedule, nil), "copy setting at position 0: you must set a region name")
+	})
+
+	t.Run("copy settings don't require the replication status without a deployment", func(t *testing.T) {
+		bSchedule := &mdbv1.AtlasBackupSchedule{
+			Spec: mdbv1.AtlasBackupScheduleSpec{
+				CopySettings: []mdbv1.CopySetting{
+					{
+						RegionName:       toptr.MakePtr("US_WEST_1"),
+						CloudProvider:    toptr.MakePtr("AWS"),
+						ShouldCopyOplogs: toptr.MakePtr(true),
+					},
+				},
+			},
+		}
+		assert.NoError(t, BackupSchedule(bSchedule, nil))
+	})
+}
+
+func TestBackupPolicyValidation(t *testing.T) {
+	t.Run("valid policy", func(t *testing.T) {
+		bPolicy := &mdbv1.AtlasBackupPolicy{
+			Spec: mdbv1.AtlasBackupPolicySpec{
+				Items: []mdbv1.AtlasBackupPolicyItem{
+					{FrequencyType: "hourly", FrequencyInterval: 6, RetentionUnit: "days", RetentionValue: 2},
+					{FrequencyType: "weekly", FrequencyInterval: 1, RetentionUnit: "weeks", RetentionValue: 4},
+					{FrequencyType: "weekly", FrequencyInterval: 5, RetentionUnit: "weeks", RetentionValue: 4},
+				},
+			},
+		}
+		assert.NoError(t, BackupPolicy(bPolicy))
+	})
+
+	t.Run("duplicate daily items and invalid retention", func(t *testing.T) {
+		bPolicy := &mdbv1.AtlasBackupPolicy{
+			Spec: mdbv1.AtlasBackupPolicySpec{
+				Items: []mdbv1.AtlasBackupPolicyItem{
+					{FrequencyType: "daily", FrequencyInterval: 1, RetentionUnit: "days", RetentionValue: 7},
+					{FrequencyType: "daily", FrequencyInterval: 2, RetentionUnit: "days", RetentionValue: 0},
+				},
+			},
+		}
+		err := BackupPolicy(bPolicy)
+		assert.ErrorContains(t, err, "policy item at position 1: retention value must be positive")
+		assert.ErrorContains(t, err, "you cannot specify multiple daily backup policy items")
+	})
+}
+
+func TestDatabaseUserValidation(t *testing.T) {
+	t.Run("valid SCRAM user", func(t *testing.T) {
+		dbUser := mdbv1.DefaultDBUser("ns", "user", "project").WithPasswordSecret("user-password")
+		assert.NoError(t, DatabaseUser(dbUser))
+	})
+
+	t.Run("valid external users", func(t *testing.T) {
+		for _, spec := range []mdbv1.AtlasDatabaseUserSpec{
+			{Username: "arn:aws:iam::123456789012:role/app", DatabaseName: "$external", AWSIAMType: "ROLE"},
+			{Username: "CN=user,OU=users,DC=example,DC=com", DatabaseName: "admin", LDAPAuthType: "USER", X509Type: "NONE"},
+			{Username: "idp/group", OIDCAuthType: "IDP_GROUP"},
+		} {
+			spec.Project = common.ResourceRefNamespaced{Name: "project"}
+			assert.NoError(t, DatabaseUser(&mdbv1.AtlasDatabaseUser{Spec: spec}))
+		}
+	})
+
+	t.Run("multiple authentication types", func(t *testing.T) {
+		dbUser := &mdbv1.AtlasDatabaseUser{
+			Spec: mdbv1.AtlasDatabaseUserSpec{
+				Project:      common.ResourceRefNamespaced{Name: "project"},
+				Username:     "user",
+				DatabaseName: "$external",
+				AWSIAMType:   "USER",
+				LDAPAuthType: "USER",
+			},
+		}
+		assert.EqualError(t, DatabaseUser(dbUser), "only one authentication type can be enabled for a database user, but awsIAMType, ldapAuthType were set")
+	})
+
+	t.Run("external user with password and database", func(t *testing.T) {
+		dbUser := mdbv1.DefaultDBUser("ns", "user", "project").WithPasswordSecret("user-password")
+		dbUser.Spec.DatabaseName = "test"
+		dbUser.Spec.OIDCAuthType = "USER"
+		err := DatabaseUser(dbUser)
+		assert.ErrorContains(t, err, "passwordSecretRef must not be set for a database user with oidcAuthType authentication")
+		assert.ErrorContains(t, err, `databaseName must be "admin" or "$external" for a database user with oidcAuthType authentication, but was "test"`)
+	})
+
+	t.Run("external user allowed to keep a password", func(t *testing.T) {
+		dbUser := mdbv1.DefaultDBUser("ns", "user", "project").WithPasswordSecret("user-password")
+		dbUser.Spec.X509Type = "MANAGED"
+		assert.NoError(t, DatabaseUser(dbUser), "the password of an X.509 user is ignored")
+
+		dbUser.Spec.X509Type = ""
+		dbUser.Spec.LDAPAuthType = "USER"
+		assert.NoError(t, DatabaseUser(dbUser), "the LDAP password is used by the connection secrets")
+
+		dbUser.Spec.LDAPAuthType = "GROUP"
+		assert.ErrorContains(t, DatabaseUser(dbUser), "passwordSecretRef must not be set for a database user with ldapAuthType authentication")
+	})
+
+	t.Run("connection secret template", func(t *testing.T) {
+		dbUser := mdbv1.DefaultDBUser("ns", "user", "project").WithPasswordSecret("user-password")
+		dbUser.Spec.ConnectionSecretTemplate = &mdbv1.ConnectionSecretTemplate{
+			Name: "{{ .ClusterName }}-{{ .DBUserName }}-app",
+			Data: map[string]string{"MONGODB_URI": "{{ .SrvConnURL }}"},
+		}
+		assert.NoError(t, DatabaseUser(dbUser))
+
+		dbUser.Spec.ConnectionSecretTemplate.Name = ""
+		assert.NoError(t, DatabaseUser(dbUser), "the default name is used without a name template")
+
+		dbUser.Spec.ConnectionSecretTemplate.Name = "{{ .ClusterName }}-app"
+		assert.ErrorContains(t, DatabaseUser(dbUser), "must reference both {{ .DBUserName }} and {{ .ClusterName }}")
+
+		dbUser.Spec.ConnectionSecretTemplate.Name = "{{ if .DBUserName }}{{ .DBUserName }}{{ end }}-app"
+		assert.ErrorContains(t, DatabaseUser(dbUser), "must reference both {{ .DBUserName }} and {{ .ClusterName }}")
+
+		dbUser.Spec.ConnectionSecretTemplate.Name = "{{ .ClusterName"
+		dbUser.Spec.ConnectionSecretTemplate.Data["MONGODB_URI"] = "{{ end }}"
+		err := DatabaseUser(dbUser)
+		assert.ErrorContains(t, err, "invalid connection secret name template")
+		assert.ErrorContains(t, err, "invalid connection secret template for key MONGODB_URI")
+	})
+
+	t.Run("password rotation", func(t *testing.T) {
+		dbUser := mdbv1.DefaultDBUser("ns", "user", "project").WithPasswordSecret("user-password")
+		dbUser.Spec.PasswordRotation = &mdbv1.PasswordRotationSpec{Interval: metav1.Duration{Duration: 720 * time.Hour}}
+		assert.NoError(t, DatabaseUser(dbUser))
+
+		dbUser.Spec.PasswordSecret = nil
+		dbUser.Spec.PasswordRotation.Interval.Duration = time.Minute
+		err := DatabaseUser(dbUser)
+		assert.ErrorContains(t, err, "passwordRotation requires passwordSecretRef to be set")
+		assert.ErrorContains(t, err, "passwordRotation interval must be at least 1h, but was 1m0s")
+	})
+
+	t.Run("project selector", func(t *testing.T) {
+		dbUser := mdbv1.DefaultDBUser("ns", "user", "").WithPasswordSecret("user-password")
+		assert.EqualError(t, DatabaseUser(dbUser), "either projectRef or projectSelector must be set")
+
+		dbUser.Spec.ProjectSelector = &metav1.LabelSelector{}
+		assert.ErrorContains(t, DatabaseUser(dbUser), "an empty selector matching all the projects is not allowed")
+
+		dbUser.Spec.ProjectSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}}
+		assert.NoError(t, DatabaseUser(dbUser))
+
+		dbUser.Spec.ProjectSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Unknown"}}
+		assert.ErrorContains(t, DatabaseUser(dbUser), "invalid projectSelector")
+
+		dbUser.Spec.Project.Name = "project"
+		assert.ErrorContains(t, DatabaseUser(dbUser), "only one of projectRef and projectSelector can be set")
+	})
+}
+
+func TestTeamValidation(t *testing.T) {
+	team := &mdbv1.AtlasTeam{
+		Spec: mdbv1.TeamSpec{
+			Name:      "team",
+			Usernames: []mdbv1.TeamUser{"user1@mongodb.com", "user2@mongodb.com"},
+		},
+	}
+	assert.NoError(t, Team(team))
+
+	team.Spec.Usernames = append(team.Spec.Usernames, "user1@mongodb.com")
+	assert.EqualError(t, Team(team), "the username \"user1@mongodb.com\" is duplicate. usernames in a team must be unique")
+}
+
+func TestDataFederationValidation(t *testing.T) {
+	dataFederation := &mdbv1.AtlasDataFederation{
+		Spec: mdbv1.DataFederationSpec{
+			PrivateEndpoints: []mdbv1.DataFederationPE{
+				{EndpointID: "vpce-1", Provider: "AWS", Type: "DATA_LAKE"},
+				{EndpointID: "vpce-2", Provider: "AWS", Type: "DATA_LAKE"},
+			},
+		},
+	}
+	assert.NoError(t, DataFederation(dataFederation))
+
+	dataFederation.Spec.PrivateEndpoints = append(dataFederation.Spec.PrivateEndpoints, mdbv1.DataFederationPE{EndpointID: "vpce-2"})
+	assert.EqualError(t, DataFederation(dataFederation), "the private endpoint \"vpce-2\" is duplicate. private endpoint ID must be unique")
+}
+
+func TestFederatedAuthValidation(t *testing.T) {
+	t.Run("valid role mappings", func(t *testing.T) {
+		fedAuth := &mdbv1.AtlasFederatedAuth{
+			Spec: mdbv1.AtlasFederatedAuthSpec{
+				RoleMappings: []mdbv1.RoleMapping{
+					{
+						ExternalGroupName: "admins",
+						RoleAssignments: []mdbv1.RoleAssignment{
+							{Role: "ORG_OWNER"},
+							{Role: "GROUP_OWNER", ProjectName: "test-project"},
+						},
+					},
+				},
+			},
+		}
+		assert.NoError(t, FederatedAuth(fedAuth))
+	})
+
+	t.Run("invalid role mappings", func(t *testing.T) {
+		fedAuth := &mdbv1.AtlasFederatedAuth{
+			Spec: mdbv1.AtlasFederatedAuthSpec{
+				RoleMappings: []mdbv1.RoleMapping{
+					{
+						ExternalGroupName: "admins",
+						RoleAssignments: []mdbv1.RoleAssignment{
+							{Role: "ORG_OWNER", ProjectName: "test-project"},
+						},
+					},
+					{
+						ExternalGroupName: "admins",
+						RoleAssignments: []mdbv1.RoleAssignment{
+							{Role: "GROUP_READ_ONLY"},
+						},
+					},
+				},
+			},
+		}
+		err := FederatedAuth(fedAuth)
+		assert.ErrorContains(t, err, "organization role ORG_OWNER can't be assigned to a project")
+		assert.ErrorContains(t, err, "the role mapping for the group \"admins\" is duplicate")
+		assert.ErrorContains(t, err, "project role GROUP_READ_ONLY requires a project name")
+	})
+
+	t.Run("both connection secret and atlas connection", func(t *testing.T) {
+		fedAuth := &mdbv1.AtlasFederatedAuth{
+			Spec: mdbv1.AtlasFederatedAuthSpec{
+				ConnectionSecretRef: common.ResourceRefNamespaced{Name: "credentials"},
+				AtlasConnectionRef:  &common.ResourceRef{Name: "gov"},
+			},
+		}
+		assert.EqualError(t, FederatedAuth(fedAuth), "connectionSecretRef and atlasConnectionRef can't be used together")
+	})
+}
+
+func TestAtlasConnectionValidation(t *testing.T) {
+	t.Run("valid connection", func(t *testing.T) {
+		atlasConnection := &mdbv1.AtlasConnection{
+			Spec: mdbv1.AtlasConnectionSpec{
+				AtlasDomain:          "https://cloud.mongodbgov.com/",
+				OrgID:                "org",
+				CredentialsSecretRef: common.ResourceRefNamespaced{Name: "credentials", Namespace: "ns"},
+				AllowedNamespaces:    []string{"projects"},
+			},
+		}
+		assert.NoError(t, AtlasConnection(atlasConnection))
+	})
+
+	t.Run("invalid connection", func(t *testing.T) {
+		atlasConnection := &mdbv1.AtlasConnection{
+			Spec: mdbv1.AtlasConnectionSpec{
+				AtlasDomain:          "cloud.mongodbgov.com",
+				CredentialsSecretRef: common.ResourceRefNamespaced{Name: "credentials"},
+				AllowedNamespaces:    []string{""},
+			},
+		}
+		err := AtlasConnection(atlasConnection)
+		assert.ErrorContains(t, err, "orgId must be set")
+		assert.ErrorContains(t, err, "atlasDomain \"cloud.mongodbgov.com\" must be an absolute URL")
+		assert.ErrorContains(t, err, "credentialsSecretRef must have both the name and the namespace of the Secret")
+		assert.ErrorContains(t, err, "allowedNamespaces can't have an empty namespace")
+	})
+
+	t.Run("no allowed namespaces", func(t *testing.T) {
+		atlasConnection := &mdbv1.AtlasConnection{
+			Spec: mdbv1.AtlasConnectionSpec{
+				OrgID:                "org",
+				CredentialsSecretRef: common.ResourceRefNamespaced{Name: "credentials", Namespace: "ns"},
+			},
+		}
+		assert.EqualError(t, AtlasConnection(atlasConnection), `allowedNamespaces must list at least one namespace, or "*" for all the namespaces`)
+	})
+}
+
 func TestProjectIpAccessList(t *testing.T) {
 	t.Run("should return no error for empty list", func(t *testing.T) {
 		assert.NoError(t, projectIPAccessList([]project.IPAccessList{}))
@@ -1128,3 +1439,195 @@ func TestAutoscalingForAdvancedDeployment(t *testing.T) {
 		assert.EqualError(t, autoscalingForAdvancedDeployment(replicationSpecs), "autoscaling must be the same for all regions and across all replication specs for advanced deployment")
 	})
 }
+
+func TestIPAccessListValidation(t *testing.T) {
+	ipAccessList := &mdbv1.AtlasIPAccessList{
+		Spec: mdbv1.AtlasIPAccessListSpec{
+			Entries: []project.IPAccessList{{IPAddress: "192.0.2.1"}},
+		},
+	}
+	assert.NoError(t, IPAccessList(ipAccessList))
+
+	ipAccessList.Spec.Entries = []project.IPAccessList{{IPAddress: "192.0.2.1", CIDRBlock: "10.0.0.0/24"}}
+	assert.Error(t, IPAccessList(ipAccessList))
+}
+
+func TestNetworkPeeringValidation(t *testing.T) {
+	networkPeering := &mdbv1.AtlasNetworkPeering{
+		Spec: mdbv1.AtlasNetworkPeeringSpec{
+			NetworkPeers: []mdbv1.NetworkPeer{{ProviderName: "GCP", AccepterRegionName: "europe-west-1"}},
+		},
+	}
+
+	t.Run("any peer is valid outside of Atlas for government", func(t *testing.T) {
+		assert.NoError(t, NetworkPeering(networkPeering, false, ""))
+	})
+
+	t.Run("the peers are restricted in Atlas for government", func(t *testing.T) {
+		assert.ErrorContains(t, NetworkPeering(networkPeering, true, "GOV_REGIONS_ONLY"), "atlas for government only supports AWS provider. one or more network peers are not set to AWS")
+	})
+}
+
+func TestPrivateEndpointValidation(t *testing.T) {
+	privateEndpoint := &mdbv1.AtlasPrivateEndpoint{
+		Spec: mdbv1.AtlasPrivateEndpointSpec{
+			PrivateEndpoints: []mdbv1.PrivateEndpoint{{Provider: "AWS", Region: "eu-east-1"}},
+		},
+	}
+
+	t.Run("any private endpoint is valid outside of Atlas for government", func(t *testing.T) {
+		assert.NoError(t, PrivateEndpoint(privateEndpoint, false, ""))
+	})
+
+	t.Run("the private endpoints are restricted in Atlas for government", func(t *testing.T) {
+		assert.ErrorContains(t, PrivateEndpoint(privateEndpoint, true, "COMMERCIAL_FEDRAMP_REGIONS_ONLY"), "private endpoint in atlas for government support a restricted set of regions: eu-east-1 is not part of AWS FedRAMP regions")
+	})
+}
+
+func TestSearchIndexValidation(t *testing.T) {
+	searchIndex := &mdbv1.AtlasSearchIndex{
+		Spec: mdbv1.AtlasSearchIndexSpec{
+			Name:           "default",
+			Database:       "shop",
+			CollectionName: "products",
+			Mappings:       mdbv1.SearchIndexMappings{Fields: &apiextensionsv1.JSON{Raw: []byte(`{"title": {"type": "string"}}`)}},
+			Synonyms: []mdbv1.SearchIndexSynonym{
+				{Name: "synonyms", Analyzer: "lucene.english", Source: mdbv1.SearchIndexSynonymSource{Collection: "synonyms"}},
+			},
+		},
+	}
+	assert.NoError(t, SearchIndex(searchIndex))
+
+	t.Run("the fields are mapped statically without dynamic mapping", func(t *testing.T) {
+		invalid := searchIndex.DeepCopy()
+		invalid.Spec.Mappings.Fields = nil
+		assert.EqualError(t, SearchIndex(invalid), "mappings.fields must be set when the dynamic mapping is disabled")
+	})
+
+	t.Run("the fields are a JSON object", func(t *testing.T) {
+		invalid := searchIndex.DeepCopy()
+		invalid.Spec.Mappings.Fields = &apiextensionsv1.JSON{Raw: []byte(`["title"]`)}
+		assert.ErrorContains(t, SearchIndex(invalid), "mappings.fields must be a JSON object")
+	})
+
+	t.Run("the synonym mappings are unique", func(t *testing.T) {
+		invalid := searchIndex.DeepCopy()
+		invalid.Spec.Synonyms = append(invalid.Spec.Synonyms, invalid.Spec.Synonyms[0])
+		assert.EqualError(t, SearchIndex(invalid), "the synonym mapping \"synonyms\" is duplicate. synonym mapping names must be unique")
+	})
+}
+
+func TestBackupRestoreJobValidation(t *testing.T) {
+	restoreJob := &mdbv1.AtlasBackupRestoreJob{
+		Spec: mdbv1.AtlasBackupRestoreJobSpec{
+			DeploymentRef:       common.ResourceRefNamespaced{Name: "source"},
+			DeliveryType:        mdbv1.RestoreDeliveryAutomated,
+			SnapshotRef:         &common.ResourceRefNamespaced{Name: "snapshot"},
+			TargetDeploymentRef: &common.ResourceRefNamespaced{Name: "target"},
+		},
+	}
+	assert.NoError(t, BackupRestoreJob(restoreJob))
+
+	t.Run("only one snapshot is restored", func(t *testing.T) {
+		invalid := restoreJob.DeepCopy()
+		invalid.Spec.SnapshotID = "snapshot-id"
+		assert.EqualError(t, BackupRestoreJob(invalid), "only one of snapshotRef and snapshotID can be set")
+	})
+
+	t.Run("a download restore has no target", func(t *testing.T) {
+		invalid := restoreJob.DeepCopy()
+		invalid.Spec.DeliveryType = mdbv1.RestoreDeliveryDownload
+		assert.EqualError(t, BackupRestoreJob(invalid), "targetDeploymentRef can't be set for the download restore")
+
+		invalid.Spec.TargetDeploymentRef = nil
+		assert.NoError(t, BackupRestoreJob(invalid))
+	})
+
+	t.Run("a point in time restore requires the point in time and no snapshot", func(t *testing.T) {
+		invalid := restoreJob.DeepCopy()
+		invalid.Spec.DeliveryType = mdbv1.RestoreDeliveryPointInTime
+		assert.EqualError(t, BackupRestoreJob(invalid), "the snapshot can't be set for the pointInTime restore\n"+
+			"the pointInTime restore requires either pointInTimeUTCSeconds or oplogTs and oplogInc")
+
+		invalid.Spec.SnapshotRef = nil
+		invalid.Spec.OplogTs = 1700000000
+		assert.EqualError(t, BackupRestoreJob(invalid), "oplogTs and oplogInc must be set together")
+
+		invalid.Spec.OplogInc = 1
+		assert.NoError(t, BackupRestoreJob(invalid))
+	})
+}
+
+func TestOnlineArchivesValidation(t *testing.T) {
+	archives := []mdbv1.OnlineArchive{
+		{
+			DBName:          "shop",
+			CollName:        "orders",
+			Criteria:        mdbv1.OnlineArchiveCriteria{Type: "DATE", DateField: "createdAt", ExpireAfterDays: 90},
+			PartitionFields: []mdbv1.OnlineArchivePartitionField{{FieldName: "customerId"}},
+		},
+		{
+			DBName:   "shop",
+			CollName: "carts",
+			Criteria: mdbv1.OnlineArchiveCriteria{Type: "CUSTOM", Query: `{"abandoned": true}`},
+		},
+	}
+	assert.NoError(t, onlineArchives(archives))
+
+	t.Run("a collection has a single archive", func(t *testing.T) {
+		invalid := append([]mdbv1.OnlineArchive{}, archives...)
+		invalid = append(invalid, archives[0])
+		assert.EqualError(t, onlineArchives(invalid), "the online archive of \"shop.orders\" is duplicate. a collection can only have one online archive")
+	})
+
+	t.Run("the date criteria sets the date field and the expiration", func(t *testing.T) {
+		invalid := []mdbv1.OnlineArchive{archives[0]}
+		invalid[0].Criteria.ExpireAfterDays = 0
+		assert.EqualError(t, onlineArchives(invalid), "the online archive of \"shop.orders\" must set dateField and expireAfterDays with the DATE criteria")
+	})
+
+	t.Run("the custom criteria sets a JSON query", func(t *testing.T) {
+		invalid := []mdbv1.OnlineArchive{archives[1]}
+		invalid[0].Criteria.Query = "abandoned"
+		assert.EqualError(t, onlineArchives(invalid), "the online archive of \"shop.carts\" must set a JSON query with the CUSTOM criteria")
+	})
+
+	t.Run("the partition fields are unique", func(t *testing.T) {
+		invalid := []mdbv1.OnlineArchive{*archives[0].DeepCopy()}
+		invalid[0].PartitionFields = append(invalid[0].PartitionFields, invalid[0].PartitionFields[0])
+		assert.EqualError(t, onlineArchives(invalid), "the partition field \"customerId\" of the online archive of \"shop.orders\" is duplicate")
+	})
+}
+
+func TestPauseScheduleValidation(t *testing.T) {
+	deployment := &mdbv1.AdvancedDeploymentSpec{
+		PauseSchedule: &mdbv1.PauseSchedule{
+			TimeZone: "Europe/Paris",
+			Windows: []mdbv1.PauseWindow{
+				{Days: []mdbv1.Weekday{"Monday"}, Start: "20:00", End: "08:00"},
+				{Days: []mdbv1.Weekday{"Saturday"}, Start: "00:00", End: "24:00"},
+			},
+		},
+	}
+	assert.NoError(t, pauseSchedule(deployment))
+	assert.NoError(t, pauseSchedule(&mdbv1.AdvancedDeploymentSpec{Paused: toptr.MakePtr(true)}))
+
+	t.Run("paused and the schedule are exclusive", func(t *testing.T) {
+		invalid := deployment.DeepCopy()
+		invalid.Paused = toptr.MakePtr(false)
+		assert.EqualError(t, pauseSchedule(invalid), "paused and pauseSchedule can't be set together")
+	})
+
+	t.Run("the time zone exists", func(t *testing.T) {
+		invalid := deployment.DeepCopy()
+		invalid.PauseSchedule.TimeZone = "Mars/Olympus_Mons"
+		assert.ErrorContains(t, pauseSchedule(invalid), "the time zone \"Mars/Olympus_Mons\" of the pause schedule is invalid")
+	})
+
+	t.Run("the windows are HH:MM times", func(t *testing.T) {
+		invalid := deployment.DeepCopy()
+		invalid.PauseSchedule.Windows[0].Start = "24:00"
+		invalid.PauseSchedule.Windows[1].End = "8:00"
+		assert.EqualError(t, pauseSchedule(invalid), "the start \"24:00\" of the pause window 0 must be a HH:MM time\nthe end \"8:00\" of the pause window 1 must be a HH:MM time or 24:00")
+	})
+}
@@ -0,0 +1,84 @@
+package watch
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// DefaultGlobalSecretEnqueueInterval is the delay between the reconciliations of the resources of a kind triggered by
+// a change of the global API Secret
+const DefaultGlobalSecretEnqueueInterval = 200 * time.Millisecond
+
+// GlobalSecretHandler is the 'handler.EventHandler' triggering the reconciliation of the resources using the global API
+// Secret once it changes, e.g. when the global API key is rotated. The resources are enqueued one Interval after the
+// other so that they don't all call the Atlas API at once. The generic events are the changes of the credentials
+// directory, the events for the other Secrets are ignored.
+type GlobalSecretHandler struct {
+	GlobalAPISecret client.ObjectKey
+	// Dependants lists the resources using the global API Secret
+	Dependants func(ctx context.Context) ([]client.ObjectKey, error)
+	Interval   time.Duration
+
+	// since filters out the Create events of the initial listing, the resources are reconciled on start anyway
+	since time.Time
+}
+
+func NewGlobalSecretHandler(globalAPISecret client.ObjectKey, dependants func(ctx context.Context) ([]client.ObjectKey, error)) *GlobalSecretHandler {
+	return &GlobalSecretHandler{
+		GlobalAPISecret: globalAPISecret,
+		Dependants:      dependants,
+		Interval:        DefaultGlobalSecretEnqueueInterval,
+		since:           time.Now().Truncate(time.Second),
+	}
+}
+
+// Create handles the global API Secret being deleted and then created again
+func (h *GlobalSecretHandler) Create(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	if e.Object.GetCreationTimestamp().Time.Before(h.since) {
+		return
+	}
+	h.enqueueDependants(e.Object, q)
+}
+
+func (h *GlobalSecretHandler) Update(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	if !shouldHandleUpdate(e) {
+		return
+	}
+	h.enqueueDependants(e.ObjectNew, q)
+}
+
+// Delete doesn't trigger the reconciliation, the resources would only fail to read the credentials
+func (h *GlobalSecretHandler) Delete(event.DeleteEvent, workqueue.RateLimitingInterface) {}
+
+func (h *GlobalSecretHandler) Generic(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.enqueueDependants(e.Object, q)
+}
+
+func (h *GlobalSecretHandler) enqueueDependants(obj client.Object, q workqueue.RateLimitingInterface) {
+	if kube.ObjectKeyFromObject(obj) != h.GlobalAPISecret {
+		return
+	}
+
+	dependants, err := h.Dependants(context.Background())
+	if err != nil {
+		zap.S().Errorf("failed to list the resources using the global API Secret %s: %s", h.GlobalAPISecret, err)
+		return
+	}
+	sort.Slice(dependants, func(i, j int) bool {
+		return dependants[i].String() < dependants[j].String()
+	})
+
+	zap.S().Infof("the global API Secret %s has been modified -> triggering reconciliation for %d resources", h.GlobalAPISecret, len(dependants))
+	for i, dependant := range dependants {
+		q.AddAfter(reconcile.Request{NamespacedName: dependant}, time.Duration(i)*h.Interval)
+	}
+}
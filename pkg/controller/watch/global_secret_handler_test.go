@@ -0,0 +1,99 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+// delayingQueue records the delays the items are added with
+type delayingQueue struct {
+	controllertest.Queue
+	delays map[interface{}]time.Duration
+}
+
+func (q *delayingQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.delays[item] = duration
+}
+
+func newDelayingQueue() *delayingQueue {
+	return &delayingQueue{Queue: controllertest.Queue{Interface: workqueue.New()}, delays: map[interface{}]time.Duration{}}
+}
+
+func TestGlobalSecretHandler(t *testing.T) {
+	globalSecret := secretForTesting("global")
+	dependants := []client.ObjectKey{kube.ObjectKey("ns", "project-b"), kube.ObjectKey("ns", "project-a")}
+	handler := NewGlobalSecretHandler(kube.ObjectKeyFromObject(globalSecret), func(context.Context) ([]client.ObjectKey, error) {
+		return dependants, nil
+	})
+	expected := map[interface{}]time.Duration{
+		reconcile.Request{NamespacedName: kube.ObjectKey("ns", "project-a")}: 0,
+		reconcile.Request{NamespacedName: kube.ObjectKey("ns", "project-b")}: DefaultGlobalSecretEnqueueInterval,
+	}
+
+	t.Run("Update event of the global Secret is handled", func(t *testing.T) {
+		rotated := globalSecret.DeepCopy()
+		rotated.Data["testKey"] = []byte("rotated")
+		queue := newDelayingQueue()
+
+		handler.Update(event.UpdateEvent{ObjectOld: globalSecret, ObjectNew: rotated}, queue)
+		assert.Equal(t, expected, queue.delays)
+	})
+	t.Run("Update event without data change is not handled", func(t *testing.T) {
+		queue := newDelayingQueue()
+
+		handler.Update(event.UpdateEvent{ObjectOld: globalSecret, ObjectNew: globalSecret.DeepCopy()}, queue)
+		assert.Empty(t, queue.delays)
+	})
+	t.Run("Update event of another Secret is not handled", func(t *testing.T) {
+		other := secretForTesting("other")
+		rotated := other.DeepCopy()
+		rotated.Data["testKey"] = []byte("rotated")
+		queue := newDelayingQueue()
+
+		handler.Update(event.UpdateEvent{ObjectOld: other, ObjectNew: rotated}, queue)
+		assert.Empty(t, queue.delays)
+	})
+	t.Run("Create event of the initial listing is not handled", func(t *testing.T) {
+		existing := globalSecret.DeepCopy()
+		existing.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		queue := newDelayingQueue()
+
+		handler.Create(event.CreateEvent{Object: existing}, queue)
+		assert.Empty(t, queue.delays)
+	})
+	t.Run("Create event of a recreated global Secret is handled", func(t *testing.T) {
+		recreated := globalSecret.DeepCopy()
+		recreated.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Second))
+		queue := newDelayingQueue()
+
+		handler.Create(event.CreateEvent{Object: recreated}, queue)
+		assert.Equal(t, expected, queue.delays)
+	})
+	t.Run("Generic event of the credentials directory is handled", func(t *testing.T) {
+		queue := newDelayingQueue()
+
+		handler.Generic(event.GenericEvent{Object: secretForTesting("global")}, queue)
+		assert.Equal(t, expected, queue.delays)
+	})
+	t.Run("Dependants can't be listed", func(t *testing.T) {
+		failing := NewGlobalSecretHandler(kube.ObjectKeyFromObject(globalSecret), func(context.Context) ([]client.ObjectKey, error) {
+			return nil, errors.New("failed")
+		})
+		queue := newDelayingQueue()
+
+		failing.Generic(event.GenericEvent{Object: globalSecret}, queue)
+		assert.Empty(t, queue.delays)
+	})
+}
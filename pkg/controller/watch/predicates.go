@@ -21,6 +21,30 @@ func CommonPredicates() predicate.Funcs {
 	}
 }
 
+// AnnotationsChanged returns the predicate which only lets through the updates changing any of the given annotations.
+// These annotations request actions from the Operator, so they must be reconciled even though the spec doesn't change.
+func AnnotationsChanged(keys ...string) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			for _, key := range keys {
+				if e.ObjectOld.GetAnnotations()[key] != e.ObjectNew.GetAnnotations()[key] {
+					return true
+				}
+			}
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
 // DeleteOnly returns a predicate that will filter out everything except the Delete event
 func DeleteOnly() predicate.Funcs {
 	return predicate.Funcs{
@@ -1,6 +1,9 @@
 package watch
 
 import (
+	"fmt"
+	"sync"
+
 	"go.uber.org/zap"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -8,17 +11,49 @@ import (
 func NewResourceWatcher() ResourceWatcher {
 	return ResourceWatcher{
 		WatchedResources: map[WatchedObject]map[client.ObjectKey]bool{},
+		mu:               &sync.RWMutex{},
 	}
 }
 
 // ResourceWatcher is the object containing the map of watched_resource -> []dependant_resource.
+// It's safe for concurrent use when created with NewResourceWatcher, as concurrent reconciliations update the map while
+// the event handlers read it.
 type ResourceWatcher struct {
 	WatchedResources map[WatchedObject]map[client.ObjectKey]bool
+	mu               *sync.RWMutex
+}
+
+// SecretHandler returns the handler triggering the reconciliation of the dependants of the watched Secrets
+func (r ResourceWatcher) SecretHandler() *ResourcesHandler {
+	return &ResourcesHandler{ResourceKind: "Secret", TrackedResources: r.WatchedResources, mu: r.mu}
+}
+
+// AtlasTeamHandler returns the handler triggering the reconciliation of the dependants of the watched AtlasTeams
+func (r ResourceWatcher) AtlasTeamHandler() *ResourcesHandler {
+	return &ResourcesHandler{ResourceKind: "AtlasTeam", TrackedResources: r.WatchedResources, mu: r.mu}
+}
+
+func (r ResourceWatcher) String() string {
+	if r.mu != nil {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+	}
+	return fmt.Sprintf("%v", r.WatchedResources)
+}
+
+func (r ResourceWatcher) lock() func() {
+	if r.mu == nil {
+		return func() {}
+	}
+	r.mu.Lock()
+	return r.mu.Unlock
 }
 
 // EnsureResourcesAreWatched registers a dependant for the watched objects.
 // This will let the controller to react on the events for the watched objects and trigger reconciliation for dependants.
 func (r ResourceWatcher) EnsureResourcesAreWatched(dependant client.ObjectKey, resourceKind string, log *zap.SugaredLogger, watchedObjectsKeys ...client.ObjectKey) {
+	defer r.lock()()
+
 	for _, watchedObjectKey := range watchedObjectsKeys {
 		r.addWatchedResourceIfNotAdded(watchedObjectKey, resourceKind, dependant, log)
 	}
@@ -28,6 +63,8 @@ func (r ResourceWatcher) EnsureResourcesAreWatched(dependant client.ObjectKey, r
 }
 
 func (r ResourceWatcher) EnsureMultiplesResourcesAreWatched(dependant client.ObjectKey, log *zap.SugaredLogger, resources ...WatchedObject) {
+	defer r.lock()()
+
 	for _, res := range resources {
 		r.addWatchedResourceIfNotAdded(res.Resource, res.ResourceKind, dependant, log)
 		log.Debugf("resource watcher: watching %v to trigger reconciliation for %v", res.Resource, dependant)
@@ -1,11 +1,17 @@
 package watch
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
 )
@@ -124,3 +130,30 @@ func TestEnsureResourcesAreWatched(t *testing.T) {
 	})
 	// TODO: add test for different kind of resources
 }
+
+func TestResourceWatcherConcurrentUse(t *testing.T) {
+	watcher := NewResourceWatcher()
+	handler := watcher.SecretHandler()
+	connectionSecret := kube.ObjectKey("test", "connectionSecret")
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		project := kube.ObjectKey("test", fmt.Sprintf("project%d", i))
+		go func() {
+			defer wg.Done()
+			watcher.EnsureResourcesAreWatched(project, "Secret", zap.S(), connectionSecret)
+		}()
+		go func() {
+			defer wg.Done()
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: connectionSecret.Name, Namespace: connectionSecret.Namespace}}
+			secret.Kind = "Secret"
+			handler.Create(event.CreateEvent{Object: secret}, queue)
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, watcher.WatchedResources[WatchedObject{ResourceKind: "Secret", Resource: connectionSecret}], 10)
+}
@@ -3,6 +3,7 @@ package watch
 import (
 	"fmt"
 	"reflect"
+	"sync"
 
 	v1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
 
@@ -32,6 +33,8 @@ func (w WatchedObject) String() string {
 type ResourcesHandler struct {
 	ResourceKind     string
 	TrackedResources map[WatchedObject]map[client.ObjectKey]bool
+	// mu guards TrackedResources when it's shared with a ResourceWatcher
+	mu *sync.RWMutex
 }
 
 // NewSecretHandler TODO Igor: refactor this to create generic constructor
@@ -91,6 +94,10 @@ func (c *ResourcesHandler) doHandle(namespace, name, kind string, q workqueue.Ra
 		ResourceKind: kind,
 		Resource:     types.NamespacedName{Name: name, Namespace: namespace},
 	}
+	if c.mu != nil {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
 	for k := range c.TrackedResources[watchedResource] {
 		zap.S().Infof("%s has been modified -> triggering reconciliation for the %s", watchedResource, k)
 		q.Add(reconcile.Request{NamespacedName: k})
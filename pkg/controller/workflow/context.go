@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"context"
+	"time"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.uber.org/zap"
@@ -42,13 +43,17 @@ type Context struct {
 
 	// Go context, when appropriate
 	Context context.Context
+
+	// startTime is the moment the reconciliation has started, used to report its duration
+	startTime time.Time
 }
 
 func NewContext(log *zap.SugaredLogger, conditions []status.Condition, context context.Context) *Context {
 	return &Context{
-		status:  NewStatus(conditions),
-		Log:     log,
-		Context: context,
+		status:    NewStatus(conditions),
+		Log:       log,
+		Context:   context,
+		startTime: time.Now(),
 	}
 }
 
@@ -72,6 +77,11 @@ func (c Context) LastConditionWarn() bool {
 	return c.lastConditionWarn
 }
 
+// Elapsed returns the time passed since the reconciliation has started
+func (c Context) Elapsed() time.Duration {
+	return time.Since(c.startTime)
+}
+
 func (c *Context) EnsureStatusOption(option status.Option) *Context {
 	c.status.EnsureOption(option)
 	return c
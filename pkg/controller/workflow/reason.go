@@ -14,11 +14,15 @@ const (
 	AtlasFinalizerNotRemoved      ConditionReason = "AtlasFinalizerNotRemoved"
 	AtlasDeletionProtection       ConditionReason = "AtlasDeletionProtection"
 	AtlasGovUnsupported           ConditionReason = "AtlasGovUnsupported"
+	AtlasDriftDetected            ConditionReason = "AtlasDriftDetected"
+	AtlasPolicyViolated           ConditionReason = "AtlasPolicyViolated"
+	AtlasDryRunDeletionPlanned    ConditionReason = "AtlasDryRunDeletionPlanned"
 )
 
 // Atlas Project reasons
 const (
 	ProjectNotCreatedInAtlas                   ConditionReason = "ProjectNotCreatedInAtlas"
+	ProjectCreationPlanned                     ConditionReason = "ProjectCreationPlanned"
 	ProjectIPAccessInvalid                     ConditionReason = "ProjectIPAccessListInvalid"
 	ProjectIPNotCreatedInAtlas                 ConditionReason = "ProjectIPAccessListNotCreatedInAtlas"
 	ProjectWindowInvalid                       ConditionReason = "ProjectWindowInvalid"
@@ -42,6 +46,7 @@ const (
 	ProjectAlertConfigurationIsNotReadyInAtlas ConditionReason = "ProjectAlertConfigurationIsNotReadyInAtlas"
 	ProjectCustomRolesReady                    ConditionReason = "ProjectCustomRolesReady"
 	ProjectTeamUnavailable                     ConditionReason = "ProjectTeamUnavailable"
+	ProjectSubResourceConflict                 ConditionReason = "ProjectSubResourceConflict"
 )
 
 // Atlas Deployment reasons
@@ -55,6 +60,9 @@ const (
 	ServerlessPrivateEndpointReady        ConditionReason = "ServerlessPrivateEndpointReady"
 	ManagedNamespacesReady                ConditionReason = "ManagedNamespacesReady"
 	CustomZoneMappingReady                ConditionReason = "CustomZoneMappingReady"
+	OnlineArchivesReady                   ConditionReason = "OnlineArchivesReady"
+	DeploymentOperationInProgress         ConditionReason = "DeploymentOperationInProgress"
+	DeploymentUpgradePending              ConditionReason = "DeploymentUpgradePending"
 )
 
 // Atlas Database User reasons
@@ -78,6 +86,36 @@ const (
 	DataFederationUpdating          ConditionReason = "DataFederationUpdating"
 )
 
+// Atlas Backup reasons
+const (
+	BackupScheduleNotApplied ConditionReason = "BackupScheduleNotApplied"
+	BackupScheduleReferenced ConditionReason = "BackupScheduleReferenced"
+	BackupPolicyNotFound     ConditionReason = "BackupPolicyNotFound"
+	BackupPolicyReferenced   ConditionReason = "BackupPolicyReferenced"
+
+	BackupDeploymentNotReady         ConditionReason = "BackupDeploymentNotReady"
+	BackupSnapshotNotCreatedInAtlas  ConditionReason = "BackupSnapshotNotCreatedInAtlas"
+	BackupSnapshotNotDeletedInAtlas  ConditionReason = "BackupSnapshotNotDeletedInAtlas"
+	BackupSnapshotInProgress         ConditionReason = "BackupSnapshotInProgress"
+	BackupSnapshotFailed             ConditionReason = "BackupSnapshotFailed"
+	BackupSnapshotNotReady           ConditionReason = "BackupSnapshotNotReady"
+	RestoreJobNotCreatedInAtlas      ConditionReason = "RestoreJobNotCreatedInAtlas"
+	RestoreJobNotCancelledInAtlas    ConditionReason = "RestoreJobNotCancelledInAtlas"
+	RestoreJobDownloadSecretNotSaved ConditionReason = "RestoreJobDownloadSecretNotSaved"
+	RestoreJobInProgress             ConditionReason = "RestoreJobInProgress"
+	RestoreJobFailed                 ConditionReason = "RestoreJobFailed"
+)
+
+// Atlas Search Index reasons
+const (
+	SearchIndexDeploymentNotReady ConditionReason = "SearchIndexDeploymentNotReady"
+	SearchIndexNotCreatedInAtlas  ConditionReason = "SearchIndexNotCreatedInAtlas"
+	SearchIndexNotUpdatedInAtlas  ConditionReason = "SearchIndexNotUpdatedInAtlas"
+	SearchIndexNotDeletedInAtlas  ConditionReason = "SearchIndexNotDeletedInAtlas"
+	SearchIndexBuilding           ConditionReason = "SearchIndexBuilding"
+	SearchIndexFailed             ConditionReason = "SearchIndexFailed"
+)
+
 // Atlas Teams reasons
 const (
 	TeamNotCreatedInAtlas ConditionReason = "TeamNotCreatedInAtlas"
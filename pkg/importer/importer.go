@@ -0,0 +1,324 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/common"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasdatafederation"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasdeployment"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/compat"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
+)
+
+const itemsPerPage = 500
+
+// SecretPlaceholder is a Secret referenced by the imported resources. The importer never reads secret values from
+// Atlas, so these Secrets must be created before the resources are applied.
+type SecretPlaceholder struct {
+	Name   string
+	Keys   []string
+	Labels map[string]string
+}
+
+// Result holds the Atlas Custom Resources equivalent to an Atlas project.
+type Result struct {
+	Objects  []client.Object
+	Secrets  []SecretPlaceholder
+	Warnings []string
+}
+
+// Importer reads an existing Atlas project and generates the Atlas Custom Resources managing it.
+type Importer struct {
+	Client    mongodbatlas.Client
+	Namespace string
+}
+
+// Import generates the AtlasProject, AtlasDeployment, AtlasBackupSchedule, AtlasBackupPolicy, AtlasDatabaseUser and
+// AtlasDataFederation resources of the Atlas project. Nothing is changed in Atlas.
+func (i *Importer) Import(ctx context.Context, projectID string) (*Result, error) {
+	atlasProject, _, err := i.Client.Projects.GetOneProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the project %s: %w", projectID, err)
+	}
+
+	result := &Result{}
+	projectResource, err := i.importProject(ctx, atlasProject, result)
+	if err != nil {
+		return nil, err
+	}
+	projectRef := common.ResourceRefNamespaced{Name: projectResource.Name}
+
+	if err = i.importDeployments(ctx, projectID, projectRef, result); err != nil {
+		return nil, err
+	}
+	if err = i.importServerless(ctx, projectID, projectRef, result); err != nil {
+		return nil, err
+	}
+	if err = i.importDatabaseUsers(ctx, projectID, projectRef, result); err != nil {
+		return nil, err
+	}
+	if err = i.importDataFederations(ctx, projectID, projectRef, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (i *Importer) importProject(ctx context.Context, atlasProject *mongodbatlas.Project, result *Result) (*mdbv1.AtlasProject, error) {
+	name := kube.NormalizeIdentifier(atlasProject.Name)
+	credentials := SecretPlaceholder{
+		Name:   kube.NormalizeIdentifier(name + "-atlas-credentials"),
+		Keys:   []string{"orgId", "publicApiKey", "privateApiKey"},
+		Labels: map[string]string{"atlas.mongodb.com/type": "credentials"},
+	}
+	result.Secrets = append(result.Secrets, credentials)
+
+	accessList, err := listAll(func(options *mongodbatlas.ListOptions) ([]mongodbatlas.ProjectIPAccessList, int, *mongodbatlas.Response, error) {
+		page, response, err := i.Client.ProjectIPAccessList.List(ctx, atlasProject.ID, options)
+		if err != nil {
+			return nil, 0, response, err
+		}
+		return page.Results, page.TotalCount, response, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the IP access list: %w", err)
+	}
+	ipAccessList := make([]project.IPAccessList, 0, len(accessList))
+	for _, entry := range accessList {
+		item := project.IPAccessList{}
+		if err = compat.JSONCopy(&item, entry); err != nil {
+			return nil, err
+		}
+		// Atlas returns the CIDR block for IP addresses and security groups too, while only one of them can be set
+		if item.IPAddress != "" || item.AwsSecurityGroup != "" {
+			item.CIDRBlock = ""
+		}
+		ipAccessList = append(ipAccessList, item)
+	}
+
+	resource := &mdbv1.AtlasProject{
+		ObjectMeta: i.objectMeta(name),
+		Spec: mdbv1.AtlasProjectSpec{
+			Name:                atlasProject.Name,
+			ConnectionSecret:    &common.ResourceRefNamespaced{Name: credentials.Name},
+			ProjectIPAccessList: ipAccessList,
+		},
+	}
+	result.add(resource, "AtlasProject")
+
+	return resource, nil
+}
+
+func (i *Importer) importDeployments(ctx context.Context, projectID string, projectRef common.ResourceRefNamespaced, result *Result) error {
+	deployments, err := listAll(func(options *mongodbatlas.ListOptions) ([]*mongodbatlas.AdvancedCluster, int, *mongodbatlas.Response, error) {
+		page, response, err := i.Client.AdvancedClusters.List(ctx, projectID, options)
+		if err != nil {
+			return nil, 0, response, err
+		}
+		return page.Results, page.TotalCount, response, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the deployments: %w", err)
+	}
+
+	for _, deployment := range deployments {
+		spec, err := atlasdeployment.AdvancedDeploymentFromAtlas(*deployment)
+		if err != nil {
+			return fmt.Errorf("failed to convert the deployment %s: %w", deployment.Name, err)
+		}
+
+		resource := &mdbv1.AtlasDeployment{
+			ObjectMeta: i.objectMeta(resourceName(projectRef.Name, deployment.Name)),
+			Spec: mdbv1.AtlasDeploymentSpec{
+				Project:        projectRef,
+				DeploymentSpec: &spec,
+			},
+		}
+
+		if deployment.BackupEnabled != nil && *deployment.BackupEnabled {
+			scheduleRef, err := i.importBackupSchedule(ctx, projectID, resource.Name, deployment.Name, result)
+			if err != nil {
+				return err
+			}
+			resource.Spec.BackupScheduleRef = scheduleRef
+		}
+
+		result.add(resource, "AtlasDeployment")
+	}
+
+	return nil
+}
+
+func (i *Importer) importBackupSchedule(ctx context.Context, projectID, deploymentResource, deploymentName string, result *Result) (common.ResourceRefNamespaced, error) {
+	atlasSchedule, _, err := i.Client.CloudProviderSnapshotBackupPolicies.Get(ctx, projectID, deploymentName)
+	if err != nil {
+		return common.ResourceRefNamespaced{}, fmt.Errorf("failed to get the backup schedule of the deployment %s: %w", deploymentName, err)
+	}
+
+	policy := &mdbv1.AtlasBackupPolicy{
+		ObjectMeta: i.objectMeta(kube.NormalizeIdentifier(deploymentResource + "-backup-policy")),
+		Spec:       mdbv1.AtlasBackupPolicySpec{Items: []mdbv1.AtlasBackupPolicyItem{}},
+	}
+	for _, atlasPolicy := range atlasSchedule.Policies {
+		for _, item := range atlasPolicy.PolicyItems {
+			policy.Spec.Items = append(policy.Spec.Items, mdbv1.AtlasBackupPolicyItem{
+				FrequencyType:     item.FrequencyType,
+				FrequencyInterval: item.FrequencyInterval,
+				RetentionUnit:     item.RetentionUnit,
+				RetentionValue:    item.RetentionValue,
+			})
+		}
+	}
+
+	schedule := &mdbv1.AtlasBackupSchedule{
+		ObjectMeta: i.objectMeta(kube.NormalizeIdentifier(deploymentResource + "-backup-schedule")),
+	}
+	if err = compat.JSONCopy(&schedule.Spec, atlasSchedule); err != nil {
+		return common.ResourceRefNamespaced{}, err
+	}
+	// The replication spec IDs of the copy settings are not part of the spec as the operator resolves them itself
+	schedule.Spec.PolicyRef = common.ResourceRefNamespaced{Name: policy.Name}
+
+	result.add(policy, "AtlasBackupPolicy")
+	result.add(schedule, "AtlasBackupSchedule")
+
+	return common.ResourceRefNamespaced{Name: schedule.Name}, nil
+}
+
+func (i *Importer) importServerless(ctx context.Context, projectID string, projectRef common.ResourceRefNamespaced, result *Result) error {
+	instances, err := listAll(func(options *mongodbatlas.ListOptions) ([]*mongodbatlas.Cluster, int, *mongodbatlas.Response, error) {
+		page, response, err := i.Client.ServerlessInstances.List(ctx, projectID, options)
+		if err != nil {
+			return nil, 0, response, err
+		}
+		return page.Results, page.TotalCount, response, nil
+	})
+	if err != nil {
+		// Serverless instances are not available in every Atlas domain, e.g. Atlas for Government
+		result.Warnings = append(result.Warnings, fmt.Sprintf("serverless instances were not imported: %s", err))
+		return nil
+	}
+
+	for _, instance := range instances {
+		spec := mdbv1.ServerlessSpec{}
+		if err = compat.JSONCopy(&spec, instance); err != nil {
+			return fmt.Errorf("failed to convert the serverless instance %s: %w", instance.Name, err)
+		}
+
+		result.add(&mdbv1.AtlasDeployment{
+			ObjectMeta: i.objectMeta(resourceName(projectRef.Name, instance.Name)),
+			Spec: mdbv1.AtlasDeploymentSpec{
+				Project:        projectRef,
+				ServerlessSpec: &spec,
+			},
+		}, "AtlasDeployment")
+	}
+
+	return nil
+}
+
+func (i *Importer) importDatabaseUsers(ctx context.Context, projectID string, projectRef common.ResourceRefNamespaced, result *Result) error {
+	users, err := listAll(func(options *mongodbatlas.ListOptions) ([]mongodbatlas.DatabaseUser, int, *mongodbatlas.Response, error) {
+		// the client doesn't return the total count of the users, the pages are read until the last one instead
+		page, response, err := i.Client.DatabaseUsers.List(ctx, projectID, options)
+		return page, 0, response, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the database users: %w", err)
+	}
+
+	for _, user := range users {
+		spec := mdbv1.AtlasDatabaseUserSpec{}
+		if err = compat.JSONCopy(&spec, user); err != nil {
+			return fmt.Errorf("failed to convert the database user %s: %w", user.Username, err)
+		}
+		spec.Project = projectRef
+		for _, authType := range []*string{&spec.X509Type, &spec.AWSIAMType, &spec.LDAPAuthType, &spec.OIDCAuthType} {
+			if *authType == mdbv1.NoneAuthType {
+				*authType = ""
+			}
+		}
+
+		resource := &mdbv1.AtlasDatabaseUser{
+			ObjectMeta: i.objectMeta(resourceName(projectRef.Name, user.Username)),
+			Spec:       spec,
+		}
+		if authType, _ := spec.AuthType(); authType == "" {
+			password := SecretPlaceholder{
+				Name: kube.NormalizeIdentifier(resource.Name + "-password"),
+				Keys: []string{"password"},
+			}
+			result.Secrets = append(result.Secrets, password)
+			resource.Spec.PasswordSecret = &common.ResourceRef{Name: password.Name}
+		}
+
+		result.add(resource, "AtlasDatabaseUser")
+	}
+
+	return nil
+}
+
+func (i *Importer) importDataFederations(ctx context.Context, projectID string, projectRef common.ResourceRefNamespaced, result *Result) error {
+	dataFederations, _, err := i.Client.DataFederation.List(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to list the data federations: %w", err)
+	}
+
+	for _, dataFederation := range dataFederations {
+		spec, err := atlasdatafederation.DataFederationFromAtlas(dataFederation)
+		if err != nil {
+			return fmt.Errorf("failed to convert the data federation %s: %w", dataFederation.Name, err)
+		}
+		spec.Project = projectRef
+
+		result.add(&mdbv1.AtlasDataFederation{
+			ObjectMeta: i.objectMeta(resourceName(projectRef.Name, dataFederation.Name)),
+			Spec:       *spec,
+		}, "AtlasDataFederation")
+	}
+
+	return nil
+}
+
+// listAll reads all the pages of an Atlas list. The pages are read until the total count of items is reached, or until
+// Atlas reports the last page if the total count isn't known.
+func listAll[T any](list func(options *mongodbatlas.ListOptions) ([]T, int, *mongodbatlas.Response, error)) ([]T, error) {
+	var all []T
+	for pageNum := 1; ; pageNum++ {
+		items, totalCount, response, err := list(&mongodbatlas.ListOptions{PageNum: pageNum, ItemsPerPage: itemsPerPage, IncludeCount: true})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if len(items) == 0 {
+			return all, nil
+		}
+		if totalCount > 0 && len(all) >= totalCount {
+			return all, nil
+		}
+		if totalCount <= 0 && (response == nil || response.IsLastPage()) {
+			return all, nil
+		}
+	}
+}
+
+func (i *Importer) objectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: i.Namespace}
+}
+
+func (r *Result) add(object client.Object, kind string) {
+	object.GetObjectKind().SetGroupVersionKind(mdbv1.GroupVersion.WithKind(kind))
+	r.Objects = append(r.Objects, object)
+}
+
+func resourceName(projectResource, name string) string {
+	return kube.NormalizeIdentifier(projectResource + "-" + name)
+}
@@ -0,0 +1,225 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+)
+
+const projectID = "603e7bf38a94956835659ae5"
+
+var atlasResponses = map[string]string{
+	"/api/atlas/v1.0/groups/" + projectID: `{"id": "` + projectID + `", "name": "My Project"}`,
+	"/api/atlas/v1.0/groups/" + projectID + "/accessList": `{"results": [
+		{"cidrBlock": "10.0.0.1/32", "ipAddress": "10.0.0.1", "comment": "office"},
+		{"cidrBlock": "192.168.0.0/16"}
+	]}`,
+	"/api/atlas/v1.5/groups/" + projectID + "/clusters": `{"results": [{
+		"name": "Cluster0",
+		"clusterType": "REPLICASET",
+		"backupEnabled": true,
+		"replicationSpecs": [{"zoneName": "Zone 1", "numShards": 1, "regionConfigs": [{
+			"providerName": "AWS", "regionName": "US_EAST_1", "priority": 7,
+			"electableSpecs": {"instanceSize": "M10", "nodeCount": 3}
+		}]}]
+	}]}`,
+	"/api/atlas/v1.0/groups/" + projectID + "/clusters/Cluster0/backup/schedule": `{
+		"referenceHourOfDay": 12,
+		"restoreWindowDays": 7,
+		"policies": [{"id": "policy", "policyItems": [
+			{"frequencyType": "daily", "frequencyInterval": 1, "retentionUnit": "days", "retentionValue": 7}
+		]}]
+	}`,
+	"/api/atlas/v1.0/groups/" + projectID + "/serverless": `{"results": [{
+		"name": "Serverless0",
+		"providerSettings": {"providerName": "SERVERLESS", "backingProviderName": "AWS", "regionName": "US_EAST_1"}
+	}]}`,
+	"/api/atlas/v1.0/groups/" + projectID + "/databaseUsers": `{"results": [
+		{"username": "app", "databaseName": "admin", "x509Type": "NONE", "awsIAMType": "NONE", "ldapAuthType": "NONE", "oidcAuthType": "NONE",
+			"roles": [{"roleName": "readWriteAnyDatabase", "databaseName": "admin"}]},
+		{"username": "arn:aws:iam::123456789012:role/app", "databaseName": "$external", "x509Type": "NONE", "awsIAMType": "ROLE", "ldapAuthType": "NONE", "oidcAuthType": "NONE",
+			"roles": [{"roleName": "read", "databaseName": "test"}]}
+	]}`,
+	"/api/atlas/v1.0/groups/" + projectID + "/dataFederation": `[{"name": "Federation0", "cloudProviderConfig": {"aws": {"roleId": "role"}}}]`,
+}
+
+func TestImport(t *testing.T) {
+	result := importProject(t, nil)
+
+	kinds := map[string][]string{}
+	for _, object := range result.Objects {
+		kind := object.GetObjectKind().GroupVersionKind().Kind
+		kinds[kind] = append(kinds[kind], object.GetName())
+		assert.Equal(t, "atlas", object.GetNamespace())
+	}
+	assert.Equal(t, map[string][]string{
+		"AtlasProject":        {"my-project"},
+		"AtlasBackupPolicy":   {"my-project-cluster0-backup-policy"},
+		"AtlasBackupSchedule": {"my-project-cluster0-backup-schedule"},
+		"AtlasDeployment":     {"my-project-cluster0", "my-project-serverless0"},
+		"AtlasDatabaseUser":   {"my-project-app", "my-project-arn-aws-iam-123456789012-role-app"},
+		"AtlasDataFederation": {"my-project-federation0"},
+	}, kinds)
+
+	t.Run("Project", func(t *testing.T) {
+		project := result.Objects[0].(*mdbv1.AtlasProject)
+		assert.Equal(t, "My Project", project.Spec.Name)
+		assert.Equal(t, "my-project-atlas-credentials", project.Spec.ConnectionSecret.Name)
+		require.Len(t, project.Spec.ProjectIPAccessList, 2)
+		assert.Equal(t, "10.0.0.1", project.Spec.ProjectIPAccessList[0].IPAddress)
+		assert.Empty(t, project.Spec.ProjectIPAccessList[0].CIDRBlock)
+		assert.Equal(t, "192.168.0.0/16", project.Spec.ProjectIPAccessList[1].CIDRBlock)
+	})
+
+	t.Run("Deployment with backup", func(t *testing.T) {
+		policy := result.Objects[1].(*mdbv1.AtlasBackupPolicy)
+		assert.Equal(t, []mdbv1.AtlasBackupPolicyItem{
+			{FrequencyType: "daily", FrequencyInterval: 1, RetentionUnit: "days", RetentionValue: 7},
+		}, policy.Spec.Items)
+
+		schedule := result.Objects[2].(*mdbv1.AtlasBackupSchedule)
+		assert.Equal(t, policy.Name, schedule.Spec.PolicyRef.Name)
+		assert.Equal(t, int64(12), schedule.Spec.ReferenceHourOfDay)
+		assert.Equal(t, int64(7), schedule.Spec.RestoreWindowDays)
+
+		deployment := result.Objects[3].(*mdbv1.AtlasDeployment)
+		assert.Equal(t, "my-project", deployment.Spec.Project.Name)
+		assert.Equal(t, "Cluster0", deployment.Spec.DeploymentSpec.Name)
+		assert.Equal(t, schedule.Name, deployment.Spec.BackupScheduleRef.Name)
+	})
+
+	t.Run("Serverless instance", func(t *testing.T) {
+		deployment := result.Objects[4].(*mdbv1.AtlasDeployment)
+		require.NotNil(t, deployment.Spec.ServerlessSpec)
+		assert.Equal(t, "Serverless0", deployment.Spec.ServerlessSpec.Name)
+		assert.Nil(t, deployment.Spec.DeploymentSpec)
+	})
+
+	t.Run("Database users", func(t *testing.T) {
+		scramUser := result.Objects[5].(*mdbv1.AtlasDatabaseUser)
+		assert.Equal(t, "app", scramUser.Spec.Username)
+		assert.Empty(t, scramUser.Spec.X509Type)
+		assert.Empty(t, scramUser.Spec.AWSIAMType)
+		require.NotNil(t, scramUser.Spec.PasswordSecret)
+		assert.Equal(t, "my-project-app-password", scramUser.Spec.PasswordSecret.Name)
+
+		iamUser := result.Objects[6].(*mdbv1.AtlasDatabaseUser)
+		assert.Equal(t, "ROLE", iamUser.Spec.AWSIAMType)
+		assert.Nil(t, iamUser.Spec.PasswordSecret)
+	})
+
+	t.Run("Secret placeholders", func(t *testing.T) {
+		assert.Equal(t, []SecretPlaceholder{
+			{
+				Name:   "my-project-atlas-credentials",
+				Keys:   []string{"orgId", "publicApiKey", "privateApiKey"},
+				Labels: map[string]string{"atlas.mongodb.com/type": "credentials"},
+			},
+			{Name: "my-project-app-password", Keys: []string{"password"}},
+		}, result.Secrets)
+	})
+}
+
+func TestImportPaginated(t *testing.T) {
+	clustersPath := "/api/atlas/v1.5/groups/" + projectID + "/clusters"
+	usersPath := "/api/atlas/v1.0/groups/" + projectID + "/databaseUsers"
+	result := importProject(t, map[string]string{
+		clustersPath + "?pageNum=1": `{"totalCount": 2, "results": [{"name": "Cluster0", "clusterType": "REPLICASET"}]}`,
+		clustersPath + "?pageNum=2": `{"totalCount": 2, "results": [{"name": "Cluster1", "clusterType": "REPLICASET"}]}`,
+		usersPath + "?pageNum=1": `{"links": [{"rel": "next", "href": "` + usersPath + `?pageNum=2"}], "results": [
+			{"username": "app", "databaseName": "admin", "roles": [{"roleName": "read", "databaseName": "test"}]}
+		]}`,
+		usersPath + "?pageNum=2": `{"results": [
+			{"username": "other", "databaseName": "admin", "roles": [{"roleName": "read", "databaseName": "test"}]}
+		]}`,
+	})
+
+	var names []string
+	for _, object := range result.Objects {
+		switch object.(type) {
+		case *mdbv1.AtlasDeployment, *mdbv1.AtlasDatabaseUser:
+			names = append(names, object.GetName())
+		}
+	}
+	assert.Equal(t, []string{
+		"my-project-cluster0",
+		"my-project-cluster1",
+		"my-project-serverless0",
+		"my-project-app",
+		"my-project-other",
+	}, names)
+}
+
+func TestWriteManifests(t *testing.T) {
+	result := importProject(t, nil)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteManifests(&out, result))
+	manifests := out.String()
+
+	assert.Contains(t, manifests, "#   my-project-atlas-credentials with the keys orgId, publicApiKey, privateApiKey and the label atlas.mongodb.com/type=credentials\n")
+	assert.Contains(t, manifests, "#   my-project-app-password with the keys password\n")
+	assert.Contains(t, manifests, "kind: AtlasProject\n")
+	assert.Contains(t, manifests, "apiVersion: atlas.mongodb.com/v1\n")
+	assert.Contains(t, manifests, "passwordSecretRef:\n    name: my-project-app-password\n")
+	assert.NotContains(t, manifests, "status:")
+	assert.NotContains(t, manifests, "creationTimestamp")
+	assert.Equal(t, len(result.Objects), bytes.Count(out.Bytes(), []byte("---\n")))
+}
+
+func TestImportFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errorCode": "GROUP_NOT_FOUND", "detail": "Group not found"}`)
+	}))
+	defer server.Close()
+
+	atlasClient, err := mongodbatlas.New(server.Client(), mongodbatlas.SetBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	imp := Importer{Client: *atlasClient, Namespace: "atlas"}
+	_, err = imp.Import(context.Background(), projectID)
+	assert.ErrorContains(t, err, "failed to get the project "+projectID)
+}
+
+// importProject imports the project from a server answering with atlasResponses. The pages of the lists override them,
+// they're keyed by the path and the page number, e.g. "/path?pageNum=2"
+func importProject(t *testing.T, pages map[string]string) *Result {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		response, ok := pages[r.URL.Path+"?pageNum="+r.URL.Query().Get("pageNum")]
+		if !ok {
+			response, ok = atlasResponses[r.URL.Path]
+		}
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, response)
+	}))
+	t.Cleanup(server.Close)
+
+	atlasClient, err := mongodbatlas.New(server.Client(), mongodbatlas.SetBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	imp := Importer{Client: *atlasClient, Namespace: "atlas"}
+	result, err := imp.Import(context.Background(), projectID)
+	require.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+
+	return result
+}
@@ -0,0 +1,51 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// WriteManifests writes the imported resources as a multi-document YAML. The Secrets to create and the warnings are
+// listed in the leading comment.
+func WriteManifests(w io.Writer, result *Result) error {
+	var header strings.Builder
+	header.WriteString("# Generated from an existing Atlas project. Create the following Secrets before applying:\n")
+	for _, secret := range result.Secrets {
+		fmt.Fprintf(&header, "#   %s with the keys %s", secret.Name, strings.Join(secret.Keys, ", "))
+		for key, value := range secret.Labels {
+			fmt.Fprintf(&header, " and the label %s=%s", key, value)
+		}
+		header.WriteString("\n")
+	}
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(&header, "# WARNING: %s\n", warning)
+	}
+	if _, err := io.WriteString(w, header.String()); err != nil {
+		return err
+	}
+
+	for _, object := range result.Objects {
+		manifest, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return err
+		}
+		// Neither the status nor the server populated metadata belong to a manifest
+		unstructured.RemoveNestedField(manifest, "status")
+		unstructured.RemoveNestedField(manifest, "metadata", "creationTimestamp")
+
+		data, err := yaml.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		if _, err = fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package metrics
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "atlas_operator"
+
+	kindLabel          = "kind"
+	namespaceLabel     = "namespace"
+	conditionTypeLabel = "condition_type"
+	reasonLabel        = "reason"
+	resultLabel        = "result"
+	readyLabel         = "ready"
+	methodLabel        = "method"
+	statusCodeLabel    = "status_code"
+	errorCodeLabel     = "error_code"
+)
+
+// Reconcile results reported in the 'result' label
+const (
+	ResultSuccess    = "success"
+	ResultInProgress = "in_progress"
+	ResultFailure    = "failure"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "reconcile_total",
+			Help:      "Total number of reconciliations of Atlas Custom Resources by the last reported condition",
+		},
+		[]string{kindLabel, namespaceLabel, conditionTypeLabel, reasonLabel, resultLabel},
+	)
+
+	reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "reconcile_duration_seconds",
+			Help:      "Duration of reconciliations of Atlas Custom Resources by the last reported condition",
+			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		},
+		[]string{kindLabel, namespaceLabel, conditionTypeLabel, reasonLabel, resultLabel},
+	)
+
+	resources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "resources",
+			Help:      "Number of Atlas Custom Resources by their Ready state",
+		},
+		[]string{kindLabel, namespaceLabel, readyLabel},
+	)
+
+	atlasRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "atlas_request_duration_seconds",
+			Help:      "Duration of the requests sent to the Atlas Admin API",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{methodLabel, statusCodeLabel},
+	)
+
+	atlasRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "atlas_request_errors_total",
+			Help:      "Total number of failed requests to the Atlas Admin API by the Atlas error code",
+		},
+		[]string{methodLabel, statusCodeLabel, errorCodeLabel},
+	)
+
+	atlasReachable = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "atlas_reachable",
+			Help:      "Whether the Atlas Admin API was reachable with the global API keys at the last check (1) or not (0)",
+		},
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		reconcileTotal,
+		reconcileDuration,
+		resources,
+		atlasRequestDuration,
+		atlasRequestErrors,
+		atlasReachable,
+	)
+}
+
+// ObserveReconcile records the outcome of a single reconciliation of the Atlas Custom Resource.
+func ObserveReconcile(kind, namespace, conditionType, reason, result string, duration time.Duration) {
+	labels := prometheus.Labels{
+		kindLabel:          kind,
+		namespaceLabel:     namespace,
+		conditionTypeLabel: conditionType,
+		reasonLabel:        reason,
+		resultLabel:        result,
+	}
+	reconcileTotal.With(labels).Inc()
+	reconcileDuration.With(labels).Observe(duration.Seconds())
+}
+
+// ObserveAtlasRequest records the latency of the Atlas API call and the error code if the call failed.
+// The 'statusCode' is 0 if no response was received.
+func ObserveAtlasRequest(method string, statusCode int, errorCode string, duration time.Duration) {
+	code := strconv.Itoa(statusCode)
+	atlasRequestDuration.WithLabelValues(method, code).Observe(duration.Seconds())
+
+	if statusCode == 0 || statusCode >= 400 {
+		atlasRequestErrors.WithLabelValues(method, code, errorCode).Inc()
+	}
+}
+
+// SetAtlasReachable records the outcome of the last check of the Atlas Admin API with the global API keys.
+func SetAtlasReachable(reachable bool) {
+	if reachable {
+		atlasReachable.Set(1)
+		return
+	}
+	atlasReachable.Set(0)
+}
+
+type resourceKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// readyTracker remembers the last known Ready state of each resource so that the 'resources' gauge
+// can be moved from one state to the other without listing all the resources.
+type readyTracker struct {
+	mu     sync.Mutex
+	states map[resourceKey]bool
+}
+
+var tracker = &readyTracker{states: map[resourceKey]bool{}}
+
+// SetResourceReady updates the Ready state of the resource reported by the 'resources' gauge.
+func SetResourceReady(kind, namespace, name string, ready bool) {
+	tracker.set(resourceKey{kind: kind, namespace: namespace, name: name}, ready)
+}
+
+// ForgetResource removes the resource from the 'resources' gauge. Should be called once the resource is removed.
+func ForgetResource(kind, namespace, name string) {
+	tracker.forget(resourceKey{kind: kind, namespace: namespace, name: name})
+}
+
+func (t *readyTracker) set(key resourceKey, ready bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous, found := t.states[key]
+	if found && previous == ready {
+		return
+	}
+	if found {
+		resources.WithLabelValues(key.kind, key.namespace, strconv.FormatBool(previous)).Dec()
+	}
+	t.states[key] = ready
+	resources.WithLabelValues(key.kind, key.namespace, strconv.FormatBool(ready)).Inc()
+}
+
+func (t *readyTracker) forget(key resourceKey) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous, found := t.states[key]
+	if !found {
+		return
+	}
+	delete(t.states, key)
+	resources.WithLabelValues(key.kind, key.namespace, strconv.FormatBool(previous)).Dec()
+}
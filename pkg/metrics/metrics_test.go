@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveReconcile(t *testing.T) {
+	ObserveReconcile("AtlasProject", "test-ns", "IPAccessListReady", "ProjectIPAccessListInvalid", ResultFailure, time.Second)
+	ObserveReconcile("AtlasProject", "test-ns", "IPAccessListReady", "ProjectIPAccessListInvalid", ResultFailure, time.Second)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(reconcileTotal.WithLabelValues("AtlasProject", "test-ns", "IPAccessListReady", "ProjectIPAccessListInvalid", ResultFailure)))
+}
+
+func TestObserveAtlasRequest(t *testing.T) {
+	ObserveAtlasRequest("GET", 200, "", time.Millisecond)
+	ObserveAtlasRequest("GET", 404, "CLUSTER_NOT_FOUND", time.Millisecond)
+	ObserveAtlasRequest("POST", 0, "", time.Millisecond)
+
+	assert.Equal(t, 0.0, testutil.ToFloat64(atlasRequestErrors.WithLabelValues("GET", "200", "")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(atlasRequestErrors.WithLabelValues("GET", "404", "CLUSTER_NOT_FOUND")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(atlasRequestErrors.WithLabelValues("POST", "0", "")))
+}
+
+func TestResourceReadyState(t *testing.T) {
+	readyGauge := func(ready string) float64 {
+		return testutil.ToFloat64(resources.WithLabelValues("AtlasDeployment", "ready-ns", ready))
+	}
+
+	SetResourceReady("AtlasDeployment", "ready-ns", "first", false)
+	SetResourceReady("AtlasDeployment", "ready-ns", "second", false)
+	assert.Equal(t, 2.0, readyGauge("false"))
+	assert.Equal(t, 0.0, readyGauge("true"))
+
+	SetResourceReady("AtlasDeployment", "ready-ns", "first", true)
+	SetResourceReady("AtlasDeployment", "ready-ns", "first", true)
+	assert.Equal(t, 1.0, readyGauge("false"))
+	assert.Equal(t, 1.0, readyGauge("true"))
+
+	ForgetResource("AtlasDeployment", "ready-ns", "first")
+	ForgetResource("AtlasDeployment", "ready-ns", "unknown")
+	assert.Equal(t, 1.0, readyGauge("false"))
+	assert.Equal(t, 0.0, readyGauge("true"))
+}
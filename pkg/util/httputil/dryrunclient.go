@@ -0,0 +1,79 @@
+package httputil
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// MutationRecorder records the requests that would change the state of the remote resources
+type MutationRecorder interface {
+	Record(request *http.Request)
+}
+
+// DryRun is the option that stops an http Client from sending any request that is not safe (GET, HEAD, OPTIONS).
+// Such requests are passed to the recorder and answered with a successful response instead. The response echoes
+// the JSON object sent in the request, so that the caller sees the resource as if it was created or updated.
+func DryRun(recorder MutationRecorder) ClientOpt {
+	return func(c *http.Client) error {
+		if recorder == nil {
+			return nil
+		}
+		c.Transport = &dryRunRoundTripper{rt: c.Transport, recorder: recorder}
+		return nil
+	}
+}
+
+type dryRunRoundTripper struct {
+	rt       http.RoundTripper
+	recorder MutationRecorder
+}
+
+func (d *dryRunRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return d.rt.RoundTrip(request)
+	}
+
+	d.recorder.Record(request)
+
+	body, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Request:    request,
+	}
+	if request.Method == http.MethodDelete {
+		response.Status = "204 No Content"
+		response.StatusCode = http.StatusNoContent
+		body = nil
+	}
+	// only the single objects are echoed: a list of created items is usually answered with a paginated object
+	// the client would fail to decode the list into
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		body = nil
+	}
+	if len(body) > 0 {
+		response.Header.Set("Content-Type", "application/json")
+	}
+	response.ContentLength = int64(len(body))
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	return response, nil
+}
+
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+	defer request.Body.Close()
+	return io.ReadAll(request.Body)
+}
@@ -0,0 +1,96 @@
+package httputil
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type requestsRecorder struct {
+	requests []string
+}
+
+func (r *requestsRecorder) Record(request *http.Request) {
+	r.requests = append(r.requests, request.Method+" "+request.URL.Path)
+}
+
+func Test_DryRun(t *testing.T) {
+	var served int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&served, 1)
+		_, _ = w.Write([]byte(`{"name":"remote"}`))
+	}))
+	defer server.Close()
+
+	recorder := &requestsRecorder{}
+	client, err := DecorateClient(&http.Client{Transport: http.DefaultTransport}, DryRun(recorder))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "reads are sent",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"remote"}`,
+		},
+		{
+			name:           "created object is echoed",
+			method:         http.MethodPost,
+			body:           `{"name":"local"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"local"}`,
+		},
+		{
+			name:           "created list is not echoed",
+			method:         http.MethodPost,
+			body:           `[{"name":"local"}]`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "updated object is echoed",
+			method:         http.MethodPatch,
+			body:           `{"name":"local"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"local"}`,
+		},
+		{
+			name:           "deletion has no content",
+			method:         http.MethodDelete,
+			expectedStatus: http.StatusNoContent,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			request, err := http.NewRequestWithContext(context.Background(), tc.method, server.URL+"/groups/1", body)
+			require.NoError(t, err)
+
+			response, err := client.Do(request)
+			require.NoError(t, err)
+			defer response.Body.Close()
+
+			responseBody, err := io.ReadAll(response.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, response.StatusCode)
+			assert.Equal(t, tc.expectedBody, string(responseBody))
+		})
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&served))
+	assert.Equal(t, []string{"POST /groups/1", "POST /groups/1", "PATCH /groups/1", "DELETE /groups/1"}, recorder.requests)
+}
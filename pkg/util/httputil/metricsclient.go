@@ -0,0 +1,64 @@
+package httputil
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/metrics"
+)
+
+// MetricsTransport is the option adding Prometheus instrumentation of the requests to an http Client
+func MetricsTransport() ClientOpt {
+	return func(c *http.Client) error {
+		c.Transport = &metricsRoundTripper{rt: c.Transport}
+		return nil
+	}
+}
+
+type metricsRoundTripper struct {
+	rt http.RoundTripper
+}
+
+func (m *metricsRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	startTime := time.Now()
+	response, err := m.rt.RoundTrip(request)
+	duration := time.Since(startTime)
+
+	if err != nil || response == nil {
+		metrics.ObserveAtlasRequest(request.Method, 0, "", duration)
+		return response, err
+	}
+
+	errorCode := ""
+	if response.StatusCode >= 400 {
+		errorCode = readErrorCode(response)
+	}
+	metrics.ObserveAtlasRequest(request.Method, response.StatusCode, errorCode, duration)
+
+	return response, err
+}
+
+// readErrorCode extracts the 'errorCode' field from the Atlas error response. The body is restored so that
+// the caller can still read it.
+func readErrorCode(response *http.Response) string {
+	if response.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	atlasError := struct {
+		ErrorCode string `json:"errorCode"`
+	}{}
+	if err = json.Unmarshal(body, &atlasError); err != nil {
+		return ""
+	}
+	return atlasError.ErrorCode
+}
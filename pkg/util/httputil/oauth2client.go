@@ -0,0 +1,83 @@
+package httputil
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// maxTokenSources bounds the number of cached token sources. The least recently used one is evicted once it's reached,
+// so the service accounts no longer used don't stay in memory
+const maxTokenSources = 256
+
+// tokenSources caches the token sources by token URL and client ID. The Atlas clients are created on every
+// reconciliation, sharing the token sources avoids requesting a new token each time
+var tokenSources = struct {
+	sync.Mutex
+	sources map[string]*cachedTokenSource
+}{sources: map[string]*cachedTokenSource{}}
+
+type cachedTokenSource struct {
+	source     oauth2.TokenSource
+	secretHash string
+	lastUsed   time.Time
+}
+
+// OAuth2ClientCredentials is the option authenticating the requests of an http client with the bearer tokens of the
+// OAuth2 client credentials flow, used by the Atlas service accounts. The tokens are cached and refreshed before they
+// expire, they are requested with the transport the client has before the option is applied
+func OAuth2ClientCredentials(tokenURL, clientID, clientSecret string) ClientOpt {
+	return func(c *http.Client) error {
+		c.Transport = &oauth2.Transport{
+			Source: tokenSource(tokenURL, clientID, clientSecret, c.Transport),
+			Base:   c.Transport,
+		}
+		return nil
+	}
+}
+
+func tokenSource(tokenURL, clientID, clientSecret string, transport http.RoundTripper) oauth2.TokenSource {
+	tokenSources.Lock()
+	defer tokenSources.Unlock()
+
+	// a rotated secret replaces the token source of the client, it's hashed to not keep it in clear
+	hash := sha256.Sum256([]byte(clientSecret))
+	secretHash := hex.EncodeToString(hash[:])
+	key := tokenURL + "/" + clientID
+	cached, ok := tokenSources.sources[key]
+	if ok && cached.secretHash == secretHash {
+		cached.lastUsed = time.Now()
+		return cached.source
+	}
+	if !ok && len(tokenSources.sources) >= maxTokenSources {
+		evictLeastRecentlyUsedTokenSource()
+	}
+
+	config := clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     tokenURL,
+		AuthStyle:    oauth2.AuthStyleInHeader,
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: transport})
+	source := config.TokenSource(ctx)
+	tokenSources.sources[key] = &cachedTokenSource{source: source, secretHash: secretHash, lastUsed: time.Now()}
+	return source
+}
+
+func evictLeastRecentlyUsedTokenSource() {
+	var leastRecentKey string
+	var leastRecent *cachedTokenSource
+	for key, cached := range tokenSources.sources {
+		if leastRecent == nil || cached.lastUsed.Before(leastRecent.lastUsed) {
+			leastRecentKey, leastRecent = key, cached
+		}
+	}
+	delete(tokenSources.sources, leastRecentKey)
+}
@@ -0,0 +1,86 @@
+package httputil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			clientID, clientSecret, ok := r.BasicAuth()
+			if !ok || clientID != "id" || clientSecret != "secret" || r.FormValue("grant_type") != "client_credentials" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, issued.Add(1))
+			return
+		}
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	get := func(clientSecret string) (string, error) {
+		c, err := DecorateClient(&http.Client{Transport: http.DefaultTransport}, OAuth2ClientCredentials(server.URL+"/token", "id", clientSecret))
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/resource", nil)
+		require.NoError(t, err)
+		resp, err := c.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+
+		body := make([]byte, 64)
+		n, _ := resp.Body.Read(body)
+		return string(body[:n]), nil
+	}
+
+	t.Run("The token is reused by the clients of the same credentials", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			authorization, err := get("secret")
+			require.NoError(t, err)
+			assert.Equal(t, "Bearer token-1", authorization)
+		}
+		assert.Equal(t, int32(1), issued.Load())
+	})
+
+	t.Run("Invalid credentials fail the requests", func(t *testing.T) {
+		_, err := get("wrong")
+		assert.Error(t, err)
+	})
+}
+
+func TestTokenSourceCache(t *testing.T) {
+	tokenSources.Lock()
+	tokenSources.sources = map[string]*cachedTokenSource{}
+	tokenSources.Unlock()
+
+	t.Run("A rotated secret replaces the token source of the client", func(t *testing.T) {
+		first := tokenSource("https://atlas/token", "id", "secret", http.DefaultTransport)
+		assert.Same(t, first, tokenSource("https://atlas/token", "id", "secret", http.DefaultTransport))
+
+		rotated := tokenSource("https://atlas/token", "id", "rotated", http.DefaultTransport)
+		assert.NotSame(t, first, rotated)
+		assert.Len(t, tokenSources.sources, 1)
+	})
+
+	t.Run("The least recently used token source is evicted once the cache is full", func(t *testing.T) {
+		for i := 0; i < maxTokenSources; i++ {
+			tokenSource("https://atlas/token", fmt.Sprintf("id-%d", i), "secret", http.DefaultTransport)
+		}
+		assert.Len(t, tokenSources.sources, maxTokenSources)
+		assert.NotContains(t, tokenSources.sources, "https://atlas/token/id")
+		assert.Contains(t, tokenSources.sources, "https://atlas/token/id-0")
+	})
+}
@@ -0,0 +1,31 @@
+package httputil
+
+import "net/http"
+
+// RequestLimiter blocks until the request is allowed to be sent or its context is done
+type RequestLimiter interface {
+	Wait(request *http.Request) error
+}
+
+// RateLimit is the option making every request sent by an http Client wait for the permission of the limiter
+func RateLimit(limiter RequestLimiter) ClientOpt {
+	return func(c *http.Client) error {
+		if limiter == nil {
+			return nil
+		}
+		c.Transport = &rateLimitedRoundTripper{rt: c.Transport, limiter: limiter}
+		return nil
+	}
+}
+
+type rateLimitedRoundTripper struct {
+	rt      http.RoundTripper
+	limiter RequestLimiter
+}
+
+func (r *rateLimitedRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if err := r.limiter.Wait(request); err != nil {
+		return nil, err
+	}
+	return r.rt.RoundTrip(request)
+}
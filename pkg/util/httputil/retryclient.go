@@ -0,0 +1,152 @@
+package httputil
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultRetryBaseDelay = time.Second
+	DefaultRetryMaxDelay  = time.Second * 30
+)
+
+// Retry is the option adding retries of the requests that failed with HTTP 429 (Too Many Requests) or with a server
+// error. The Retry-After header is honored if present, otherwise an exponential backoff with full jitter is used.
+// Server errors are only retried for idempotent methods, as the request may have been processed already.
+func Retry(maxRetries int, baseDelay, maxDelay time.Duration) ClientOpt {
+	return func(c *http.Client) error {
+		if maxRetries <= 0 {
+			return nil
+		}
+		c.Transport = &retryRoundTripper{
+			rt:         c.Transport,
+			maxRetries: maxRetries,
+			baseDelay:  baseDelay,
+			maxDelay:   maxDelay,
+		}
+		return nil
+	}
+}
+
+type retryRoundTripper struct {
+	rt         http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+func (r *retryRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := r.rt.RoundTrip(request)
+		if err != nil || attempt >= r.maxRetries || !shouldRetry(request, response) {
+			return response, err
+		}
+
+		retryRequest, err := rewind(request)
+		if err != nil {
+			// the body can't be sent again, so the last response is the best we can return
+			return response, nil
+		}
+
+		delay := r.delay(attempt, response)
+		drain(response)
+		if err = sleep(request.Context(), delay); err != nil {
+			return nil, err
+		}
+		request = retryRequest
+	}
+}
+
+// delay returns the time to wait before the next attempt: the one requested by Atlas in the Retry-After header,
+// or an exponential backoff with full jitter. It's never longer than 'maxDelay'.
+func (r *retryRoundTripper) delay(attempt int, response *http.Response) time.Duration {
+	if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+		return min(retryAfter, r.maxDelay)
+	}
+
+	backoff := r.maxDelay
+	if attempt < 32 {
+		backoff = min(r.baseDelay<<attempt, r.maxDelay)
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	//nolint:gosec // jitter doesn't require a cryptographically secure random
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+func shouldRetry(request *http.Request, response *http.Response) bool {
+	if response.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if response.StatusCode < 500 || response.StatusCode == http.StatusNotImplemented {
+		return false
+	}
+
+	return isIdempotent(request.Method)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses the Retry-After header that is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// rewind returns the copy of the request that can be sent once again
+func rewind(request *http.Request) (*http.Request, error) {
+	clone := request.Clone(request.Context())
+	if request.Body == nil || request.Body == http.NoBody {
+		return clone, nil
+	}
+	if request.GetBody == nil {
+		return nil, http.ErrBodyNotAllowed
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone.Body = body
+	return clone, nil
+}
+
+// drain reads the rest of the body so that the connection can be reused
+func drain(response *http.Response) {
+	if response.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+	_ = response.Body.Close()
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
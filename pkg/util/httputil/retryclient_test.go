@@ -0,0 +1,119 @@
+package httputil
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Retry(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		method           string
+		statuses         []int
+		expectedStatus   int
+		expectedAttempts int32
+	}{
+		{
+			name:             "too many requests is retried",
+			method:           http.MethodPost,
+			statuses:         []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusCreated},
+			expectedStatus:   http.StatusCreated,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "server error is retried for idempotent requests",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "server error is not retried for non idempotent requests",
+			method:           http.MethodPost,
+			statuses:         []int{http.StatusBadGateway, http.StatusOK},
+			expectedStatus:   http.StatusBadGateway,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "client error is not retried",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusNotFound, http.StatusOK},
+			expectedStatus:   http.StatusNotFound,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retries are limited",
+			method:           http.MethodDelete,
+			statuses:         []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests},
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedAttempts: 3,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, "payload", string(body))
+				w.WriteHeader(tc.statuses[attempt-1])
+			}))
+			defer server.Close()
+
+			c, err := DecorateClient(&http.Client{Transport: http.DefaultTransport}, Retry(2, time.Millisecond, 5*time.Millisecond))
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.method, server.URL, strings.NewReader("payload"))
+			require.NoError(t, err)
+			resp, err := c.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+func Test_RetryStopsOnContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c, err := DecorateClient(&http.Client{Transport: http.DefaultTransport}, Retry(2, time.Millisecond, time.Minute))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = c.Do(req) //nolint:bodyclose // no response is returned on error
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func Test_retryDelay(t *testing.T) {
+	r := &retryRoundTripper{baseDelay: time.Second, maxDelay: 10 * time.Second}
+
+	withHeader := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	assert.Equal(t, 3*time.Second, r.delay(0, withHeader("3")))
+	assert.Equal(t, 10*time.Second, r.delay(0, withHeader("120")))
+	assert.LessOrEqual(t, r.delay(2, withHeader("")), 4*time.Second)
+	assert.LessOrEqual(t, r.delay(100, withHeader("invalid")), 10*time.Second)
+
+	date := time.Now().Add(5 * time.Second).UTC().Format(http.TimeFormat)
+	assert.InDelta(t, 5*time.Second, r.delay(0, withHeader(date)), float64(time.Second))
+}
@@ -125,6 +125,7 @@ func BuildManager(initCfg *Config) (manager.Manager, error) {
 		Log:                         logger.Named("controllers").Named("AtlasProject").Sugar(),
 		Scheme:                      mgr.GetScheme(),
 		AtlasDomain:                 config.AtlasDomain,
+		AtlasProvider:               atlasProvider,
 		ResourceWatcher:             watch.NewResourceWatcher(),
 		GlobalAPISecret:             config.GlobalAPISecret,
 		GlobalPredicates:            globalPredicates,
@@ -142,6 +143,7 @@ func BuildManager(initCfg *Config) (manager.Manager, error) {
 		Log:                         logger.Named("controllers").Named("AtlasDatabaseUser").Sugar(),
 		Scheme:                      mgr.GetScheme(),
 		AtlasDomain:                 config.AtlasDomain,
+		AtlasProvider:               atlasProvider,
 		GlobalAPISecret:             config.GlobalAPISecret,
 		EventRecorder:               mgr.GetEventRecorderFor("AtlasDatabaseUser"),
 		GlobalPredicates:            globalPredicates,
@@ -158,6 +160,7 @@ func BuildManager(initCfg *Config) (manager.Manager, error) {
 		Log:                         logger.Named("controllers").Named("AtlasDataFederation").Sugar(),
 		Scheme:                      mgr.GetScheme(),
 		AtlasDomain:                 config.AtlasDomain,
+		AtlasProvider:               atlasProvider,
 		GlobalAPISecret:             config.GlobalAPISecret,
 		EventRecorder:               mgr.GetEventRecorderFor("AtlasDataFederation"),
 		GlobalPredicates:            globalPredicates,
@@ -133,6 +133,7 @@ var _ = BeforeSuite(func() {
 			Client:           k8sManager.GetClient(),
 			Log:              logger.Named("controllers").Named("AtlasProject").Sugar(),
 			AtlasDomain:      atlasDomain,
+			AtlasProvider:    atlasProvider,
 			ResourceWatcher:  watch.NewResourceWatcher(),
 			GlobalPredicates: globalPredicates,
 			EventRecorder:    k8sManager.GetEventRecorderFor("AtlasProject"),
@@ -152,6 +153,7 @@ var _ = BeforeSuite(func() {
 			Client:           k8sManager.GetClient(),
 			Log:              logger.Named("controllers").Named("AtlasDeployment").Sugar(),
 			AtlasDomain:      atlasDomain,
+			AtlasProvider:    atlasProvider,
 			GlobalPredicates: globalPredicates,
 			EventRecorder:    k8sManager.GetEventRecorderFor("AtlasDeployment"),
 			ResourceWatcher:  watch.NewResourceWatcher(),
@@ -22,11 +22,13 @@ import (
 	"encoding/gob"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/internal/fakeatlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasdatafederation"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasfederatedauth"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/test/helper/control"
@@ -51,9 +53,13 @@ import (
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasbackuppolicy"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasbackupschedule"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasbackupsnapshot"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasdatabaseuser"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasdeployment"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlasproject"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlassearchindex"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/httputil"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/util/kube"
@@ -78,6 +84,7 @@ var (
 	atlasClient          *mongodbatlas.Client
 	dataFederationClient *atlasdatafederation.DataFederationServiceOp
 	connection           atlas.Connection
+	fakeAtlas            *httptest.Server
 
 	// These variables are per each test and are changed by each BeforeRun
 	namespace         corev1.Namespace
@@ -149,6 +156,9 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 	Expect(err).NotTo(HaveOccurred())
 	Expect(k8sClient).ToNot(BeNil())
 
+	if control.Enabled("AKO_FAKE_ATLAS") {
+		startFakeAtlas()
+	}
 	atlasClient, connection = prepareAtlasClient()
 	defaultTimeouts()
 
@@ -156,6 +166,9 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 })
 
 var _ = SynchronizedAfterSuite(func() {
+	if fakeAtlas != nil {
+		fakeAtlas.Close()
+	}
 }, func() {
 	By("tearing down the test environment")
 	err := testEnv.Stop()
@@ -168,6 +181,24 @@ func defaultTimeouts() {
 	SetDefaultConsistentlyDuration(ConsistentlyTimeout)
 }
 
+// startFakeAtlas runs an in-memory fake Atlas for the ginkgo node and points the tests to it instead of the Atlas of
+// ATLAS_DOMAIN, so that the tests run offline. The fake doesn't implement all the Atlas endpoints, the tests of the
+// resources it doesn't support fail
+func startFakeAtlas() {
+	config := fakeatlas.Config{
+		OrgID:           "000000000000000000000000",
+		PublicKey:       "fake-public-key",
+		PrivateKey:      "fake-private-key",
+		TransitionDelay: 5 * time.Second,
+	}
+	fakeAtlas = httptest.NewServer(fakeatlas.New(config))
+	atlasDomain = fakeAtlas.URL + "/"
+
+	Expect(os.Setenv("ATLAS_ORG_ID", config.OrgID)).To(Succeed())
+	Expect(os.Setenv("ATLAS_PUBLIC_KEY", config.PublicKey)).To(Succeed())
+	Expect(os.Setenv("ATLAS_PRIVATE_KEY", config.PrivateKey)).To(Succeed())
+}
+
 func prepareAtlasClient() (*mongodbatlas.Client, atlas.Connection) {
 	orgID, publicKey, privateKey := os.Getenv("ATLAS_ORG_ID"), os.Getenv("ATLAS_PUBLIC_KEY"), os.Getenv("ATLAS_PRIVATE_KEY")
 	if orgID == "" || publicKey == "" || privateKey == "" {
@@ -235,6 +266,7 @@ func prepareControllers(deletionProtection bool) (*corev1.Namespace, context.Can
 		Client:                      k8sManager.GetClient(),
 		Log:                         logger.Named("controllers").Named("AtlasProject").Sugar(),
 		AtlasDomain:                 atlasDomain,
+		AtlasProvider:               atlasProvider,
 		ResourceWatcher:             watch.NewResourceWatcher(),
 		GlobalAPISecret:             kube.ObjectKey(namespace.Name, "atlas-operator-api-key"),
 		GlobalPredicates:            globalPredicates,
@@ -256,10 +288,89 @@ func prepareControllers(deletionProtection bool) (*corev1.Namespace, context.Can
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&atlasbackupschedule.AtlasBackupScheduleReconciler{
+		Client:           k8sManager.GetClient(),
+		Log:              logger.Named("controllers").Named("AtlasBackupSchedule").Sugar(),
+		GlobalPredicates: globalPredicates,
+		EventRecorder:    k8sManager.GetEventRecorderFor("AtlasBackupSchedule"),
+		AtlasProvider:    atlasProvider,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&atlasbackuppolicy.AtlasBackupPolicyReconciler{
+		Client:           k8sManager.GetClient(),
+		Log:              logger.Named("controllers").Named("AtlasBackupPolicy").Sugar(),
+		GlobalPredicates: globalPredicates,
+		EventRecorder:    k8sManager.GetEventRecorderFor("AtlasBackupPolicy"),
+		AtlasProvider:    atlasProvider,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&atlasproject.AtlasIPAccessListReconciler{
+		Client:                   k8sManager.GetClient(),
+		Log:                      logger.Named("controllers").Named("AtlasIPAccessList").Sugar(),
+		GlobalPredicates:         globalPredicates,
+		EventRecorder:            k8sManager.GetEventRecorderFor("AtlasIPAccessList"),
+		AtlasProvider:            atlasProvider,
+		ObjectDeletionProtection: deletionProtection,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&atlasproject.AtlasNetworkPeeringReconciler{
+		Client:                   k8sManager.GetClient(),
+		Log:                      logger.Named("controllers").Named("AtlasNetworkPeering").Sugar(),
+		GlobalPredicates:         globalPredicates,
+		EventRecorder:            k8sManager.GetEventRecorderFor("AtlasNetworkPeering"),
+		AtlasProvider:            atlasProvider,
+		ObjectDeletionProtection: deletionProtection,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&atlasproject.AtlasPrivateEndpointReconciler{
+		Client:                   k8sManager.GetClient(),
+		Log:                      logger.Named("controllers").Named("AtlasPrivateEndpoint").Sugar(),
+		GlobalPredicates:         globalPredicates,
+		EventRecorder:            k8sManager.GetEventRecorderFor("AtlasPrivateEndpoint"),
+		AtlasProvider:            atlasProvider,
+		ObjectDeletionProtection: deletionProtection,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&atlassearchindex.AtlasSearchIndexReconciler{
+		Client:                   k8sManager.GetClient(),
+		Log:                      logger.Named("controllers").Named("AtlasSearchIndex").Sugar(),
+		GlobalPredicates:         globalPredicates,
+		EventRecorder:            k8sManager.GetEventRecorderFor("AtlasSearchIndex"),
+		AtlasProvider:            atlasProvider,
+		ObjectDeletionProtection: deletionProtection,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&atlasbackupsnapshot.AtlasBackupSnapshotReconciler{
+		Client:                   k8sManager.GetClient(),
+		Log:                      logger.Named("controllers").Named("AtlasBackupSnapshot").Sugar(),
+		GlobalPredicates:         globalPredicates,
+		EventRecorder:            k8sManager.GetEventRecorderFor("AtlasBackupSnapshot"),
+		AtlasProvider:            atlasProvider,
+		ObjectDeletionProtection: deletionProtection,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&atlasbackupsnapshot.AtlasBackupRestoreJobReconciler{
+		Client:                   k8sManager.GetClient(),
+		Log:                      logger.Named("controllers").Named("AtlasBackupRestoreJob").Sugar(),
+		GlobalPredicates:         globalPredicates,
+		EventRecorder:            k8sManager.GetEventRecorderFor("AtlasBackupRestoreJob"),
+		AtlasProvider:            atlasProvider,
+		ObjectDeletionProtection: deletionProtection,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&atlasdatabaseuser.AtlasDatabaseUserReconciler{
 		Client:                      k8sManager.GetClient(),
 		Log:                         logger.Named("controllers").Named("AtlasDatabaseUser").Sugar(),
 		AtlasDomain:                 atlasDomain,
+		AtlasProvider:               atlasProvider,
 		EventRecorder:               k8sManager.GetEventRecorderFor("AtlasDatabaseUser"),
 		ResourceWatcher:             watch.NewResourceWatcher(),
 		GlobalAPISecret:             kube.ObjectKey(namespace.Name, "atlas-operator-api-key"),
@@ -273,6 +384,7 @@ func prepareControllers(deletionProtection bool) (*corev1.Namespace, context.Can
 		Client:                      k8sManager.GetClient(),
 		Log:                         logger.Named("controllers").Named("AtlasDataFederation").Sugar(),
 		AtlasDomain:                 atlasDomain,
+		AtlasProvider:               atlasProvider,
 		EventRecorder:               k8sManager.GetEventRecorderFor("AtlasDatabaseUser"),
 		ResourceWatcher:             watch.NewResourceWatcher(),
 		GlobalAPISecret:             kube.ObjectKey(namespace.Name, "atlas-operator-api-key"),
@@ -286,6 +398,7 @@ func prepareControllers(deletionProtection bool) (*corev1.Namespace, context.Can
 		Client:                      k8sManager.GetClient(),
 		Log:                         logger.Named("controllers").Named("AtlasFederatedAuth").Sugar(),
 		AtlasDomain:                 atlasDomain,
+		AtlasProvider:               atlasProvider,
 		ResourceWatcher:             watch.NewResourceWatcher(),
 		GlobalPredicates:            globalPredicates,
 		EventRecorder:               k8sManager.GetEventRecorderFor("AtlasFederatedAuth"),
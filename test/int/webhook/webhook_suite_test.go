@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/zapr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	ctrzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/admission"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/test/helper/control"
+)
+
+// These tests run the validating webhooks against a real API server started by envtest. They don't need
+// an Atlas account as no controllers are started.
+
+var (
+	testEnv           *envtest.Environment
+	k8sClient         client.Client
+	managerCancelFunc context.CancelFunc
+)
+
+func TestWebhooks(t *testing.T) {
+	if !control.Enabled("AKO_INT_TEST") {
+		t.Skip("Skipping int tests, AKO_INT_TEST is not set")
+	}
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Atlas Operator Webhook Suite")
+}
+
+var _ = BeforeSuite(func() {
+	if !control.Enabled("AKO_INT_TEST") {
+		fmt.Println("Skipping int BeforeSuite, AKO_INT_TEST is not set")
+		return
+	}
+
+	logger := ctrzap.NewRaw(ctrzap.UseDevMode(true), ctrzap.WriteTo(GinkgoWriter), ctrzap.StacktraceLevel(zap.ErrorLevel))
+	ctrl.SetLogger(zapr.NewLogger(logger))
+
+	By("bootstrapping test environment")
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths: []string{filepath.Join("..", "..", "..", "config", "crd", "bases")},
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join("..", "..", "..", "config", "webhook", "manifests.yaml")},
+		},
+	}
+
+	cfg, err := testEnv.Start()
+	Expect(err).ToNot(HaveOccurred())
+	Expect(cfg).ToNot(BeNil())
+
+	Expect(mdbv1.AddToScheme(scheme.Scheme)).To(Succeed())
+
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	Expect(err).ToNot(HaveOccurred())
+
+	webhookOptions := testEnv.WebhookInstallOptions
+	k8sManager, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:             scheme.Scheme,
+		Host:               webhookOptions.LocalServingHost,
+		Port:               webhookOptions.LocalServingPort,
+		CertDir:            webhookOptions.LocalServingCertDir,
+		MetricsBindAddress: "0",
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(admission.SetupWebhooksWithManager(k8sManager, "https://cloud.mongodb.com/", atlas.NewProductionProvider("https://cloud.mongodb.com/", client.ObjectKey{}, k8sManager.GetClient()), false)).To(Succeed())
+
+	var ctx context.Context
+	ctx, managerCancelFunc = context.WithCancel(context.Background())
+	go func() {
+		defer GinkgoRecover()
+		Expect(k8sManager.Start(ctx)).To(Succeed())
+	}()
+
+	By("waiting for the webhook server to be ready")
+	dialer := &net.Dialer{Timeout: time.Second}
+	addrPort := fmt.Sprintf("%s:%d", webhookOptions.LocalServingHost, webhookOptions.LocalServingPort)
+	Eventually(func() error {
+		//nolint:gosec // the certificate is generated by envtest
+		conn, err := tls.DialWithDialer(dialer, "tcp", addrPort, &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}).WithTimeout(30 * time.Second).Should(Succeed())
+})
+
+var _ = AfterSuite(func() {
+	if !control.Enabled("AKO_INT_TEST") {
+		return
+	}
+	By("tearing down the test environment")
+	managerCancelFunc()
+	Expect(testEnv.Stop()).To(Succeed())
+})
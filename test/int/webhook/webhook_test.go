@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v2/pkg/api/v1/project"
+)
+
+var _ = Describe("Validating webhooks", Label("webhook"), func() {
+	var namespace *corev1.Namespace
+
+	BeforeEach(func() {
+		namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "webhook"}}
+		Expect(k8sClient.Create(context.Background(), namespace)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), namespace)).To(Succeed())
+	})
+
+	It("Rejects an AtlasProject with an invalid IP access list", func() {
+		atlasProject := mdbv1.DefaultProject(namespace.Name, "connection").
+			WithIPAccessList(project.IPAccessList{IPAddress: "192.168.0.300"})
+
+		err := k8sClient.Create(context.Background(), atlasProject)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid ipAddress: 192.168.0.300"))
+	})
+
+	It("Accepts a valid AtlasProject and rejects an invalid update", func() {
+		atlasProject := mdbv1.DefaultProject(namespace.Name, "connection").
+			WithIPAccessList(project.IPAccessList{IPAddress: "192.168.0.1"})
+		Expect(k8sClient.Create(context.Background(), atlasProject)).To(Succeed())
+
+		atlasProject.Spec.RegionUsageRestrictions = "GOV_REGIONS_ONLY"
+		err := k8sClient.Update(context.Background(), atlasProject)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("regionUsageRestriction can be used only with Atlas for government"))
+	})
+
+	It("Rejects an AtlasDeployment without a deployment spec", func() {
+		deployment := mdbv1.DefaultAWSDeployment(namespace.Name, "test-project")
+		deployment.Spec.DeploymentSpec = nil
+
+		err := k8sClient.Create(context.Background(), deployment)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("expected exactly one of spec.deploymentSpec or spec.serverlessSpec"))
+	})
+
+	It("Rejects an AtlasBackupPolicy with multiple daily items", func() {
+		bPolicy := &mdbv1.AtlasBackupPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: namespace.Name},
+			Spec: mdbv1.AtlasBackupPolicySpec{
+				Items: []mdbv1.AtlasBackupPolicyItem{
+					{FrequencyType: "daily", FrequencyInterval: 1, RetentionUnit: "days", RetentionValue: 7},
+					{FrequencyType: "daily", FrequencyInterval: 2, RetentionUnit: "days", RetentionValue: 7},
+				},
+			},
+		}
+
+		err := k8sClient.Create(context.Background(), bPolicy)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("you cannot specify multiple daily backup policy items"))
+	})
+
+	It("Rejects an AtlasTeam with duplicate users", func() {
+		team := &mdbv1.AtlasTeam{
+			ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: namespace.Name},
+			Spec: mdbv1.TeamSpec{
+				Name:      "team",
+				Usernames: []mdbv1.TeamUser{"user@mongodb.com", "user@mongodb.com"},
+			},
+		}
+
+		err := k8sClient.Create(context.Background(), team)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("is duplicate"))
+	})
+})